@@ -22,6 +22,8 @@ import (
 	"github.com/vmware-tanzu/octant/internal/config"
 	"github.com/vmware-tanzu/octant/internal/mime"
 	"github.com/vmware-tanzu/octant/internal/module"
+	"github.com/vmware-tanzu/octant/internal/resourceviewer"
+	"github.com/vmware-tanzu/octant/internal/terminal"
 	"github.com/vmware-tanzu/octant/pkg/log"
 )
 
@@ -161,6 +163,14 @@ func (a *API) Handler(ctx context.Context) (http.Handler, error) {
 	s := router.PathPrefix(a.prefix).Subrouter()
 
 	s.Handle("/stream", websocketService(a.wsClientManager, a.dashConfig))
+	s.Handle(logsDownloadPath, logsDownloadService(a.dashConfig)).Methods(http.MethodGet)
+	s.Handle(applyPreviewPath, applyPreviewService(a.dashConfig)).Methods(http.MethodPost)
+	s.Handle(applyPath, applyService(a.dashConfig)).Methods(http.MethodPost)
+	s.Handle(resourceviewer.ExportRoute, resourceViewerExportService(a.dashConfig)).Methods(http.MethodGet)
+	newRESTService(a.dashConfig).register(s)
+	if dir := terminal.RecordingDir(); dir != "" {
+		s.Handle(terminal.RecordingRoute, terminalRecordingService(dir, a.logger)).Methods(http.MethodGet)
+	}
 
 	s.NotFoundHandler = http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
 		a.logger.Errorf("api handler not found: %s", r.URL.String())
@@ -0,0 +1,220 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	sigyaml "sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/octant/internal/cluster"
+	"github.com/vmware-tanzu/octant/internal/config"
+	"github.com/vmware-tanzu/octant/internal/mime"
+	"github.com/vmware-tanzu/octant/internal/objectstore"
+	"github.com/vmware-tanzu/octant/internal/util/json"
+	"github.com/vmware-tanzu/octant/pkg/log"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+const (
+	// applyPreviewPath is the path for previewing a server-side apply. Objects are
+	// applied with dryRun=All and the response contains a diff of each object.
+	applyPreviewPath = "/apply/preview"
+	// applyPath is the path for server-side applying objects. Objects are only applied
+	// if all of them can be applied with dryRun=All. It responds with 409 Conflict if
+	// fields are owned by another field manager and force is not set.
+	applyPath = "/apply"
+
+	// maxApplySize is the largest request body accepted by the apply endpoints.
+	maxApplySize = 4 << 20
+)
+
+// Apply operations.
+const (
+	applyOperationCreate    = "create"
+	applyOperationUpdate    = "update"
+	applyOperationUnchanged = "unchanged"
+	applyOperationConflict  = "conflict"
+)
+
+type applyRequest struct {
+	Namespace string `json:"namespace"`
+	Update    string `json:"update"`
+	Force     bool   `json:"force"`
+}
+
+type applyConflict struct {
+	Field   string `json:"field,omitempty"`
+	Message string `json:"message"`
+}
+
+type applyObject struct {
+	APIVersion string          `json:"apiVersion"`
+	Kind       string          `json:"kind"`
+	Namespace  string          `json:"namespace,omitempty"`
+	Name       string          `json:"name"`
+	Operation  string          `json:"operation"`
+	Changes    []string        `json:"changes,omitempty"`
+	Conflicts  []applyConflict `json:"conflicts,omitempty"`
+	Diff       *component.Diff `json:"diff,omitempty"`
+}
+
+type applyResponse struct {
+	DryRun    bool `json:"dryRun"`
+	Conflicts bool `json:"conflicts"`
+	// Applied is true if any of the objects were persisted.
+	Applied bool          `json:"applied"`
+	Objects []applyObject `json:"objects"`
+	// Error is the reason the remaining objects were not applied.
+	Error string `json:"error,omitempty"`
+}
+
+func applyPreviewService(dashConfig config.Dash) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		serveApply(dashConfig, objectstore.ApplyOptions{DryRun: true}, w, r)
+	}
+}
+
+func applyService(dashConfig config.Dash) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		serveApply(dashConfig, objectstore.ApplyOptions{}, w, r)
+	}
+}
+
+func serveApply(dashConfig config.Dash, options objectstore.ApplyOptions, w http.ResponseWriter, r *http.Request) {
+	logger := dashConfig.Logger().With("component", "apply")
+
+	if !options.DryRun && dashConfig.ReadOnly() {
+		RespondWithError(w, http.StatusForbidden, "octant is running in read-only mode", logger)
+		return
+	}
+
+	var req applyRequest
+	if err := json.NewDecoder(http.MaxBytesReader(w, r.Body, maxApplySize)).Decode(&req); err != nil {
+		RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("invalid request: %v", err), logger)
+		return
+	}
+
+	if req.Namespace == "" {
+		req.Namespace = dashConfig.DefaultNamespace()
+	}
+	options.Force = req.Force && !options.DryRun
+
+	ctx := r.Context()
+
+	client, err := cluster.ClientForContext(ctx, dashConfig.ClusterClient())
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+		return
+	}
+
+	results, applyErr := objectstore.ServerSideApply(ctx, client, req.Namespace, req.Update, options)
+	if applyErr != nil && len(results) == 0 {
+		RespondWithError(w, http.StatusBadRequest, applyErr.Error(), logger)
+		return
+	}
+
+	resp := applyResponse{
+		DryRun:    options.DryRun,
+		Conflicts: objectstore.HasApplyConflicts(results),
+	}
+	for _, result := range results {
+		object, err := newApplyObject(result, options.DryRun)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+			return
+		}
+		resp.Objects = append(resp.Objects, object)
+		resp.Applied = resp.Applied || !result.DryRun
+	}
+
+	code := http.StatusOK
+	switch {
+	case applyErr != nil:
+		// The objects which were applied before the failure are in the response.
+		resp.Error = applyErr.Error()
+		code = http.StatusBadRequest
+	case resp.Conflicts && !options.DryRun:
+		code = http.StatusConflict
+	}
+
+	respondWithJSON(w, code, &resp, logger)
+}
+
+func newApplyObject(result objectstore.ApplyResult, dryRun bool) (applyObject, error) {
+	object := applyObject{
+		APIVersion: result.Key.APIVersion,
+		Kind:       result.Key.Kind,
+		Namespace:  result.Key.Namespace,
+		Name:       result.Key.Name,
+	}
+
+	for _, conflict := range result.Conflicts {
+		object.Conflicts = append(object.Conflicts, applyConflict{
+			Field:   conflict.Field,
+			Message: conflict.Message,
+		})
+	}
+
+	switch {
+	case len(result.Conflicts) > 0:
+		object.Operation = applyOperationConflict
+		return object, nil
+	case result.Created():
+		object.Operation = applyOperationCreate
+	default:
+		object.Changes = result.Changes()
+		object.Operation = applyOperationUpdate
+		if len(object.Changes) == 0 {
+			object.Operation = applyOperationUnchanged
+		}
+	}
+
+	if !dryRun {
+		return object, nil
+	}
+
+	live, err := objectYAML(result.Live)
+	if err != nil {
+		return applyObject{}, err
+	}
+	applied, err := objectYAML(result.Applied)
+	if err != nil {
+		return applyObject{}, err
+	}
+
+	title := fmt.Sprintf("%s %s", result.Key.Kind, result.Key.Name)
+	if result.Key.Namespace != "" {
+		title = fmt.Sprintf("%s %s/%s", result.Key.Kind, result.Key.Namespace, result.Key.Name)
+	}
+	object.Diff = component.NewDiff(component.TitleFromString(title), live, applied)
+
+	return object, nil
+}
+
+func objectYAML(object *unstructured.Unstructured) (string, error) {
+	if object == nil {
+		return "", nil
+	}
+
+	data, err := sigyaml.Marshal(object.Object)
+	if err != nil {
+		return "", fmt.Errorf("marshal %s as yaml: %w", object.GetName(), err)
+	}
+
+	return string(data), nil
+}
+
+func respondWithJSON(w http.ResponseWriter, code int, v interface{}, logger log.Logger) {
+	w.Header().Set("Content-Type", mime.JSONContentType)
+	w.WriteHeader(code)
+
+	if err := json.NewEncoder(w).Encode(v); err != nil {
+		logger.Errorf("encoding JSON response: %v", err)
+	}
+}
@@ -0,0 +1,287 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+
+	clusterFake "github.com/vmware-tanzu/octant/internal/cluster/fake"
+	configFake "github.com/vmware-tanzu/octant/internal/config/fake"
+	"github.com/vmware-tanzu/octant/internal/log"
+)
+
+const applyTestConfigMap = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: cm
+data:
+  key: new
+`
+
+func Test_applyService(t *testing.T) {
+	cases := []struct {
+		name      string
+		path      string
+		body      string
+		readOnly  bool
+		conflict  bool
+		code      int
+		operation string
+		force     string
+	}{
+		{
+			name:      "preview",
+			path:      applyPreviewPath,
+			body:      `{"update": ` + quote(applyTestConfigMap) + `, "force": true}`,
+			code:      http.StatusOK,
+			operation: applyOperationUpdate,
+			force:     "false",
+		},
+		{
+			name:      "preview conflict",
+			path:      applyPreviewPath,
+			body:      `{"update": ` + quote(applyTestConfigMap) + `}`,
+			conflict:  true,
+			code:      http.StatusOK,
+			operation: applyOperationConflict,
+		},
+		{
+			name:      "apply",
+			path:      applyPath,
+			body:      `{"update": ` + quote(applyTestConfigMap) + `}`,
+			code:      http.StatusOK,
+			operation: applyOperationUpdate,
+			force:     "false",
+		},
+		{
+			name:      "apply conflict",
+			path:      applyPath,
+			body:      `{"update": ` + quote(applyTestConfigMap) + `}`,
+			conflict:  true,
+			code:      http.StatusConflict,
+			operation: applyOperationConflict,
+		},
+		{
+			name:      "force apply",
+			path:      applyPath,
+			body:      `{"update": ` + quote(applyTestConfigMap) + `, "force": true}`,
+			code:      http.StatusOK,
+			operation: applyOperationUpdate,
+			force:     "true",
+		},
+		{
+			name:     "read-only",
+			path:     applyPath,
+			body:     `{"update": ` + quote(applyTestConfigMap) + `}`,
+			readOnly: true,
+			code:     http.StatusForbidden,
+		},
+		{
+			name: "invalid request",
+			path: applyPreviewPath,
+			body: `{`,
+			code: http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			cluster := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+				assert.Equal(t, "/api/v1/namespaces/default/configmaps/cm", r.URL.Path)
+
+				if r.Method == http.MethodPatch {
+					if tc.force != "" {
+						assert.Equal(t, tc.force, r.URL.Query().Get("force"))
+					}
+					if tc.conflict {
+						status := kerrors.NewApplyConflict([]metav1.StatusCause{{
+							Type:    metav1.CauseTypeFieldManagerConflict,
+							Field:   ".data.key",
+							Message: `conflict with "kubectl"`,
+						}}, "conflict").Status()
+						status.Kind, status.APIVersion = "Status", "v1"
+						w.WriteHeader(http.StatusConflict)
+						require.NoError(t, json.NewEncoder(w).Encode(status))
+						return
+					}
+				}
+
+				value := "old"
+				if r.Method == http.MethodPatch {
+					value = "new"
+				}
+				_, err := w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMap",` +
+					`"metadata":{"name":"cm","namespace":"default"},"data":{"key":"` + value + `"}}`))
+				require.NoError(t, err)
+			}))
+			defer cluster.Close()
+
+			dynamicClient, err := dynamic.NewForConfig(&rest.Config{Host: cluster.URL})
+			require.NoError(t, err)
+
+			clusterClient := clusterFake.NewMockClientInterface(controller)
+			clusterClient.EXPECT().DynamicClient().Return(dynamicClient, nil).AnyTimes()
+			clusterClient.EXPECT().
+				Resource(schema.GroupKind{Kind: "ConfigMap"}).
+				Return(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, true, nil).
+				AnyTimes()
+
+			dashConfig := configFake.NewMockDash(controller)
+			dashConfig.EXPECT().Logger().Return(log.NopLogger()).AnyTimes()
+			dashConfig.EXPECT().ReadOnly().Return(tc.readOnly).AnyTimes()
+			dashConfig.EXPECT().DefaultNamespace().Return("default").AnyTimes()
+			dashConfig.EXPECT().ClusterClient().Return(clusterClient).AnyTimes()
+
+			handler := applyService(dashConfig)
+			if tc.path == applyPreviewPath {
+				handler = applyPreviewService(dashConfig)
+			}
+
+			server := httptest.NewServer(handler)
+			defer server.Close()
+
+			res, err := http.Post(server.URL, "application/json", strings.NewReader(tc.body))
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			require.Equal(t, tc.code, res.StatusCode)
+			if tc.operation == "" {
+				return
+			}
+
+			data, err := ioutil.ReadAll(res.Body)
+			require.NoError(t, err)
+
+			var got struct {
+				DryRun    bool `json:"dryRun"`
+				Conflicts bool `json:"conflicts"`
+				Applied   bool `json:"applied"`
+				Objects   []struct {
+					Namespace string          `json:"namespace"`
+					Name      string          `json:"name"`
+					Operation string          `json:"operation"`
+					Changes   []string        `json:"changes"`
+					Conflicts []applyConflict `json:"conflicts"`
+					Diff      *struct {
+						Config struct {
+							Original string `json:"original"`
+							Modified string `json:"modified"`
+						} `json:"config"`
+					} `json:"diff"`
+				} `json:"objects"`
+			}
+			require.NoError(t, json.NewDecoder(bytes.NewReader(data)).Decode(&got))
+
+			assert.Equal(t, tc.path == applyPreviewPath, got.DryRun)
+			assert.Equal(t, tc.conflict, got.Conflicts)
+			assert.Equal(t, tc.path == applyPath && !tc.conflict, got.Applied)
+			require.Len(t, got.Objects, 1)
+
+			object := got.Objects[0]
+			assert.Equal(t, "default", object.Namespace)
+			assert.Equal(t, "cm", object.Name)
+			assert.Equal(t, tc.operation, object.Operation)
+
+			if tc.conflict {
+				assert.Equal(t, []applyConflict{{Field: ".data.key", Message: `conflict with "kubectl"`}}, object.Conflicts)
+				assert.Nil(t, object.Diff)
+				return
+			}
+
+			assert.Equal(t, []string{"data.key"}, object.Changes)
+			if got.DryRun {
+				require.NotNil(t, object.Diff)
+				assert.Contains(t, object.Diff.Config.Original, "key: old")
+				assert.Contains(t, object.Diff.Config.Modified, "key: new")
+			} else {
+				assert.Nil(t, object.Diff)
+			}
+		})
+	}
+}
+
+func Test_applyService_partialFailure(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	cluster := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		w.Header().Set("Content-Type", "application/json")
+
+		if r.Method == http.MethodPatch && r.URL.Query().Get("dryRun") == "" &&
+			strings.HasSuffix(r.URL.Path, "/other") {
+			status := kerrors.NewInternalError(fmt.Errorf("unavailable")).Status()
+			status.Kind, status.APIVersion = "Status", "v1"
+			w.WriteHeader(http.StatusInternalServerError)
+			require.NoError(t, json.NewEncoder(w).Encode(status))
+			return
+		}
+
+		name := r.URL.Path[strings.LastIndex(r.URL.Path, "/")+1:]
+		_, err := w.Write([]byte(`{"apiVersion":"v1","kind":"ConfigMap",` +
+			`"metadata":{"name":"` + name + `","namespace":"default"},"data":{"key":"new"}}`))
+		require.NoError(t, err)
+	}))
+	defer cluster.Close()
+
+	dynamicClient, err := dynamic.NewForConfig(&rest.Config{Host: cluster.URL})
+	require.NoError(t, err)
+
+	clusterClient := clusterFake.NewMockClientInterface(controller)
+	clusterClient.EXPECT().DynamicClient().Return(dynamicClient, nil).AnyTimes()
+	clusterClient.EXPECT().
+		Resource(schema.GroupKind{Kind: "ConfigMap"}).
+		Return(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, true, nil).
+		AnyTimes()
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().Logger().Return(log.NopLogger()).AnyTimes()
+	dashConfig.EXPECT().ReadOnly().Return(false).AnyTimes()
+	dashConfig.EXPECT().DefaultNamespace().Return("default").AnyTimes()
+	dashConfig.EXPECT().ClusterClient().Return(clusterClient).AnyTimes()
+
+	server := httptest.NewServer(applyService(dashConfig))
+	defer server.Close()
+
+	update := applyTestConfigMap + "---\n" + strings.Replace(applyTestConfigMap, "name: cm", "name: other", 1)
+	res, err := http.Post(server.URL, "application/json", strings.NewReader(`{"update": `+quote(update)+`}`))
+	require.NoError(t, err)
+	defer res.Body.Close()
+
+	require.Equal(t, http.StatusBadRequest, res.StatusCode)
+
+	var got applyResponse
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&got))
+
+	assert.True(t, got.Applied)
+	assert.Contains(t, got.Error, "unable to apply")
+	require.Len(t, got.Objects, 1)
+	assert.Equal(t, "cm", got.Objects[0].Name)
+}
+
+func quote(s string) string {
+	data, _ := json.Marshal(s)
+	return string(data)
+}
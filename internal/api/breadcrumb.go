@@ -6,6 +6,7 @@
 package api
 
 import (
+	"context"
 	"path"
 	"sort"
 	"strings"
@@ -13,7 +14,6 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 
 	"github.com/vmware-tanzu/octant/internal/module"
-	"github.com/vmware-tanzu/octant/internal/octant"
 	"github.com/vmware-tanzu/octant/internal/util/path_util"
 	"github.com/vmware-tanzu/octant/pkg/store"
 	"github.com/vmware-tanzu/octant/pkg/view/component"
@@ -27,11 +27,11 @@ type LinkDefinition struct {
 }
 
 // Generate breadcrumb for specified path
-func GenerateBreadcrumb(cm *ContentManager, contentPath string, state octant.State, m module.Module, options module.ContentOptions) []component.TitleComponent {
+func GenerateBreadcrumb(ctx context.Context, cm *ContentManager, contentPath, namespace string, m module.Module, options module.ContentOptions) []component.TitleComponent {
 	var title []component.TitleComponent
 	crPath := "custom-resources"
 
-	navs, err := cm.moduleManager.Navigation(cm.ctx, state.GetNamespace(), m.Name())
+	navs, err := cm.moduleManager.Navigation(ctx, namespace, m.Name())
 	if err != nil {
 		return title
 	}
@@ -48,7 +48,7 @@ func GenerateBreadcrumb(cm *ContentManager, contentPath string, state octant.Sta
 			title = append(title, component.NewLink("", parent.Title, path_util.PrefixedPath(parent.Url)), component.NewText(path.Base(contentPath)))
 		}
 	} else {
-		gvk, err := cm.moduleManager.GvkFromPath(path.Dir(contentPath), state.GetNamespace())
+		gvk, err := cm.moduleManager.GvkFromPath(path.Dir(contentPath), namespace)
 		if err != nil {
 			title = append(title, component.NewText(parent.Title))
 			return title
@@ -57,9 +57,9 @@ func GenerateBreadcrumb(cm *ContentManager, contentPath string, state octant.Sta
 		key.Selector = options.LabelSet
 
 		if !isClusterScoped(m) {
-			key.Namespace = state.GetNamespace()
+			key.Namespace = namespace
 		}
-		list, _, err := cm.dashConfig.ObjectStore().List(cm.ctx, key)
+		list, _, err := cm.dashConfig.ObjectStore().List(ctx, key)
 		if err == nil && list.Items != nil && len(list.Items) > 0 {
 			second := dropdownFromList(parent, path.Base(contentPath), list)
 			title = append(title, second, component.NewText(path.Base(contentPath)))
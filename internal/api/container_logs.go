@@ -12,6 +12,8 @@ import (
 	"sync"
 	"time"
 
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
 	"github.com/vmware-tanzu/octant/pkg/event"
 
 	"github.com/vmware-tanzu/octant/internal/gvk"
@@ -25,8 +27,10 @@ import (
 
 type logEntry struct {
 	Timestamp *time.Time `json:"timestamp,omitempty"`
+	Pod       string     `json:"pod,omitempty"`
 	Container string     `json:"container,omitempty"`
 	Message   string     `json:"message,omitempty"`
+	Severity  string     `json:"severity,omitempty"`
 }
 
 const (
@@ -67,15 +71,67 @@ func (s *podLogsStateManager) Handlers() []octant.ClientRequestHandler {
 	}
 }
 
-func (s *podLogsStateManager) StreamPodLogsSubscribe(_ octant.State, payload action.Payload) error {
+// logStreamTarget describes the pods a log stream is for. A stream is either for a
+// single pod, for the pods of a workload, or for the pods matching a label selector.
+type logStreamTarget struct {
+	namespace string
+	podName   string
+	kind      string
+	name      string
+	selector  string
+}
+
+func logStreamTargetFromPayload(payload action.Payload) (logStreamTarget, error) {
 	namespace, err := payload.String("namespace")
 	if err != nil {
-		return fmt.Errorf("getting namespace from payload: %w", err)
+		return logStreamTarget{}, fmt.Errorf("getting namespace from payload: %w", err)
+	}
+
+	target := logStreamTarget{namespace: namespace}
+
+	if target.podName, err = payload.OptionalString("podName"); err != nil {
+		return logStreamTarget{}, fmt.Errorf("getting podName from payload: %w", err)
+	}
+	if target.kind, err = payload.OptionalString("kind"); err != nil {
+		return logStreamTarget{}, fmt.Errorf("getting kind from payload: %w", err)
 	}
+	if target.name, err = payload.OptionalString("name"); err != nil {
+		return logStreamTarget{}, fmt.Errorf("getting name from payload: %w", err)
+	}
+	if target.selector, err = payload.OptionalString("selector"); err != nil {
+		return logStreamTarget{}, fmt.Errorf("getting selector from payload: %w", err)
+	}
+
+	switch {
+	case target.podName != "":
+	case target.kind != "" && target.name != "":
+		if !container.IsLogWorkload(target.kind) {
+			return logStreamTarget{}, fmt.Errorf("can't stream logs for a %s", target.kind)
+		}
+	case target.selector != "":
+	default:
+		return logStreamTarget{}, fmt.Errorf("payload requires one of podName, kind and name, or selector")
+	}
+
+	return target, nil
+}
 
-	podName, err := payload.String("podName")
+// eventType returns the event type logs for the target are sent with.
+func (t logStreamTarget) eventType() event.EventType {
+	switch {
+	case t.podName != "":
+		return event.NewLoggingEventType(t.namespace, t.podName)
+	case t.kind != "":
+		return event.NewWorkloadLoggingEventType(t.namespace, t.kind, t.name)
+	default:
+		return event.NewSelectorLoggingEventType(t.namespace, t.selector)
+	}
+}
+
+func (s *podLogsStateManager) StreamPodLogsSubscribe(_ octant.State, payload action.Payload) error {
+	target, err := logStreamTargetFromPayload(payload)
 	if err != nil {
-		return fmt.Errorf("getting podName from payload: %w", err)
+		return err
 	}
 
 	containerName, err := payload.String("containerName")
@@ -95,7 +151,19 @@ func (s *podLogsStateManager) StreamPodLogsSubscribe(_ octant.State, payload act
 		sinceSeconds = since
 	}
 
-	eventType := event.NewLoggingEventType(namespace, podName)
+	filter, err := logFilterFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	previous := false
+	if _, ok := payload["previous"]; ok {
+		if previous, err = payload.Bool("previous"); err != nil {
+			return fmt.Errorf("getting previous from payload: %w", err)
+		}
+	}
+
+	eventType := target.eventType()
 
 	val, ok := s.podLogSubscriptions.Load(eventType)
 	if ok {
@@ -106,33 +174,98 @@ func (s *podLogsStateManager) StreamPodLogsSubscribe(_ octant.State, payload act
 		cancelFn()
 	}
 
-	key := store.KeyFromGroupVersionKind(gvk.Pod)
-	key.Name = podName
-	key.Namespace = namespace
-
-	logStreamer, err := container.NewLogStreamer(s.ctx, s.config, key, sinceSeconds, containerName)
+	logStreamer, err := s.logStreamer(target, sinceSeconds, containerName, previous)
 	if err != nil {
 		return fmt.Errorf("creating log streamer: %w", err)
 	}
 
-	cancelFn := s.startStream(key, logStreamer)
+	cancelFn := s.startStream(eventType, logStreamer, filter)
 	s.podLogSubscriptions.Store(eventType, cancelFn)
 
 	return nil
 }
 
-func (s *podLogsStateManager) StreamPodLogsUnsubscribe(_ octant.State, payload action.Payload) error {
-	namespace, err := payload.String("namespace")
+func (s *podLogsStateManager) logStreamer(target logStreamTarget, sinceSeconds int64, containerName string, previous bool) (container.LogStreamer, error) {
+	if target.podName != "" {
+		key := store.KeyFromGroupVersionKind(gvk.Pod)
+		key.Name = target.podName
+		key.Namespace = target.namespace
+
+		logStreamer, err := container.NewLogStreamer(s.ctx, s.config, key, sinceSeconds, containerName)
+		if err != nil {
+			return nil, err
+		}
+		logStreamer.SetPrevious(previous)
+		return logStreamer, nil
+	}
+
+	var selector *metav1.LabelSelector
+	if target.kind != "" {
+		key, err := container.WorkloadKey(target.namespace, target.kind, target.name)
+		if err != nil {
+			return nil, err
+		}
+
+		selector, err = container.WorkloadSelector(s.ctx, s.config.ObjectStore(), key)
+		if err != nil {
+			return nil, err
+		}
+	} else {
+		var err error
+		selector, err = metav1.ParseToLabelSelector(target.selector)
+		if err != nil {
+			return nil, fmt.Errorf("parse label selector: %w", err)
+		}
+	}
+
+	var options []container.SelectorLogStreamerOption
+	if previous {
+		options = append(options, container.WithPreviousLogs())
+	}
+
+	return container.NewSelectorLogStreamer(s.ctx, s.config, target.namespace, selector, sinceSeconds, containerName, options...)
+}
+
+// logFilterFromPayload creates a log filter from the optional include, exclude, severity,
+// startTime and endTime payload fields. Times are RFC3339 formatted.
+func logFilterFromPayload(payload action.Payload) (*container.LogFilter, error) {
+	values := make(map[string]string)
+	for _, key := range []string{"include", "exclude", "severity", "startTime", "endTime"} {
+		value, err := payload.OptionalString(key)
+		if err != nil {
+			return nil, fmt.Errorf("getting %s from payload: %w", key, err)
+		}
+		values[key] = value
+	}
+
+	start, err := parseOptionalTime(values["startTime"])
 	if err != nil {
-		return fmt.Errorf("getting namespace from payload: %w", err)
+		return nil, fmt.Errorf("parse startTime: %w", err)
+	}
+
+	end, err := parseOptionalTime(values["endTime"])
+	if err != nil {
+		return nil, fmt.Errorf("parse endTime: %w", err)
+	}
+
+	return container.NewLogFilter(values["include"], values["exclude"], values["severity"], start, end)
+}
+
+func parseOptionalTime(s string) (time.Time, error) {
+	if s == "" {
+		return time.Time{}, nil
 	}
 
-	podName, err := payload.String("podName")
+	return time.Parse(time.RFC3339, s)
+}
+
+func (s *podLogsStateManager) StreamPodLogsUnsubscribe(_ octant.State, payload action.Payload) error {
+	target, err := logStreamTargetFromPayload(payload)
 	if err != nil {
-		return fmt.Errorf("getting podName from payload: %w", err)
+		return err
 	}
 
-	eventType := event.NewLoggingEventType(namespace, podName)
+	eventType := target.eventType()
 	val, ok := s.podLogSubscriptions.Load(eventType)
 	if ok {
 		cancelFn, ok := val.(context.CancelFunc)
@@ -150,7 +283,7 @@ func (s *podLogsStateManager) Start(ctx context.Context, _ octant.State, client
 	s.ctx = ctx
 }
 
-func (s *podLogsStateManager) streamEventsToClient(ctx context.Context, logEventType event.EventType, logCh <-chan container.LogEntry) {
+func (s *podLogsStateManager) streamEventsToClient(ctx context.Context, logEventType event.EventType, logCh <-chan container.LogEntry, filter *container.LogFilter) {
 	done := false
 	for !done {
 		select {
@@ -159,6 +292,11 @@ func (s *podLogsStateManager) streamEventsToClient(ctx context.Context, logEvent
 		case entry, ok := <-logCh:
 			if ok {
 				le := newLogEntry(entry.Line(), entry.Container())
+				if !filter.Match(le.Message, le.Timestamp) {
+					continue
+				}
+				le.Pod = entry.Pod()
+				le.Severity = container.DetectSeverity(le.Message).String()
 				logEvent := event.Event{
 					Type: logEventType,
 					Data: le,
@@ -172,12 +310,11 @@ func (s *podLogsStateManager) streamEventsToClient(ctx context.Context, logEvent
 	}
 }
 
-func (s *podLogsStateManager) startStream(key store.Key, logStreamer container.LogStreamer) context.CancelFunc {
+func (s *podLogsStateManager) startStream(eventType event.EventType, logStreamer container.LogStreamer, filter *container.LogFilter) context.CancelFunc {
 	ctx, cancelFn := context.WithCancel(s.ctx)
 
-	eventType := event.NewLoggingEventType(key.Namespace, key.Name)
 	logCh := make(chan container.LogEntry)
-	go s.streamEventsToClient(ctx, eventType, logCh)
+	go s.streamEventsToClient(ctx, eventType, logCh, filter)
 
 	logStreamer.Stream(ctx, logCh)
 
@@ -203,5 +340,8 @@ func formatTimestamp(line string) (string, time.Time, error) {
 	if err != nil {
 		return "", ts, err
 	}
+	if len(parts) == 1 {
+		return "", ts, nil
+	}
 	return parts[1], ts, nil
 }
@@ -0,0 +1,176 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"net/http"
+	"strconv"
+	"time"
+
+	"github.com/gorilla/mux"
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/vmware-tanzu/octant/internal/cluster"
+	"github.com/vmware-tanzu/octant/internal/config"
+	"github.com/vmware-tanzu/octant/internal/gvk"
+	"github.com/vmware-tanzu/octant/internal/mime"
+	"github.com/vmware-tanzu/octant/internal/modules/overview/container"
+	"github.com/vmware-tanzu/octant/internal/util/kubernetes"
+	"github.com/vmware-tanzu/octant/pkg/action"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+// logsDownloadPath is the path for downloading the logs of a pod. The query accepts
+// container, sinceSeconds, previous, and the filter fields accepted by the pod logs
+// subscription: include, exclude, severity, startTime and endTime.
+const logsDownloadPath = "/logs/namespace/{namespace}/pod/{pod}"
+
+func logsDownloadService(dashConfig config.Dash) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		serveLogsDownload(dashConfig, w, r)
+	}
+}
+
+func serveLogsDownload(dashConfig config.Dash, w http.ResponseWriter, r *http.Request) {
+	logger := dashConfig.Logger().With("component", "logs-download")
+
+	vars := mux.Vars(r)
+	namespace, podName := vars["namespace"], vars["pod"]
+	query := r.URL.Query()
+
+	payload := action.Payload{}
+	for _, key := range []string{"include", "exclude", "severity", "startTime", "endTime"} {
+		payload[key] = query.Get(key)
+	}
+
+	filter, err := logFilterFromPayload(payload)
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error(), logger)
+		return
+	}
+
+	options := corev1.PodLogOptions{
+		Timestamps: true,
+	}
+
+	if previous := query.Get("previous"); previous != "" {
+		if options.Previous, err = strconv.ParseBool(previous); err != nil {
+			RespondWithError(w, http.StatusBadRequest, "previous must be a boolean", logger)
+			return
+		}
+	}
+
+	if since := query.Get("sinceSeconds"); since != "" && !options.Previous {
+		sinceSeconds, err := strconv.ParseInt(since, 10, 64)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, "sinceSeconds must be an integer", logger)
+			return
+		}
+		if sinceSeconds > 0 {
+			options.SinceSeconds = &sinceSeconds
+		}
+	}
+
+	ctx := r.Context()
+
+	containerNames := []string{query.Get("container")}
+	if containerNames[0] == "" {
+		containerNames, err = podContainerNames(ctx, dashConfig, namespace, podName)
+		if err != nil {
+			RespondWithError(w, http.StatusNotFound, err.Error(), logger)
+			return
+		}
+	}
+
+	filename := podName
+	if len(containerNames) == 1 {
+		filename = fmt.Sprintf("%s-%s", podName, containerNames[0])
+	}
+
+	w.Header().Set("Content-Type", mime.TextContentType)
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename+".log"))
+
+	for _, containerName := range containerNames {
+		prefix := ""
+		if len(containerNames) > 1 {
+			prefix = fmt.Sprintf("[%s] ", containerName)
+		}
+
+		containerOptions := options
+		containerOptions.Container = containerName
+
+		if err := writeContainerLogs(ctx, dashConfig, namespace, podName, &containerOptions, filter, prefix, w); err != nil {
+			logger.WithErr(err).Errorf("download logs for %s/%s", podName, containerName)
+		}
+	}
+}
+
+func podContainerNames(ctx context.Context, dashConfig config.Dash, namespace, podName string) ([]string, error) {
+	key := store.KeyFromGroupVersionKind(gvk.Pod)
+	key.Namespace = namespace
+	key.Name = podName
+
+	object, err := dashConfig.ObjectStore().Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("get pod: %w", err)
+	}
+	if object == nil {
+		return nil, fmt.Errorf("pod %s/%s was not found", namespace, podName)
+	}
+
+	var pod corev1.Pod
+	if err := kubernetes.FromUnstructured(object, &pod); err != nil {
+		return nil, fmt.Errorf("converting unstructured: %w", err)
+	}
+
+	var names []string
+	for _, c := range pod.Spec.Containers {
+		names = append(names, c.Name)
+	}
+
+	return names, nil
+}
+
+func writeContainerLogs(ctx context.Context, dashConfig config.Dash, namespace, podName string, options *corev1.PodLogOptions, filter *container.LogFilter, prefix string, w io.Writer) error {
+	clusterClient, err := cluster.ClientForContext(ctx, dashConfig.ClusterClient())
+	if err != nil {
+		return err
+	}
+
+	client, err := clusterClient.KubernetesClient()
+	if err != nil {
+		return err
+	}
+
+	stream, err := client.CoreV1().Pods(namespace).GetLogs(podName, options).Stream(ctx)
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	for scanner.Scan() {
+		le := newLogEntry(scanner.Text(), options.Container)
+		if !filter.Match(le.Message, le.Timestamp) {
+			continue
+		}
+
+		line := prefix + le.Message
+		if le.Timestamp != nil {
+			line = fmt.Sprintf("%s %s", le.Timestamp.Format(time.RFC3339Nano), line)
+		}
+
+		if _, err := fmt.Fprintln(w, line); err != nil {
+			return err
+		}
+	}
+
+	return scanner.Err()
+}
@@ -0,0 +1,117 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	clusterFake "github.com/vmware-tanzu/octant/internal/cluster/fake"
+	configFake "github.com/vmware-tanzu/octant/internal/config/fake"
+	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/testutil"
+	storeFake "github.com/vmware-tanzu/octant/pkg/store/fake"
+)
+
+func Test_logsDownloadService(t *testing.T) {
+	pod := testutil.CreatePod("pod", func(pod *corev1.Pod) {
+		pod.Spec.Containers = []corev1.Container{{Name: "app"}, {Name: "sidecar"}}
+	})
+
+	cases := []struct {
+		name        string
+		query       string
+		code        int
+		body        string
+		disposition string
+	}{
+		{
+			name:        "single container",
+			query:       "?container=app",
+			code:        http.StatusOK,
+			body:        "fake logs\n",
+			disposition: `attachment; filename="pod-app.log"`,
+		},
+		{
+			name:        "all containers",
+			query:       "?include=fake",
+			code:        http.StatusOK,
+			body:        "[app] fake logs\n[sidecar] fake logs\n",
+			disposition: `attachment; filename="pod.log"`,
+		},
+		{
+			name:  "excluded",
+			query: "?container=app&exclude=fake",
+			code:  http.StatusOK,
+			body:  "",
+		},
+		{
+			name:  "invalid expression",
+			query: "?include=(",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "invalid previous",
+			query: "?container=app&previous=maybe",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "invalid start time",
+			query: "?container=app&startTime=yesterday",
+			code:  http.StatusBadRequest,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			objectStore := storeFake.NewMockStore(controller)
+			objectStore.EXPECT().Get(gomock.Any(), gomock.Any()).Return(testutil.ToUnstructured(t, pod), nil).AnyTimes()
+
+			clusterClient := clusterFake.NewMockClientInterface(controller)
+			clusterClient.EXPECT().KubernetesClient().Return(kubefake.NewSimpleClientset(), nil).AnyTimes()
+
+			dashConfig := configFake.NewMockDash(controller)
+			dashConfig.EXPECT().Logger().Return(log.NopLogger()).AnyTimes()
+			dashConfig.EXPECT().ObjectStore().Return(objectStore).AnyTimes()
+			dashConfig.EXPECT().ClusterClient().Return(clusterClient).AnyTimes()
+
+			router := mux.NewRouter()
+			router.Handle(logsDownloadPath, logsDownloadService(dashConfig))
+
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			res, err := http.Get(server.URL + "/logs/namespace/default/pod/pod" + tc.query)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			require.Equal(t, tc.code, res.StatusCode)
+			if tc.code != http.StatusOK {
+				return
+			}
+
+			body, err := ioutil.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, tc.body, string(body))
+
+			if tc.disposition != "" {
+				assert.Equal(t, tc.disposition, res.Header.Get("Content-Disposition"))
+			}
+		})
+	}
+}
@@ -10,9 +10,11 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
 
 	configFake "github.com/vmware-tanzu/octant/internal/config/fake"
 	"github.com/vmware-tanzu/octant/internal/modules/overview/container"
+	"github.com/vmware-tanzu/octant/pkg/action"
 	"github.com/vmware-tanzu/octant/pkg/store"
 )
 
@@ -32,6 +34,153 @@ func TestContainerLogs_NewLogEntry(t *testing.T) {
 	assert.Equal(t, ts.String(), le.Timestamp.String())
 }
 
+func TestContainerLogs_logStreamTargetFromPayload(t *testing.T) {
+	cases := []struct {
+		name      string
+		payload   action.Payload
+		eventType event.EventType
+		isErr     bool
+	}{
+		{
+			name:      "pod",
+			payload:   action.Payload{"namespace": "test-ns", "podName": "test-pod"},
+			eventType: event.NewLoggingEventType("test-ns", "test-pod"),
+		},
+		{
+			name:      "workload",
+			payload:   action.Payload{"namespace": "test-ns", "kind": "Deployment", "name": "web"},
+			eventType: "event.octant.dev/logging/namespace/test-ns/deployment/web",
+		},
+		{
+			name:      "selector",
+			payload:   action.Payload{"namespace": "test-ns", "selector": "app=web"},
+			eventType: "event.octant.dev/logging/namespace/test-ns/selector/app=web",
+		},
+		{
+			name:    "unsupported workload",
+			payload: action.Payload{"namespace": "test-ns", "kind": "ConfigMap", "name": "web"},
+			isErr:   true,
+		},
+		{
+			name:    "no target",
+			payload: action.Payload{"namespace": "test-ns"},
+			isErr:   true,
+		},
+		{
+			name:    "no namespace",
+			payload: action.Payload{"podName": "test-pod"},
+			isErr:   true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			target, err := logStreamTargetFromPayload(tc.payload)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.eventType, target.eventType())
+		})
+	}
+}
+
+func TestContainerLogs_SendLogEventsPod(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	dashConfig := configFake.NewMockDash(controller)
+	client := newOctantClient()
+	defer client.Close()
+
+	eventType := event.NewSelectorLoggingEventType("test-ns", "app=web")
+	logCh := make(chan container.LogEntry, 1)
+
+	s := NewPodLogsStateManager(dashConfig)
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Start(ctx, nil, client)
+
+	go func() {
+		s.streamEventsToClient(ctx, eventType, logCh, nil)
+	}()
+
+	logCh <- container.NewPodLogEntry("web-1", "app", "testing log line")
+
+	<-client.ch
+	cancel()
+	close(logCh)
+
+	clientLe, ok := client.sendCalledWith.Data.(logEntry)
+	require.True(t, ok)
+	assert.Equal(t, "web-1", clientLe.Pod)
+	assert.Equal(t, "app", clientLe.Container)
+	assert.Equal(t, "testing log line", clientLe.Message)
+}
+
+func TestContainerLogs_NewLogEntry_empty_line(t *testing.T) {
+	le := newLogEntry("1985-04-12T23:20:50.52Z", "container-name")
+	assert.Equal(t, "", le.Message)
+	assert.NotNil(t, le.Timestamp)
+}
+
+func TestContainerLogs_logFilterFromPayload(t *testing.T) {
+	filter, err := logFilterFromPayload(action.Payload{
+		"include":   "GET",
+		"severity":  "error",
+		"startTime": "2021-01-01T10:00:00Z",
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, "GET", filter.Include.String())
+	assert.Nil(t, filter.Exclude)
+	assert.Equal(t, container.SeverityError, filter.MinSeverity)
+	assert.Equal(t, time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC), filter.Start.UTC())
+	assert.True(t, filter.End.IsZero())
+
+	_, err = logFilterFromPayload(action.Payload{"endTime": "tomorrow"})
+	require.Error(t, err)
+
+	_, err = logFilterFromPayload(action.Payload{"exclude": "("})
+	require.Error(t, err)
+}
+
+func TestContainerLogs_SendLogEventsFiltered(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	dashConfig := configFake.NewMockDash(controller)
+	client := newOctantClient()
+	defer client.Close()
+
+	filter, err := container.NewLogFilter("", "healthz", "", time.Time{}, time.Time{})
+	require.NoError(t, err)
+
+	eventType := event.NewLoggingEventType("test-ns", "test-pod")
+	logCh := make(chan container.LogEntry, 2)
+
+	s := NewPodLogsStateManager(dashConfig)
+	ctx, cancel := context.WithCancel(context.Background())
+	s.Start(ctx, nil, client)
+
+	go func() {
+		s.streamEventsToClient(ctx, eventType, logCh, filter)
+	}()
+
+	logCh <- container.NewLogEntry("app", "GET /healthz")
+	logCh <- container.NewLogEntry("app", `{"level":"error","msg":"GET /"}`)
+
+	<-client.ch
+	cancel()
+	close(logCh)
+
+	clientLe, ok := client.sendCalledWith.Data.(logEntry)
+	require.True(t, ok)
+	assert.Equal(t, `{"level":"error","msg":"GET /"}`, clientLe.Message)
+	assert.Equal(t, "error", clientLe.Severity)
+}
+
 func TestContainerLogs_SendLogEventsStops(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
@@ -47,7 +196,7 @@ func TestContainerLogs_SendLogEventsStops(t *testing.T) {
 	var wg sync.WaitGroup
 	wg.Add(1)
 	go func() {
-		s.streamEventsToClient(s.ctx, eventType, logCh)
+		s.streamEventsToClient(s.ctx, eventType, logCh, nil)
 		wg.Done()
 	}()
 
@@ -79,7 +228,7 @@ func TestContainerLogs_SendLogEventsClientSend(t *testing.T) {
 	s.Start(ctx, nil, client)
 
 	go func() {
-		s.streamEventsToClient(ctx, eventType, logCh)
+		s.streamEventsToClient(ctx, eventType, logCh, nil)
 	}()
 
 	le := container.NewLogEntry("container-a", "testing log line")
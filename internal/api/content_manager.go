@@ -25,6 +25,7 @@ import (
 	internalLog "github.com/vmware-tanzu/octant/internal/log"
 	"github.com/vmware-tanzu/octant/internal/module"
 	"github.com/vmware-tanzu/octant/internal/octant"
+	"github.com/vmware-tanzu/octant/internal/scheduling"
 	"github.com/vmware-tanzu/octant/pkg/action"
 	"github.com/vmware-tanzu/octant/pkg/log"
 	"github.com/vmware-tanzu/octant/pkg/view/component"
@@ -177,7 +178,23 @@ func (cm *ContentManager) runUpdate(state octant.State, s OctantClient) PollerFu
 }
 
 func (cm *ContentManager) generateContent(ctx context.Context, state octant.State) (Content, bool, error) {
-	contentPath := state.GetContentPath()
+	options := module.ContentOptions{
+		LabelSet: FiltersToLabelSet(state.GetFilters()),
+	}
+
+	ctx = ocontext.WithWebsocketClientID(ctx, state.GetClientID())
+
+	content, _, err := cm.generate(ctx, state.GetContentPath(), state.GetNamespace(), options)
+	if err != nil {
+		return emptyContent, false, err
+	}
+
+	return content, false, nil
+}
+
+// generate generates the content for a content path. If the module owning the path
+// reports it doesn't exist, found is false and the content is a not found page.
+func (cm *ContentManager) generate(ctx context.Context, contentPath, namespace string, options module.ContentOptions) (content Content, found bool, err error) {
 	logger := cm.logger.With("contentPath", contentPath)
 
 	now := time.Now()
@@ -190,32 +207,33 @@ func (cm *ContentManager) generateContent(ctx context.Context, state octant.Stat
 		return emptyContent, false, fmt.Errorf("unable to find module for content path %q", contentPath)
 	}
 	modulePath := strings.TrimPrefix(contentPath, m.Name())
-	options := module.ContentOptions{
-		LabelSet: FiltersToLabelSet(state.GetFilters()),
-	}
 
-	ctx = ocontext.WithWebsocketClientID(ctx, state.GetClientID())
+	// Pending pods in the content share the cluster state their scheduling is
+	// diagnosed with.
+	ctx = scheduling.WithStateCache(ctx)
 
+	found = true
 	contentResponse, err := m.Content(ctx, modulePath, options)
 	if err != nil {
 		if nfe, ok := err.(notFound); ok && nfe.NotFound() {
 			logger.Debugf("path not found")
 			contentResponse = notFoundPage(contentPath)
+			found = false
 		} else {
 			return emptyContent, false, fmt.Errorf("generate content: %w", err)
 		}
 	}
 
-	title := GenerateBreadcrumb(cm, contentPath, state, m, options)
+	title := GenerateBreadcrumb(ctx, cm, contentPath, namespace, m, options)
 	if len(title) > 0 {
 		contentResponse.Title = title
 	}
 
-	content := Content{
+	content = Content{
 		Response: contentResponse,
 		Path:     contentPath,
 	}
-	return content, false, nil
+	return content, found, nil
 }
 
 // Handlers returns a slice of client request handlers.
@@ -87,6 +87,12 @@ func shouldAllowHost(host string, acceptedHosts []string) bool {
 	return dashstrings.Contains(host, acceptedHosts)
 }
 
+// SameOriginHandler is a middleware that only accepts requests for the accepted
+// hosts which come from the same origin.
+func SameOriginHandler(ctx context.Context) mux.MiddlewareFunc {
+	return rebindHandler(ctx, acceptedHosts())
+}
+
 // rebindHandler is a middleware that will only accept the supplied hosts
 func rebindHandler(ctx context.Context, acceptedHosts []string) mux.MiddlewareFunc {
 	return func(h http.Handler) http.Handler {
@@ -129,9 +129,11 @@ func NavigationGenerator(ctx context.Context, state octant.State, config Navigat
 		return nil, errors.New("navigation config is nil")
 	}
 
-	modules := config.ModuleManager().Modules()
-	namespace := state.GetNamespace()
+	return moduleNavigation(ctx, config.ModuleManager().Modules(), state.GetNamespace())
+}
 
+// moduleNavigation generates the navigation tree for modules in a namespace.
+func moduleNavigation(ctx context.Context, modules []module.Module, namespace string) ([]navigation.Navigation, error) {
 	var sections []navigation.Navigation
 
 	lookup := make(map[string][]navigation.Navigation)
@@ -0,0 +1,73 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/vmware-tanzu/octant/internal/config"
+	"github.com/vmware-tanzu/octant/internal/resourceviewer"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+func resourceViewerExportService(dashConfig config.Dash) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		serveResourceViewerExport(dashConfig, w, r)
+	}
+}
+
+// serveResourceViewerExport exports the resource viewer graph of an object as DOT,
+// SVG or JSON.
+func serveResourceViewerExport(dashConfig config.Dash, w http.ResponseWriter, r *http.Request) {
+	logger := dashConfig.Logger().With("component", "resource-viewer-export")
+
+	query := r.URL.Query()
+
+	format, err := resourceviewer.ParseExportFormat(query.Get("format"))
+	if err != nil {
+		RespondWithError(w, http.StatusBadRequest, err.Error(), logger)
+		return
+	}
+
+	key := store.Key{
+		Namespace:  query.Get("namespace"),
+		APIVersion: query.Get("apiVersion"),
+		Kind:       query.Get("kind"),
+		Name:       query.Get("name"),
+	}
+	if key.APIVersion == "" || key.Kind == "" || key.Name == "" {
+		RespondWithError(w, http.StatusBadRequest, "apiVersion, kind and name are required", logger)
+		return
+	}
+
+	ctx := r.Context()
+
+	object, err := dashConfig.ObjectStore().Get(ctx, key)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, fmt.Sprintf("get object: %s", err), logger)
+		return
+	}
+	if object == nil {
+		RespondWithError(w, http.StatusNotFound, fmt.Sprintf("%s %s was not found", key.Kind, key.Name), logger)
+		return
+	}
+
+	data, err := resourceviewer.ExportObject(ctx, dashConfig, object, format)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+		return
+	}
+
+	filename := fmt.Sprintf("%s-%s.%s", strings.ToLower(key.Kind), key.Name, format)
+
+	w.Header().Set("Content-Type", format.ContentType())
+	w.Header().Set("Content-Disposition", fmt.Sprintf("attachment; filename=%q", filename))
+	if _, err := w.Write(data); err != nil {
+		logger.WithErr(err).Errorf("write resource viewer export")
+	}
+}
@@ -0,0 +1,79 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/require"
+
+	configFake "github.com/vmware-tanzu/octant/internal/config/fake"
+	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/resourceviewer"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	storeFake "github.com/vmware-tanzu/octant/pkg/store/fake"
+)
+
+func Test_resourceViewerExportService(t *testing.T) {
+	cases := []struct {
+		name  string
+		query string
+		init  func(objectStore *storeFake.MockStore)
+		code  int
+	}{
+		{
+			name:  "unknown format",
+			query: "?apiVersion=apps/v1&kind=Deployment&namespace=default&name=web&format=png",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "missing name",
+			query: "?apiVersion=apps/v1&kind=Deployment&namespace=default",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "object not found",
+			query: "?apiVersion=apps/v1&kind=Deployment&namespace=default&name=web&format=dot",
+			init: func(objectStore *storeFake.MockStore) {
+				key := store.Key{Namespace: "default", APIVersion: "apps/v1", Kind: "Deployment", Name: "web"}
+				objectStore.EXPECT().Get(gomock.Any(), key).Return(nil, nil)
+			},
+			code: http.StatusNotFound,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			objectStore := storeFake.NewMockStore(controller)
+			if tc.init != nil {
+				tc.init(objectStore)
+			}
+
+			dashConfig := configFake.NewMockDash(controller)
+			dashConfig.EXPECT().Logger().Return(log.NopLogger()).AnyTimes()
+			dashConfig.EXPECT().ObjectStore().Return(objectStore).AnyTimes()
+
+			router := mux.NewRouter()
+			router.Handle(resourceviewer.ExportRoute, resourceViewerExportService(dashConfig))
+
+			server := httptest.NewServer(router)
+			defer server.Close()
+
+			res, err := http.Get(server.URL + resourceviewer.ExportRoute + tc.query)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			require.Equal(t, tc.code, res.StatusCode)
+		})
+	}
+}
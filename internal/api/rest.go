@@ -0,0 +1,224 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/vmware-tanzu/octant/internal/config"
+	"github.com/vmware-tanzu/octant/internal/event"
+	"github.com/vmware-tanzu/octant/internal/module"
+	"github.com/vmware-tanzu/octant/internal/octant"
+	"github.com/vmware-tanzu/octant/pkg/log"
+	"github.com/vmware-tanzu/octant/pkg/navigation"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+// Read-only REST endpoints. They return the same JSON as the websocket events, so
+// tools can reuse Octant's views without speaking the websocket protocol.
+const (
+	// restContentPath serves the content for a content path. The namespace and
+	// filters (key:value) query parameters work like the dashboard's.
+	restContentPath = "/content/{path:.*}"
+	// restNavigationPath serves the navigation tree for the namespace query parameter.
+	restNavigationPath = "/navigation"
+	// restNamespacesPath serves the namespace names.
+	restNamespacesPath = "/namespaces"
+	// restContextsPath serves the kube config contexts.
+	restContextsPath = "/contexts"
+	// restObjectsPath serves objects from the object store. Query parameters are the
+	// fields of store.Key: apiVersion, kind, namespace, name and selector. A list is
+	// returned when name is empty.
+	restObjectsPath = "/objects"
+)
+
+type restContent struct {
+	Content     component.ContentResponse `json:"content"`
+	Namespace   string                    `json:"namespace"`
+	ContentPath string                    `json:"contentPath"`
+}
+
+type restNavigation struct {
+	Sections []navigation.Navigation `json:"sections"`
+}
+
+type restNamespaces struct {
+	Namespaces []string `json:"namespaces"`
+}
+
+// restService registers the REST endpoints on a router.
+type restService struct {
+	dashConfig config.Dash
+	logger     log.Logger
+}
+
+func newRESTService(dashConfig config.Dash) *restService {
+	return &restService{
+		dashConfig: dashConfig,
+		logger:     dashConfig.Logger().With("component", "rest"),
+	}
+}
+
+func (s *restService) register(router *mux.Router) {
+	router.HandleFunc(restContentPath, s.content).Methods(http.MethodGet)
+	router.HandleFunc(restNavigationPath, s.navigation).Methods(http.MethodGet)
+	router.HandleFunc(restNamespacesPath, s.namespaces).Methods(http.MethodGet)
+	router.HandleFunc(restContextsPath, s.contexts).Methods(http.MethodGet)
+	router.HandleFunc(restObjectsPath, s.objects).Methods(http.MethodGet)
+}
+
+func (s *restService) namespace(r *http.Request) string {
+	if namespace := r.URL.Query().Get("namespace"); namespace != "" {
+		return namespace
+	}
+	return s.dashConfig.DefaultNamespace()
+}
+
+// content serves the content for a path. The ETag is the content's checksum, so
+// clients polling a path can send If-None-Match and get 304 Not Modified.
+func (s *restService) content(w http.ResponseWriter, r *http.Request) {
+	contentPath := mux.Vars(r)["path"]
+	namespace := s.namespace(r)
+
+	var filters []octant.Filter
+	for _, raw := range r.URL.Query()["filters"] {
+		filter, err := ParseFilterQueryParam(raw)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, err.Error(), s.logger)
+			return
+		}
+		filters = append(filters, filter)
+	}
+
+	options := module.ContentOptions{
+		LabelSet: FiltersToLabelSet(filters),
+	}
+
+	cm := NewContentManager(s.dashConfig.ModuleManager(), s.dashConfig, s.logger)
+	content, found, err := cm.generate(r.Context(), contentPath, namespace, options)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, err.Error(), s.logger)
+		return
+	}
+
+	etag := fmt.Sprintf("%q", fmt.Sprintf("%x", content.Checksum()))
+	w.Header().Set("ETag", etag)
+	if found && etagMatches(r.Header.Get("If-None-Match"), etag) {
+		w.WriteHeader(http.StatusNotModified)
+		return
+	}
+
+	code := http.StatusOK
+	if !found {
+		code = http.StatusNotFound
+	}
+
+	respondWithJSON(w, code, &restContent{
+		Content:     content.Response,
+		Namespace:   namespace,
+		ContentPath: contentPath,
+	}, s.logger)
+}
+
+func (s *restService) navigation(w http.ResponseWriter, r *http.Request) {
+	sections, err := moduleNavigation(r.Context(), s.dashConfig.ModuleManager().Modules(), s.namespace(r))
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, err.Error(), s.logger)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, &restNavigation{Sections: sections}, s.logger)
+}
+
+func (s *restService) namespaces(w http.ResponseWriter, r *http.Request) {
+	names, err := NamespacesGenerator(r.Context(), s.dashConfig)
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, err.Error(), s.logger)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, &restNamespaces{Namespaces: names}, s.logger)
+}
+
+func (s *restService) contexts(w http.ResponseWriter, r *http.Request) {
+	ev, err := event.NewContextsGenerator(s.dashConfig).Event(r.Context())
+	if err != nil {
+		RespondWithError(w, http.StatusInternalServerError, err.Error(), s.logger)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, ev.Data, s.logger)
+}
+
+func (s *restService) objects(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	key := store.Key{
+		APIVersion: query.Get("apiVersion"),
+		Kind:       query.Get("kind"),
+		Namespace:  query.Get("namespace"),
+		Name:       query.Get("name"),
+	}
+	if key.APIVersion == "" || key.Kind == "" {
+		RespondWithError(w, http.StatusBadRequest, "apiVersion and kind are required", s.logger)
+		return
+	}
+
+	if selector := query.Get("selector"); selector != "" {
+		set, err := labels.ConvertSelectorToLabelsMap(selector)
+		if err != nil {
+			RespondWithError(w, http.StatusBadRequest, fmt.Sprintf("invalid selector: %v", err), s.logger)
+			return
+		}
+		key.Selector = &set
+	}
+
+	objectStore := s.dashConfig.ObjectStore()
+
+	if key.Name == "" {
+		list, _, err := objectStore.List(r.Context(), key)
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error(), s.logger)
+			return
+		}
+		respondWithJSON(w, http.StatusOK, list, s.logger)
+		return
+	}
+
+	object, err := objectStore.Get(r.Context(), key)
+	if err != nil {
+		code := http.StatusInternalServerError
+		if kerrors.IsNotFound(err) {
+			code = http.StatusNotFound
+		}
+		RespondWithError(w, code, err.Error(), s.logger)
+		return
+	}
+	if object == nil {
+		RespondWithError(w, http.StatusNotFound, fmt.Sprintf("%s not found", key), s.logger)
+		return
+	}
+
+	respondWithJSON(w, http.StatusOK, object, s.logger)
+}
+
+// etagMatches returns true if an If-None-Match header contains etag.
+func etagMatches(header, etag string) bool {
+	for _, candidate := range strings.Split(header, ",") {
+		candidate = strings.TrimSpace(candidate)
+		if candidate == "*" || strings.TrimPrefix(candidate, "W/") == etag {
+			return true
+		}
+	}
+	return false
+}
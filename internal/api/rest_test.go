@@ -0,0 +1,281 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"context"
+	"encoding/json"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	clusterFake "github.com/vmware-tanzu/octant/internal/cluster/fake"
+	configFake "github.com/vmware-tanzu/octant/internal/config/fake"
+	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/module"
+	moduleFake "github.com/vmware-tanzu/octant/internal/module/fake"
+	"github.com/vmware-tanzu/octant/pkg/navigation"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	storeFake "github.com/vmware-tanzu/octant/pkg/store/fake"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+type restTestMocks struct {
+	dashConfig      *configFake.MockDash
+	moduleManager   *moduleFake.MockManagerInterface
+	module          *moduleFake.MockModule
+	objectStore     *storeFake.MockStore
+	namespaceClient *clusterFake.MockNamespaceInterface
+}
+
+func newRESTTestServer(controller *gomock.Controller) (*httptest.Server, restTestMocks) {
+	mocks := restTestMocks{
+		dashConfig:      configFake.NewMockDash(controller),
+		moduleManager:   moduleFake.NewMockManagerInterface(controller),
+		module:          moduleFake.NewMockModule(controller),
+		objectStore:     storeFake.NewMockStore(controller),
+		namespaceClient: clusterFake.NewMockNamespaceInterface(controller),
+	}
+
+	clusterClient := clusterFake.NewMockClientInterface(controller)
+	clusterClient.EXPECT().NamespaceClient().Return(mocks.namespaceClient, nil).AnyTimes()
+
+	mocks.dashConfig.EXPECT().Logger().Return(log.NopLogger()).AnyTimes()
+	mocks.dashConfig.EXPECT().DefaultNamespace().Return("default").AnyTimes()
+	mocks.dashConfig.EXPECT().ModuleManager().Return(mocks.moduleManager).AnyTimes()
+	mocks.dashConfig.EXPECT().ObjectStore().Return(mocks.objectStore).AnyTimes()
+	mocks.dashConfig.EXPECT().ClusterClient().Return(clusterClient).AnyTimes()
+
+	mocks.module.EXPECT().Name().Return("module").AnyTimes()
+	mocks.module.EXPECT().ContentPath().Return("module").AnyTimes()
+	mocks.module.EXPECT().Description().Return("Module").AnyTimes()
+	mocks.module.EXPECT().
+		Navigation(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, namespace, prefix string) ([]navigation.Navigation, error) {
+			return []navigation.Navigation{{Title: namespace, Path: prefix}}, nil
+		}).
+		AnyTimes()
+	mocks.module.EXPECT().
+		Content(gomock.Any(), gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, contentPath string, options module.ContentOptions) (component.ContentResponse, error) {
+			if contentPath != "/page" {
+				return component.ContentResponse{}, NewNotFoundError(contentPath)
+			}
+			text := component.NewText("content")
+			if options.LabelSet != nil && options.LabelSet.Has("app") {
+				text = component.NewText("filtered " + options.LabelSet.Get("app"))
+			}
+			return component.ContentResponse{Components: []component.Component{text}}, nil
+		}).
+		AnyTimes()
+
+	mocks.moduleManager.EXPECT().Modules().Return([]module.Module{mocks.module}).AnyTimes()
+	mocks.moduleManager.EXPECT().
+		ModuleForContentPath(gomock.Any()).
+		DoAndReturn(func(contentPath string) (module.Module, bool) {
+			return mocks.module, contentPath == "module/page" || contentPath == "module/missing"
+		}).
+		AnyTimes()
+	mocks.moduleManager.EXPECT().Navigation(gomock.Any(), gomock.Any(), "module").Return(nil, nil).AnyTimes()
+	mocks.moduleManager.EXPECT().GvkFromPath(gomock.Any(), gomock.Any()).Return(schema.GroupVersionKind{}, kerrors.NewBadRequest("no gvk")).AnyTimes()
+
+	router := mux.NewRouter()
+	newRESTService(mocks.dashConfig).register(router)
+
+	return httptest.NewServer(router), mocks
+}
+
+func TestRESTService_content(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	server, _ := newRESTTestServer(controller)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/content/module/page")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var got struct {
+		Content struct {
+			ViewComponents []struct {
+				Config struct {
+					Value string `json:"value"`
+				} `json:"config"`
+			} `json:"viewComponents"`
+		} `json:"content"`
+		Namespace   string `json:"namespace"`
+		ContentPath string `json:"contentPath"`
+	}
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&got))
+	require.Len(t, got.Content.ViewComponents, 1)
+	assert.Equal(t, "content", got.Content.ViewComponents[0].Config.Value)
+	assert.Equal(t, "default", got.Namespace)
+	assert.Equal(t, "module/page", got.ContentPath)
+
+	etag := res.Header.Get("ETag")
+	require.NotEmpty(t, etag)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/content/module/page", nil)
+	require.NoError(t, err)
+	req.Header.Set("If-None-Match", etag)
+	res, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusNotModified, res.StatusCode)
+
+	req, err = http.NewRequest(http.MethodGet, server.URL+"/content/module/page?filters=app:web", nil)
+	require.NoError(t, err)
+	req.Header.Set("If-None-Match", etag)
+	res, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+	assert.Equal(t, http.StatusOK, res.StatusCode, "content changed, so the etag should not match")
+	assert.NotEqual(t, etag, res.Header.Get("ETag"))
+}
+
+func TestRESTService_content_errors(t *testing.T) {
+	cases := []struct {
+		name string
+		path string
+		code int
+	}{
+		{name: "not found", path: "/content/module/missing", code: http.StatusNotFound},
+		{name: "unknown module", path: "/content/other", code: http.StatusInternalServerError},
+		{name: "invalid filter", path: "/content/module/page?filters=invalid", code: http.StatusBadRequest},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			server, _ := newRESTTestServer(controller)
+			defer server.Close()
+
+			res, err := http.Get(server.URL + tc.path)
+			require.NoError(t, err)
+			res.Body.Close()
+			assert.Equal(t, tc.code, res.StatusCode)
+		})
+	}
+}
+
+func TestRESTService_navigation(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	server, _ := newRESTTestServer(controller)
+	defer server.Close()
+
+	res, err := http.Get(server.URL + "/navigation?namespace=kube-system")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var got restNavigation
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&got))
+	require.Len(t, got.Sections, 1)
+	assert.Equal(t, "kube-system", got.Sections[0].Title)
+	assert.Equal(t, "module", got.Sections[0].Module)
+}
+
+func TestRESTService_namespaces(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	server, mocks := newRESTTestServer(controller)
+	defer server.Close()
+
+	mocks.namespaceClient.EXPECT().ProvidedNamespaces().Return(nil)
+	mocks.namespaceClient.EXPECT().Names().Return([]string{"default", "kube-system"}, nil)
+
+	res, err := http.Get(server.URL + "/namespaces")
+	require.NoError(t, err)
+	defer res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	var got restNamespaces
+	require.NoError(t, json.NewDecoder(res.Body).Decode(&got))
+	assert.Equal(t, []string{"default", "kube-system"}, got.Namespaces)
+}
+
+func TestRESTService_objects(t *testing.T) {
+	cases := []struct {
+		name  string
+		query string
+		key   *store.Key
+		err   error
+		code  int
+	}{
+		{
+			name:  "missing kind",
+			query: "apiVersion=v1",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "invalid selector",
+			query: "apiVersion=v1&kind=Pod&selector=app",
+			code:  http.StatusBadRequest,
+		},
+		{
+			name:  "not found",
+			query: "apiVersion=v1&kind=Pod&namespace=default&name=pod&selector=app%3Dweb",
+			key: &store.Key{
+				APIVersion: "v1",
+				Kind:       "Pod",
+				Namespace:  "default",
+				Name:       "pod",
+				Selector:   &labels.Set{"app": "web"},
+			},
+			err:  kerrors.NewNotFound(schema.GroupResource{Resource: "pods"}, "pod"),
+			code: http.StatusNotFound,
+		},
+		{
+			name:  "store error",
+			query: "apiVersion=v1&kind=Pod&name=pod",
+			key:   &store.Key{APIVersion: "v1", Kind: "Pod", Name: "pod"},
+			err:   kerrors.NewForbidden(schema.GroupResource{Resource: "pods"}, "pod", nil),
+			code:  http.StatusInternalServerError,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			server, mocks := newRESTTestServer(controller)
+			defer server.Close()
+
+			if tc.key != nil {
+				mocks.objectStore.EXPECT().Get(gomock.Any(), *tc.key).Return(nil, tc.err)
+			}
+
+			res, err := http.Get(server.URL + "/objects?" + tc.query)
+			require.NoError(t, err)
+			res.Body.Close()
+			assert.Equal(t, tc.code, res.StatusCode)
+		})
+	}
+}
+
+func Test_etagMatches(t *testing.T) {
+	assert.True(t, etagMatches(`"a"`, `"a"`))
+	assert.True(t, etagMatches(`"b", W/"a"`, `"a"`))
+	assert.True(t, etagMatches(`*`, `"a"`))
+	assert.False(t, etagMatches(`"b"`, `"a"`))
+	assert.False(t, etagMatches(``, `"a"`))
+}
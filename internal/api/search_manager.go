@@ -0,0 +1,169 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	"github.com/vmware-tanzu/octant/internal/auth"
+	"github.com/vmware-tanzu/octant/internal/cluster"
+	"github.com/vmware-tanzu/octant/internal/config"
+	"github.com/vmware-tanzu/octant/internal/objectstore"
+	"github.com/vmware-tanzu/octant/internal/octant"
+	"github.com/vmware-tanzu/octant/internal/search"
+	"github.com/vmware-tanzu/octant/pkg/action"
+	"github.com/vmware-tanzu/octant/pkg/event"
+)
+
+const (
+	// RequestSearch searches the cached objects. The payload contains the query and
+	// an optional limit.
+	RequestSearch = "action.octant.dev/search"
+)
+
+// SearchResult is an object matching a search, with the path to its content.
+type SearchResult struct {
+	search.Result
+	Path string `json:"path,omitempty"`
+}
+
+// SearchResponse is the data of a search event.
+type SearchResponse struct {
+	Query   string         `json:"query"`
+	Results []SearchResult `json:"results"`
+}
+
+// SearchManager searches the objects cached by the object store.
+type SearchManager struct {
+	dashConfig config.Dash
+	client     OctantClient
+	ctx        context.Context
+
+	// accessMu guards userClient and userAccess, which check what the client's
+	// authenticated user can list.
+	accessMu   sync.Mutex
+	userClient cluster.ClientInterface
+	userAccess objectstore.ResourceAccess
+}
+
+var _ StateManager = (*SearchManager)(nil)
+
+// NewSearchManager creates an instance of SearchManager.
+func NewSearchManager(dashConfig config.Dash) *SearchManager {
+	return &SearchManager{
+		dashConfig: dashConfig,
+	}
+}
+
+// Handlers returns a slice of handlers.
+func (sm *SearchManager) Handlers() []octant.ClientRequestHandler {
+	return []octant.ClientRequestHandler{
+		{
+			RequestType: RequestSearch,
+			Handler:     sm.Search,
+		},
+	}
+}
+
+// Start starts the manager.
+func (sm *SearchManager) Start(ctx context.Context, state octant.State, client OctantClient) {
+	sm.ctx = ctx
+	sm.client = client
+}
+
+// Search searches for a query and sends the results to the client.
+func (sm *SearchManager) Search(state octant.State, payload action.Payload) error {
+	query, err := payload.String("query")
+	if err != nil {
+		return fmt.Errorf("get query from payload: %w", err)
+	}
+
+	limit := search.DefaultLimit
+	if _, ok := payload["limit"]; ok {
+		l, err := payload.Float64("limit")
+		if err != nil {
+			return fmt.Errorf("get limit from payload: %w", err)
+		}
+		limit = int(l)
+	}
+
+	ctx := sm.ctx
+	if ctx == nil {
+		ctx = context.Background()
+	}
+
+	response := sm.search(ctx, query, limit)
+	if sm.client != nil {
+		sm.client.Send(event.Event{
+			Type: event.EventTypeSearch,
+			Data: response,
+		})
+	}
+
+	return nil
+}
+
+func (sm *SearchManager) search(ctx context.Context, query string, limit int) SearchResponse {
+	response := SearchResponse{
+		Query:   query,
+		Results: []SearchResult{},
+	}
+
+	index := sm.dashConfig.SearchIndex()
+	if index == nil {
+		return response
+	}
+
+	logger := sm.dashConfig.Logger()
+
+	var results []search.Result
+	if _, ok := auth.UserFrom(ctx); ok {
+		// The index's access uses Octant's credentials, so check access as the user.
+		access, err := sm.accessForUser(ctx)
+		if err != nil {
+			logger.WithErr(err).Errorf("unable to check search access for user")
+			return response
+		}
+		results = index.SearchWithAccess(ctx, access, query, limit)
+	} else {
+		results = index.Search(ctx, query, limit)
+	}
+
+	for _, result := range results {
+		path, err := sm.dashConfig.ObjectPath(result.Namespace, result.APIVersion, result.Kind, result.Name)
+		if err != nil {
+			logger.WithErr(err).Debugf("unable to find path for search result")
+		}
+
+		response.Results = append(response.Results, SearchResult{
+			Result: result,
+			Path:   path,
+		})
+	}
+
+	return response
+}
+
+// accessForUser returns the access of the user authenticated in ctx. The access is
+// reused until the user's cluster client changes, e.g. after a context switch.
+func (sm *SearchManager) accessForUser(ctx context.Context) (objectstore.ResourceAccess, error) {
+	client, err := cluster.ClientForContext(ctx, sm.dashConfig.ClusterClient())
+	if err != nil {
+		return nil, fmt.Errorf("client for user: %w", err)
+	}
+
+	sm.accessMu.Lock()
+	defer sm.accessMu.Unlock()
+
+	if sm.userAccess == nil || sm.userClient != client {
+		sm.userClient = client
+		sm.userAccess = objectstore.NewResourceAccess(client)
+	}
+
+	return sm.userAccess, nil
+}
@@ -0,0 +1,166 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+
+	"github.com/vmware-tanzu/octant/internal/api"
+	"github.com/vmware-tanzu/octant/internal/api/fake"
+	"github.com/vmware-tanzu/octant/internal/auth"
+	clusterFake "github.com/vmware-tanzu/octant/internal/cluster/fake"
+	configFake "github.com/vmware-tanzu/octant/internal/config/fake"
+	"github.com/vmware-tanzu/octant/internal/log"
+	octantFake "github.com/vmware-tanzu/octant/internal/octant/fake"
+	"github.com/vmware-tanzu/octant/internal/search"
+	"github.com/vmware-tanzu/octant/pkg/action"
+	"github.com/vmware-tanzu/octant/pkg/event"
+)
+
+func TestSearchManager_Handlers(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	dashConfig := configFake.NewMockDash(controller)
+
+	manager := api.NewSearchManager(dashConfig)
+	AssertHandlers(t, manager, []string{api.RequestSearch})
+}
+
+func TestSearchManager_Search(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	index := search.NewIndex()
+	for _, name := range []string{"web", "web-frontend", "worker"} {
+		object := &unstructured.Unstructured{}
+		object.SetAPIVersion("v1")
+		object.SetKind("Service")
+		object.SetNamespace("default")
+		object.SetName(name)
+		index.Add(object)
+	}
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().Logger().Return(log.NopLogger()).AnyTimes()
+	dashConfig.EXPECT().SearchIndex().Return(index).AnyTimes()
+	dashConfig.EXPECT().
+		ObjectPath("default", "v1", "Service", gomock.Any()).
+		DoAndReturn(func(namespace, apiVersion, kind, name string) (string, error) {
+			if name == "web-frontend" {
+				return "", fmt.Errorf("no path")
+			}
+			return "overview/namespace/default/discovery-and-load-balancing/services/" + name, nil
+		}).
+		AnyTimes()
+
+	var got event.Event
+	octantClient := fake.NewMockOctantClient(controller)
+	octantClient.EXPECT().Send(gomock.Any()).Do(func(ev event.Event) { got = ev })
+
+	state := octantFake.NewMockState(controller)
+
+	manager := api.NewSearchManager(dashConfig)
+	manager.Start(context.Background(), state, octantClient)
+
+	require.NoError(t, manager.Search(state, action.Payload{"query": "web", "limit": float64(2)}))
+
+	assert.Equal(t, event.EventTypeSearch, got.Type)
+	response, ok := got.Data.(api.SearchResponse)
+	require.True(t, ok)
+	assert.Equal(t, "web", response.Query)
+	require.Len(t, response.Results, 2)
+	assert.Equal(t, "web", response.Results[0].Name)
+	assert.Equal(t, "overview/namespace/default/discovery-and-load-balancing/services/web", response.Results[0].Path)
+	assert.Equal(t, "web-frontend", response.Results[1].Name)
+	assert.Empty(t, response.Results[1].Path, "results without a path are still returned")
+}
+
+func TestSearchManager_Search_userWithoutListAccess(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	index := search.NewIndex()
+	for _, kind := range []string{"Service", "Secret"} {
+		object := &unstructured.Unstructured{}
+		object.SetAPIVersion("v1")
+		object.SetKind(kind)
+		object.SetNamespace("default")
+		object.SetName("web")
+		index.Add(object)
+	}
+
+	clusterClient := clusterFake.NewMockClientInterface(controller)
+	userClient := clusterFake.NewMockClientInterface(controller)
+	clusterClient.EXPECT().ForUser("alice", []string{"dev"}).Return(userClient, nil).AnyTimes()
+	userClient.EXPECT().ForUser("alice", []string{"dev"}).Return(userClient, nil).AnyTimes()
+	userClient.EXPECT().
+		Resource(gomock.Any()).
+		DoAndReturn(func(gk schema.GroupKind) (schema.GroupVersionResource, bool, error) {
+			if gk.Kind == "Secret" {
+				return schema.GroupVersionResource{Version: "v1", Resource: "secrets"}, true, nil
+			}
+			return schema.GroupVersionResource{Version: "v1", Resource: "services"}, true, nil
+		}).
+		AnyTimes()
+
+	// alice can list services, but not secrets.
+	clientset := kubefake.NewSimpleClientset()
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(a ktesting.Action) (bool, runtime.Object, error) {
+		review := a.(ktesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		review.Status.Allowed = review.Spec.ResourceAttributes.Resource != "secrets"
+		return true, review, nil
+	})
+	userClient.EXPECT().KubernetesClient().Return(clientset, nil).AnyTimes()
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().Logger().Return(log.NopLogger()).AnyTimes()
+	dashConfig.EXPECT().SearchIndex().Return(index).AnyTimes()
+	dashConfig.EXPECT().ClusterClient().Return(clusterClient).AnyTimes()
+	dashConfig.EXPECT().ObjectPath(gomock.Any(), gomock.Any(), gomock.Any(), gomock.Any()).Return("", nil).AnyTimes()
+
+	var got event.Event
+	octantClient := fake.NewMockOctantClient(controller)
+	octantClient.EXPECT().Send(gomock.Any()).Do(func(ev event.Event) { got = ev })
+
+	state := octantFake.NewMockState(controller)
+
+	ctx := auth.WithUser(context.Background(), &auth.User{Name: "alice", Groups: []string{"dev"}})
+
+	manager := api.NewSearchManager(dashConfig)
+	manager.Start(ctx, state, octantClient)
+
+	require.NoError(t, manager.Search(state, action.Payload{"query": "web"}))
+
+	response, ok := got.Data.(api.SearchResponse)
+	require.True(t, ok)
+	require.Len(t, response.Results, 1)
+	assert.Equal(t, "Service", response.Results[0].Kind)
+}
+
+func TestSearchManager_Search_invalidPayload(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	dashConfig := configFake.NewMockDash(controller)
+	state := octantFake.NewMockState(controller)
+
+	manager := api.NewSearchManager(dashConfig)
+	assert.Error(t, manager.Search(state, action.Payload{}))
+	assert.Error(t, manager.Search(state, action.Payload{"query": "web", "limit": true}))
+}
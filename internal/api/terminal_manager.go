@@ -13,6 +13,7 @@ import (
 
 	corev1 "k8s.io/api/core/v1"
 
+	"github.com/vmware-tanzu/octant/internal/cluster"
 	"github.com/vmware-tanzu/octant/internal/util/kubernetes"
 
 	"github.com/vmware-tanzu/octant/pkg/event"
@@ -82,6 +83,10 @@ func (s *terminalStateManager) Handlers() []octant.ClientRequestHandler {
 }
 
 func (s *terminalStateManager) SetActiveTerminal(state octant.State, payload action.Payload) error {
+	if s.config.ReadOnly() {
+		return errors.New("terminal is disabled in read-only mode")
+	}
+
 	namespace, err := payload.String("namespace")
 	if err != nil {
 		return fmt.Errorf("getting namespace from payload: %w", err)
@@ -158,8 +163,19 @@ func (s *terminalStateManager) startStream(pod *corev1.Pod, key store.Key, conta
 		commands = []string{"powershell", "cmd"}
 	}
 
+	var options []terminal.InstanceOption
+	if dir := terminal.RecordingDir(); dir != "" {
+		options = append(options, terminal.WithRecording(dir))
+	}
+
+	clusterClient, err := cluster.ClientForContext(ctx, s.config.ClusterClient())
+	if err != nil {
+		logger.WithErr(err).Errorf("client for user")
+		return cancelFn
+	}
+
 	for _, command := range commands {
-		validInstance, err := terminal.NewTerminalInstance(ctx, s.config.ClusterClient(), logger, key, container, command, s.chanInstance)
+		validInstance, err := terminal.NewTerminalInstance(ctx, clusterClient, logger, key, container, command, s.chanInstance, options...)
 		if err != nil {
 			logger.Debugf("streaming: %+v", err)
 			continue
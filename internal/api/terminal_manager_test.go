@@ -15,6 +15,7 @@ import (
 	"github.com/vmware-tanzu/octant/internal/api/fake"
 	configFake "github.com/vmware-tanzu/octant/internal/config/fake"
 	octantFake "github.com/vmware-tanzu/octant/internal/octant/fake"
+	"github.com/vmware-tanzu/octant/pkg/action"
 )
 
 func Test_TerminalStateManager(t *testing.T) {
@@ -32,6 +33,32 @@ func Test_TerminalStateManager(t *testing.T) {
 	tsm.Start(ctx, state, octantClient)
 }
 
+func Test_TerminalStateManager_ReadOnly(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().ReadOnly().Return(true)
+
+	state := octantFake.NewMockState(controller)
+	octantClient := fake.NewMockOctantClient(controller)
+
+	tsm := api.NewTerminalStateManager(dashConfig)
+	tsm.Start(context.Background(), state, octantClient)
+
+	payload := action.Payload{
+		"namespace":     "default",
+		"podName":       "pod",
+		"containerName": "container",
+	}
+
+	for _, handler := range tsm.Handlers() {
+		if handler.RequestType == api.RequestActiveTerminal {
+			assert.Error(t, handler.Handler(state, payload))
+		}
+	}
+}
+
 func Test_isWindowsContainer(t *testing.T) {
 	windowsPod := testutil.CreatePod("pod")
 	windowsPod.Spec.Tolerations = []corev1.Toleration{
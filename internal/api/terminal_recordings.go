@@ -0,0 +1,47 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"net/http"
+	"os"
+
+	"github.com/gorilla/mux"
+
+	"github.com/vmware-tanzu/octant/internal/terminal"
+	"github.com/vmware-tanzu/octant/pkg/log"
+)
+
+// asciicastContentType is the content type of asciicast recordings.
+const asciicastContentType = "application/x-asciicast"
+
+func terminalRecordingService(dir string, logger log.Logger) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		vars := mux.Vars(r)
+
+		path, err := terminal.RecordingPath(dir, vars["namespace"], vars["pod"], vars["name"])
+		if err != nil {
+			RespondWithError(w, http.StatusNotFound, err.Error(), logger)
+			return
+		}
+
+		f, err := os.Open(path)
+		if err != nil {
+			RespondWithError(w, http.StatusNotFound, "recording not found", logger)
+			return
+		}
+		defer f.Close()
+
+		info, err := f.Stat()
+		if err != nil {
+			RespondWithError(w, http.StatusInternalServerError, err.Error(), logger)
+			return
+		}
+
+		w.Header().Set("Content-Type", asciicastContentType)
+		http.ServeContent(w, r, info.Name(), info.ModTime(), f)
+	}
+}
@@ -0,0 +1,67 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/terminal"
+)
+
+func Test_terminalRecordingService(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recordings")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	podDir := filepath.Join(dir, "default", "pod")
+	require.NoError(t, os.MkdirAll(podDir, 0700))
+	data := `{"version":2,"width":80,"height":24,"timestamp":1600000000}` + "\n"
+	require.NoError(t, ioutil.WriteFile(filepath.Join(podDir, "app-1.cast"), []byte(data), 0600))
+
+	router := mux.NewRouter()
+	router.Handle(terminal.RecordingRoute, terminalRecordingService(dir, log.NopLogger()))
+
+	server := httptest.NewServer(router)
+	defer server.Close()
+
+	cases := []struct {
+		name string
+		path string
+		code int
+	}{
+		{name: "recording", path: terminal.RecordingURLPath("default", "pod", "app-1.cast"), code: http.StatusOK},
+		{name: "missing", path: terminal.RecordingURLPath("default", "pod", "app-2.cast"), code: http.StatusNotFound},
+		{name: "not a recording", path: terminal.RecordingURLPath("default", "pod", "app-1.txt"), code: http.StatusNotFound},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			res, err := http.Get(server.URL + tc.path)
+			require.NoError(t, err)
+			defer res.Body.Close()
+
+			require.Equal(t, tc.code, res.StatusCode)
+			if tc.code != http.StatusOK {
+				return
+			}
+
+			body, err := ioutil.ReadAll(res.Body)
+			require.NoError(t, err)
+			assert.Equal(t, data, string(body))
+			assert.Equal(t, asciicastContentType, res.Header.Get("Content-Type"))
+		})
+	}
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/gorilla/websocket"
 	"golang.org/x/sync/errgroup"
 
+	"github.com/vmware-tanzu/octant/internal/auth"
 	"github.com/vmware-tanzu/octant/internal/config"
 	internalLog "github.com/vmware-tanzu/octant/internal/log"
 	"github.com/vmware-tanzu/octant/internal/octant"
@@ -80,7 +81,12 @@ func NewWebsocketClient(ctx context.Context, conn *websocket.Conn, manager *Webs
 		stopCh:     make(chan struct{}, 1),
 	}
 
-	state := NewWebsocketState(dashConfig, actionDispatcher, client)
+	var stateOptions []WebsocketStateOption
+	if user, ok := auth.UserFrom(ctx); ok {
+		stateOptions = append(stateOptions, WebsocketStateUser(user))
+	}
+
+	state := NewWebsocketState(dashConfig, actionDispatcher, client, stateOptions...)
 	go state.Start(ctx)
 
 	client.state = state
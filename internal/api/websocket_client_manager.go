@@ -13,7 +13,9 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/vmware-tanzu/octant/internal/auth"
 	"github.com/vmware-tanzu/octant/internal/config"
+	"github.com/vmware-tanzu/octant/internal/objectstore"
 )
 
 //go:generate mockgen -destination=./fake/mock_client_manager.go -package=fake github.com/vmware-tanzu/octant/internal/api ClientManager
@@ -109,6 +111,15 @@ func (m *WebsocketClientManager) ClientFromRequest(dashConfig config.Dash, w htt
 	}
 
 	ctx, cancel := context.WithCancel(m.ctx)
+	if user, ok := auth.UserFrom(r.Context()); ok {
+		ctx = auth.WithUser(ctx, user)
+
+		// Keep the user's store while the websocket is open.
+		if attacher, ok := dashConfig.ObjectStore().(objectstore.Attacher); ok {
+			attacher.Attach(ctx)
+		}
+	}
+
 	client := NewWebsocketClient(ctx, conn, m, dashConfig, m.actionDispatcher, clientID)
 	m.register <- &clientMeta{
 		cancelFunc: func() {
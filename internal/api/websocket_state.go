@@ -20,6 +20,7 @@ import (
 
 	"github.com/google/uuid"
 
+	"github.com/vmware-tanzu/octant/internal/auth"
 	"github.com/vmware-tanzu/octant/internal/config"
 	"github.com/vmware-tanzu/octant/internal/octant"
 	"github.com/vmware-tanzu/octant/pkg/action"
@@ -51,6 +52,7 @@ func defaultStateManagers(clientID string, dashConfig config.Dash) []StateManage
 		NewActionRequestManager(),
 		NewTerminalStateManager(dashConfig),
 		NewPodLogsStateManager(dashConfig),
+		NewSearchManager(dashConfig),
 	}
 }
 
@@ -97,6 +99,13 @@ func WebsocketStateManagers(managers []StateManager) WebsocketStateOption {
 	}
 }
 
+// WebsocketStateUser configures the authenticated user actions are dispatched for.
+func WebsocketStateUser(user *auth.User) WebsocketStateOption {
+	return func(w *WebsocketState) {
+		w.user = user
+	}
+}
+
 // WebsocketState manages state for a websocket client.
 type WebsocketState struct {
 	dashConfig         config.Dash
@@ -113,9 +122,12 @@ type WebsocketState struct {
 
 	startCtx           context.Context
 	managersCancelFunc context.CancelFunc
+
+	user *auth.User
 }
 
 var _ octant.State = (*WebsocketState)(nil)
+var _ octant.EventSender = (*WebsocketState)(nil)
 
 // NewWebsocketState creates an instance of WebsocketState.
 func NewWebsocketState(dashConfig config.Dash, actionDispatcher ActionDispatcher, wsClient OctantClient, options ...WebsocketStateOption) *WebsocketState {
@@ -182,8 +194,12 @@ func (c *WebsocketState) Handlers() []octant.ClientRequestHandler {
 	return handlers
 }
 
-// Dispatch dispatches a message.
+// Dispatch dispatches a message. Actions are dispatched for the client's user.
 func (c *WebsocketState) Dispatch(ctx context.Context, actionName string, payload action.Payload) error {
+	if c.user != nil {
+		ctx = auth.WithUser(ctx, c.user)
+	}
+
 	return c.actionDispatcher.Dispatch(ctx, c, actionName, payload)
 }
 
@@ -390,6 +406,11 @@ func (c *WebsocketState) SendAlert(alert action.Alert) {
 	c.wsClient.Send(CreateAlertUpdate(alert))
 }
 
+// SendEvent sends an event to the websocket client.
+func (c *WebsocketState) SendEvent(ev event.Event) {
+	c.wsClient.Send(ev)
+}
+
 func (c *WebsocketState) GetClientID() string {
 	if c.wsClient == nil {
 		return ""
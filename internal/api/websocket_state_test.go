@@ -17,10 +17,12 @@ import (
 
 	"github.com/vmware-tanzu/octant/internal/api"
 	"github.com/vmware-tanzu/octant/internal/api/fake"
+	"github.com/vmware-tanzu/octant/internal/auth"
 	configFake "github.com/vmware-tanzu/octant/internal/config/fake"
 	"github.com/vmware-tanzu/octant/internal/log"
 	moduleFake "github.com/vmware-tanzu/octant/internal/module/fake"
 	"github.com/vmware-tanzu/octant/internal/octant"
+	"github.com/vmware-tanzu/octant/pkg/action"
 )
 
 func TestWebsocketState_Start(t *testing.T) {
@@ -266,6 +268,27 @@ func TestWebSocketState_SetContext(t *testing.T) {
 	s.SetContext(contextName)
 }
 
+func TestWebsocketState_Dispatch_user(t *testing.T) {
+	mocks := newWebsocketStateMocks(t, "default")
+	defer mocks.finish()
+
+	user := &auth.User{Name: "user"}
+	payload := action.Payload{"foo": "bar"}
+
+	mocks.actionDispatcher.EXPECT().
+		Dispatch(gomock.Any(), gomock.Any(), "action", payload).
+		DoAndReturn(func(ctx context.Context, alerter action.Alerter, actionName string, payload action.Payload) error {
+			got, ok := auth.UserFrom(ctx)
+			require.True(t, ok)
+			assert.Equal(t, user, got)
+			return nil
+		})
+
+	options := append(mocks.options(), api.WebsocketStateUser(user))
+	s := api.NewWebsocketState(mocks.dashConfig, mocks.actionDispatcher, mocks.wsClient, options...)
+	require.NoError(t, s.Dispatch(context.Background(), "action", payload))
+}
+
 type websocketStateMocks struct {
 	controller       *gomock.Controller
 	module           *moduleFake.MockModule
@@ -0,0 +1,137 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"encoding/base64"
+	"errors"
+	"net/http"
+	"strings"
+
+	"github.com/gorilla/mux"
+
+	"github.com/vmware-tanzu/octant/internal/mime"
+	"github.com/vmware-tanzu/octant/internal/util/json"
+)
+
+const (
+	// PathPrefix is the path authentication handlers are served from.
+	PathPrefix = "/auth"
+	// LoginPath is the path of the login handler.
+	LoginPath = PathPrefix + "/login"
+	// CallbackPath is the path of the OIDC callback handler.
+	CallbackPath = PathPrefix + "/callback"
+	// LogoutPath is the path of the logout handler.
+	LogoutPath = PathPrefix + "/logout"
+)
+
+// ErrUnauthenticated is returned when a request does not have valid credentials.
+var ErrUnauthenticated = errors.New("unauthenticated")
+
+// User is an authenticated user.
+type User struct {
+	// Name is the user's name.
+	Name string `json:"name"`
+	// Groups are the groups the user is a member of.
+	Groups []string `json:"groups,omitempty"`
+}
+
+// Provider authenticates HTTP requests.
+type Provider interface {
+	// Authenticate returns the user making the request. It returns ErrUnauthenticated
+	// if the request does not have valid credentials.
+	Authenticate(r *http.Request) (*User, error)
+	// Challenge responds to a request which could not be authenticated.
+	Challenge(w http.ResponseWriter, r *http.Request)
+	// RegisterRoutes registers the provider's login and logout handlers. The router
+	// is rooted at PathPrefix.
+	RegisterRoutes(router *mux.Router)
+}
+
+type userKey struct{}
+
+// WithUser returns a copy of ctx which contains user.
+func WithUser(ctx context.Context, user *User) context.Context {
+	return context.WithValue(ctx, userKey{}, user)
+}
+
+// UserFrom returns the authenticated user stored in ctx.
+func UserFrom(ctx context.Context) (*User, bool) {
+	user, ok := ctx.Value(userKey{}).(*User)
+	return user, ok && user != nil
+}
+
+// Middleware only passes authenticated requests to the next handler. The user is
+// stored in the request context. Requests for the provider's own handlers are always
+// passed through.
+func Middleware(provider Provider) mux.MiddlewareFunc {
+	return func(next http.Handler) http.Handler {
+		return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+			if r.URL.Path == PathPrefix || strings.HasPrefix(r.URL.Path, PathPrefix+"/") {
+				next.ServeHTTP(w, r)
+				return
+			}
+
+			user, err := provider.Authenticate(r)
+			if err != nil {
+				provider.Challenge(w, r)
+				return
+			}
+
+			next.ServeHTTP(w, r.WithContext(WithUser(r.Context(), user)))
+		})
+	}
+}
+
+// wantsHTML returns true if the request was made by a browser navigating to a page,
+// rather than by a script or API client.
+func wantsHTML(r *http.Request) bool {
+	return r.Method == http.MethodGet && strings.Contains(r.Header.Get("Accept"), "text/html")
+}
+
+// redirectTarget returns the local path a user is sent to after logging in. Only paths
+// on this server are allowed so the login handler can't be used as an open redirect.
+func redirectTarget(target string) string {
+	if target == "" || !strings.HasPrefix(target, "/") || strings.HasPrefix(target, "//") ||
+		strings.HasPrefix(target, "/\\") {
+		return "/"
+	}
+
+	return target
+}
+
+// errorResponse matches the error responses returned by the API.
+type errorResponse struct {
+	Error struct {
+		Code    int    `json:"code"`
+		Message string `json:"message"`
+	} `json:"error"`
+}
+
+// unauthorized responds with a 401 and a JSON error.
+func unauthorized(w http.ResponseWriter, scheme string) {
+	w.Header().Set("WWW-Authenticate", scheme)
+	w.Header().Set("Content-Type", mime.JSONContentType)
+	w.WriteHeader(http.StatusUnauthorized)
+
+	var resp errorResponse
+	resp.Error.Code = http.StatusUnauthorized
+	resp.Error.Message = ErrUnauthenticated.Error()
+
+	_ = json.NewEncoder(w).Encode(resp)
+}
+
+// randomString returns a URL safe random string.
+func randomString() (string, error) {
+	b := make([]byte, 32)
+	if _, err := rand.Read(b); err != nil {
+		return "", err
+	}
+
+	return base64.RawURLEncoding.EncodeToString(b), nil
+}
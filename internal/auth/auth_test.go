@@ -0,0 +1,133 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"net/http/httptest"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMiddleware(t *testing.T) {
+	provider, err := NewTokenProvider("secret", nil)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	provider.RegisterRoutes(router.PathPrefix(PathPrefix).Subrouter())
+	router.PathPrefix("/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := UserFrom(r.Context())
+		require.True(t, ok)
+		fmt.Fprint(w, user.Name)
+	})
+	router.Use(Middleware(provider))
+
+	tests := []struct {
+		name         string
+		path         string
+		header       http.Header
+		wantCode     int
+		wantBody     string
+		wantLocation string
+	}{
+		{
+			name:     "api request without credentials",
+			path:     "/api/v1/namespaces",
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:         "browser request without credentials",
+			path:         "/overview?x=1",
+			header:       http.Header{"Accept": []string{"text/html,application/xhtml+xml"}},
+			wantCode:     http.StatusFound,
+			wantLocation: LoginPath + "?redirect=%2Foverview%3Fx%3D1",
+		},
+		{
+			name:     "invalid bearer token",
+			path:     "/api/v1/namespaces",
+			header:   http.Header{"Authorization": []string{"Bearer wrong"}},
+			wantCode: http.StatusUnauthorized,
+		},
+		{
+			name:     "valid bearer token",
+			path:     "/api/v1/namespaces",
+			header:   http.Header{"Authorization": []string{"Bearer secret"}},
+			wantCode: http.StatusOK,
+			wantBody: TokenUser,
+		},
+		{
+			name:     "login page is not authenticated",
+			path:     LoginPath,
+			wantCode: http.StatusOK,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			req := httptest.NewRequest(http.MethodGet, test.path, nil)
+			for k, v := range test.header {
+				req.Header[k] = v
+			}
+
+			w := httptest.NewRecorder()
+			router.ServeHTTP(w, req)
+
+			assert.Equal(t, test.wantCode, w.Code)
+			if test.wantBody != "" {
+				assert.Equal(t, test.wantBody, w.Body.String())
+			}
+			assert.Equal(t, test.wantLocation, w.Header().Get("Location"))
+		})
+	}
+}
+
+func Test_redirectTarget(t *testing.T) {
+	tests := []struct {
+		target string
+		want   string
+	}{
+		{target: "", want: "/"},
+		{target: "/overview/namespace/default", want: "/overview/namespace/default"},
+		{target: "https://example.com", want: "/"},
+		{target: "//example.com", want: "/"},
+		{target: "/\\example.com", want: "/"},
+	}
+
+	for _, test := range tests {
+		t.Run(test.target, func(t *testing.T) {
+			assert.Equal(t, test.want, redirectTarget(test.target))
+		})
+	}
+}
+
+func TestSessionStore(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+
+	s := NewSessionStore(time.Hour)
+	s.now = func() time.Time { return now }
+
+	session, err := s.Create(User{Name: "user"})
+	require.NoError(t, err)
+
+	got, ok := s.Get(session.ID)
+	require.True(t, ok)
+	assert.Equal(t, "user", got.User.Name)
+
+	now = now.Add(time.Hour)
+	_, ok = s.Get(session.ID)
+	assert.False(t, ok)
+
+	session, err = s.Create(User{Name: "user"})
+	require.NoError(t, err)
+	s.Delete(session.ID)
+	_, ok = s.Get(session.ID)
+	assert.False(t, ok)
+}
@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"context"
+	"errors"
+
+	"github.com/spf13/viper"
+)
+
+const (
+	// TokenKey is the configuration key for the static bearer token.
+	TokenKey = "auth-token"
+	// SessionTTLKey is the configuration key for how long sessions last.
+	SessionTTLKey = "auth-session-ttl"
+	// OIDCIssuerURLKey is the configuration key for the OIDC issuer URL.
+	OIDCIssuerURLKey = "oidc-issuer-url"
+	// OIDCClientIDKey is the configuration key for the OIDC client ID.
+	OIDCClientIDKey = "oidc-client-id"
+	// OIDCClientSecretKey is the configuration key for the OIDC client secret.
+	OIDCClientSecretKey = "oidc-client-secret"
+	// OIDCRedirectURLKey is the configuration key for the OIDC redirect URL.
+	OIDCRedirectURLKey = "oidc-redirect-url"
+	// OIDCScopesKey is the configuration key for extra OIDC scopes.
+	OIDCScopesKey = "oidc-scopes"
+	// OIDCUsernameClaimKey is the configuration key for the OIDC username claim.
+	OIDCUsernameClaimKey = "oidc-username-claim"
+	// OIDCGroupsClaimKey is the configuration key for the OIDC groups claim.
+	OIDCGroupsClaimKey = "oidc-groups-claim"
+)
+
+// NewProvider creates a provider from configuration. It returns nil if
+// authentication is not configured.
+func NewProvider(ctx context.Context) (Provider, error) {
+	token := viper.GetString(TokenKey)
+	issuerURL := viper.GetString(OIDCIssuerURLKey)
+
+	sessions := NewSessionStore(viper.GetDuration(SessionTTLKey))
+
+	switch {
+	case token != "" && issuerURL != "":
+		return nil, errors.New("only one of a token or an OIDC issuer can be configured")
+	case token != "":
+		return NewTokenProvider(token, sessions)
+	case issuerURL != "":
+		return NewOIDCProvider(ctx, OIDCConfig{
+			IssuerURL:     issuerURL,
+			ClientID:      viper.GetString(OIDCClientIDKey),
+			ClientSecret:  viper.GetString(OIDCClientSecretKey),
+			RedirectURL:   viper.GetString(OIDCRedirectURLKey),
+			Scopes:        viper.GetStringSlice(OIDCScopesKey),
+			UsernameClaim: viper.GetString(OIDCUsernameClaimKey),
+			GroupsClaim:   viper.GetString(OIDCGroupsClaimKey),
+		}, sessions)
+	default:
+		return nil, nil
+	}
+}
@@ -0,0 +1,331 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"net/http"
+	"net/url"
+	"sync"
+	"time"
+
+	"github.com/coreos/go-oidc"
+	"github.com/gorilla/mux"
+	"golang.org/x/oauth2"
+)
+
+const (
+	// stateCookieName is the name of the cookie which binds a login to the browser
+	// which started it.
+	stateCookieName = "octant-auth-state"
+
+	// loginTimeout is how long a user has to complete a login with the identity provider.
+	loginTimeout = 10 * time.Minute
+
+	defaultUsernameClaim = "email"
+	defaultGroupsClaim   = "groups"
+)
+
+// OIDCConfig configures an OIDCProvider.
+type OIDCConfig struct {
+	// IssuerURL is the URL of the OpenID Connect issuer.
+	IssuerURL string
+	// ClientID is the OAuth2 client ID.
+	ClientID string
+	// ClientSecret is the OAuth2 client secret.
+	ClientSecret string
+	// RedirectURL is the URL of Octant's callback handler, e.g.
+	// https://octant.example.com/auth/callback.
+	RedirectURL string
+	// Scopes are requested in addition to the openid scope.
+	Scopes []string
+	// UsernameClaim is the ID token claim used as the user name. It defaults to email.
+	// The subject is used if the claim is missing.
+	UsernameClaim string
+	// GroupsClaim is the ID token claim listing the user's groups. It defaults to groups.
+	GroupsClaim string
+}
+
+// pendingLogin is a login which is waiting for the identity provider's callback.
+type pendingLogin struct {
+	nonce     string
+	redirect  string
+	expiresAt time.Time
+}
+
+// OIDCProvider authenticates users with the OpenID Connect authorization code flow.
+type OIDCProvider struct {
+	config       OIDCConfig
+	oauth2Config oauth2.Config
+	verifier     *oidc.IDTokenVerifier
+	endSession   string
+	sessions     *SessionStore
+	now          func() time.Time
+
+	mu      sync.Mutex
+	pending map[string]pendingLogin
+}
+
+var _ Provider = (*OIDCProvider)(nil)
+
+// NewOIDCProvider creates an instance of OIDCProvider. The issuer's configuration is
+// discovered using ctx.
+func NewOIDCProvider(ctx context.Context, config OIDCConfig, sessions *SessionStore) (*OIDCProvider, error) {
+	if config.IssuerURL == "" {
+		return nil, errors.New("OIDC issuer URL is required")
+	}
+	if config.ClientID == "" {
+		return nil, errors.New("OIDC client ID is required")
+	}
+	if config.RedirectURL == "" {
+		return nil, errors.New("OIDC redirect URL is required")
+	}
+
+	if config.UsernameClaim == "" {
+		config.UsernameClaim = defaultUsernameClaim
+	}
+	if config.GroupsClaim == "" {
+		config.GroupsClaim = defaultGroupsClaim
+	}
+
+	if sessions == nil {
+		sessions = NewSessionStore(DefaultSessionTTL)
+	}
+
+	provider, err := oidc.NewProvider(ctx, config.IssuerURL)
+	if err != nil {
+		return nil, fmt.Errorf("discover OIDC issuer %s: %w", config.IssuerURL, err)
+	}
+
+	var discovery struct {
+		EndSessionEndpoint string `json:"end_session_endpoint"`
+	}
+	if err := provider.Claims(&discovery); err != nil {
+		return nil, fmt.Errorf("read OIDC issuer configuration: %w", err)
+	}
+
+	return &OIDCProvider{
+		config: config,
+		oauth2Config: oauth2.Config{
+			ClientID:     config.ClientID,
+			ClientSecret: config.ClientSecret,
+			RedirectURL:  config.RedirectURL,
+			Endpoint:     provider.Endpoint(),
+			Scopes:       append([]string{oidc.ScopeOpenID}, config.Scopes...),
+		},
+		verifier:   provider.Verifier(&oidc.Config{ClientID: config.ClientID}),
+		endSession: discovery.EndSessionEndpoint,
+		sessions:   sessions,
+		now:        time.Now,
+		pending:    make(map[string]pendingLogin),
+	}, nil
+}
+
+// Authenticate authenticates a request with a session cookie.
+func (p *OIDCProvider) Authenticate(r *http.Request) (*User, error) {
+	session, ok := p.sessions.FromRequest(r)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	return &session.User, nil
+}
+
+// Challenge redirects browsers to the login handler. Other clients receive a 401.
+func (p *OIDCProvider) Challenge(w http.ResponseWriter, r *http.Request) {
+	if wantsHTML(r) {
+		http.Redirect(w, r, LoginPath+"?redirect="+url.QueryEscape(r.URL.RequestURI()), http.StatusFound)
+		return
+	}
+
+	unauthorized(w, "Cookie")
+}
+
+// RegisterRoutes registers the login, callback and logout handlers.
+func (p *OIDCProvider) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/login", p.login).Methods(http.MethodGet)
+	router.HandleFunc("/callback", p.callback).Methods(http.MethodGet)
+	router.HandleFunc("/logout", logoutPage).Methods(http.MethodGet)
+	router.HandleFunc("/logout", p.logout).Methods(http.MethodPost)
+}
+
+// login sends the user to the identity provider.
+func (p *OIDCProvider) login(w http.ResponseWriter, r *http.Request) {
+	state, err := randomString()
+	if err != nil {
+		http.Error(w, "unable to start login", http.StatusInternalServerError)
+		return
+	}
+
+	nonce, err := randomString()
+	if err != nil {
+		http.Error(w, "unable to start login", http.StatusInternalServerError)
+		return
+	}
+
+	p.addPending(state, pendingLogin{
+		nonce:     nonce,
+		redirect:  redirectTarget(r.URL.Query().Get("redirect")),
+		expiresAt: p.now().Add(loginTimeout),
+	})
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     stateCookieName,
+		Value:    state,
+		Path:     PathPrefix,
+		MaxAge:   int(loginTimeout.Seconds()),
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	http.Redirect(w, r, p.oauth2Config.AuthCodeURL(state, oidc.Nonce(nonce)), http.StatusFound)
+}
+
+// callback completes a login when the identity provider redirects back to Octant.
+func (p *OIDCProvider) callback(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+
+	if errCode := query.Get("error"); errCode != "" {
+		p.loginFailed(w, fmt.Sprintf("The identity provider returned an error: %s %s",
+			errCode, query.Get("error_description")))
+		return
+	}
+
+	state := query.Get("state")
+	cookie, err := r.Cookie(stateCookieName)
+	if err != nil || state == "" || cookie.Value != state {
+		p.loginFailed(w, "The login could not be verified.")
+		return
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:   stateCookieName,
+		Path:   PathPrefix,
+		MaxAge: -1,
+	})
+
+	pending, ok := p.takePending(state)
+	if !ok {
+		p.loginFailed(w, "The login has expired.")
+		return
+	}
+
+	user, err := p.exchange(r.Context(), query.Get("code"), pending.nonce)
+	if err != nil {
+		p.loginFailed(w, "The login could not be completed.")
+		return
+	}
+
+	if err := p.sessions.Login(w, r, *user); err != nil {
+		http.Error(w, "unable to create session", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, pending.redirect, http.StatusFound)
+}
+
+// exchange exchanges an authorization code for an ID token and returns its user.
+func (p *OIDCProvider) exchange(ctx context.Context, code, nonce string) (*User, error) {
+	if code == "" {
+		return nil, errors.New("authorization code is missing")
+	}
+
+	token, err := p.oauth2Config.Exchange(ctx, code)
+	if err != nil {
+		return nil, fmt.Errorf("exchange authorization code: %w", err)
+	}
+
+	rawIDToken, ok := token.Extra("id_token").(string)
+	if !ok {
+		return nil, errors.New("token response does not contain an ID token")
+	}
+
+	idToken, err := p.verifier.Verify(ctx, rawIDToken)
+	if err != nil {
+		return nil, fmt.Errorf("verify ID token: %w", err)
+	}
+
+	if idToken.Nonce != nonce {
+		return nil, errors.New("ID token nonce does not match")
+	}
+
+	var claims map[string]interface{}
+	if err := idToken.Claims(&claims); err != nil {
+		return nil, fmt.Errorf("read ID token claims: %w", err)
+	}
+
+	user := &User{Name: idToken.Subject}
+	if name, ok := claims[p.config.UsernameClaim].(string); ok && name != "" {
+		user.Name = name
+	}
+
+	if groups, ok := claims[p.config.GroupsClaim].([]interface{}); ok {
+		for _, group := range groups {
+			if s, ok := group.(string); ok {
+				user.Groups = append(user.Groups, s)
+			}
+		}
+	}
+
+	return user, nil
+}
+
+// logout ends the Octant session. Users are sent to the identity provider's end
+// session endpoint if it has one.
+func (p *OIDCProvider) logout(w http.ResponseWriter, r *http.Request) {
+	p.sessions.Logout(w, r)
+
+	if p.endSession != "" {
+		http.Redirect(w, r, p.endSession, http.StatusFound)
+		return
+	}
+
+	renderPage(w, http.StatusOK, page{
+		Message:  "You have been logged out.",
+		LoginURL: LoginPath,
+	})
+}
+
+func (p *OIDCProvider) loginFailed(w http.ResponseWriter, message string) {
+	renderPage(w, http.StatusUnauthorized, page{
+		Error:    message,
+		LoginURL: LoginPath,
+	})
+}
+
+func (p *OIDCProvider) addPending(state string, login pendingLogin) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	now := p.now()
+	for key, existing := range p.pending {
+		if !now.Before(existing.expiresAt) {
+			delete(p.pending, key)
+		}
+	}
+
+	p.pending[state] = login
+}
+
+func (p *OIDCProvider) takePending(state string) (pendingLogin, bool) {
+	p.mu.Lock()
+	defer p.mu.Unlock()
+
+	login, ok := p.pending[state]
+	if !ok {
+		return pendingLogin{}, false
+	}
+	delete(p.pending, state)
+
+	if !p.now().Before(login.expiresAt) {
+		return pendingLogin{}, false
+	}
+
+	return login, true
+}
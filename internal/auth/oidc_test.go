@@ -0,0 +1,287 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"context"
+	"crypto/rand"
+	"crypto/rsa"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"net/http"
+	"net/http/cookiejar"
+	"net/http/httptest"
+	"net/url"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	jose "gopkg.in/square/go-jose.v2"
+)
+
+// stubIdP is a minimal OpenID Connect identity provider which logs in every user
+// as the same subject.
+type stubIdP struct {
+	t        *testing.T
+	server   *httptest.Server
+	key      *rsa.PrivateKey
+	clientID string
+	nonce    string
+
+	mu     sync.Mutex
+	nonces map[string]string
+}
+
+func newStubIdP(t *testing.T, clientID string) *stubIdP {
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	require.NoError(t, err)
+
+	idp := &stubIdP{
+		t:        t,
+		key:      key,
+		clientID: clientID,
+		nonces:   make(map[string]string),
+	}
+
+	router := mux.NewRouter()
+	router.HandleFunc("/.well-known/openid-configuration", idp.discovery)
+	router.HandleFunc("/keys", idp.keys)
+	router.HandleFunc("/authorize", idp.authorize)
+	router.HandleFunc("/token", idp.token).Methods(http.MethodPost)
+	idp.server = httptest.NewServer(router)
+
+	return idp
+}
+
+func (idp *stubIdP) Close() {
+	idp.server.Close()
+}
+
+func (idp *stubIdP) writeJSON(w http.ResponseWriter, v interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	require.NoError(idp.t, json.NewEncoder(w).Encode(v))
+}
+
+func (idp *stubIdP) discovery(w http.ResponseWriter, r *http.Request) {
+	idp.writeJSON(w, struct {
+		Issuer                string   `json:"issuer"`
+		AuthorizationEndpoint string   `json:"authorization_endpoint"`
+		TokenEndpoint         string   `json:"token_endpoint"`
+		JWKSURI               string   `json:"jwks_uri"`
+		Algorithms            []string `json:"id_token_signing_alg_values_supported"`
+	}{
+		Issuer:                idp.server.URL,
+		AuthorizationEndpoint: idp.server.URL + "/authorize",
+		TokenEndpoint:         idp.server.URL + "/token",
+		JWKSURI:               idp.server.URL + "/keys",
+		Algorithms:            []string{"RS256"},
+	})
+}
+
+func (idp *stubIdP) keys(w http.ResponseWriter, r *http.Request) {
+	idp.writeJSON(w, jose.JSONWebKeySet{
+		Keys: []jose.JSONWebKey{
+			{Key: &idp.key.PublicKey, KeyID: "test", Algorithm: "RS256", Use: "sig"},
+		},
+	})
+}
+
+func (idp *stubIdP) authorize(w http.ResponseWriter, r *http.Request) {
+	query := r.URL.Query()
+	require.Equal(idp.t, idp.clientID, query.Get("client_id"))
+
+	code := fmt.Sprintf("code-%d", time.Now().UnixNano())
+
+	idp.mu.Lock()
+	idp.nonces[code] = query.Get("nonce")
+	idp.mu.Unlock()
+
+	redirect, err := url.Parse(query.Get("redirect_uri"))
+	require.NoError(idp.t, err)
+
+	values := redirect.Query()
+	values.Set("code", code)
+	values.Set("state", query.Get("state"))
+	redirect.RawQuery = values.Encode()
+
+	http.Redirect(w, r, redirect.String(), http.StatusFound)
+}
+
+func (idp *stubIdP) token(w http.ResponseWriter, r *http.Request) {
+	code := r.PostFormValue("code")
+
+	idp.mu.Lock()
+	nonce, ok := idp.nonces[code]
+	delete(idp.nonces, code)
+	idp.mu.Unlock()
+
+	if !ok {
+		http.Error(w, `{"error":"invalid_grant"}`, http.StatusBadRequest)
+		return
+	}
+
+	if idp.nonce != "" {
+		nonce = idp.nonce
+	}
+
+	now := time.Now()
+	claims := struct {
+		Issuer   string   `json:"iss"`
+		Subject  string   `json:"sub"`
+		Audience string   `json:"aud"`
+		Expiry   int64    `json:"exp"`
+		IssuedAt int64    `json:"iat"`
+		Nonce    string   `json:"nonce"`
+		Email    string   `json:"email"`
+		Groups   []string `json:"groups"`
+	}{
+		Issuer:   idp.server.URL,
+		Subject:  "1234",
+		Audience: idp.clientID,
+		Expiry:   now.Add(time.Hour).Unix(),
+		IssuedAt: now.Unix(),
+		Nonce:    nonce,
+		Email:    "user@example.com",
+		Groups:   []string{"developers", "operators"},
+	}
+
+	payload, err := json.Marshal(claims)
+	require.NoError(idp.t, err)
+
+	signer, err := jose.NewSigner(jose.SigningKey{Algorithm: jose.RS256, Key: idp.key},
+		(&jose.SignerOptions{}).WithHeader("kid", "test"))
+	require.NoError(idp.t, err)
+
+	signed, err := signer.Sign(payload)
+	require.NoError(idp.t, err)
+
+	idToken, err := signed.CompactSerialize()
+	require.NoError(idp.t, err)
+
+	idp.writeJSON(w, struct {
+		AccessToken string `json:"access_token"`
+		TokenType   string `json:"token_type"`
+		ExpiresIn   int    `json:"expires_in"`
+		IDToken     string `json:"id_token"`
+	}{
+		AccessToken: "access-token",
+		TokenType:   "Bearer",
+		ExpiresIn:   3600,
+		IDToken:     idToken,
+	})
+}
+
+// newOIDCTestServer starts an Octant stand-in which authenticates with the stub IdP.
+func newOIDCTestServer(t *testing.T, idp *stubIdP) *httptest.Server {
+	var handler http.Handler
+	server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		handler.ServeHTTP(w, r)
+	}))
+
+	provider, err := NewOIDCProvider(context.Background(), OIDCConfig{
+		IssuerURL:   idp.server.URL,
+		ClientID:    idp.clientID,
+		RedirectURL: server.URL + CallbackPath,
+	}, nil)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	provider.RegisterRoutes(router.PathPrefix(PathPrefix).Subrouter())
+	router.PathPrefix("/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+		user, ok := UserFrom(r.Context())
+		require.True(t, ok)
+		fmt.Fprintf(w, "%s %v", user.Name, user.Groups)
+	})
+	router.Use(Middleware(provider))
+	handler = router
+
+	return server
+}
+
+func newBrowser(t *testing.T) *http.Client {
+	jar, err := cookiejar.New(nil)
+	require.NoError(t, err)
+
+	return &http.Client{Jar: jar}
+}
+
+func browse(t *testing.T, client *http.Client, target string) (*http.Response, string) {
+	req, err := http.NewRequest(http.MethodGet, target, nil)
+	require.NoError(t, err)
+	req.Header.Set("Accept", "text/html")
+
+	resp, err := client.Do(req)
+	require.NoError(t, err)
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	require.NoError(t, err)
+
+	return resp, string(body)
+}
+
+func TestOIDCProvider(t *testing.T) {
+	idp := newStubIdP(t, "octant")
+	defer idp.Close()
+
+	server := newOIDCTestServer(t, idp)
+	defer server.Close()
+
+	browser := newBrowser(t)
+
+	resp, body := browse(t, browser, server.URL+"/workloads")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "/workloads", resp.Request.URL.Path)
+	assert.Equal(t, "user@example.com [developers operators]", body)
+
+	resp, body = browse(t, browser, server.URL+LogoutPath)
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Contains(t, body, "Do you want to log out of Octant?")
+
+	resp, body = browse(t, browser, server.URL+"/workloads")
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+	assert.Equal(t, "user@example.com [developers operators]", body, "visiting the logout page ended the session")
+
+	resp, err := browser.PostForm(server.URL+LogoutPath, nil)
+	require.NoError(t, err)
+	resp.Body.Close()
+	require.Equal(t, http.StatusOK, resp.StatusCode)
+
+	req, err := http.NewRequest(http.MethodGet, server.URL+"/api/v1/namespaces", nil)
+	require.NoError(t, err)
+	resp, err = browser.Do(req)
+	require.NoError(t, err)
+	resp.Body.Close()
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
+
+func TestOIDCProvider_invalidNonce(t *testing.T) {
+	idp := newStubIdP(t, "octant")
+	idp.nonce = "replayed"
+	defer idp.Close()
+
+	server := newOIDCTestServer(t, idp)
+	defer server.Close()
+
+	resp, _ := browse(t, newBrowser(t), server.URL+"/workloads")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+	assert.Equal(t, CallbackPath, resp.Request.URL.Path)
+}
+
+func TestOIDCProvider_invalidState(t *testing.T) {
+	idp := newStubIdP(t, "octant")
+	defer idp.Close()
+
+	server := newOIDCTestServer(t, idp)
+	defer server.Close()
+
+	resp, _ := browse(t, newBrowser(t), server.URL+CallbackPath+"?code=code&state=forged")
+	assert.Equal(t, http.StatusUnauthorized, resp.StatusCode)
+}
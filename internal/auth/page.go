@@ -0,0 +1,80 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"html/template"
+	"net/http"
+
+	"github.com/vmware-tanzu/octant/internal/mime"
+)
+
+// page is the data rendered by pageTemplate.
+type page struct {
+	// Message is shown above the form.
+	Message string
+	// Error is shown if the last attempt failed.
+	Error string
+	// Redirect is where the user is sent after logging in.
+	Redirect string
+	// TokenForm shows a form for entering a bearer token.
+	TokenForm bool
+	// LoginURL is linked to if there is no form.
+	LoginURL string
+	// LogoutForm shows a form which ends the session.
+	LogoutForm bool
+}
+
+var pageTemplate = template.Must(template.New("page").Parse(`<!DOCTYPE html>
+<html lang="en">
+<head>
+  <meta charset="utf-8">
+  <title>Octant</title>
+  <style>
+    body { font-family: sans-serif; display: flex; justify-content: center; margin-top: 10em; }
+    form, p { margin: 0.5em 0; }
+    .error { color: #c92100; }
+  </style>
+</head>
+<body>
+  <main>
+    <h1>Octant</h1>
+    {{ if .Message }}<p>{{ .Message }}</p>{{ end }}
+    {{ if .Error }}<p class="error">{{ .Error }}</p>{{ end }}
+    {{ if .TokenForm }}
+    <form method="POST" action="` + LoginPath + `">
+      <input type="hidden" name="redirect" value="{{ .Redirect }}">
+      <input type="password" name="token" placeholder="Token" autofocus required>
+      <button type="submit">Log in</button>
+    </form>
+    {{ else if .LogoutForm }}
+    <form method="POST" action="` + LogoutPath + `">
+      <button type="submit" autofocus>Log out</button>
+    </form>
+    {{ else if .LoginURL }}
+    <p><a href="{{ .LoginURL }}">Log in</a></p>
+    {{ end }}
+  </main>
+</body>
+</html>
+`))
+
+// logoutPage asks the user to confirm logging out. Sessions are only ended by a
+// POST so other sites can't log users out with a link or an image.
+func logoutPage(w http.ResponseWriter, r *http.Request) {
+	renderPage(w, http.StatusOK, page{
+		Message:    "Do you want to log out of Octant?",
+		LogoutForm: true,
+	})
+}
+
+func renderPage(w http.ResponseWriter, code int, p page) {
+	w.Header().Set("Content-Type", mime.HTMLContentType)
+	w.Header().Set("Cache-Control", "no-store")
+	w.WriteHeader(code)
+
+	_ = pageTemplate.Execute(w, p)
+}
@@ -0,0 +1,157 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"fmt"
+	"net/http"
+	"sync"
+	"time"
+)
+
+const (
+	// SessionCookieName is the name of the cookie which holds the session ID.
+	SessionCookieName = "octant-session"
+
+	// DefaultSessionTTL is how long a session lasts by default.
+	DefaultSessionTTL = 12 * time.Hour
+)
+
+// Session is a logged in user's session.
+type Session struct {
+	// ID identifies the session.
+	ID string
+	// User is the user who logged in.
+	User User
+	// ExpiresAt is when the session expires.
+	ExpiresAt time.Time
+}
+
+// SessionStore stores sessions in memory. Sessions do not survive a restart.
+type SessionStore struct {
+	ttl time.Duration
+	now func() time.Time
+
+	mu       sync.Mutex
+	sessions map[string]Session
+}
+
+// NewSessionStore creates an instance of SessionStore.
+func NewSessionStore(ttl time.Duration) *SessionStore {
+	if ttl <= 0 {
+		ttl = DefaultSessionTTL
+	}
+
+	return &SessionStore{
+		ttl:      ttl,
+		now:      time.Now,
+		sessions: make(map[string]Session),
+	}
+}
+
+// Create creates a session for a user.
+func (s *SessionStore) Create(user User) (Session, error) {
+	id, err := randomString()
+	if err != nil {
+		return Session{}, fmt.Errorf("generate session id: %w", err)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	s.removeExpired()
+
+	session := Session{
+		ID:        id,
+		User:      user,
+		ExpiresAt: s.now().Add(s.ttl),
+	}
+	s.sessions[id] = session
+
+	return session, nil
+}
+
+// Get returns an unexpired session.
+func (s *SessionStore) Get(id string) (Session, bool) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	session, ok := s.sessions[id]
+	if !ok {
+		return Session{}, false
+	}
+
+	if !s.now().Before(session.ExpiresAt) {
+		delete(s.sessions, id)
+		return Session{}, false
+	}
+
+	return session, true
+}
+
+// Delete deletes a session.
+func (s *SessionStore) Delete(id string) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	delete(s.sessions, id)
+}
+
+func (s *SessionStore) removeExpired() {
+	now := s.now()
+	for id, session := range s.sessions {
+		if !now.Before(session.ExpiresAt) {
+			delete(s.sessions, id)
+		}
+	}
+}
+
+// FromRequest returns the session for the request's session cookie.
+func (s *SessionStore) FromRequest(r *http.Request) (Session, bool) {
+	cookie, err := r.Cookie(SessionCookieName)
+	if err != nil || cookie.Value == "" {
+		return Session{}, false
+	}
+
+	return s.Get(cookie.Value)
+}
+
+// Login creates a session for a user and sets the session cookie.
+func (s *SessionStore) Login(w http.ResponseWriter, r *http.Request, user User) error {
+	session, err := s.Create(user)
+	if err != nil {
+		return err
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    session.ID,
+		Path:     "/",
+		Expires:  session.ExpiresAt,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+
+	return nil
+}
+
+// Logout deletes the request's session and clears the session cookie.
+func (s *SessionStore) Logout(w http.ResponseWriter, r *http.Request) {
+	if session, ok := s.FromRequest(r); ok {
+		s.Delete(session.ID)
+	}
+
+	http.SetCookie(w, &http.Cookie{
+		Name:     SessionCookieName,
+		Value:    "",
+		Path:     "/",
+		MaxAge:   -1,
+		HttpOnly: true,
+		Secure:   r.TLS != nil,
+		SameSite: http.SameSiteLaxMode,
+	})
+}
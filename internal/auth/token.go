@@ -0,0 +1,129 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"crypto/subtle"
+	"errors"
+	"net/http"
+	"net/url"
+	"strings"
+
+	"github.com/gorilla/mux"
+)
+
+// TokenUser is the name of the user authenticated with a static token.
+const TokenUser = "octant-token"
+
+// TokenProvider authenticates requests with a static bearer token. Browsers exchange
+// the token for a session cookie on the login page, since they can't send an
+// Authorization header on websocket connections.
+type TokenProvider struct {
+	token    string
+	sessions *SessionStore
+}
+
+var _ Provider = (*TokenProvider)(nil)
+
+// NewTokenProvider creates an instance of TokenProvider.
+func NewTokenProvider(token string, sessions *SessionStore) (*TokenProvider, error) {
+	if token == "" {
+		return nil, errors.New("token is empty")
+	}
+
+	if sessions == nil {
+		sessions = NewSessionStore(DefaultSessionTTL)
+	}
+
+	return &TokenProvider{
+		token:    token,
+		sessions: sessions,
+	}, nil
+}
+
+// Authenticate authenticates a request with a bearer token or a session cookie.
+func (p *TokenProvider) Authenticate(r *http.Request) (*User, error) {
+	if token, ok := bearerToken(r); ok {
+		if !p.validToken(token) {
+			return nil, ErrUnauthenticated
+		}
+
+		return &User{Name: TokenUser}, nil
+	}
+
+	session, ok := p.sessions.FromRequest(r)
+	if !ok {
+		return nil, ErrUnauthenticated
+	}
+
+	return &session.User, nil
+}
+
+// Challenge redirects browsers to the login page. Other clients receive a 401.
+func (p *TokenProvider) Challenge(w http.ResponseWriter, r *http.Request) {
+	if wantsHTML(r) {
+		http.Redirect(w, r, LoginPath+"?redirect="+url.QueryEscape(r.URL.RequestURI()), http.StatusFound)
+		return
+	}
+
+	unauthorized(w, "Bearer")
+}
+
+// RegisterRoutes registers the login and logout handlers.
+func (p *TokenProvider) RegisterRoutes(router *mux.Router) {
+	router.HandleFunc("/login", p.loginPage).Methods(http.MethodGet)
+	router.HandleFunc("/login", p.login).Methods(http.MethodPost)
+	router.HandleFunc("/logout", logoutPage).Methods(http.MethodGet)
+	router.HandleFunc("/logout", p.logout).Methods(http.MethodPost)
+}
+
+func (p *TokenProvider) loginPage(w http.ResponseWriter, r *http.Request) {
+	renderPage(w, http.StatusOK, page{
+		Message:   "Enter the token Octant was started with.",
+		Redirect:  redirectTarget(r.URL.Query().Get("redirect")),
+		TokenForm: true,
+	})
+}
+
+func (p *TokenProvider) login(w http.ResponseWriter, r *http.Request) {
+	redirect := redirectTarget(r.PostFormValue("redirect"))
+
+	if !p.validToken(r.PostFormValue("token")) {
+		renderPage(w, http.StatusUnauthorized, page{
+			Error:     "Invalid token.",
+			Redirect:  redirect,
+			TokenForm: true,
+		})
+		return
+	}
+
+	if err := p.sessions.Login(w, r, User{Name: TokenUser}); err != nil {
+		http.Error(w, "unable to create session", http.StatusInternalServerError)
+		return
+	}
+
+	http.Redirect(w, r, redirect, http.StatusFound)
+}
+
+func (p *TokenProvider) logout(w http.ResponseWriter, r *http.Request) {
+	p.sessions.Logout(w, r)
+	http.Redirect(w, r, LoginPath, http.StatusFound)
+}
+
+func (p *TokenProvider) validToken(token string) bool {
+	return subtle.ConstantTimeCompare([]byte(token), []byte(p.token)) == 1
+}
+
+// bearerToken returns the token from the request's Authorization header.
+func bearerToken(r *http.Request) (string, bool) {
+	header := r.Header.Get("Authorization")
+	const prefix = "bearer "
+	if len(header) < len(prefix) || !strings.EqualFold(header[:len(prefix)], prefix) {
+		return "", false
+	}
+
+	return strings.TrimSpace(header[len(prefix):]), true
+}
@@ -0,0 +1,85 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package auth
+
+import (
+	"net/http"
+	"net/http/httptest"
+	"net/url"
+	"strings"
+	"testing"
+
+	"github.com/gorilla/mux"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestNewTokenProvider_empty(t *testing.T) {
+	_, err := NewTokenProvider("", nil)
+	require.Error(t, err)
+}
+
+func TestTokenProvider_login(t *testing.T) {
+	provider, err := NewTokenProvider("secret", nil)
+	require.NoError(t, err)
+
+	router := mux.NewRouter()
+	provider.RegisterRoutes(router.PathPrefix(PathPrefix).Subrouter())
+
+	login := func(token string) *httptest.ResponseRecorder {
+		form := url.Values{"token": {token}, "redirect": {"/workloads"}}
+		req := httptest.NewRequest(http.MethodPost, LoginPath, strings.NewReader(form.Encode()))
+		req.Header.Set("Content-Type", "application/x-www-form-urlencoded")
+
+		w := httptest.NewRecorder()
+		router.ServeHTTP(w, req)
+		return w
+	}
+
+	w := login("wrong")
+	assert.Equal(t, http.StatusUnauthorized, w.Code)
+	assert.Empty(t, w.Result().Cookies())
+
+	w = login("secret")
+	require.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, "/workloads", w.Header().Get("Location"))
+
+	cookies := w.Result().Cookies()
+	require.Len(t, cookies, 1)
+	assert.Equal(t, SessionCookieName, cookies[0].Name)
+	assert.True(t, cookies[0].HttpOnly)
+
+	req := httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(cookies[0])
+	user, err := provider.Authenticate(req)
+	require.NoError(t, err)
+	assert.Equal(t, TokenUser, user.Name)
+
+	// Visiting the logout page only asks for confirmation.
+	req = httptest.NewRequest(http.MethodGet, LogoutPath, nil)
+	req.AddCookie(cookies[0])
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusOK, w.Code)
+	assert.Contains(t, w.Body.String(), `<form method="POST" action="`+LogoutPath+`">`)
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(cookies[0])
+	_, err = provider.Authenticate(req)
+	require.NoError(t, err)
+
+	req = httptest.NewRequest(http.MethodPost, LogoutPath, nil)
+	req.AddCookie(cookies[0])
+	w = httptest.NewRecorder()
+	router.ServeHTTP(w, req)
+	assert.Equal(t, http.StatusFound, w.Code)
+	assert.Equal(t, LoginPath, w.Header().Get("Location"))
+
+	req = httptest.NewRequest(http.MethodGet, "/", nil)
+	req.AddCookie(cookies[0])
+	_, err = provider.Authenticate(req)
+	assert.Equal(t, ErrUnauthenticated, err)
+}
@@ -60,6 +60,8 @@ type ClientInterface interface {
 	DiscoveryClient() (discovery.DiscoveryInterface, error)
 	NamespaceClient() (clusterTypes.NamespaceInterface, error)
 	InfoClient() (clusterTypes.InfoInterface, error)
+	ForUser(user string, groups []string) (ClientInterface, error)
+	ReleaseUser(user string, groups []string)
 	Close()
 	RESTInterface
 }
@@ -85,6 +87,9 @@ type Cluster struct {
 
 	defaultNamespace   string
 	providedNamespaces []string
+
+	impersonateUsers bool
+	impersonation    *impersonationCache
 }
 
 var _ ClientInterface = (*Cluster)(nil)
@@ -135,6 +140,7 @@ func newCluster(ctx context.Context, clientConfig clientcmd.ClientConfig, restCl
 		logger:             internalLog.From(ctx),
 		defaultNamespace:   defaultNamespace,
 		providedNamespaces: providedNamespaces,
+		impersonation:      newImpersonationCache(),
 	}
 
 	ctx, cancel := context.WithCancel(ctx)
@@ -246,6 +252,7 @@ type clusterOptions struct {
 	InitialNamespace   string
 	ProvidedNamespaces []string
 	RESTConfigOptions  RESTConfigOptions
+	ImpersonateUsers   bool
 }
 
 type ClusterOption func(*clusterOptions)
@@ -280,6 +287,14 @@ func WithClientUserAgent(userAgent string) ClusterOption {
 	}
 }
 
+// WithUserImpersonation configures the cluster to impersonate authenticated users.
+// See ForUser.
+func WithUserImpersonation() ClusterOption {
+	return func(clusterOptions *clusterOptions) {
+		clusterOptions.ImpersonateUsers = true
+	}
+}
+
 func WithRESTConfigOptions(restConfigOptions RESTConfigOptions) ClusterOption {
 	return func(clusterOptions *clusterOptions) {
 		clusterOptions.RESTConfigOptions = restConfigOptions
@@ -320,7 +335,14 @@ func FromClientConfig(
 
 	restConfig = withConfigDefaults(restConfig, options.RESTConfigOptions)
 
-	return newCluster(ctx, clientConfig, restConfig, defaultNamespace, options.ProvidedNamespaces)
+	c, err := newCluster(ctx, clientConfig, restConfig, defaultNamespace, options.ProvidedNamespaces)
+	if err != nil {
+		return nil, err
+	}
+
+	c.impersonateUsers = options.ImpersonateUsers
+
+	return c, nil
 }
 
 // withConfigDefaults returns an extended rest.Config object with additional defaults applied
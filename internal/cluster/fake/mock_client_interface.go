@@ -14,7 +14,8 @@ import (
 	kubernetes "k8s.io/client-go/kubernetes"
 	rest "k8s.io/client-go/rest"
 
-	cluster "github.com/vmware-tanzu/octant/pkg/cluster"
+	cluster "github.com/vmware-tanzu/octant/internal/cluster"
+	cluster0 "github.com/vmware-tanzu/octant/pkg/cluster"
 )
 
 // MockClientInterface is a mock of ClientInterface interface
@@ -142,10 +143,10 @@ func (mr *MockClientInterfaceMockRecorder) DiscoveryClient() *gomock.Call {
 }
 
 // NamespaceClient mocks base method
-func (m *MockClientInterface) NamespaceClient() (cluster.NamespaceInterface, error) {
+func (m *MockClientInterface) NamespaceClient() (cluster0.NamespaceInterface, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "NamespaceClient")
-	ret0, _ := ret[0].(cluster.NamespaceInterface)
+	ret0, _ := ret[0].(cluster0.NamespaceInterface)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -157,10 +158,10 @@ func (mr *MockClientInterfaceMockRecorder) NamespaceClient() *gomock.Call {
 }
 
 // InfoClient mocks base method
-func (m *MockClientInterface) InfoClient() (cluster.InfoInterface, error) {
+func (m *MockClientInterface) InfoClient() (cluster0.InfoInterface, error) {
 	m.ctrl.T.Helper()
 	ret := m.ctrl.Call(m, "InfoClient")
-	ret0, _ := ret[0].(cluster.InfoInterface)
+	ret0, _ := ret[0].(cluster0.InfoInterface)
 	ret1, _ := ret[1].(error)
 	return ret0, ret1
 }
@@ -171,6 +172,33 @@ func (mr *MockClientInterfaceMockRecorder) InfoClient() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "InfoClient", reflect.TypeOf((*MockClientInterface)(nil).InfoClient))
 }
 
+// ForUser mocks base method
+func (m *MockClientInterface) ForUser(user string, groups []string) (cluster.ClientInterface, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ForUser", user, groups)
+	ret0, _ := ret[0].(cluster.ClientInterface)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// ForUser indicates an expected call of ForUser
+func (mr *MockClientInterfaceMockRecorder) ForUser(user, groups interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ForUser", reflect.TypeOf((*MockClientInterface)(nil).ForUser), user, groups)
+}
+
+// ReleaseUser mocks base method
+func (m *MockClientInterface) ReleaseUser(user string, groups []string) {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "ReleaseUser", user, groups)
+}
+
+// ReleaseUser indicates an expected call of ReleaseUser
+func (mr *MockClientInterfaceMockRecorder) ReleaseUser(user, groups interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReleaseUser", reflect.TypeOf((*MockClientInterface)(nil).ReleaseUser), user, groups)
+}
+
 // Close mocks base method
 func (m *MockClientInterface) Close() {
 	m.ctrl.T.Helper()
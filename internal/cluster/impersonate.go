@@ -0,0 +1,132 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cluster
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/rest"
+
+	"github.com/vmware-tanzu/octant/internal/auth"
+)
+
+// impersonationCache caches clients which impersonate users, so each user's clients
+// are only created once.
+type impersonationCache struct {
+	mu      sync.Mutex
+	clients map[string]*Cluster
+}
+
+func newImpersonationCache() *impersonationCache {
+	return &impersonationCache{
+		clients: make(map[string]*Cluster),
+	}
+}
+
+// ForUser returns a client which sends Kubernetes impersonation headers for user and
+// groups. The cluster's own credential must be allowed to impersonate them. The
+// cluster itself is returned if user impersonation is not enabled or user is empty.
+func (c *Cluster) ForUser(user string, groups []string) (ClientInterface, error) {
+	if !c.impersonateUsers || user == "" || c.impersonation == nil {
+		return c, nil
+	}
+
+	key := IdentityKey(user, groups)
+
+	c.impersonation.mu.Lock()
+	defer c.impersonation.mu.Unlock()
+
+	if client, ok := c.impersonation.clients[key]; ok {
+		return client, nil
+	}
+
+	client, err := c.impersonate(user, groups)
+	if err != nil {
+		return nil, err
+	}
+
+	c.impersonation.clients[key] = client
+
+	return client, nil
+}
+
+// ReleaseUser removes the cached client for user and groups, so it can be garbage
+// collected. A later ForUser call creates a new client.
+func (c *Cluster) ReleaseUser(user string, groups []string) {
+	if c.impersonation == nil {
+		return
+	}
+
+	c.impersonation.mu.Lock()
+	defer c.impersonation.mu.Unlock()
+
+	delete(c.impersonation.clients, IdentityKey(user, groups))
+}
+
+// impersonate creates a copy of the cluster which impersonates user and groups.
+// Discovery is shared with the cluster since API resources don't depend on the user.
+func (c *Cluster) impersonate(user string, groups []string) (*Cluster, error) {
+	restConfig := rest.CopyConfig(c.restConfig)
+	restConfig.Impersonate = rest.ImpersonationConfig{
+		UserName: user,
+		Groups:   groups,
+	}
+
+	kubernetesClient, err := kubernetes.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "create kubernetes client for user %s", user)
+	}
+
+	dynamicClient, err := dynamic.NewForConfig(restConfig)
+	if err != nil {
+		return nil, errors.Wrapf(err, "create dynamic client for user %s", user)
+	}
+
+	return &Cluster{
+		clientConfig:       c.clientConfig,
+		restConfig:         restConfig,
+		logger:             c.logger,
+		kubernetesClient:   kubernetesClient,
+		dynamicClient:      dynamicClient,
+		discoveryClient:    c.discoveryClient,
+		restMapper:         c.restMapper,
+		defaultNamespace:   c.defaultNamespace,
+		providedNamespaces: c.providedNamespaces,
+		impersonateUsers:   c.impersonateUsers,
+		// Share the cache so asking an impersonating client for a user returns the
+		// same client as asking the cluster.
+		impersonation: c.impersonation,
+	}, nil
+}
+
+// IdentityKey returns a key which is the same for a user regardless of group order.
+func IdentityKey(user string, groups []string) string {
+	sorted := append([]string(nil), groups...)
+	sort.Strings(sorted)
+
+	return user + "\x00" + strings.Join(sorted, "\x00")
+}
+
+// ClientForContext returns the client for the user authenticated in ctx. client is
+// returned if ctx does not have a user.
+func ClientForContext(ctx context.Context, client ClientInterface) (ClientInterface, error) {
+	user, ok := auth.UserFrom(ctx)
+	if !ok {
+		return client, nil
+	}
+
+	if client == nil {
+		return nil, errors.New("cluster client is nil")
+	}
+
+	return client.ForUser(user.Name, user.Groups)
+}
@@ -0,0 +1,100 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package cluster
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/client-go/rest"
+
+	"github.com/vmware-tanzu/octant/internal/auth"
+)
+
+func TestCluster_ForUser(t *testing.T) {
+	c := &Cluster{
+		restConfig:       &rest.Config{Host: "https://example.com"},
+		impersonateUsers: true,
+		impersonation:    newImpersonationCache(),
+	}
+
+	client, err := c.ForUser("user", []string{"b", "a"})
+	require.NoError(t, err)
+
+	impersonated, ok := client.(*Cluster)
+	require.True(t, ok)
+	assert.Equal(t, rest.ImpersonationConfig{UserName: "user", Groups: []string{"b", "a"}},
+		impersonated.RESTConfig().Impersonate)
+	assert.Empty(t, c.RESTConfig().Impersonate.UserName)
+
+	got, err := c.ForUser("user", []string{"a", "b"})
+	require.NoError(t, err)
+	assert.Same(t, impersonated, got)
+
+	got, err = impersonated.ForUser("user", []string{"a", "b"})
+	require.NoError(t, err)
+	assert.Same(t, impersonated, got)
+
+	got, err = c.ForUser("other", nil)
+	require.NoError(t, err)
+	assert.NotSame(t, impersonated, got)
+
+	got, err = c.ForUser("", nil)
+	require.NoError(t, err)
+	assert.Same(t, c, got)
+}
+
+func TestCluster_ReleaseUser(t *testing.T) {
+	c := &Cluster{
+		restConfig:       &rest.Config{Host: "https://example.com"},
+		impersonateUsers: true,
+		impersonation:    newImpersonationCache(),
+	}
+
+	client, err := c.ForUser("user", []string{"b", "a"})
+	require.NoError(t, err)
+
+	c.ReleaseUser("user", []string{"a", "b"})
+	assert.Empty(t, c.impersonation.clients)
+
+	got, err := c.ForUser("user", []string{"b", "a"})
+	require.NoError(t, err)
+	assert.NotSame(t, client, got)
+}
+
+func TestCluster_ForUser_disabled(t *testing.T) {
+	c := &Cluster{
+		restConfig:    &rest.Config{Host: "https://example.com"},
+		impersonation: newImpersonationCache(),
+	}
+
+	got, err := c.ForUser("user", nil)
+	require.NoError(t, err)
+	assert.Same(t, c, got)
+}
+
+func TestClientForContext(t *testing.T) {
+	c := &Cluster{
+		restConfig:       &rest.Config{Host: "https://example.com"},
+		impersonateUsers: true,
+		impersonation:    newImpersonationCache(),
+	}
+
+	got, err := ClientForContext(context.Background(), c)
+	require.NoError(t, err)
+	assert.Same(t, c, got)
+
+	ctx := auth.WithUser(context.Background(), &auth.User{Name: "user", Groups: []string{"group"}})
+	got, err = ClientForContext(ctx, c)
+	require.NoError(t, err)
+	assert.Equal(t, "user", got.RESTConfig().Impersonate.UserName)
+	assert.Equal(t, []string{"group"}, got.RESTConfig().Impersonate.Groups)
+
+	_, err = ClientForContext(ctx, nil)
+	require.Error(t, err)
+}
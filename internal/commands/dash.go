@@ -20,8 +20,12 @@ import (
 	"k8s.io/klog"
 
 	"github.com/vmware-tanzu/octant/internal/api"
+	"github.com/vmware-tanzu/octant/internal/auth"
 	"github.com/vmware-tanzu/octant/internal/config"
 	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/metrics"
+	"github.com/vmware-tanzu/octant/internal/portforward"
+	"github.com/vmware-tanzu/octant/internal/terminal"
 	pconfig "github.com/vmware-tanzu/octant/pkg/config"
 	"github.com/vmware-tanzu/octant/pkg/dash"
 )
@@ -96,9 +100,43 @@ func newOctantCmd(version string, gitCommit string, buildTime string) *cobra.Com
 				if viper.GetBool("disable-cluster-overview") {
 					options = append(options, dash.WithoutClusterOverview())
 				}
+				if viper.GetBool("read-only") {
+					options = append(options, dash.WithReadOnly())
+				}
+				if source := viper.GetString("from-snapshot"); source != "" {
+					options = append(options, dash.WithSnapshot(source))
+				}
+
+				authProvider, err := auth.NewProvider(ctx)
+				if err != nil {
+					golog.Printf("unable to configure authentication: %v", err)
+					os.Exit(1)
+				}
+				if authProvider != nil {
+					options = append(options, dash.WithAuthProvider(authProvider))
+				}
+				if viper.GetBool("impersonate-users") {
+					options = append(options, dash.WithUserImpersonation())
+				}
+
 				if viper.GetBool("enable-opencensus") {
 					options = append(options, dash.WithOpenCensus())
 				}
+
+				portForwardsPath := viper.GetString("port-forwards-path")
+				if portForwardsPath == "" {
+					if path, err := portforward.DefaultDefinitionsPath(); err == nil {
+						portForwardsPath = path
+					} else {
+						logger.WithErr(err).Warnf("port forwards won't be saved")
+					}
+				}
+				options = append(options, dash.WithPortForwardsPath(portForwardsPath))
+
+				if path := viper.GetString("status-overrides-path"); path != "" {
+					options = append(options, dash.WithStatusOverridesPath(path))
+				}
+
 				if file := viper.GetString("memstats"); file != "" {
 					options = append(options, dash.WithMemStats())
 				}
@@ -157,11 +195,29 @@ func newOctantCmd(version string, gitCommit string, buildTime string) *cobra.Com
 	octantCmd.Flags().BoolP("disable-cluster-overview", "", false, "disable cluster overview")
 	octantCmd.Flags().BoolP("enable-feature-applications", "", false, "enable applications feature")
 	octantCmd.Flags().String("kubeconfig", "", "absolute path to kubeConfig file")
+	octantCmd.Flags().Bool("read-only", false, "disable actions which change the cluster, such as delete, edit and terminal exec")
+	octantCmd.Flags().String("from-snapshot", "", "serve a read-only dashboard from a snapshot archive or a directory of manifests instead of a cluster")
 	octantCmd.Flags().StringP("namespace", "n", "", "initial namespace")
 	octantCmd.Flags().StringSlice("namespace-list", []string{}, "a list of namespaces to use on start")
 	octantCmd.Flags().StringP("plugin-path", "", "", "plugin path")
 	octantCmd.Flags().BoolP("verbose", "v", false, "turn on debug logging")
 	octantCmd.Flags().IntP("client-max-recv-msg-size", "", pconfig.MaxMessageSize, "client max receiver message size")
+	octantCmd.Flags().Duration("metrics-sample-interval", metrics.DefaultSampleInterval, "interval between pod and node metrics samples")
+	octantCmd.Flags().Int("metrics-history-size", metrics.DefaultHistorySize, "number of metrics samples kept for each pod and node")
+	octantCmd.Flags().String("status-overrides-path", "", "YAML file overriding which conditions and phases set the status of a kind")
+	octantCmd.Flags().String("port-forwards-path", "", "file saved port forwards are stored in (defaults to port-forwards.json in the octant config directory)")
+	octantCmd.Flags().String(terminal.RecordingDirKey, "", "directory to record terminal sessions to in asciicast format (disabled if empty)")
+
+	octantCmd.Flags().String(auth.TokenKey, "", "require this bearer token to access the dashboard")
+	octantCmd.Flags().Duration(auth.SessionTTLKey, auth.DefaultSessionTTL, "how long a login session lasts")
+	octantCmd.Flags().Bool("impersonate-users", false, "make Kubernetes requests as the logged in user using impersonation (requires authentication)")
+	octantCmd.Flags().String(auth.OIDCIssuerURLKey, "", "OpenID Connect issuer URL used to log in to the dashboard")
+	octantCmd.Flags().String(auth.OIDCClientIDKey, "", "OpenID Connect client ID")
+	octantCmd.Flags().String(auth.OIDCClientSecretKey, "", "OpenID Connect client secret")
+	octantCmd.Flags().String(auth.OIDCRedirectURLKey, "", "OpenID Connect redirect URL, e.g. https://octant.example.com"+auth.CallbackPath)
+	octantCmd.Flags().StringSlice(auth.OIDCScopesKey, []string{"email", "profile"}, "OpenID Connect scopes requested in addition to openid")
+	octantCmd.Flags().String(auth.OIDCUsernameClaimKey, "email", "OpenID Connect claim used as the user name")
+	octantCmd.Flags().String(auth.OIDCGroupsClaimKey, "groups", "OpenID Connect claim used as the user's groups")
 
 	octantCmd.Flags().StringP("accepted-hosts", "", "", "accepted hosts list [DEV]")
 	octantCmd.Flags().Float32P("client-qps", "", 200, "maximum QPS for client [DEV]")
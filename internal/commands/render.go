@@ -0,0 +1,128 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"os"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/util/json"
+	"github.com/vmware-tanzu/octant/pkg/dash"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+	"github.com/vmware-tanzu/octant/pkg/view/html"
+)
+
+func newRenderCmd(version string) *cobra.Command {
+	renderCmd := &cobra.Command{
+		Use:   "render <content-path>",
+		Short: "Render a content path without a browser",
+		Long: "Render the content for a content path, e.g. overview/namespace/default/workloads/deployments, " +
+			"as JSON or as a self-contained HTML document",
+		Args: cobra.ExactArgs(1),
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			if err := bindViper(cmd); err != nil {
+				return fmt.Errorf("unable to bind flags: %w", err)
+			}
+
+			format := viper.GetString("format")
+			if format != "json" && format != "html" {
+				return fmt.Errorf("unsupported format %q, must be json or html", format)
+			}
+
+			var labelSet *labels.Set
+			if selector := viper.GetString("selector"); selector != "" {
+				set, err := labels.ConvertSelectorToLabelsMap(selector)
+				if err != nil {
+					return fmt.Errorf("invalid selector: %w", err)
+				}
+				labelSet = &set
+			}
+
+			logLevel := 0
+			if viper.GetBool("verbose") {
+				logLevel = 1
+			}
+
+			logger, err := log.Init(logLevel)
+			if err != nil {
+				return fmt.Errorf("unable to initialize logger: %w", err)
+			}
+			defer logger.Close()
+
+			kubeConfig := viper.GetString("kubeconfig")
+			if kubeConfig == "" {
+				kubeConfig = clientcmd.NewDefaultClientConfigLoadingRules().GetDefaultFilename()
+			}
+
+			options := []dash.RunnerOption{
+				dash.WithKubeConfig(kubeConfig),
+				dash.WithContext(viper.GetString("context")),
+				dash.WithNamespaces(viper.GetStringSlice("namespace-list")),
+				dash.WithClientUserAgent(fmt.Sprintf("octant/%s", version)),
+				dash.WithReadOnly(),
+			}
+			if source := viper.GetString("from-snapshot"); source != "" {
+				options = append(options, dash.WithSnapshot(source))
+			}
+
+			response, err := dash.Render(ctx, logger, args[0], labelSet, options...)
+			if err != nil {
+				return err
+			}
+
+			out := cmd.OutOrStdout()
+			if output := viper.GetString("output"); output != "" {
+				f, err := os.Create(output)
+				if err != nil {
+					return fmt.Errorf("create output file: %w", err)
+				}
+				defer f.Close()
+				out = f
+			}
+
+			if format == "html" {
+				return html.Render(out, response)
+			}
+			return writeJSON(out, response)
+		},
+	}
+
+	renderCmd.Flags().SortFlags = false
+
+	renderCmd.Flags().StringP("format", "f", "json", "output format, one of json or html")
+	renderCmd.Flags().StringP("output", "o", "", "file to write to (defaults to stdout)")
+	renderCmd.Flags().StringP("selector", "l", "", "label selector used to filter the content, e.g. app=web")
+	renderCmd.Flags().String("kubeconfig", "", "absolute path to kubeConfig file")
+	renderCmd.Flags().String("context", "", "context to render from")
+	renderCmd.Flags().StringSlice("namespace-list", []string{}, "a list of namespaces to use")
+	renderCmd.Flags().String("from-snapshot", "", "render from a snapshot archive or a directory of manifests instead of a cluster")
+	renderCmd.Flags().Bool("enable-feature-applications", false, "enable applications feature")
+	renderCmd.Flags().String("plugin-path", "", "plugin path")
+	renderCmd.Flags().BoolP("verbose", "v", false, "turn on debug logging")
+
+	return renderCmd
+}
+
+func writeJSON(w io.Writer, response component.ContentResponse) error {
+	data, err := json.MarshalIndent(response, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal content: %w", err)
+	}
+
+	_, err = fmt.Fprintf(w, "%s\n", data)
+	return err
+}
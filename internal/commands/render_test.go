@@ -0,0 +1,113 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package commands
+
+import (
+	"bytes"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+	"testing"
+
+	"github.com/spf13/viper"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+const renderManifest = `apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: web-config
+  namespace: default
+data:
+  key: value
+`
+
+func Test_newRenderCmd(t *testing.T) {
+	dir, err := ioutil.TempDir("", "render")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "app.yaml"), []byte(renderManifest), 0600))
+
+	cases := []struct {
+		name      string
+		args      []string
+		output    string
+		expected  string
+		wantError string
+	}{
+		{
+			name:     "html to file",
+			args:     []string{"overview/namespace/default/config-and-storage/config-maps", "--format", "html"},
+			output:   filepath.Join(dir, "out.html"),
+			expected: "<!DOCTYPE html>",
+		},
+		{
+			name:      "unsupported format",
+			args:      []string{"overview/namespace/default/config-and-storage/config-maps", "--format", "yaml"},
+			wantError: `unsupported format "yaml"`,
+		},
+		{
+			name:      "invalid selector",
+			args:      []string{"overview/namespace/default/config-and-storage/config-maps", "--selector", "a=b=c"},
+			wantError: "invalid selector",
+		},
+	}
+
+	// The logger can only be initialized once per process, so only one case renders.
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			defer viper.Reset()
+
+			args := append(tc.args, "--from-snapshot", dir)
+			if tc.output != "" {
+				args = append(args, "--output", tc.output)
+			}
+
+			var out bytes.Buffer
+			cmd := newRenderCmd("dev")
+			cmd.SetArgs(args)
+			cmd.SetOut(&out)
+			cmd.SilenceUsage = true
+			cmd.SilenceErrors = true
+
+			err := cmd.Execute()
+			if tc.wantError != "" {
+				require.Error(t, err)
+				assert.Contains(t, err.Error(), tc.wantError)
+				return
+			}
+			require.NoError(t, err)
+
+			got := out.String()
+			if tc.output != "" {
+				data, err := ioutil.ReadFile(tc.output)
+				require.NoError(t, err)
+				assert.Empty(t, got)
+				got = string(data)
+			}
+			assert.Contains(t, got, tc.expected)
+			assert.Contains(t, got, "web-config")
+		})
+	}
+}
+
+func Test_writeJSON(t *testing.T) {
+	response := component.ContentResponse{
+		Title:      component.TitleFromString("Config Maps"),
+		Components: []component.Component{component.NewText("web-config")},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, writeJSON(&buf, response))
+
+	assert.Contains(t, buf.String(), `"value":"web-config"`)
+	assert.True(t, strings.HasSuffix(buf.String(), "}\n"))
+}
@@ -0,0 +1,115 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package commands
+
+import (
+	"context"
+	"fmt"
+	"os"
+	"time"
+
+	"github.com/spf13/cobra"
+	"github.com/spf13/viper"
+	"k8s.io/client-go/tools/clientcmd"
+
+	"github.com/vmware-tanzu/octant/internal/kubeconfig"
+	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/snapshot"
+)
+
+func newSnapshotCmd() *cobra.Command {
+	snapshotCmd := &cobra.Command{
+		Use:   "snapshot",
+		Short: "Capture cluster state to an archive",
+		Long:  "Capture the objects, recent events and API discovery information of a cluster to a gzipped tar archive which can be inspected offline",
+		Args:  cobra.NoArgs,
+		RunE: func(cmd *cobra.Command, args []string) error {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			if err := bindViper(cmd); err != nil {
+				return fmt.Errorf("unable to bind flags: %w", err)
+			}
+
+			logLevel := 0
+			if viper.GetBool("verbose") {
+				logLevel = 1
+			}
+
+			logger, err := log.Init(logLevel)
+			if err != nil {
+				return fmt.Errorf("unable to initialize logger: %w", err)
+			}
+			defer logger.Close()
+
+			ctx = log.WithLoggerContext(ctx, logger)
+
+			kubeConfig := viper.GetString("kubeconfig")
+			if kubeConfig == "" {
+				kubeConfig = clientcmd.NewDefaultClientConfigLoadingRules().GetDefaultFilename()
+			}
+
+			manager, err := kubeconfig.NewKubeConfigContextManager(ctx,
+				kubeconfig.WithKubeConfigList(kubeConfig),
+				kubeconfig.WithContextName(viper.GetString("context")))
+			if err != nil {
+				return err
+			}
+
+			client := manager.ClusterClient()
+			defer client.Close()
+
+			output := viper.GetString("output")
+			if output == "" {
+				output = fmt.Sprintf("octant-snapshot-%s.tar.gz", time.Now().UTC().Format("20060102-150405"))
+			}
+
+			f, err := os.Create(output)
+			if err != nil {
+				return fmt.Errorf("create snapshot file: %w", err)
+			}
+			defer f.Close()
+
+			options := snapshot.Options{
+				Context:       manager.CurrentContext(),
+				Namespaces:    viper.GetStringSlice("namespace-list"),
+				RedactSecrets: viper.GetBool("redact-secrets"),
+				EventWindow:   viper.GetDuration("event-window"),
+			}
+
+			metadata, err := snapshot.NewWriter(client).Write(ctx, f, options)
+			if err != nil {
+				return fmt.Errorf("write snapshot: %w", err)
+			}
+
+			if err := f.Close(); err != nil {
+				return fmt.Errorf("close snapshot file: %w", err)
+			}
+
+			out := cmd.OutOrStdout()
+			fmt.Fprintf(out, "Wrote %d objects and %d events from context %q to %s\n",
+				metadata.ObjectCount, metadata.EventCount, metadata.Context, output)
+			if len(metadata.Errors) > 0 {
+				fmt.Fprintf(out, "Unable to capture %d resources, see %s in the snapshot for details\n",
+					len(metadata.Errors), snapshot.MetadataFile)
+			}
+
+			return nil
+		},
+	}
+
+	snapshotCmd.Flags().SortFlags = false
+
+	snapshotCmd.Flags().StringP("output", "o", "", "snapshot file to write (defaults to octant-snapshot-<time>.tar.gz)")
+	snapshotCmd.Flags().String("kubeconfig", "", "absolute path to kubeConfig file")
+	snapshotCmd.Flags().String("context", "", "context to capture")
+	snapshotCmd.Flags().StringSlice("namespace-list", []string{}, "namespaces to capture (defaults to all namespaces)")
+	snapshotCmd.Flags().Bool("redact-secrets", true, "remove the values of Secrets from the snapshot")
+	snapshotCmd.Flags().Duration("event-window", time.Hour, "capture events which occurred within this duration (0 captures all events)")
+	snapshotCmd.Flags().BoolP("verbose", "v", false, "turn on debug logging")
+
+	return snapshotCmd
+}
@@ -9,6 +9,7 @@ import (
 	"context"
 
 	"github.com/vmware-tanzu/octant/internal/kubeconfig"
+	"github.com/vmware-tanzu/octant/internal/metrics"
 	"github.com/vmware-tanzu/octant/internal/octant"
 	"github.com/vmware-tanzu/octant/pkg/store"
 
@@ -20,6 +21,7 @@ import (
 	internalErr "github.com/vmware-tanzu/octant/internal/errors"
 	"github.com/vmware-tanzu/octant/internal/module"
 	"github.com/vmware-tanzu/octant/internal/portforward"
+	"github.com/vmware-tanzu/octant/internal/search"
 	"github.com/vmware-tanzu/octant/pkg/log"
 	"github.com/vmware-tanzu/octant/pkg/plugin"
 )
@@ -127,6 +129,12 @@ type Dash interface {
 
 	PortForwarder() portforward.PortForwarder
 
+	MetricsHistory() metrics.History
+
+	SearchIndex() *search.Index
+
+	ReadOnly() bool
+
 	SetContextChosenInUI(contextChosen bool)
 
 	UseFSContext(ctx context.Context) error
@@ -159,9 +167,12 @@ type Live struct {
 	errorStore           internalErr.ErrorStore
 	pluginManager        plugin.ManagerInterface
 	portForwarder        portforward.PortForwarder
+	metricsHistory       metrics.History
+	searchIndex          *search.Index
 	restConfigOptions    cluster.RESTConfigOptions
 	buildInfo            BuildInfo
 	contextChosenInUI    bool
+	readOnly             bool
 }
 
 var _ Dash = (*Live)(nil)
@@ -176,9 +187,12 @@ func NewLiveConfig(
 	errorStore internalErr.ErrorStore,
 	pluginManager plugin.ManagerInterface,
 	portForwarder portforward.PortForwarder,
+	metricsHistory metrics.History,
+	searchIndex *search.Index,
 	restConfigOptions cluster.RESTConfigOptions,
 	buildInfo BuildInfo,
 	contextChosenInUI bool,
+	readOnly bool,
 ) *Live {
 	l := &Live{
 		kubeContextDecorator: kubeContextDecorator,
@@ -189,9 +203,12 @@ func NewLiveConfig(
 		errorStore:           errorStore,
 		pluginManager:        pluginManager,
 		portForwarder:        portForwarder,
+		metricsHistory:       metricsHistory,
+		searchIndex:          searchIndex,
 		restConfigOptions:    restConfigOptions,
 		buildInfo:            buildInfo,
 		contextChosenInUI:    contextChosenInUI,
+		readOnly:             readOnly,
 	}
 	objectStore.RegisterOnUpdate(func(store store.Store) {
 		l.objectStore = store
@@ -240,6 +257,21 @@ func (l *Live) PortForwarder() portforward.PortForwarder {
 	return l.portForwarder
 }
 
+// MetricsHistory returns the pod and node metrics history.
+func (l *Live) MetricsHistory() metrics.History {
+	return l.metricsHistory
+}
+
+// SearchIndex returns the index of cached objects.
+func (l *Live) SearchIndex() *search.Index {
+	return l.searchIndex
+}
+
+// ReadOnly returns true if mutating actions are disabled.
+func (l *Live) ReadOnly() bool {
+	return l.readOnly
+}
+
 func (l *Live) SetContextChosenInUI(contextChosen bool) {
 	l.contextChosenInUI = contextChosen
 }
@@ -270,6 +302,14 @@ func (l *Live) UseContext(ctx context.Context, contextName string) error {
 
 	l.Logger().With("new-kube-context", contextName).Infof("updated kube config context")
 
+	l.metricsHistory.Reset()
+
+	// Port forwards are to the previous cluster, so they are replaced by the new context's
+	// saved port forwards. A failure to restore them doesn't stop the context switch.
+	if err := l.portForwarder.UpdateClusterClient(ctx, l.CurrentContext(), client); err != nil {
+		l.Logger().WithErr(err).Errorf("unable to restore saved port forwards")
+	}
+
 	for _, m := range l.moduleManager.Modules() {
 		if err := m.ResetCRDs(ctx); err != nil {
 			return errors.Wrapf(err, "unable to reset CRDs for module %s", m.Name())
@@ -326,6 +366,14 @@ func (l *Live) Validate() error {
 		return errors.New("port forwarder is nil")
 	}
 
+	if l.metricsHistory == nil {
+		return errors.New("metrics history is nil")
+	}
+
+	if l.searchIndex == nil {
+		return errors.New("search index is nil")
+	}
+
 	return nil
 }
 
@@ -21,9 +21,11 @@ import (
 	configFake "github.com/vmware-tanzu/octant/internal/config/fake"
 	internalErr "github.com/vmware-tanzu/octant/internal/errors"
 	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/metrics"
 	"github.com/vmware-tanzu/octant/internal/module"
 	moduleFake "github.com/vmware-tanzu/octant/internal/module/fake"
 	portForwardFake "github.com/vmware-tanzu/octant/internal/portforward/fake"
+	"github.com/vmware-tanzu/octant/internal/search"
 	"github.com/vmware-tanzu/octant/internal/testutil"
 	pluginFake "github.com/vmware-tanzu/octant/pkg/plugin/fake"
 	objectStoreFake "github.com/vmware-tanzu/octant/pkg/store/fake"
@@ -89,6 +91,8 @@ func TestLiveConfig(t *testing.T) {
 	assert.NoError(t, err)
 	pluginManager := pluginFake.NewMockManagerInterface(controller)
 	portForwarder := portForwardFake.NewMockPortForwarder(controller)
+	metricsHistory := metrics.NewMemoryHistory(metrics.DefaultHistorySize)
+	searchIndex := search.NewIndex()
 	buildInfo := BuildInfo{}
 
 	objectStore.EXPECT().
@@ -105,9 +109,12 @@ func TestLiveConfig(t *testing.T) {
 		errorStore,
 		pluginManager,
 		portForwarder,
+		metricsHistory,
+		searchIndex,
 		restConfigOptions,
 		buildInfo,
 		false,
+		false,
 	)
 
 	assert.NoError(t, config.Validate())
@@ -117,12 +124,42 @@ func TestLiveConfig(t *testing.T) {
 	assert.Equal(t, objectStore, config.ObjectStore())
 	assert.Equal(t, pluginManager, config.PluginManager())
 	assert.Equal(t, portForwarder, config.PortForwarder())
+	assert.Equal(t, metricsHistory, config.MetricsHistory())
+	assert.Equal(t, searchIndex, config.SearchIndex())
+	assert.False(t, config.ReadOnly())
 
 	objectPath, err := config.ObjectPath("", "", "", "")
 	require.NoError(t, err)
 	assert.Equal(t, "/pod", objectPath)
 }
 
+func TestLiveConfig_ReadOnly(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	objectStore := objectStoreFake.NewMockStore(controller)
+	objectStore.EXPECT().RegisterOnUpdate(gomock.Any())
+
+	config := NewLiveConfig(
+		StaticClusterClient(clusterFake.NewMockClientInterface(controller)),
+		stubCRDWatcher{},
+		log.NopLogger(),
+		moduleFake.NewMockManagerInterface(controller),
+		objectStore,
+		nil,
+		pluginFake.NewMockManagerInterface(controller),
+		portForwardFake.NewMockPortForwarder(controller),
+		metrics.NewMemoryHistory(metrics.DefaultHistorySize),
+		search.NewIndex(),
+		cluster.RESTConfigOptions{},
+		BuildInfo{},
+		false, // contextChosenInUI
+		true,  // readOnly
+	)
+
+	assert.True(t, config.ReadOnly())
+}
+
 func TestLiveConfig_UseContext_WithContextChosenByUISetToTrue(t *testing.T) {
 	controller := gomock.NewController(t)
 	defer controller.Finish()
@@ -138,6 +175,8 @@ func TestLiveConfig_UseContext_WithContextChosenByUISetToTrue(t *testing.T) {
 	assert.NoError(t, err)
 	pluginManager := pluginFake.NewMockManagerInterface(controller)
 	portForwarder := portForwardFake.NewMockPortForwarder(controller)
+	metricsHistory := metrics.NewMemoryHistory(metrics.DefaultHistorySize)
+	searchIndex := search.NewIndex()
 	buildInfo := BuildInfo{}
 
 	objectStore.EXPECT().
@@ -156,15 +195,19 @@ func TestLiveConfig_UseContext_WithContextChosenByUISetToTrue(t *testing.T) {
 		errorStore,
 		pluginManager,
 		portForwarder,
+		metricsHistory,
+		searchIndex,
 		restConfigOptions,
 		buildInfo,
-		true, // contextChosenInUI
+		true,  // contextChosenInUI
+		false, // readOnly
 	)
 
 	objectStore.EXPECT().UpdateClusterClient(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	moduleManager.EXPECT().Modules().Return(make([]module.Module, 0)).AnyTimes()
 	pluginManager.EXPECT().SetOctantClient(gomock.Eq(config)).AnyTimes()
 	contextDecorator.EXPECT().ClusterClient().AnyTimes()
+	portForwarder.EXPECT().UpdateClusterClient(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	newContext := ""
 	currentContext := "socketContext"
@@ -198,6 +241,8 @@ func TestLiveConfig_UseContext_WithContextChosenByUISetToFalse(t *testing.T) {
 	assert.NoError(t, err)
 	pluginManager := pluginFake.NewMockManagerInterface(controller)
 	portForwarder := portForwardFake.NewMockPortForwarder(controller)
+	metricsHistory := metrics.NewMemoryHistory(metrics.DefaultHistorySize)
+	searchIndex := search.NewIndex()
 	buildInfo := BuildInfo{}
 
 	objectStore.EXPECT().
@@ -216,15 +261,19 @@ func TestLiveConfig_UseContext_WithContextChosenByUISetToFalse(t *testing.T) {
 		errorStore,
 		pluginManager,
 		portForwarder,
+		metricsHistory,
+		searchIndex,
 		restConfigOptions,
 		buildInfo,
 		false, // contextChosenInUI
+		false, // readOnly
 	)
 
 	objectStore.EXPECT().UpdateClusterClient(gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 	moduleManager.EXPECT().Modules().Return(make([]module.Module, 0)).AnyTimes()
 	pluginManager.EXPECT().SetOctantClient(gomock.Eq(config)).AnyTimes()
 	contextDecorator.EXPECT().ClusterClient().AnyTimes()
+	portForwarder.EXPECT().UpdateClusterClient(gomock.Any(), gomock.Any(), gomock.Any()).Return(nil).AnyTimes()
 
 	newContext := ""
 	currentContext := "socketContext"
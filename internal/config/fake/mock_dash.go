@@ -14,8 +14,10 @@ import (
 	config "github.com/vmware-tanzu/octant/internal/config"
 	errors "github.com/vmware-tanzu/octant/internal/errors"
 	kubeconfig "github.com/vmware-tanzu/octant/internal/kubeconfig"
+	metrics "github.com/vmware-tanzu/octant/internal/metrics"
 	module "github.com/vmware-tanzu/octant/internal/module"
 	portforward "github.com/vmware-tanzu/octant/internal/portforward"
+	search "github.com/vmware-tanzu/octant/internal/search"
 	log "github.com/vmware-tanzu/octant/pkg/log"
 	plugin "github.com/vmware-tanzu/octant/pkg/plugin"
 	store "github.com/vmware-tanzu/octant/pkg/store"
@@ -158,6 +160,20 @@ func (mr *MockDashMockRecorder) Logger() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Logger", reflect.TypeOf((*MockDash)(nil).Logger))
 }
 
+// MetricsHistory mocks base method
+func (m *MockDash) MetricsHistory() metrics.History {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "MetricsHistory")
+	ret0, _ := ret[0].(metrics.History)
+	return ret0
+}
+
+// MetricsHistory indicates an expected call of MetricsHistory
+func (mr *MockDashMockRecorder) MetricsHistory() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "MetricsHistory", reflect.TypeOf((*MockDash)(nil).MetricsHistory))
+}
+
 // ModuleManager mocks base method
 func (m *MockDash) ModuleManager() module.ManagerInterface {
 	m.ctrl.T.Helper()
@@ -229,6 +245,34 @@ func (mr *MockDashMockRecorder) PortForwarder() *gomock.Call {
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PortForwarder", reflect.TypeOf((*MockDash)(nil).PortForwarder))
 }
 
+// ReadOnly mocks base method
+func (m *MockDash) ReadOnly() bool {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "ReadOnly")
+	ret0, _ := ret[0].(bool)
+	return ret0
+}
+
+// ReadOnly indicates an expected call of ReadOnly
+func (mr *MockDashMockRecorder) ReadOnly() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "ReadOnly", reflect.TypeOf((*MockDash)(nil).ReadOnly))
+}
+
+// SearchIndex mocks base method
+func (m *MockDash) SearchIndex() *search.Index {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "SearchIndex")
+	ret0, _ := ret[0].(*search.Index)
+	return ret0
+}
+
+// SearchIndex indicates an expected call of SearchIndex
+func (mr *MockDashMockRecorder) SearchIndex() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "SearchIndex", reflect.TypeOf((*MockDash)(nil).SearchIndex))
+}
+
 // SetContextChosenInUI mocks base method
 func (m *MockDash) SetContextChosenInUI(arg0 bool) {
 	m.ctrl.T.Helper()
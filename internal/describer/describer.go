@@ -18,6 +18,7 @@ import (
 	oerrors "github.com/vmware-tanzu/octant/internal/errors"
 	"github.com/vmware-tanzu/octant/internal/link"
 	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/modules/overview/container"
 	"github.com/vmware-tanzu/octant/internal/printer"
 	"github.com/vmware-tanzu/octant/internal/queryer"
 	"github.com/vmware-tanzu/octant/pkg/store"
@@ -159,3 +160,7 @@ func isPod(object runtime.Object) bool {
 	apiVersion, kind := gvk.ToAPIVersionAndKind()
 	return apiVersion == "v1" && kind == "Pod"
 }
+
+func isLogWorkload(object runtime.Object) bool {
+	return container.IsLogWorkload(object.GetObjectKind().GroupVersionKind().Kind)
+}
@@ -26,9 +26,12 @@ func defaultObjectTabs() []Tab {
 		{Name: "Summary", Factory: SummaryTab},
 		{Name: "Metadata", Factory: MetadataTab},
 		{Name: "Resource Viewer", Factory: ResourceViewerTab},
+		{Name: "Network Reachability", Factory: NetworkReachabilityTab},
 		{Name: "YAML", Factory: YAMLViewerTab},
+		{Name: "History", Factory: HistoryTab},
 		{Name: "Logs", Factory: LogsTab},
 		{Name: "Terminal", Factory: TerminalTab},
+		{Name: "Terminal Recordings", Factory: TerminalRecordingsTab},
 	}
 }
 
@@ -124,7 +127,7 @@ func (d *Object) Describe(ctx context.Context, namespace string, options Options
 		return component.EmptyContentResponse, err
 	}
 
-	if objAccessor.GetDeletionTimestamp() == nil {
+	if objAccessor.GetDeletionTimestamp() == nil && !options.ReadOnly() {
 		key, err := store.KeyFromObject(currentObject)
 		if err != nil {
 			return component.EmptyContentResponse, err
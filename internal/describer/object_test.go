@@ -51,6 +51,7 @@ func TestObjectDescriber(t *testing.T) {
 
 	pluginManager := plugin.NewManager(nil, moduleRegistrar, actionRegistrar, wsClient)
 	dashConfig.EXPECT().PluginManager().Return(pluginManager).AnyTimes()
+	dashConfig.EXPECT().ReadOnly().Return(false).AnyTimes()
 
 	podSummary := component.NewText("summary")
 
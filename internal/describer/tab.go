@@ -10,16 +10,23 @@ import (
 	"context"
 	"fmt"
 
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/meta"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/vmware-tanzu/octant/internal/link"
 	"github.com/vmware-tanzu/octant/internal/log"
 	"github.com/vmware-tanzu/octant/internal/modules/overview/logviewer"
+	"github.com/vmware-tanzu/octant/internal/modules/overview/rollout"
 	"github.com/vmware-tanzu/octant/internal/modules/overview/terminalviewer"
 	"github.com/vmware-tanzu/octant/internal/modules/overview/yamlviewer"
+	"github.com/vmware-tanzu/octant/internal/networkpolicy"
+	"github.com/vmware-tanzu/octant/internal/octant"
 	"github.com/vmware-tanzu/octant/internal/printer"
 	"github.com/vmware-tanzu/octant/internal/resourceviewer"
+	"github.com/vmware-tanzu/octant/internal/terminal"
 	"github.com/vmware-tanzu/octant/pkg/view/component"
 )
 
@@ -93,25 +100,99 @@ func ResourceViewerTab(ctx context.Context, object runtime.Object, options Optio
 		return nil, fmt.Errorf("create resource viewer: %w", err)
 	}
 
+	resourceViewerComponent.SetExportPath(resourceviewer.ExportURLPath(u.GetAPIVersion(), u.GetKind(), u.GetNamespace(), u.GetName()))
 	resourceViewerComponent.SetAccessor("resourceViewer")
 	return resourceViewerComponent, nil
 }
 
-// YAMLViewerTab generates a yaml viewer for an object.
-func YAMLViewerTab(_ context.Context, object runtime.Object, _ Options) (component.Component, error) {
+// NetworkReachabilityTab generates a graph of the traffic network policies allow in
+// the namespace of a pod or network policy. It highlights the pod, or the pods the
+// policy selects. If the object is neither, the returned component will be nil with
+// a nil error.
+func NetworkReachabilityTab(ctx context.Context, object runtime.Object, options Options) (component.Component, error) {
+	var pod *corev1.Pod
+	var policy *networkingv1.NetworkPolicy
+
+	switch t := object.(type) {
+	case *corev1.Pod:
+		pod = t
+	case *networkingv1.NetworkPolicy:
+		policy = t
+	default:
+		return nil, nil
+	}
+
+	analyzer, err := networkpolicy.Load(ctx, options.ObjectStore())
+	if err != nil {
+		return nil, fmt.Errorf("load network policies: %w", err)
+	}
+
+	var reachabilityComponent *component.FlexLayout
+	var namespace, source string
+	if pod != nil {
+		g := analyzer.Graph(pod.Namespace, func(p *corev1.Pod) bool {
+			return p.Name == pod.Name
+		})
+		node, _ := g.PodNode(pod.Name)
+		reachabilityComponent = networkpolicy.ToComponent(g, node.ID)
+		namespace, source = pod.Namespace, pod.Name
+	} else {
+		g := analyzer.Graph(policy.Namespace, func(p *corev1.Pod) bool {
+			return networkpolicy.Selects(policy, p)
+		})
+		reachabilityComponent = networkpolicy.ToComponent(g, "")
+		namespace = policy.Namespace
+	}
+
+	buttonGroup := component.NewButtonGroup()
+	buttonGroup.AddButton(component.NewButton("Check Reachability", nil,
+		component.WithModal(networkpolicy.QueryModal(octant.ActionNetworkReachability, namespace, source))))
+	reachabilityComponent.SetButtonGroup(buttonGroup)
+
+	reachabilityComponent.SetAccessor("networkReachability")
+	return reachabilityComponent, nil
+}
+
+// YAMLViewerTab generates a yaml viewer for an object. The viewer can't be edited
+// in read-only mode.
+func YAMLViewerTab(_ context.Context, object runtime.Object, options Options) (component.Component, error) {
 	yvComponent, err := yamlviewer.ToComponent(object)
 	if err != nil {
 		return nil, fmt.Errorf("create yaml viewer: %w", err)
 	}
 
+	yvComponent.Config.ReadOnly = options.ReadOnly()
+
 	yvComponent.SetAccessor("yaml")
 	return yvComponent, nil
 }
 
-// LogsTab generates a logs tab for a pod. If the object is not a pod, the
-// returned component will be nil with a nil error.
+// HistoryTab generates a revision history tab for a deployment, daemon set or stateful
+// set. If the object has no revision history, the returned component will be nil with a
+// nil error. Rollbacks are disabled in read-only mode.
+func HistoryTab(ctx context.Context, object runtime.Object, options Options) (component.Component, error) {
+	if !rollout.IsSupported(object) {
+		return nil, nil
+	}
+
+	rollbackAction := octant.ActionRollback
+	if options.ReadOnly() {
+		rollbackAction = ""
+	}
+
+	historyComponent, err := rollout.ToComponent(ctx, options.ObjectStore(), object, rollbackAction)
+	if err != nil {
+		return nil, fmt.Errorf("create history: %w", err)
+	}
+
+	historyComponent.SetAccessor("history")
+	return historyComponent, nil
+}
+
+// LogsTab generates a logs tab for a pod or a workload. If the object is neither,
+// the returned component will be nil with a nil error.
 func LogsTab(_ context.Context, object runtime.Object, _ Options) (component.Component, error) {
-	if isPod(object) {
+	if isPod(object) || isLogWorkload(object) {
 		logsComponent, err := logviewer.ToComponent(object)
 		if err != nil {
 			return nil, fmt.Errorf("create log viewer: %w", err)
@@ -124,10 +205,10 @@ func LogsTab(_ context.Context, object runtime.Object, _ Options) (component.Com
 	return nil, nil
 }
 
-// TerminalTab generates a terminal tab for a pod. If the object is not a pod,
-// the returned component will be nil with a nil error.
+// TerminalTab generates a terminal tab for a pod. If the object is not a pod, or
+// octant is in read-only mode, the returned component will be nil with a nil error.
 func TerminalTab(ctx context.Context, object runtime.Object, options Options) (component.Component, error) {
-	if isPod(object) {
+	if isPod(object) && !options.ReadOnly() {
 		logger := log.From(ctx)
 
 		terminalComponent, err := terminalviewer.ToComponent(ctx, object, logger, options.Dash)
@@ -141,3 +222,26 @@ func TerminalTab(ctx context.Context, object runtime.Object, options Options) (c
 
 	return nil, nil
 }
+
+// TerminalRecordingsTab generates a tab listing the terminal recordings for a pod. If the
+// object is not a pod or terminal recording is disabled, the returned component will be
+// nil with a nil error.
+func TerminalRecordingsTab(_ context.Context, object runtime.Object, _ Options) (component.Component, error) {
+	dir := terminal.RecordingDir()
+	if !isPod(object) || dir == "" {
+		return nil, nil
+	}
+
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return nil, fmt.Errorf("access pod metadata: %w", err)
+	}
+
+	recordingsComponent, err := terminalviewer.RecordingsToComponent(dir, accessor.GetNamespace(), accessor.GetName())
+	if err != nil {
+		return nil, fmt.Errorf("create terminal recordings viewer: %w", err)
+	}
+
+	recordingsComponent.SetAccessor("terminalRecordings")
+	return recordingsComponent, nil
+}
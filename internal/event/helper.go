@@ -16,9 +16,10 @@ import (
 )
 
 type buildInfoResponse struct {
-	Version string `json:"version"`
-	Commit  string `json:"commit"`
-	Time    string `json:"time"`
+	Version  string `json:"version"`
+	Commit   string `json:"commit"`
+	Time     string `json:"time"`
+	ReadOnly bool   `json:"readOnly"`
 }
 
 type HelperGeneratorOption func(generator *HelperGenerator)
@@ -45,9 +46,10 @@ func (h *HelperGenerator) Event(ctx context.Context) (event.Event, error) {
 	version, commit, time := h.DashConfig.BuildInfo()
 
 	resp := buildInfoResponse{
-		Version: version,
-		Commit:  commit,
-		Time:    time,
+		Version:  version,
+		Commit:   commit,
+		Time:     time,
+		ReadOnly: h.DashConfig.ReadOnly(),
 	}
 
 	e := event.Event{
@@ -0,0 +1,76 @@
+// Code generated by MockGen. DO NOT EDIT.
+// Source: github.com/vmware-tanzu/octant/internal/metrics (interfaces: History)
+
+// Package fake is a generated GoMock package.
+package fake
+
+import (
+	reflect "reflect"
+
+	gomock "github.com/golang/mock/gomock"
+
+	metrics "github.com/vmware-tanzu/octant/internal/metrics"
+)
+
+// MockHistory is a mock of History interface
+type MockHistory struct {
+	ctrl     *gomock.Controller
+	recorder *MockHistoryMockRecorder
+}
+
+// MockHistoryMockRecorder is the mock recorder for MockHistory
+type MockHistoryMockRecorder struct {
+	mock *MockHistory
+}
+
+// NewMockHistory creates a new mock instance
+func NewMockHistory(ctrl *gomock.Controller) *MockHistory {
+	mock := &MockHistory{ctrl: ctrl}
+	mock.recorder = &MockHistoryMockRecorder{mock}
+	return mock
+}
+
+// EXPECT returns an object that allows the caller to indicate expected use
+func (m *MockHistory) EXPECT() *MockHistoryMockRecorder {
+	return m.recorder
+}
+
+// NodeSamples mocks base method
+func (m *MockHistory) NodeSamples(arg0 string) []metrics.Sample {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "NodeSamples", arg0)
+	ret0, _ := ret[0].([]metrics.Sample)
+	return ret0
+}
+
+// NodeSamples indicates an expected call of NodeSamples
+func (mr *MockHistoryMockRecorder) NodeSamples(arg0 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "NodeSamples", reflect.TypeOf((*MockHistory)(nil).NodeSamples), arg0)
+}
+
+// PodSamples mocks base method
+func (m *MockHistory) PodSamples(arg0, arg1 string) []metrics.Sample {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "PodSamples", arg0, arg1)
+	ret0, _ := ret[0].([]metrics.Sample)
+	return ret0
+}
+
+// PodSamples indicates an expected call of PodSamples
+func (mr *MockHistoryMockRecorder) PodSamples(arg0, arg1 interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "PodSamples", reflect.TypeOf((*MockHistory)(nil).PodSamples), arg0, arg1)
+}
+
+// Reset mocks base method
+func (m *MockHistory) Reset() {
+	m.ctrl.T.Helper()
+	m.ctrl.Call(m, "Reset")
+}
+
+// Reset indicates an expected call of Reset
+func (mr *MockHistoryMockRecorder) Reset() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Reset", reflect.TypeOf((*MockHistory)(nil).Reset))
+}
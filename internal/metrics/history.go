@@ -0,0 +1,194 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"fmt"
+	"sync"
+	"time"
+)
+
+//go:generate mockgen -destination=./fake/mock_history.go -package=fake github.com/vmware-tanzu/octant/internal/metrics History
+
+// DefaultHistorySize is the default number of samples kept for each pod and node.
+const DefaultHistorySize = 120
+
+// Sample is resource usage at a point in time.
+type Sample struct {
+	// Timestamp is the time the usage was measured.
+	Timestamp time.Time
+	// CPU is the CPU usage in millicores.
+	CPU int64
+	// Memory is the memory usage in bytes.
+	Memory int64
+}
+
+// History is a store of resource usage samples for pods and nodes.
+type History interface {
+	// PodSamples returns the samples for a pod ordered from oldest to newest.
+	PodSamples(namespace, name string) []Sample
+	// NodeSamples returns the samples for a node ordered from oldest to newest.
+	NodeSamples(name string) []Sample
+	// Reset removes all samples.
+	Reset()
+}
+
+// MemoryHistory is an in-memory History. It keeps a fixed number of samples for each
+// pod and node.
+type MemoryHistory struct {
+	size int
+
+	mu    sync.RWMutex
+	pods  map[string]*ring
+	nodes map[string]*ring
+}
+
+var _ History = (*MemoryHistory)(nil)
+
+// NewMemoryHistory creates an instance of MemoryHistory which keeps size samples for
+// each pod and node.
+func NewMemoryHistory(size int) *MemoryHistory {
+	if size < 1 {
+		size = DefaultHistorySize
+	}
+
+	return &MemoryHistory{
+		size:  size,
+		pods:  make(map[string]*ring),
+		nodes: make(map[string]*ring),
+	}
+}
+
+// RecordPod records a sample for a pod.
+func (h *MemoryHistory) RecordPod(namespace, name string, sample Sample) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.record(h.pods, podKey(namespace, name), sample)
+}
+
+// RecordNode records a sample for a node.
+func (h *MemoryHistory) RecordNode(name string, sample Sample) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.record(h.nodes, name, sample)
+}
+
+func (h *MemoryHistory) record(m map[string]*ring, key string, sample Sample) {
+	r, ok := m[key]
+	if !ok {
+		r = newRing(h.size)
+		m[key] = r
+	}
+
+	r.add(sample)
+}
+
+// PodSamples returns the samples for a pod ordered from oldest to newest.
+func (h *MemoryHistory) PodSamples(namespace, name string) []Sample {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	r, ok := h.pods[podKey(namespace, name)]
+	if !ok {
+		return nil
+	}
+
+	return r.samples()
+}
+
+// NodeSamples returns the samples for a node ordered from oldest to newest.
+func (h *MemoryHistory) NodeSamples(name string) []Sample {
+	h.mu.RLock()
+	defer h.mu.RUnlock()
+
+	r, ok := h.nodes[name]
+	if !ok {
+		return nil
+	}
+
+	return r.samples()
+}
+
+// RetainPods removes the samples of pods which are not in keep. keep is a set of
+// namespace/name keys.
+func (h *MemoryHistory) RetainPods(keep map[string]bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for key := range h.pods {
+		if !keep[key] {
+			delete(h.pods, key)
+		}
+	}
+}
+
+// RetainNodes removes the samples of nodes which are not in keep.
+func (h *MemoryHistory) RetainNodes(keep map[string]bool) {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	for key := range h.nodes {
+		if !keep[key] {
+			delete(h.nodes, key)
+		}
+	}
+}
+
+// Reset removes all samples.
+func (h *MemoryHistory) Reset() {
+	h.mu.Lock()
+	defer h.mu.Unlock()
+
+	h.pods = make(map[string]*ring)
+	h.nodes = make(map[string]*ring)
+}
+
+func podKey(namespace, name string) string {
+	return fmt.Sprintf("%s/%s", namespace, name)
+}
+
+// ring is a fixed size ring buffer of samples.
+type ring struct {
+	buf   []Sample
+	start int
+	count int
+}
+
+func newRing(size int) *ring {
+	return &ring{buf: make([]Sample, size)}
+}
+
+// add adds a sample, overwriting the oldest sample if the ring is full. Samples with a
+// timestamp which isn't newer than the last sample are ignored, so polling faster than
+// the metrics are refreshed doesn't produce duplicates.
+func (r *ring) add(sample Sample) {
+	if r.count > 0 {
+		last := r.buf[(r.start+r.count-1)%len(r.buf)]
+		if !sample.Timestamp.After(last.Timestamp) {
+			return
+		}
+	}
+
+	if r.count < len(r.buf) {
+		r.buf[(r.start+r.count)%len(r.buf)] = sample
+		r.count++
+		return
+	}
+
+	r.buf[r.start] = sample
+	r.start = (r.start + 1) % len(r.buf)
+}
+
+func (r *ring) samples() []Sample {
+	out := make([]Sample, r.count)
+	for i := 0; i < r.count; i++ {
+		out[i] = r.buf[(r.start+i)%len(r.buf)]
+	}
+
+	return out
+}
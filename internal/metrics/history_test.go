@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+)
+
+func TestMemoryHistory(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+	sampleAt := func(offset int, cpu int64) Sample {
+		return Sample{Timestamp: now.Add(time.Duration(offset) * time.Second), CPU: cpu}
+	}
+
+	tests := []struct {
+		name     string
+		size     int
+		samples  []Sample
+		expected []Sample
+	}{
+		{
+			name:     "empty",
+			size:     3,
+			expected: nil,
+		},
+		{
+			name:     "partially filled",
+			size:     3,
+			samples:  []Sample{sampleAt(0, 1), sampleAt(1, 2)},
+			expected: []Sample{sampleAt(0, 1), sampleAt(1, 2)},
+		},
+		{
+			name:     "wraps around",
+			size:     3,
+			samples:  []Sample{sampleAt(0, 1), sampleAt(1, 2), sampleAt(2, 3), sampleAt(3, 4), sampleAt(4, 5)},
+			expected: []Sample{sampleAt(2, 3), sampleAt(3, 4), sampleAt(4, 5)},
+		},
+		{
+			name:     "ignores samples which are not newer",
+			size:     3,
+			samples:  []Sample{sampleAt(0, 1), sampleAt(1, 2), sampleAt(1, 3), sampleAt(0, 4)},
+			expected: []Sample{sampleAt(0, 1), sampleAt(1, 2)},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			history := NewMemoryHistory(test.size)
+			for _, sample := range test.samples {
+				history.RecordPod("default", "pod", sample)
+				history.RecordNode("node", sample)
+			}
+
+			if test.expected == nil {
+				assert.Empty(t, history.PodSamples("default", "pod"))
+				assert.Empty(t, history.NodeSamples("node"))
+				return
+			}
+
+			assert.Equal(t, test.expected, history.PodSamples("default", "pod"))
+			assert.Equal(t, test.expected, history.NodeSamples("node"))
+		})
+	}
+}
+
+func TestMemoryHistory_Retain(t *testing.T) {
+	sample := Sample{Timestamp: time.Unix(1600000000, 0)}
+
+	history := NewMemoryHistory(3)
+	history.RecordPod("default", "a", sample)
+	history.RecordPod("default", "b", sample)
+	history.RecordNode("a", sample)
+	history.RecordNode("b", sample)
+
+	history.RetainPods(map[string]bool{"default/a": true})
+	history.RetainNodes(map[string]bool{"b": true})
+
+	assert.Len(t, history.PodSamples("default", "a"), 1)
+	assert.Empty(t, history.PodSamples("default", "b"))
+	assert.Empty(t, history.NodeSamples("a"))
+	assert.Len(t, history.NodeSamples("b"), 1)
+
+	history.Reset()
+	assert.Empty(t, history.PodSamples("default", "a"))
+	assert.Empty(t, history.NodeSamples("b"))
+}
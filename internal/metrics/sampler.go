@@ -0,0 +1,171 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"context"
+	"fmt"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	metricsv1beta1 "k8s.io/metrics/pkg/apis/metrics/v1beta1"
+
+	"github.com/vmware-tanzu/octant/internal/cluster"
+	"github.com/vmware-tanzu/octant/internal/log"
+)
+
+// DefaultSampleInterval is the default interval between samples.
+const DefaultSampleInterval = 30 * time.Second
+
+var (
+	podMetricsResource  = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+	nodeMetricsResource = schema.GroupVersionResource{Group: "metrics.k8s.io", Version: "v1beta1", Resource: "nodes"}
+)
+
+// ClusterClientProvider provides the current cluster client. The client can change
+// when the current context is switched.
+type ClusterClientProvider interface {
+	ClusterClient() cluster.ClientInterface
+}
+
+// Sampler periodically records pod and node metrics into a MemoryHistory.
+type Sampler struct {
+	clientProvider ClusterClientProvider
+	history        *MemoryHistory
+	interval       time.Duration
+	now            func() time.Time
+}
+
+// SamplerOption is an option for configuring Sampler.
+type SamplerOption func(s *Sampler)
+
+// WithSampleInterval sets the interval between samples.
+func WithSampleInterval(interval time.Duration) SamplerOption {
+	return func(s *Sampler) {
+		if interval > 0 {
+			s.interval = interval
+		}
+	}
+}
+
+// NewSampler creates an instance of Sampler.
+func NewSampler(clientProvider ClusterClientProvider, history *MemoryHistory, options ...SamplerOption) *Sampler {
+	s := &Sampler{
+		clientProvider: clientProvider,
+		history:        history,
+		interval:       DefaultSampleInterval,
+		now:            time.Now,
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	return s
+}
+
+// Run samples metrics until the context is canceled.
+func (s *Sampler) Run(ctx context.Context) {
+	logger := log.From(ctx).With("component", "metrics-sampler")
+
+	ticker := time.NewTicker(s.interval)
+	defer ticker.Stop()
+
+	for {
+		if err := s.Sample(ctx); err != nil {
+			logger.WithErr(err).Debugf("sample metrics")
+		}
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+		}
+	}
+}
+
+// Sample records the current pod and node metrics. Clusters without a metrics API are
+// skipped silently.
+func (s *Sampler) Sample(ctx context.Context) error {
+	client := s.clientProvider.ClusterClient()
+	if client == nil {
+		return fmt.Errorf("cluster client is nil")
+	}
+
+	dynamicClient, err := client.DynamicClient()
+	if err != nil {
+		return fmt.Errorf("get dynamic client: %w", err)
+	}
+
+	podList, err := dynamicClient.Resource(podMetricsResource).Namespace(metav1.NamespaceAll).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if isMetricsUnavailable(err) {
+			return nil
+		}
+		return fmt.Errorf("list pod metrics: %w", err)
+	}
+
+	seenPods := make(map[string]bool)
+	for i := range podList.Items {
+		podMetrics := &metricsv1beta1.PodMetrics{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(podList.Items[i].Object, podMetrics); err != nil {
+			return fmt.Errorf("convert pod metrics: %w", err)
+		}
+
+		var usage []corev1.ResourceList
+		for _, container := range podMetrics.Containers {
+			usage = append(usage, container.Usage)
+		}
+
+		s.history.RecordPod(podMetrics.Namespace, podMetrics.Name, s.sampleFor(podMetrics.Timestamp, usage...))
+		seenPods[podKey(podMetrics.Namespace, podMetrics.Name)] = true
+	}
+	s.history.RetainPods(seenPods)
+
+	nodeList, err := dynamicClient.Resource(nodeMetricsResource).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		if isMetricsUnavailable(err) {
+			return nil
+		}
+		return fmt.Errorf("list node metrics: %w", err)
+	}
+
+	seenNodes := make(map[string]bool)
+	for i := range nodeList.Items {
+		nodeMetrics := &metricsv1beta1.NodeMetrics{}
+		if err := runtime.DefaultUnstructuredConverter.FromUnstructured(nodeList.Items[i].Object, nodeMetrics); err != nil {
+			return fmt.Errorf("convert node metrics: %w", err)
+		}
+
+		s.history.RecordNode(nodeMetrics.Name, s.sampleFor(nodeMetrics.Timestamp, nodeMetrics.Usage))
+		seenNodes[nodeMetrics.Name] = true
+	}
+	s.history.RetainNodes(seenNodes)
+
+	return nil
+}
+
+func (s *Sampler) sampleFor(timestamp metav1.Time, usage ...corev1.ResourceList) Sample {
+	sample := Sample{Timestamp: timestamp.Time}
+	if sample.Timestamp.IsZero() {
+		sample.Timestamp = s.now()
+	}
+
+	for _, resourceList := range usage {
+		sample.CPU += resourceList.Cpu().MilliValue()
+		sample.Memory += resourceList.Memory().Value()
+	}
+
+	return sample
+}
+
+func isMetricsUnavailable(err error) bool {
+	return kerrors.IsNotFound(err) || kerrors.IsServiceUnavailable(err)
+}
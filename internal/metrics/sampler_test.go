@@ -0,0 +1,131 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package metrics
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	"github.com/vmware-tanzu/octant/internal/cluster"
+	clusterFake "github.com/vmware-tanzu/octant/internal/cluster/fake"
+)
+
+type staticClientProvider struct {
+	client cluster.ClientInterface
+}
+
+func (s staticClientProvider) ClusterClient() cluster.ClientInterface {
+	return s.client
+}
+
+func TestSampler_Sample(t *testing.T) {
+	podMetrics := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "metrics.k8s.io/v1beta1",
+		"kind":       "PodMetrics",
+		"metadata": map[string]interface{}{
+			"name":      "pod",
+			"namespace": "default",
+		},
+		"timestamp": "2020-09-13T12:26:40Z",
+		"containers": []interface{}{
+			map[string]interface{}{
+				"name":  "a",
+				"usage": map[string]interface{}{"cpu": "100m", "memory": "1Mi"},
+			},
+			map[string]interface{}{
+				"name":  "b",
+				"usage": map[string]interface{}{"cpu": "50m", "memory": "1Mi"},
+			},
+		},
+	}}
+	nodeMetrics := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "metrics.k8s.io/v1beta1",
+		"kind":       "NodeMetrics",
+		"metadata": map[string]interface{}{
+			"name": "node",
+		},
+		"usage": map[string]interface{}{"cpu": "2", "memory": "1Gi"},
+	}}
+
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	dynamicClient.PrependReactor("list", "pods", listReactor(podMetrics))
+	dynamicClient.PrependReactor("list", "nodes", listReactor(nodeMetrics))
+
+	clusterClient := clusterFake.NewMockClientInterface(controller)
+	clusterClient.EXPECT().DynamicClient().Return(dynamicClient, nil)
+
+	now := time.Unix(1600000000, 0)
+	history := NewMemoryHistory(10)
+	history.RecordPod("default", "deleted", Sample{Timestamp: now})
+
+	sampler := NewSampler(staticClientProvider{client: clusterClient}, history)
+	sampler.now = func() time.Time {
+		return now
+	}
+
+	require.NoError(t, sampler.Sample(context.Background()))
+
+	podSamples := history.PodSamples("default", "pod")
+	require.Len(t, podSamples, 1)
+	assert.True(t, podSamples[0].Timestamp.Equal(time.Date(2020, 9, 13, 12, 26, 40, 0, time.UTC)))
+	assert.Equal(t, int64(150), podSamples[0].CPU)
+	assert.Equal(t, int64(2*1024*1024), podSamples[0].Memory)
+	assert.Empty(t, history.PodSamples("default", "deleted"))
+
+	expectedNode := []Sample{
+		{
+			Timestamp: now,
+			CPU:       2000,
+			Memory:    1024 * 1024 * 1024,
+		},
+	}
+	assert.Equal(t, expectedNode, history.NodeSamples("node"))
+}
+
+func TestSampler_Sample_metrics_unavailable(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(runtime.NewScheme())
+	dynamicClient.PrependReactor("list", "pods", func(action k8stesting.Action) (bool, runtime.Object, error) {
+		return true, nil, kerrors.NewNotFound(schema.GroupResource{Group: "metrics.k8s.io", Resource: "pods"}, "")
+	})
+
+	clusterClient := clusterFake.NewMockClientInterface(controller)
+	clusterClient.EXPECT().DynamicClient().Return(dynamicClient, nil)
+
+	history := NewMemoryHistory(10)
+	sampler := NewSampler(staticClientProvider{client: clusterClient}, history)
+
+	require.NoError(t, sampler.Sample(context.Background()))
+}
+
+func listReactor(objects ...*unstructured.Unstructured) k8stesting.ReactionFunc {
+	return func(action k8stesting.Action) (bool, runtime.Object, error) {
+		list := &unstructured.UnstructuredList{Object: map[string]interface{}{
+			"apiVersion": "metrics.k8s.io/v1beta1",
+			"kind":       "List",
+		}}
+		for _, object := range objects {
+			list.Items = append(list.Items, *object)
+		}
+		return true, list, nil
+	}
+}
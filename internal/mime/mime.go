@@ -8,4 +8,10 @@ package mime
 const (
 	// JSONContentType is the content type for the API.
 	JSONContentType = "application/json; charset=utf-8"
+
+	// TextContentType is the content type for plain text downloads.
+	TextContentType = "text/plain; charset=utf-8"
+
+	// HTMLContentType is the content type for HTML pages.
+	HTMLContentType = "text/html; charset=utf-8"
 )
@@ -0,0 +1,220 @@
+/*
+ * Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package applications
+
+import (
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/base64"
+	"encoding/json"
+	"fmt"
+	"io/ioutil"
+	"sort"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/util/path_util"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+const (
+	// helmReleaseType is the secret type Helm v3 uses to store releases.
+	helmReleaseType = "helm.sh/release.v1"
+	// helmReleaseKey is the secret data key containing the encoded release.
+	helmReleaseKey = "release"
+	// helmOwnerLabel is the label Helm v3 sets on release secrets.
+	helmOwnerLabel = "owner"
+)
+
+var gzipMagic = []byte{0x1f, 0x8b, 0x08}
+
+// helmRelease is a Helm v3 release. Only the fields Octant displays are decoded.
+type helmRelease struct {
+	Name      string                 `json:"name"`
+	Namespace string                 `json:"namespace"`
+	Version   int                    `json:"version"`
+	Info      helmReleaseInfo        `json:"info"`
+	Chart     helmChart              `json:"chart"`
+	Config    map[string]interface{} `json:"config,omitempty"`
+	Manifest  string                 `json:"manifest"`
+}
+
+type helmReleaseInfo struct {
+	FirstDeployed time.Time `json:"first_deployed"`
+	LastDeployed  time.Time `json:"last_deployed"`
+	Description   string    `json:"description"`
+	Status        string    `json:"status"`
+	Notes         string    `json:"notes,omitempty"`
+}
+
+type helmChart struct {
+	Metadata helmChartMetadata `json:"metadata"`
+}
+
+type helmChartMetadata struct {
+	Name        string `json:"name"`
+	Version     string `json:"version"`
+	AppVersion  string `json:"appVersion"`
+	Description string `json:"description"`
+}
+
+// ChartName returns the chart name and version.
+func (r *helmRelease) ChartName() string {
+	return fmt.Sprintf("%s-%s", r.Chart.Metadata.Name, r.Chart.Metadata.Version)
+}
+
+// Path returns the content path for the release.
+func (r *helmRelease) Path(prefix, namespace string) string {
+	return path_util.NamespacedPath(path_util.PrefixedPath(prefix), namespace, "helm", r.Name)
+}
+
+// helmReleaseHistory is every stored revision of a release.
+type helmReleaseHistory struct {
+	Name string
+	// Revisions are sorted by version, oldest first.
+	Revisions []*helmRelease
+}
+
+// Latest returns the most recent revision of the release.
+func (h *helmReleaseHistory) Latest() *helmRelease {
+	if len(h.Revisions) == 0 {
+		return nil
+	}
+	return h.Revisions[len(h.Revisions)-1]
+}
+
+// decodeHelmRelease decodes a Helm v3 release secret. Helm stores the release as
+// base64 encoded, gzipped JSON.
+func decodeHelmRelease(object *unstructured.Unstructured) (*helmRelease, error) {
+	if object == nil {
+		return nil, errors.New("object is nil")
+	}
+
+	secret := &corev1.Secret{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, secret); err != nil {
+		return nil, errors.Wrap(err, "convert object to secret")
+	}
+
+	if secret.Type != helmReleaseType {
+		return nil, errors.Errorf("secret %s is not a helm release", secret.Name)
+	}
+
+	encoded, ok := secret.Data[helmReleaseKey]
+	if !ok {
+		return nil, errors.Errorf("secret %s does not contain a release", secret.Name)
+	}
+
+	data := make([]byte, base64.StdEncoding.DecodedLen(len(encoded)))
+	n, err := base64.StdEncoding.Decode(data, encoded)
+	if err != nil {
+		return nil, errors.Wrapf(err, "decode release in secret %s", secret.Name)
+	}
+	data = data[:n]
+
+	if bytes.HasPrefix(data, gzipMagic) {
+		r, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return nil, errors.Wrapf(err, "decompress release in secret %s", secret.Name)
+		}
+		defer r.Close()
+
+		data, err = ioutil.ReadAll(r)
+		if err != nil {
+			return nil, errors.Wrapf(err, "decompress release in secret %s", secret.Name)
+		}
+	}
+
+	release := &helmRelease{}
+	if err := json.Unmarshal(data, release); err != nil {
+		return nil, errors.Wrapf(err, "unmarshal release in secret %s", secret.Name)
+	}
+
+	if release.Namespace == "" {
+		release.Namespace = secret.Namespace
+	}
+
+	return release, nil
+}
+
+// listHelmReleases lists the Helm v3 releases in a namespace sorted by name. Secrets
+// which can't be decoded are skipped.
+func listHelmReleases(ctx context.Context, objectStore store.Store, namespace string) ([]helmReleaseHistory, error) {
+	if objectStore == nil {
+		return nil, errors.New("object store is nil")
+	}
+
+	key := store.Key{
+		Namespace:  namespace,
+		APIVersion: "v1",
+		Kind:       "Secret",
+		Selector:   &labels.Set{helmOwnerLabel: "helm"},
+	}
+
+	secrets, _, err := objectStore.List(ctx, key)
+	if err != nil {
+		return nil, errors.Wrap(err, "list helm release secrets")
+	}
+
+	releases := make(map[string]*helmReleaseHistory)
+	for i := range secrets.Items {
+		secretType, _, err := unstructured.NestedString(secrets.Items[i].Object, "type")
+		if err != nil || secretType != helmReleaseType {
+			continue
+		}
+
+		release, err := decodeHelmRelease(&secrets.Items[i])
+		if err != nil {
+			// A single corrupt release should not hide the others.
+			log.From(ctx).WithErr(err).Warnf("unable to decode helm release")
+			continue
+		}
+
+		history, ok := releases[release.Name]
+		if !ok {
+			history = &helmReleaseHistory{Name: release.Name}
+			releases[release.Name] = history
+		}
+		history.Revisions = append(history.Revisions, release)
+	}
+
+	var list []helmReleaseHistory
+	for _, history := range releases {
+		sort.Slice(history.Revisions, func(i, j int) bool {
+			return history.Revisions[i].Version < history.Revisions[j].Version
+		})
+		list = append(list, *history)
+	}
+
+	sort.Slice(list, func(i, j int) bool {
+		return list[i].Name < list[j].Name
+	})
+
+	return list, nil
+}
+
+// getHelmRelease returns the history for a named release. It returns false if
+// the release does not exist.
+func getHelmRelease(ctx context.Context, objectStore store.Store, namespace, name string) (helmReleaseHistory, bool, error) {
+	releases, err := listHelmReleases(ctx, objectStore, namespace)
+	if err != nil {
+		return helmReleaseHistory{}, false, err
+	}
+
+	for _, release := range releases {
+		if release.Name == name {
+			return release, true, nil
+		}
+	}
+
+	return helmReleaseHistory{}, false, nil
+}
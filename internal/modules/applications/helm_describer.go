@@ -0,0 +1,298 @@
+/*
+ * Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package applications
+
+import (
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+	sigyaml "sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/octant/internal/cluster"
+	"github.com/vmware-tanzu/octant/internal/describer"
+	"github.com/vmware-tanzu/octant/internal/util/path_util"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+var (
+	helmReleaseListColumns    = component.NewTableCols("Name", "Revision", "Chart", "App Version", "Status", "Updated")
+	helmReleaseHistoryColumns = component.NewTableCols("Revision", "Chart", "App Version", "Status", "Updated", "Description")
+	helmManifestColumns       = component.NewTableCols("Name", "Kind", "API Version")
+)
+
+// HelmDescriber describes the Helm releases in a namespace.
+type HelmDescriber struct{}
+
+var _ describer.Describer = (*HelmDescriber)(nil)
+
+// NewHelmDescriber creates an instance of HelmDescriber.
+func NewHelmDescriber() *HelmDescriber {
+	return &HelmDescriber{}
+}
+
+// Describe prints a table of Helm releases.
+func (h *HelmDescriber) Describe(ctx context.Context, namespace string, options describer.Options) (component.ContentResponse, error) {
+	releases, err := listHelmReleases(ctx, options.ObjectStore(), namespace)
+	if err != nil {
+		return component.EmptyContentResponse, err
+	}
+
+	table := component.NewTable("Helm Releases", "There are no Helm releases!", helmReleaseListColumns)
+	for _, history := range releases {
+		release := history.Latest()
+		table.Add(component.TableRow{
+			"Name":        component.NewLink("", release.Name, release.Path("applications", namespace)),
+			"Revision":    component.NewText(fmt.Sprintf("%d", release.Version)),
+			"Chart":       component.NewText(release.ChartName()),
+			"App Version": component.NewText(release.Chart.Metadata.AppVersion),
+			"Status":      component.NewText(release.Info.Status),
+			"Updated":     component.NewTimestamp(release.Info.LastDeployed),
+		})
+	}
+
+	return component.ContentResponse{
+		Title:      component.TitleFromString("Helm Releases"),
+		Components: []component.Component{table},
+	}, nil
+}
+
+// PathFilters returns the path filters for the Helm release list.
+func (h *HelmDescriber) PathFilters() []describer.PathFilter {
+	return []describer.PathFilter{
+		*describer.NewPathFilter("/helm", h),
+	}
+}
+
+// Reset does nothing.
+func (h *HelmDescriber) Reset(ctx context.Context) error {
+	return nil
+}
+
+// HelmReleaseDescriber describes a single Helm release.
+type HelmReleaseDescriber struct{}
+
+var _ describer.Describer = (*HelmReleaseDescriber)(nil)
+
+// NewHelmReleaseDescriber creates an instance of HelmReleaseDescriber.
+func NewHelmReleaseDescriber() *HelmReleaseDescriber {
+	return &HelmReleaseDescriber{}
+}
+
+// Describe creates a content response for a release. It includes a summary, the release
+// history, the user supplied values and the objects in the rendered manifest.
+func (h *HelmReleaseDescriber) Describe(ctx context.Context, namespace string, options describer.Options) (component.ContentResponse, error) {
+	name := options.Fields["release"]
+	if name == "" {
+		return component.EmptyContentResponse, errors.New("release name is blank")
+	}
+
+	history, found, err := getHelmRelease(ctx, options.ObjectStore(), namespace, name)
+	if err != nil {
+		return component.EmptyContentResponse, err
+	}
+
+	title := component.Title(
+		component.NewLink("", "Helm Releases", helmPath(namespace)),
+		component.NewText(name))
+
+	if !found {
+		return component.ContentResponse{
+			Title:      title,
+			Components: []component.Component{component.NewText(fmt.Sprintf("Helm release %q was not found", name))},
+		}, nil
+	}
+
+	release := history.Latest()
+
+	values, err := helmValuesComponent(release)
+	if err != nil {
+		return component.EmptyContentResponse, err
+	}
+
+	manifestObjects, err := helmManifestComponent(release, options)
+	if err != nil {
+		return component.EmptyContentResponse, err
+	}
+
+	manifest := component.NewCodeBlock(release.Manifest)
+	manifest.SetTitleText("Manifest")
+
+	layout := component.NewFlexLayout("Summary")
+	layout.SetAccessor("summary")
+	layout.AddSections(
+		component.FlexLayoutSection{
+			{Width: component.WidthHalf, View: helmSummaryComponent(release)},
+			{Width: component.WidthHalf, View: helmHistoryComponent(history)},
+		},
+		component.FlexLayoutSection{
+			{Width: component.WidthFull, View: manifestObjects},
+		},
+		component.FlexLayoutSection{
+			{Width: component.WidthHalf, View: values},
+			{Width: component.WidthHalf, View: manifest},
+		},
+	)
+
+	return component.ContentResponse{
+		Title:      title,
+		Components: []component.Component{layout},
+	}, nil
+}
+
+// PathFilters returns the path filters for a release. The path for a release is
+// /helm/release-name.
+func (h *HelmReleaseDescriber) PathFilters() []describer.PathFilter {
+	return []describer.PathFilter{
+		*describer.NewPathFilter("/helm/(?P<release>[^/]*)", h),
+	}
+}
+
+// Reset does nothing.
+func (h *HelmReleaseDescriber) Reset(ctx context.Context) error {
+	return nil
+}
+
+func helmPath(namespace string) string {
+	return path_util.NamespacedPath(path_util.PrefixedPath("applications"), namespace, "helm")
+}
+
+func helmSummaryComponent(release *helmRelease) *component.Summary {
+	var sections component.SummarySections
+	sections.AddText("Chart", release.ChartName())
+	sections.AddText("App Version", release.Chart.Metadata.AppVersion)
+	sections.AddText("Status", release.Info.Status)
+	sections.AddText("Revision", fmt.Sprintf("%d", release.Version))
+	sections.Add("First Deployed", component.NewTimestamp(release.Info.FirstDeployed))
+	sections.Add("Last Deployed", component.NewTimestamp(release.Info.LastDeployed))
+	if release.Info.Description != "" {
+		sections.AddText("Description", release.Info.Description)
+	}
+
+	return component.NewSummary("Release", sections...)
+}
+
+func helmHistoryComponent(history helmReleaseHistory) *component.Table {
+	table := component.NewTable("History", "There is no history for this release", helmReleaseHistoryColumns)
+
+	// Most recent revision first.
+	for i := len(history.Revisions) - 1; i >= 0; i-- {
+		release := history.Revisions[i]
+		table.Add(component.TableRow{
+			"Revision":    component.NewText(fmt.Sprintf("%d", release.Version)),
+			"Chart":       component.NewText(release.ChartName()),
+			"App Version": component.NewText(release.Chart.Metadata.AppVersion),
+			"Status":      component.NewText(release.Info.Status),
+			"Updated":     component.NewTimestamp(release.Info.LastDeployed),
+			"Description": component.NewText(release.Info.Description),
+		})
+	}
+
+	return table
+}
+
+func helmValuesComponent(release *helmRelease) (*component.Code, error) {
+	values := "# no user supplied values"
+	if len(release.Config) > 0 {
+		data, err := sigyaml.Marshal(release.Config)
+		if err != nil {
+			return nil, errors.Wrap(err, "marshal release values")
+		}
+		values = string(data)
+	}
+
+	code := component.NewCodeBlock(values)
+	code.SetTitleText("Values")
+	return code, nil
+}
+
+func helmManifestComponent(release *helmRelease, options describer.Options) (*component.Table, error) {
+	objects, err := helmManifestObjects(release.Manifest)
+	if err != nil {
+		return nil, err
+	}
+
+	var client cluster.ClientInterface
+	if options.Dash != nil {
+		client = options.ClusterClient()
+	}
+
+	table := component.NewTable("Manifest Objects", "The manifest does not contain any objects", helmManifestColumns)
+	for _, object := range objects {
+		namespace := object.GetNamespace()
+		if namespace == "" && isNamespaced(client, object) {
+			namespace = release.Namespace
+		}
+
+		var nameView component.Component = component.NewText(object.GetName())
+		if options.Link != nil {
+			link, err := options.Link.ForGVK(namespace, object.GetAPIVersion(), object.GetKind(), object.GetName(), object.GetName())
+			if err == nil {
+				nameView = link
+			}
+		}
+
+		table.Add(component.TableRow{
+			"Name":        nameView,
+			"Kind":        component.NewText(object.GetKind()),
+			"API Version": component.NewText(object.GetAPIVersion()),
+		})
+	}
+
+	return table, nil
+}
+
+// isNamespaced returns true if an object's kind is namespaced. Helm installs
+// namespaced objects without a namespace in the release's namespace. Kinds the
+// cluster doesn't know are assumed to be namespaced.
+func isNamespaced(client cluster.ClientInterface, object *unstructured.Unstructured) bool {
+	if client == nil {
+		return true
+	}
+
+	_, namespaced, err := client.Resource(object.GroupVersionKind().GroupKind())
+	if err != nil {
+		return true
+	}
+
+	return namespaced
+}
+
+// helmManifestObjects decodes the objects in a rendered release manifest.
+func helmManifestObjects(manifest string) ([]*unstructured.Unstructured, error) {
+	decoder := kyaml.NewYAMLOrJSONDecoder(strings.NewReader(manifest), 4096)
+
+	var objects []*unstructured.Unstructured
+	for {
+		object := &unstructured.Unstructured{}
+		if err := decoder.Decode(&object.Object); err != nil {
+			if err == io.EOF {
+				break
+			}
+			return nil, errors.Wrap(err, "decode release manifest")
+		}
+
+		if len(object.Object) == 0 {
+			continue
+		}
+
+		objects = append(objects, object)
+	}
+
+	sort.SliceStable(objects, func(i, j int) bool {
+		if objects[i].GetKind() != objects[j].GetKind() {
+			return objects[i].GetKind() < objects[j].GetKind()
+		}
+		return objects[i].GetName() < objects[j].GetName()
+	})
+
+	return objects, nil
+}
@@ -0,0 +1,180 @@
+/*
+ * Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package applications
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	clusterFake "github.com/vmware-tanzu/octant/internal/cluster/fake"
+	configFake "github.com/vmware-tanzu/octant/internal/config/fake"
+	"github.com/vmware-tanzu/octant/internal/describer"
+	linkFake "github.com/vmware-tanzu/octant/internal/link/fake"
+	"github.com/vmware-tanzu/octant/internal/testutil"
+	"github.com/vmware-tanzu/octant/pkg/store/fake"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+func helmDescriberOptions(t *testing.T, controller *gomock.Controller) describer.Options {
+	objectStore := fake.NewMockStore(controller)
+	objectStore.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return(testutil.ToUnstructuredList(t,
+			testutil.LoadUnstructuredFromFile(t, "helm_release_v1.yaml"),
+			testutil.LoadUnstructuredFromFile(t, "helm_release_v2.yaml"),
+		), false, nil)
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().ObjectStore().Return(objectStore)
+
+	return describer.Options{
+		Dash:   dashConfig,
+		Fields: map[string]string{"release": "web"},
+	}
+}
+
+func TestHelmDescriber_Describe(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	options := helmDescriberOptions(t, controller)
+
+	d := NewHelmDescriber()
+	actual, err := d.Describe(context.Background(), "default", options)
+	require.NoError(t, err)
+
+	expected := component.NewTableWithRows("Helm Releases", "There are no Helm releases!", helmReleaseListColumns, []component.TableRow{
+		{
+			"Name":        component.NewLink("", "web", "/applications/namespace/default/helm/web"),
+			"Revision":    component.NewText("2"),
+			"Chart":       component.NewText("nginx-0.2.0"),
+			"App Version": component.NewText("1.20.0"),
+			"Status":      component.NewText("deployed"),
+			"Updated":     component.NewTimestamp(time.Date(2021, 3, 2, 12, 0, 0, 0, time.UTC)),
+		},
+	})
+
+	require.Len(t, actual.Components, 1)
+	table, ok := actual.Components[0].(*component.Table)
+	require.True(t, ok)
+	require.Equal(t, expected.Rows(), table.Rows())
+}
+
+func TestHelmReleaseDescriber_Describe(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	options := helmDescriberOptions(t, controller)
+
+	linkInterface := linkFake.NewMockInterface(controller)
+	linkInterface.EXPECT().
+		ForGVK("default", "apps/v1", "Deployment", "web-nginx", "web-nginx").
+		Return(component.NewLink("", "web-nginx", "/deployment"), nil)
+	linkInterface.EXPECT().
+		ForGVK("default", "v1", "Service", "web-nginx", "web-nginx").
+		Return(component.NewLink("", "web-nginx", "/service"), nil)
+	options.Link = linkInterface
+
+	clusterClient := clusterFake.NewMockClientInterface(controller)
+	clusterClient.EXPECT().Resource(gomock.Any()).Return(schema.GroupVersionResource{}, true, nil).AnyTimes()
+	options.Dash.(*configFake.MockDash).EXPECT().ClusterClient().Return(clusterClient)
+
+	d := NewHelmReleaseDescriber()
+	actual, err := d.Describe(context.Background(), "default", options)
+	require.NoError(t, err)
+
+	require.Len(t, actual.Components, 1)
+	layout, ok := actual.Components[0].(*component.FlexLayout)
+	require.True(t, ok)
+	require.Len(t, layout.Config.Sections, 3)
+
+	history, ok := layout.Config.Sections[0][1].View.(*component.Table)
+	require.True(t, ok)
+	rows := history.Rows()
+	require.Len(t, rows, 2)
+	assert.Equal(t, component.NewText("2"), rows[0]["Revision"])
+	assert.Equal(t, component.NewText("1"), rows[1]["Revision"])
+
+	manifest, ok := layout.Config.Sections[1][0].View.(*component.Table)
+	require.True(t, ok)
+	rows = manifest.Rows()
+	require.Len(t, rows, 2)
+	assert.Equal(t, component.NewLink("", "web-nginx", "/deployment"), rows[0]["Name"])
+	assert.Equal(t, component.NewLink("", "web-nginx", "/service"), rows[1]["Name"])
+
+	values, ok := layout.Config.Sections[2][0].View.(*component.Code)
+	require.True(t, ok)
+	assert.Equal(t, "image:\n  tag: 1.20.0\nreplicaCount: 2\n", values.Config.Code)
+}
+
+func TestHelmReleaseDescriber_Describe_not_found(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	options := helmDescriberOptions(t, controller)
+	options.Fields = map[string]string{"release": "missing"}
+
+	d := NewHelmReleaseDescriber()
+	actual, err := d.Describe(context.Background(), "default", options)
+	require.NoError(t, err)
+
+	require.Len(t, actual.Components, 1)
+	assert.Equal(t, component.NewText(`Helm release "missing" was not found`), actual.Components[0])
+}
+
+func Test_helmManifestComponent_clusterScoped(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	release := &helmRelease{
+		Namespace: "default",
+		Manifest: `---
+apiVersion: rbac.authorization.k8s.io/v1
+kind: ClusterRole
+metadata:
+  name: web-reader
+---
+apiVersion: v1
+kind: ServiceAccount
+metadata:
+  name: web
+`,
+	}
+
+	clusterClient := clusterFake.NewMockClientInterface(controller)
+	clusterClient.EXPECT().
+		Resource(schema.GroupKind{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}).
+		Return(schema.GroupVersionResource{}, false, nil)
+	clusterClient.EXPECT().
+		Resource(schema.GroupKind{Kind: "ServiceAccount"}).
+		Return(schema.GroupVersionResource{}, true, nil)
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().ClusterClient().Return(clusterClient)
+
+	// Cluster-scoped objects don't get the release's namespace.
+	linkInterface := linkFake.NewMockInterface(controller)
+	linkInterface.EXPECT().
+		ForGVK("", "rbac.authorization.k8s.io/v1", "ClusterRole", "web-reader", "web-reader").
+		Return(component.NewLink("", "web-reader", "/cluster-role"), nil)
+	linkInterface.EXPECT().
+		ForGVK("default", "v1", "ServiceAccount", "web", "web").
+		Return(component.NewLink("", "web", "/service-account"), nil)
+
+	table, err := helmManifestComponent(release, describer.Options{Dash: dashConfig, Link: linkInterface})
+	require.NoError(t, err)
+
+	rows := table.Rows()
+	require.Len(t, rows, 2)
+	assert.Equal(t, component.NewLink("", "web-reader", "/cluster-role"), rows[0]["Name"])
+	assert.Equal(t, component.NewLink("", "web", "/service-account"), rows[1]["Name"])
+}
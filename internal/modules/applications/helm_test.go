@@ -0,0 +1,132 @@
+/*
+ * Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package applications
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/vmware-tanzu/octant/internal/testutil"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	"github.com/vmware-tanzu/octant/pkg/store/fake"
+)
+
+func Test_decodeHelmRelease(t *testing.T) {
+	tests := []struct {
+		name          string
+		file          string
+		version       int
+		status        string
+		chart         string
+		appVersion    string
+		config        map[string]interface{}
+		lastDeployed  time.Time
+		manifestKinds []string
+	}{
+		{
+			name:          "gzipped release",
+			file:          "helm_release_v2.yaml",
+			version:       2,
+			status:        "deployed",
+			chart:         "nginx-0.2.0",
+			appVersion:    "1.20.0",
+			config:        map[string]interface{}{"replicaCount": float64(2), "image": map[string]interface{}{"tag": "1.20.0"}},
+			lastDeployed:  time.Date(2021, 3, 2, 12, 0, 0, 0, time.UTC),
+			manifestKinds: []string{"Deployment", "Service"},
+		},
+		{
+			name:          "uncompressed release",
+			file:          "helm_release_uncompressed.yaml",
+			version:       1,
+			status:        "deployed",
+			chart:         "nginx-0.1.0",
+			appVersion:    "1.19.0",
+			lastDeployed:  time.Date(2021, 3, 1, 10, 0, 0, 0, time.UTC),
+			manifestKinds: []string{"Deployment", "Service"},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			object := testutil.LoadUnstructuredFromFile(t, test.file)
+
+			release, err := decodeHelmRelease(object)
+			require.NoError(t, err)
+
+			assert.Equal(t, "web", release.Name)
+			assert.Equal(t, "default", release.Namespace)
+			assert.Equal(t, test.version, release.Version)
+			assert.Equal(t, test.status, release.Info.Status)
+			assert.Equal(t, test.chart, release.ChartName())
+			assert.Equal(t, test.appVersion, release.Chart.Metadata.AppVersion)
+			assert.Equal(t, test.config, release.Config)
+			assert.True(t, test.lastDeployed.Equal(release.Info.LastDeployed))
+
+			objects, err := helmManifestObjects(release.Manifest)
+			require.NoError(t, err)
+
+			var kinds []string
+			for _, object := range objects {
+				kinds = append(kinds, object.GetKind())
+				assert.Equal(t, "web-nginx", object.GetName())
+			}
+			assert.Equal(t, test.manifestKinds, kinds)
+		})
+	}
+}
+
+func Test_decodeHelmRelease_invalid(t *testing.T) {
+	object := testutil.LoadUnstructuredFromFile(t, "helm_release_v1.yaml")
+	require.NoError(t, unstructured.SetNestedField(object.Object, "bm90IGEgcmVsZWFzZQ==", "data", "release"))
+
+	_, err := decodeHelmRelease(object)
+	require.Error(t, err)
+
+	object.Object["type"] = "Opaque"
+	_, err = decodeHelmRelease(object)
+	require.Error(t, err)
+}
+
+func Test_listHelmReleases(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	v1 := testutil.LoadUnstructuredFromFile(t, "helm_release_v1.yaml")
+	v2 := testutil.LoadUnstructuredFromFile(t, "helm_release_v2.yaml")
+
+	corrupt := v1.DeepCopy()
+	corrupt.SetName("sh.helm.release.v1.corrupt.v1")
+	require.NoError(t, unstructured.SetNestedField(corrupt.Object, "bm90IGEgcmVsZWFzZQ==", "data", "release"))
+
+	objectStore := fake.NewMockStore(controller)
+	objectStore.EXPECT().
+		List(gomock.Any(), store.Key{
+			Namespace:  "default",
+			APIVersion: "v1",
+			Kind:       "Secret",
+			Selector:   &labels.Set{"owner": "helm"},
+		}).
+		Return(testutil.ToUnstructuredList(t, v2, corrupt, v1), false, nil)
+
+	releases, err := listHelmReleases(context.Background(), objectStore, "default")
+	require.NoError(t, err)
+	require.Len(t, releases, 1)
+
+	history := releases[0]
+	assert.Equal(t, "web", history.Name)
+	require.Len(t, history.Revisions, 2)
+	assert.Equal(t, 1, history.Revisions[0].Version)
+	assert.Equal(t, 2, history.Revisions[1].Version)
+	assert.Equal(t, 2, history.Latest().Version)
+	assert.Equal(t, "/applications/namespace/default/helm/web", history.Latest().Path("applications", "default"))
+}
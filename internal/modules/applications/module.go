@@ -16,6 +16,7 @@ import (
 	"github.com/vmware-tanzu/octant/internal/config"
 	"github.com/vmware-tanzu/octant/internal/describer"
 	"github.com/vmware-tanzu/octant/internal/generator"
+	"github.com/vmware-tanzu/octant/internal/log"
 	"github.com/vmware-tanzu/octant/internal/module"
 	"github.com/vmware-tanzu/octant/internal/octant"
 	"github.com/vmware-tanzu/octant/internal/util/path_util"
@@ -48,6 +49,12 @@ func New(ctx context.Context, options Options) *Module {
 		pm.Register(ctx, pf)
 	}
 
+	for _, d := range []describer.Describer{NewHelmDescriber(), NewHelmReleaseDescriber()} {
+		for _, pf := range d.PathFilters() {
+			pm.Register(ctx, pf)
+		}
+	}
+
 	return &Module{
 		Options:     options,
 		pathMatcher: pm,
@@ -107,6 +114,27 @@ func (m *Module) Navigation(ctx context.Context, namespace, root string) ([]navi
 		})
 	}
 
+	// Users who can't list secrets can still see the other applications.
+	releases, err := listHelmReleases(ctx, m.DashConfig.ObjectStore(), namespace)
+	if err != nil {
+		log.From(ctx).WithErr(err).Warnf("list helm releases for navigation")
+		return []navigation.Navigation{rootNav}, nil
+	}
+
+	helmNav := navigation.Navigation{
+		Title: "Helm Releases",
+		Path:  path.Join(rootPath, "helm"),
+	}
+
+	for _, history := range releases {
+		helmNav.Children = append(helmNav.Children, navigation.Navigation{
+			Title: history.Name,
+			Path:  path.Join(rootPath, "helm", history.Name),
+		})
+	}
+
+	rootNav.Children = append(rootNav.Children, helmNav)
+
 	return []navigation.Navigation{rootNav}, nil
 }
 
@@ -0,0 +1,48 @@
+/*
+ * Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package applications
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	configFake "github.com/vmware-tanzu/octant/internal/config/fake"
+	"github.com/vmware-tanzu/octant/pkg/navigation"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	"github.com/vmware-tanzu/octant/pkg/store/fake"
+)
+
+func TestModule_Navigation_withoutSecretAccess(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	objectStore := fake.NewMockStore(controller)
+	objectStore.EXPECT().
+		List(gomock.Any(), store.Key{Namespace: "default", APIVersion: "v1", Kind: "Pod"}).
+		Return(&unstructured.UnstructuredList{}, false, nil)
+	objectStore.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		Return(nil, false, fmt.Errorf("check access: forbidden"))
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().ObjectStore().Return(objectStore).AnyTimes()
+
+	m := &Module{Options: Options{DashConfig: dashConfig}}
+
+	got, err := m.Navigation(context.Background(), "default", "")
+	require.NoError(t, err)
+
+	expected := []navigation.Navigation{
+		{Title: "Applications", Path: "applications/namespace/default"},
+	}
+	assert.Equal(t, expected, got)
+}
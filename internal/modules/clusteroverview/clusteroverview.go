@@ -49,8 +49,10 @@ var _ module.Module = (*ClusterOverview)(nil)
 
 func New(ctx context.Context, options Options) (*ClusterOverview, error) {
 	pathMatcher := describer.NewPathMatcher("cluster-overview")
-	for _, pf := range rootDescriber.PathFilters() {
-		pathMatcher.Register(ctx, pf)
+	for _, d := range []describer.Describer{rootDescriber, rbacPermissionsDescriber, rbacWhoCanDescriber} {
+		for _, pf := range d.PathFilters() {
+			pathMatcher.Register(ctx, pf)
+		}
 	}
 
 	objectPathConfig := octant.ObjectPathConfig{
@@ -263,6 +265,8 @@ func rbacEntries(ctx context.Context, prefix, namespace string, objectStore stor
 		loading.IsObjectLoading(ctx, namespace, store.KeyFromGroupVersionKind(gvk.ClusterRole), objectStore))
 	neh.Add("Cluster Role Bindings", "cluster-role-bindings",
 		loading.IsObjectLoading(ctx, namespace, store.KeyFromGroupVersionKind(gvk.ClusterRoleBinding), objectStore))
+	neh.Add("Effective Permissions", "permissions", false)
+	neh.Add("Who Can", "who-can", false)
 
 	children, err := neh.Generate(prefix, namespace, "")
 	if err != nil {
@@ -139,6 +139,10 @@ var (
 
 	portForwardDescriber = NewPortForwardListDescriber()
 
+	// RBAC analysis views aren't part of the RBAC section, which lists objects.
+	rbacPermissionsDescriber = NewRBACPermissionsDescriber()
+	rbacWhoCanDescriber      = NewRBACWhoCanDescriber()
+
 	apiServerDescriber = describer.NewSection(
 		"/api-server",
 		"API Server",
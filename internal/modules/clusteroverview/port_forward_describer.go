@@ -7,11 +7,14 @@ package clusteroverview
 
 import (
 	"context"
+	"fmt"
+	"strings"
 
 	corev1 "k8s.io/api/core/v1"
 
 	"github.com/vmware-tanzu/octant/internal/describer"
 	"github.com/vmware-tanzu/octant/internal/portforward"
+	"github.com/vmware-tanzu/octant/pkg/action"
 	"github.com/vmware-tanzu/octant/pkg/view/component"
 )
 
@@ -36,6 +39,8 @@ func (d *PortForwardListDescriber) Describe(ctx context.Context, namespace strin
 	tbl := component.NewTable("Port Forwards", "There are no port forwards!", tblCols)
 	list.Add(tbl)
 
+	saved := portForwarder.Saved()
+
 	for _, pf := range portForwarder.List(ctx) {
 		t := &pf.Target
 		apiVersion, kind := t.GVK.ToAPIVersionAndKind()
@@ -50,9 +55,14 @@ func (d *PortForwardListDescriber) Describe(ctx context.Context, namespace strin
 			"Ports":     component.NewPorts(describePortForwardPorts(pf)),
 			"Age":       component.NewTimestamp(pf.CreatedAt),
 		}
+		if pf.Target.GVK.Kind == "Service" && len(pf.Ports) > 0 && !isSaved(saved, pf) {
+			pfRow.AddAction(savePortForwardAction(pf))
+		}
 		tbl.Add(pfRow)
 	}
 
+	list.Add(describeSavedPortForwards(namespace, saved))
+
 	return component.ContentResponse{
 		Components: []component.Component{list},
 	}, nil
@@ -82,3 +92,125 @@ func describePortForwardPorts(pf portforward.State) []component.Port {
 	}
 	return list
 }
+
+// describeSavedPortForwards describes saved port forwards and their health.
+func describeSavedPortForwards(namespace string, saved []portforward.SavedState) *component.Table {
+	cols := component.NewTableCols("Target", "Namespace", "Context", "Ports", "Pod", "Health", "Reconnects", "Last Error")
+	tbl := component.NewTable("Saved Port Forwards", "There are no saved port forwards!", cols)
+	tbl.AddButton("Save Port Forward", nil, component.WithModal(savePortForwardModal(namespace)))
+
+	for _, state := range saved {
+		definition := state.Definition
+
+		var ports []string
+		if len(state.Ports) > 0 {
+			for _, p := range state.Ports {
+				ports = append(ports, fmt.Sprintf("%d -> %d", p.Local, p.Remote))
+			}
+		} else {
+			for _, p := range definition.Ports {
+				ports = append(ports, fmt.Sprintf("%d", p.Remote))
+			}
+		}
+
+		row := component.TableRow{
+			"Target":     component.NewText(definition.String()),
+			"Namespace":  component.NewText(definition.Namespace),
+			"Context":    component.NewText(definition.Context),
+			"Ports":      component.NewText(strings.Join(ports, ", ")),
+			"Pod":        component.NewText(state.Pod),
+			"Health":     component.NewText(string(state.Health)),
+			"Reconnects": component.NewText(fmt.Sprintf("%d", state.Reconnects)),
+			"Last Error": component.NewText(state.LastError),
+		}
+		row.AddAction(component.GridAction{
+			Name:       "Remove",
+			ActionPath: "overview/removeSavedPortForward",
+			Payload:    action.Payload{"id": definition.ID},
+			Confirmation: &component.Confirmation{
+				Title: "Remove Saved Port Forward",
+				Body:  fmt.Sprintf("Stop and remove the saved port forward to %s?", definition.String()),
+			},
+			Type: component.GridActionDanger,
+		})
+		tbl.Add(row)
+	}
+
+	return tbl
+}
+
+// isSaved returns true if a port forward is to a saved port forward's target.
+func isSaved(saved []portforward.SavedState, pf portforward.State) bool {
+	for _, state := range saved {
+		definition := state.Definition
+		if definition.Namespace == pf.Target.Namespace &&
+			definition.Kind == pf.Target.GVK.Kind &&
+			definition.Name == pf.Target.Name {
+			return true
+		}
+	}
+	return false
+}
+
+// savePortForwardAction saves a port forward, so it is restored after its pod is replaced.
+// The port forward is replaced by the saved one, which keeps its local ports.
+func savePortForwardAction(pf portforward.State) component.GridAction {
+	apiVersion, kind := pf.Target.GVK.ToAPIVersionAndKind()
+
+	var ports []string
+	for _, p := range pf.Ports {
+		ports = append(ports, fmt.Sprintf("%d:%d", p.Local, p.Remote))
+	}
+
+	return component.GridAction{
+		Name:       "Save",
+		ActionPath: "overview/savePortForward",
+		Payload: action.Payload{
+			"forwardID":  pf.ID,
+			"namespace":  pf.Target.Namespace,
+			"apiVersion": apiVersion,
+			"kind":       kind,
+			"name":       pf.Target.Name,
+			"ports":      strings.Join(ports, ","),
+		},
+		Type: component.GridActionPrimary,
+	}
+}
+
+// savePortForwardModal is a form for saving a port forward to a service, a deployment
+// or the pods matching a selector.
+func savePortForwardModal(namespace string) *component.Modal {
+	kind := component.NewFormFieldRadio("Target", "kind", []component.InputChoice{
+		{Label: "Service", Value: "Service"},
+		{Label: "Deployment", Value: "Deployment"},
+		{Label: "Pods matching a selector", Value: "Pod"},
+	})
+	kind.AddValidator("Target is required", []string{"required"})
+
+	namespaceField := component.NewFormFieldText("Namespace", "namespace", namespace)
+	namespaceField.AddValidator("", "Namespace is required", []string{"required"})
+
+	ports := component.NewFormFieldText("Ports", "ports", "")
+	ports.AddValidator("8080:80, 9090", "Ports are required", []string{"required"})
+
+	selector := component.NewFormFieldText("Selector", "selector", "")
+	selector.AddValidator("app=web (pods only)", "", nil)
+
+	form := component.Form{
+		Fields: []component.FormField{
+			kind,
+			namespaceField,
+			component.NewFormFieldText("Name", "name", ""),
+			selector,
+			ports,
+		},
+		Action: "overview/savePortForward",
+	}
+
+	modal := component.NewModal(component.TitleFromString("Save Port Forward"))
+	modal.SetBody(component.NewText("Saved port forwards are restored when their pod is replaced, " +
+		"when Octant starts and when the context changes. Ports are local:remote or remote."))
+	modal.AddForm(form)
+
+	return modal
+}
@@ -0,0 +1,371 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clusteroverview
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	"github.com/vmware-tanzu/octant/internal/describer"
+	"github.com/vmware-tanzu/octant/internal/link"
+	"github.com/vmware-tanzu/octant/internal/rbac"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+const (
+	rbacPermissionsPath = "/rbac/permissions"
+	rbacWhoCanPath      = "/rbac/who-can"
+)
+
+// subjectPathKinds maps subject kinds to their path segment in the effective
+// permissions view.
+var subjectPathKinds = map[string]string{
+	rbacv1.UserKind:           "users",
+	rbacv1.GroupKind:          "groups",
+	rbacv1.ServiceAccountKind: "service-accounts",
+}
+
+// whoCanChecks are checks security reviews commonly start with.
+var whoCanChecks = []struct {
+	verb     string
+	resource string
+}{
+	{verb: "get", resource: "secrets"},
+	{verb: "create", resource: "pods/exec"},
+	{verb: "create", resource: "pods"},
+	{verb: "impersonate", resource: "users"},
+	{verb: "escalate", resource: "clusterroles.rbac.authorization.k8s.io"},
+	{verb: "bind", resource: "clusterroles.rbac.authorization.k8s.io"},
+	{verb: "create", resource: "clusterrolebindings.rbac.authorization.k8s.io"},
+}
+
+// RBACPermissionsDescriber describes the effective permissions of a user, group
+// or service account. Paths are:
+//
+//	/rbac/permissions                                     subjects named in bindings
+//	/rbac/permissions/users/<name>                        permissions of a user
+//	/rbac/permissions/groups/<name>                       permissions of a group
+//	/rbac/permissions/service-accounts/<namespace>/<name> permissions of a service account
+type RBACPermissionsDescriber struct {
+}
+
+var _ describer.Describer = (*RBACPermissionsDescriber)(nil)
+
+// NewRBACPermissionsDescriber creates an instance of RBACPermissionsDescriber.
+func NewRBACPermissionsDescriber() *RBACPermissionsDescriber {
+	return &RBACPermissionsDescriber{}
+}
+
+// Describe describes the effective permissions of a subject, or lists subjects if
+// the path doesn't name one.
+func (d *RBACPermissionsDescriber) Describe(ctx context.Context, _ string, options describer.Options) (component.ContentResponse, error) {
+	analyzer, err := rbac.Load(ctx, options.Dash.ObjectStore())
+	if err != nil {
+		return component.EmptyContentResponse, err
+	}
+
+	name := options.Fields["name"]
+	if name == "" {
+		return component.ContentResponse{
+			Title:      component.TitleFromString("Effective Permissions"),
+			Components: []component.Component{describeRBACSubjects(analyzer.Subjects())},
+		}, nil
+	}
+
+	subject := rbac.Subject{Name: name, Namespace: options.Fields["subjectNamespace"]}
+	for kind, segment := range subjectPathKinds {
+		if segment == options.Fields["kind"] {
+			subject.Kind = kind
+		}
+	}
+
+	matrix := rbac.NewMatrix(analyzer.Grants(subject))
+
+	permissions, err := describePermissionMatrix(matrix, options.Link)
+	if err != nil {
+		return component.EmptyContentResponse, err
+	}
+
+	bindings, err := describeGrantingBindings(matrix, options.Link)
+	if err != nil {
+		return component.EmptyContentResponse, err
+	}
+
+	return component.ContentResponse{
+		Title:      component.TitleFromString(fmt.Sprintf("Effective Permissions: %s", subject)),
+		Components: []component.Component{permissions, bindings},
+	}, nil
+}
+
+// PathFilters returns path filters for the effective permissions views.
+func (d *RBACPermissionsDescriber) PathFilters() []describer.PathFilter {
+	return []describer.PathFilter{
+		*describer.NewPathFilter(rbacPermissionsPath, d),
+		*describer.NewPathFilter(rbacPermissionsPath+"/(?P<kind>users|groups)/(?P<name>[^/]+)", d),
+		*describer.NewPathFilter(rbacPermissionsPath+"/(?P<kind>service-accounts)/(?P<subjectNamespace>[^/]+)/(?P<name>[^/]+)", d),
+	}
+}
+
+// Reset does nothing.
+func (d *RBACPermissionsDescriber) Reset(ctx context.Context) error {
+	return nil
+}
+
+// RBACWhoCanDescriber describes the subjects which can perform a verb on a
+// resource. Resources are in the format resource.group, e.g. deployments.apps.
+// Paths are:
+//
+//	/rbac/who-can                                    common checks
+//	/rbac/who-can/<verb>/<resource>                  subjects allowed in the whole cluster
+//	/namespace/<ns>/rbac/who-can/<verb>/<resource>   subjects allowed in a namespace
+type RBACWhoCanDescriber struct {
+}
+
+var _ describer.Describer = (*RBACWhoCanDescriber)(nil)
+
+// NewRBACWhoCanDescriber creates an instance of RBACWhoCanDescriber.
+func NewRBACWhoCanDescriber() *RBACWhoCanDescriber {
+	return &RBACWhoCanDescriber{}
+}
+
+// Describe describes the subjects which can perform a verb on a resource, or lists
+// common checks if the path doesn't name a verb and resource.
+func (d *RBACWhoCanDescriber) Describe(ctx context.Context, _ string, options describer.Options) (component.ContentResponse, error) {
+	verb := options.Fields["verb"]
+	if verb == "" {
+		return component.ContentResponse{
+			Title:      component.TitleFromString("Who Can"),
+			Components: []component.Component{describeWhoCanChecks()},
+		}, nil
+	}
+
+	resource, err := rbac.ParseResource(options.Fields["resource"])
+	if err != nil {
+		return component.EmptyContentResponse, err
+	}
+	namespace := options.Fields["namespace"]
+
+	analyzer, err := rbac.Load(ctx, options.Dash.ObjectStore())
+	if err != nil {
+		return component.EmptyContentResponse, err
+	}
+
+	table, err := describeWhoCan(analyzer.WhoCan(verb, resource, namespace), options.Link)
+	if err != nil {
+		return component.EmptyContentResponse, err
+	}
+
+	title := fmt.Sprintf("Who can %s %s", verb, options.Fields["resource"])
+	if namespace != "" {
+		title += fmt.Sprintf(" in %s", namespace)
+	} else {
+		title += " in all namespaces"
+	}
+
+	return component.ContentResponse{
+		Title:      component.TitleFromString(title),
+		Components: []component.Component{table},
+	}, nil
+}
+
+// PathFilters returns path filters for the who can views.
+func (d *RBACWhoCanDescriber) PathFilters() []describer.PathFilter {
+	return []describer.PathFilter{
+		*describer.NewPathFilter(rbacWhoCanPath, d),
+		// Resources can be subresources, e.g. pods/exec.
+		*describer.NewPathFilter(rbacWhoCanPath+"/(?P<verb>[^/]+)/(?P<resource>[^/]+(/[^/]+)?)", d),
+	}
+}
+
+// Reset does nothing.
+func (d *RBACWhoCanDescriber) Reset(ctx context.Context) error {
+	return nil
+}
+
+// subjectPermissionsPath returns the path of the effective permissions of a subject.
+func subjectPermissionsPath(subject rbac.Subject) (string, bool) {
+	segment, ok := subjectPathKinds[subject.Kind]
+	if !ok {
+		return "", false
+	}
+
+	if subject.Kind == rbacv1.ServiceAccountKind {
+		return path.Join("/cluster-overview", rbacPermissionsPath, segment, subject.Namespace, subject.Name), true
+	}
+	return path.Join("/cluster-overview", rbacPermissionsPath, segment, subject.Name), true
+}
+
+func subjectLink(subject rbac.Subject) component.Component {
+	text := subject.Name
+	if subject.Kind == rbacv1.ServiceAccountKind {
+		text = fmt.Sprintf("%s/%s", subject.Namespace, subject.Name)
+	}
+
+	if p, ok := subjectPermissionsPath(subject); ok {
+		return component.NewLink("", text, p)
+	}
+	return component.NewText(text)
+}
+
+func objectRefLink(ref rbac.ObjectRef, text string, l link.Interface) (component.Component, error) {
+	return l.ForGVK(ref.Namespace, ref.APIVersion(), ref.Kind, ref.Name, text)
+}
+
+func describeRBACSubjects(subjects []rbac.Subject) *component.Table {
+	cols := component.NewTableCols("Subject", "Kind")
+	table := component.NewTable("Subjects", "There are no subjects in role bindings or cluster role bindings", cols)
+
+	for _, subject := range subjects {
+		table.Add(component.TableRow{
+			"Subject": subjectLink(subject),
+			"Kind":    component.NewText(subject.Kind),
+		})
+	}
+
+	return table
+}
+
+// describePermissionMatrix describes a permission matrix. Each allowed verb links
+// to a binding which grants it.
+func describePermissionMatrix(matrix rbac.Matrix, l link.Interface) (*component.Table, error) {
+	cols := component.NewTableCols(append([]string{"Namespace", "Resource", "Resource Names"}, matrix.Verbs...)...)
+	table := component.NewTable("Permissions", "The subject has no permissions", cols)
+
+	for _, row := range matrix.Rows {
+		namespace := row.Namespace
+		if namespace == "" {
+			namespace = "*"
+		}
+
+		tableRow := component.TableRow{
+			"Namespace":      component.NewText(namespace),
+			"Resource":       component.NewText(row.Resource.String()),
+			"Resource Names": component.NewText(strings.Join(row.ResourceNames, ", ")),
+		}
+
+		for _, verb := range matrix.Verbs {
+			grants := row.Grants[verb]
+			if len(grants) == 0 {
+				tableRow[verb] = component.NewText("")
+				continue
+			}
+
+			text := grants[0].Binding.Name
+			if len(grants) > 1 {
+				text += fmt.Sprintf(" +%d", len(grants)-1)
+			}
+
+			cell, err := objectRefLink(grants[0].Binding, text, l)
+			if err != nil {
+				return nil, err
+			}
+			tableRow[verb] = cell
+		}
+
+		table.Add(tableRow)
+	}
+
+	return table, nil
+}
+
+// describeGrantingBindings describes the bindings which grant the permissions in
+// a matrix.
+func describeGrantingBindings(matrix rbac.Matrix, l link.Interface) (*component.Table, error) {
+	cols := component.NewTableCols("Binding", "Role", "Subject", "Namespace")
+	table := component.NewTable("Granted By", "The subject isn't in any bindings", cols)
+
+	seen := map[rbac.ObjectRef]bool{}
+	for _, row := range matrix.Rows {
+		for _, verb := range matrix.Verbs {
+			for _, grant := range row.Grants[verb] {
+				if seen[grant.Binding] {
+					continue
+				}
+				seen[grant.Binding] = true
+
+				tableRow, err := grantRow(grant, l)
+				if err != nil {
+					return nil, err
+				}
+				table.Add(tableRow)
+			}
+		}
+	}
+
+	return table, nil
+}
+
+func describeWhoCan(grants []rbac.Grant, l link.Interface) (*component.Table, error) {
+	cols := component.NewTableCols("Subject", "Kind", "Binding", "Role", "Namespace", "Resource Names")
+	table := component.NewTable("Subjects", "No subjects are allowed", cols)
+
+	for _, grant := range grants {
+		row, err := grantRow(grant, l)
+		if err != nil {
+			return nil, err
+		}
+		row["Kind"] = component.NewText(grant.Subject.Kind)
+		row["Resource Names"] = component.NewText(strings.Join(grant.Rule.ResourceNames, ", "))
+		table.Add(row)
+	}
+
+	return table, nil
+}
+
+func grantRow(grant rbac.Grant, l link.Interface) (component.TableRow, error) {
+	bindingLink, err := objectRefLink(grant.Binding, grant.Binding.Name, l)
+	if err != nil {
+		return nil, err
+	}
+
+	roleLink, err := objectRefLink(grant.Role, fmt.Sprintf("%s %s", grant.Role.Kind, grant.Role.Name), l)
+	if err != nil {
+		return nil, err
+	}
+
+	namespace := grant.Namespace
+	if namespace == "" {
+		namespace = "*"
+	}
+
+	return component.TableRow{
+		"Subject":   subjectLink(grant.Subject),
+		"Binding":   bindingLink,
+		"Role":      roleLink,
+		"Namespace": component.NewText(namespace),
+	}, nil
+}
+
+func describeWhoCanChecks() component.Component {
+	cols := component.NewTableCols("Check")
+	table := component.NewTable("Common Checks", "", cols)
+
+	for _, check := range whoCanChecks {
+		text := fmt.Sprintf("Who can %s %s?", check.verb, check.resource)
+		p := path.Join("/cluster-overview", rbacWhoCanPath, check.verb, check.resource)
+		table.Add(component.TableRow{
+			"Check": component.NewLink("", text, p),
+		})
+	}
+
+	layout := component.NewFlexLayout("")
+	layout.AddSections(component.FlexLayoutSection{
+		{
+			Width: component.WidthFull,
+			View: component.NewMarkdownText(
+				"Find the subjects allowed to perform a verb on a resource at `rbac/who-can/<verb>/<resource>`, " +
+					"or in a namespace at `namespace/<namespace>/rbac/who-can/<verb>/<resource>`. Resources are in the format `resource.group`, " +
+					"e.g. `deployments.apps`. A resource without a group matches any group."),
+		},
+		{Width: component.WidthFull, View: table},
+	})
+
+	return layout
+}
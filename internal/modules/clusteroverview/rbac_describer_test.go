@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package clusteroverview
+
+import (
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+
+	linkFake "github.com/vmware-tanzu/octant/internal/link/fake"
+	"github.com/vmware-tanzu/octant/internal/rbac"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+func TestRBACDescribers_PathFilters(t *testing.T) {
+	cases := []struct {
+		contentPath string
+		expected    map[string]string
+	}{
+		{
+			contentPath: "/rbac/permissions/users/system:admin",
+			expected:    map[string]string{"namespace": "", "kind": "users", "name": "system:admin"},
+		},
+		{
+			contentPath: "/rbac/permissions/service-accounts/kube-system/default",
+			expected:    map[string]string{"namespace": "", "kind": "service-accounts", "subjectNamespace": "kube-system", "name": "default"},
+		},
+		{
+			contentPath: "/rbac/who-can/create/pods/exec",
+			expected:    map[string]string{"namespace": "", "verb": "create", "resource": "pods/exec"},
+		},
+		{
+			contentPath: "/namespace/default/rbac/who-can/get/secrets",
+			expected:    map[string]string{"namespace": "default", "verb": "get", "resource": "secrets"},
+		},
+	}
+
+	pfs := append(NewRBACPermissionsDescriber().PathFilters(), NewRBACWhoCanDescriber().PathFilters()...)
+
+	for _, tc := range cases {
+		t.Run(tc.contentPath, func(t *testing.T) {
+			var got map[string]string
+			for _, pf := range pfs {
+				if pf.Match(tc.contentPath) {
+					got = pf.Fields(tc.contentPath)
+					break
+				}
+			}
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func Test_describeWhoCan(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	l := linkFake.NewMockInterface(controller)
+	l.EXPECT().
+		ForGVK("default", "rbac.authorization.k8s.io/v1", "RoleBinding", "read-secrets", "read-secrets").
+		Return(component.NewLink("", "read-secrets", "/binding"), nil)
+	l.EXPECT().
+		ForGVK("", "rbac.authorization.k8s.io/v1", "ClusterRole", "secret-reader", "ClusterRole secret-reader").
+		Return(component.NewLink("", "ClusterRole secret-reader", "/role"), nil)
+
+	grants := []rbac.Grant{
+		{
+			Subject:   rbac.Subject{Kind: rbacv1.ServiceAccountKind, Namespace: "default", Name: "builder"},
+			Binding:   rbac.ObjectRef{Kind: "RoleBinding", Namespace: "default", Name: "read-secrets"},
+			Role:      rbac.ObjectRef{Kind: "ClusterRole", Name: "secret-reader"},
+			Namespace: "default",
+			Rule:      rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, ResourceNames: []string{"token"}, Verbs: []string{"get"}},
+		},
+	}
+
+	got, err := describeWhoCan(grants, l)
+	require.NoError(t, err)
+
+	rows := got.Rows()
+	require.Len(t, rows, 1)
+	assert.Equal(t, component.TableRow{
+		"Subject":        component.NewLink("", "default/builder", "/cluster-overview/rbac/permissions/service-accounts/default/builder"),
+		"Kind":           component.NewText("ServiceAccount"),
+		"Binding":        component.NewLink("", "read-secrets", "/binding"),
+		"Role":           component.NewLink("", "ClusterRole secret-reader", "/role"),
+		"Namespace":      component.NewText("default"),
+		"Resource Names": component.NewText("token"),
+	}, rows[0])
+}
@@ -0,0 +1,94 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package events
+
+import (
+	"sort"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+)
+
+// Aggregate is a group of events with the same type and reason for the same object.
+type Aggregate struct {
+	InvolvedObject corev1.ObjectReference
+	Type           string
+	Reason         string
+	// Message is the message of the most recent event.
+	Message   string
+	Count     int32
+	FirstSeen time.Time
+	LastSeen  time.Time
+}
+
+type aggregateKey struct {
+	namespace  string
+	apiVersion string
+	kind       string
+	name       string
+	eventType  string
+	reason     string
+}
+
+// AggregateEvents groups repeated events by reason and involved object. Aggregates
+// are sorted by the time they were last seen, most recent first.
+func AggregateEvents(events []corev1.Event) []Aggregate {
+	indexes := make(map[aggregateKey]int)
+	var aggregates []Aggregate
+
+	for i := range events {
+		event := &events[i]
+
+		key := aggregateKey{
+			namespace:  event.InvolvedObject.Namespace,
+			apiVersion: event.InvolvedObject.APIVersion,
+			kind:       event.InvolvedObject.Kind,
+			name:       event.InvolvedObject.Name,
+			eventType:  event.Type,
+			reason:     event.Reason,
+		}
+
+		first, last := firstSeen(event), lastSeen(event)
+
+		index, ok := indexes[key]
+		if !ok {
+			indexes[key] = len(aggregates)
+			aggregates = append(aggregates, Aggregate{
+				InvolvedObject: event.InvolvedObject,
+				Type:           event.Type,
+				Reason:         event.Reason,
+				Message:        event.Message,
+				Count:          count(event),
+				FirstSeen:      first,
+				LastSeen:       last,
+			})
+			continue
+		}
+
+		aggregate := &aggregates[index]
+		aggregate.Count += count(event)
+		if first.Before(aggregate.FirstSeen) {
+			aggregate.FirstSeen = first
+		}
+		if last.After(aggregate.LastSeen) {
+			aggregate.LastSeen = last
+			aggregate.Message = event.Message
+		}
+	}
+
+	sort.SliceStable(aggregates, func(i, j int) bool {
+		return aggregates[i].LastSeen.After(aggregates[j].LastSeen)
+	})
+
+	return aggregates
+}
+
+// sortByLastSeen sorts events by the time they were last seen, most recent first.
+func sortByLastSeen(events []corev1.Event) {
+	sort.SliceStable(events, func(i, j int) bool {
+		return lastSeen(&events[i]).After(lastSeen(&events[j]))
+	})
+}
@@ -0,0 +1,62 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+func newEvent(namespace, kind, name, eventType, reason, message string, count int32, first, last time.Time) corev1.Event {
+	return corev1.Event{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Event"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: namespace, Name: name + "." + reason},
+		InvolvedObject: corev1.ObjectReference{
+			APIVersion: "v1",
+			Kind:       kind,
+			Namespace:  namespace,
+			Name:       name,
+		},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        message,
+		Count:          count,
+		FirstTimestamp: metav1.NewTime(first),
+		LastTimestamp:  metav1.NewTime(last),
+	}
+}
+
+func TestAggregateEvents(t *testing.T) {
+	base := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	minute := func(n int) time.Time { return base.Add(time.Duration(n) * time.Minute) }
+
+	events := []corev1.Event{
+		newEvent("default", "Pod", "web", corev1.EventTypeWarning, "BackOff", "back-off 10s", 2, minute(0), minute(2)),
+		newEvent("default", "Pod", "db", corev1.EventTypeNormal, "Pulled", "pulled image", 1, minute(1), minute(1)),
+		newEvent("default", "Pod", "web", corev1.EventTypeWarning, "BackOff", "back-off 40s", 3, minute(3), minute(5)),
+		newEvent("other", "Pod", "web", corev1.EventTypeWarning, "BackOff", "back-off 10s", 1, minute(4), minute(4)),
+	}
+
+	got := AggregateEvents(events)
+	require.Len(t, got, 3)
+
+	assert.Equal(t, Aggregate{
+		InvolvedObject: events[0].InvolvedObject,
+		Type:           corev1.EventTypeWarning,
+		Reason:         "BackOff",
+		Message:        "back-off 40s",
+		Count:          5,
+		FirstSeen:      minute(0),
+		LastSeen:       minute(5),
+	}, got[0])
+	assert.Equal(t, "other", got[1].InvolvedObject.Namespace)
+	assert.Equal(t, "db", got[2].InvolvedObject.Name)
+}
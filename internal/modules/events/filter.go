@@ -0,0 +1,168 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package events
+
+import (
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Filter keys. Filters are set with the dashboard's filter bar, e.g. type:Warning
+// or since:15m. A filter can have several comma separated values.
+const (
+	filterType   = "type"
+	filterReason = "reason"
+	filterKind   = "kind"
+	filterSince  = "since"
+)
+
+// Filter selects events by type, reason, involved object kind and time window.
+// Empty fields match all events.
+type Filter struct {
+	Types   []string
+	Reasons []string
+	Kinds   []string
+	// Since is the time window. Events last seen before it are excluded.
+	Since time.Duration
+}
+
+// ParseFilter creates a filter from the filter bar's key:value filters. Keys which
+// aren't event filters are ignored.
+func ParseFilter(set *labels.Set) (Filter, error) {
+	var filter Filter
+	if set == nil {
+		return filter, nil
+	}
+
+	filter.Types = filterValues(set.Get(filterType))
+	filter.Reasons = filterValues(set.Get(filterReason))
+	filter.Kinds = filterValues(set.Get(filterKind))
+
+	if since := set.Get(filterSince); since != "" {
+		d, err := time.ParseDuration(since)
+		if err != nil {
+			return Filter{}, fmt.Errorf("invalid %s filter %q: %w", filterSince, since, err)
+		}
+		if d <= 0 {
+			return Filter{}, fmt.Errorf("invalid %s filter %q: must be positive", filterSince, since)
+		}
+		filter.Since = d
+	}
+
+	return filter, nil
+}
+
+// Match returns true if an event matches the filter.
+func (f Filter) Match(event *corev1.Event, now time.Time) bool {
+	if event == nil {
+		return false
+	}
+
+	if !matchAny(f.Types, event.Type) ||
+		!matchAny(f.Reasons, event.Reason) ||
+		!matchAny(f.Kinds, event.InvolvedObject.Kind) {
+		return false
+	}
+
+	if f.Since > 0 && lastSeen(event).Before(now.Add(-f.Since)) {
+		return false
+	}
+
+	return true
+}
+
+// String describes the filter.
+func (f Filter) String() string {
+	var parts []string
+	if len(f.Types) > 0 {
+		parts = append(parts, fmt.Sprintf("type %s", strings.Join(f.Types, " or ")))
+	}
+	if len(f.Reasons) > 0 {
+		parts = append(parts, fmt.Sprintf("reason %s", strings.Join(f.Reasons, " or ")))
+	}
+	if len(f.Kinds) > 0 {
+		parts = append(parts, fmt.Sprintf("kind %s", strings.Join(f.Kinds, " or ")))
+	}
+	if f.Since > 0 {
+		parts = append(parts, fmt.Sprintf("last seen in the last %s", f.Since))
+	}
+
+	if len(parts) == 0 {
+		return "all events"
+	}
+
+	return "events with " + strings.Join(parts, ", ")
+}
+
+func filterValues(s string) []string {
+	var values []string
+	for _, value := range strings.Split(s, ",") {
+		if value = strings.TrimSpace(value); value != "" {
+			values = append(values, value)
+		}
+	}
+	sort.Strings(values)
+	return values
+}
+
+func matchAny(values []string, s string) bool {
+	if len(values) == 0 {
+		return true
+	}
+
+	for _, value := range values {
+		if strings.EqualFold(value, s) {
+			return true
+		}
+	}
+	return false
+}
+
+// lastSeen returns the last time an event occurred. Events created with the events.k8s.io
+// API only set the event time, and some events only have a creation timestamp.
+func lastSeen(event *corev1.Event) time.Time {
+	switch {
+	case event.Series != nil && !event.Series.LastObservedTime.IsZero():
+		return event.Series.LastObservedTime.Time
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// firstSeen returns the first time an event occurred.
+func firstSeen(event *corev1.Event) time.Time {
+	switch {
+	case !event.FirstTimestamp.IsZero():
+		return event.FirstTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return lastSeen(event)
+	}
+}
+
+// count returns the number of times an event occurred.
+func count(event *corev1.Event) int32 {
+	switch {
+	case event.Series != nil && event.Series.Count > 0:
+		return event.Series.Count
+	case event.Count > 0:
+		return event.Count
+	default:
+		return 1
+	}
+}
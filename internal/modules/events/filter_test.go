@@ -0,0 +1,119 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package events
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func TestParseFilter(t *testing.T) {
+	cases := []struct {
+		name     string
+		set      *labels.Set
+		expected Filter
+		isErr    bool
+	}{
+		{
+			name: "nil",
+		},
+		{
+			name: "all keys",
+			set: &labels.Set{
+				"type":   "Warning",
+				"reason": "Failed,BackOff",
+				"kind":   "Pod",
+				"since":  "15m",
+				"app":    "web",
+			},
+			expected: Filter{
+				Types:   []string{"Warning"},
+				Reasons: []string{"BackOff", "Failed"},
+				Kinds:   []string{"Pod"},
+				Since:   15 * time.Minute,
+			},
+		},
+		{
+			name:  "invalid since",
+			set:   &labels.Set{"since": "soon"},
+			isErr: true,
+		},
+		{
+			name:  "negative since",
+			set:   &labels.Set{"since": "-5m"},
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := ParseFilter(tc.set)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestFilter_Match(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	event := &corev1.Event{
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "web"},
+		Type:           corev1.EventTypeWarning,
+		Reason:         "BackOff",
+		LastTimestamp:  metav1.NewTime(now.Add(-10 * time.Minute)),
+	}
+
+	cases := []struct {
+		name     string
+		filter   Filter
+		expected bool
+	}{
+		{name: "empty", expected: true},
+		{name: "type", filter: Filter{Types: []string{"warning"}}, expected: true},
+		{name: "other type", filter: Filter{Types: []string{"Normal"}}, expected: false},
+		{name: "one of reasons", filter: Filter{Reasons: []string{"Failed", "BackOff"}}, expected: true},
+		{name: "other kind", filter: Filter{Kinds: []string{"Node"}}, expected: false},
+		{name: "in window", filter: Filter{Since: 15 * time.Minute}, expected: true},
+		{name: "outside window", filter: Filter{Since: 5 * time.Minute}, expected: false},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, tc.filter.Match(event, now))
+		})
+	}
+}
+
+func Test_lastSeen(t *testing.T) {
+	first := time.Date(2021, 3, 1, 11, 0, 0, 0, time.UTC)
+	last := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	assert.Equal(t, last, lastSeen(&corev1.Event{
+		FirstTimestamp: metav1.NewTime(first),
+		LastTimestamp:  metav1.NewTime(last),
+	}))
+	assert.Equal(t, last, lastSeen(&corev1.Event{
+		EventTime: metav1.NewMicroTime(first),
+		Series:    &corev1.EventSeries{Count: 3, LastObservedTime: metav1.NewMicroTime(last)},
+	}))
+	assert.Equal(t, first, lastSeen(&corev1.Event{
+		EventTime: metav1.NewMicroTime(first),
+	}))
+	assert.Equal(t, first, lastSeen(&corev1.Event{
+		ObjectMeta: metav1.ObjectMeta{CreationTimestamp: metav1.NewTime(first)},
+	}))
+}
@@ -0,0 +1,381 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package events is a module for exploring events across namespaces. Repeated events
+// are aggregated by reason and involved object, and recent warnings are shown on a
+// timeline. The live tail lists individual events as they arrive.
+package events
+
+import (
+	"context"
+	"fmt"
+	"path"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/vmware-tanzu/octant/internal/api"
+	"github.com/vmware-tanzu/octant/internal/config"
+	"github.com/vmware-tanzu/octant/internal/module"
+	"github.com/vmware-tanzu/octant/internal/octant"
+	"github.com/vmware-tanzu/octant/internal/util/kubernetes"
+	"github.com/vmware-tanzu/octant/internal/util/path_util"
+	"github.com/vmware-tanzu/octant/pkg/icon"
+	"github.com/vmware-tanzu/octant/pkg/navigation"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+const (
+	// tailPath is the path of the live tail.
+	tailPath = "tail"
+
+	// timelineSize is the number of warnings shown on the timeline.
+	timelineSize = 20
+
+	// tailSize is the number of events shown by the live tail.
+	tailSize = 100
+)
+
+// Options for configuring Module.
+type Options struct {
+	DashConfig config.Dash
+}
+
+// Module contains the implementation for the events module.
+type Module struct {
+	Options
+
+	now func() time.Time
+}
+
+var _ module.Module = (*Module)(nil)
+
+// New creates an instance of Module.
+func New(ctx context.Context, options Options) *Module {
+	return &Module{
+		Options: options,
+		now:     time.Now,
+	}
+}
+
+// Name returns the module name.
+func (m *Module) Name() string {
+	return "events"
+}
+
+// Description returns the module description.
+func (m *Module) Description() string {
+	return "Events module explores events across namespaces"
+}
+
+// ClientRequestHandlers returns nil.
+func (m *Module) ClientRequestHandlers() []octant.ClientRequestHandler {
+	return nil
+}
+
+// Content handles content for the module. Paths are:
+//
+//	/                        events in all namespaces
+//	/tail                    live tail of events in all namespaces
+//	/namespace/<ns>          events in a namespace
+//	/namespace/<ns>/tail     live tail of events in a namespace
+func (m *Module) Content(ctx context.Context, contentPath string, opts module.ContentOptions) (component.ContentResponse, error) {
+	namespace, tail, ok := parsePath(contentPath)
+	if !ok {
+		return component.EmptyContentResponse, api.NewNotFoundError(contentPath)
+	}
+
+	filter, err := ParseFilter(opts.LabelSet)
+	if err != nil {
+		return component.EmptyContentResponse, err
+	}
+
+	events, err := m.listEvents(ctx, namespace, filter)
+	if err != nil {
+		return component.EmptyContentResponse, err
+	}
+
+	title := component.Title(component.NewText("Event Explorer"))
+	if namespace != "" {
+		title = append(title, component.NewText(namespace))
+	}
+
+	if tail {
+		title = append(title, component.NewText("Live Tail"))
+		return component.ContentResponse{
+			Title:      title,
+			Components: []component.Component{m.filterText(filter), m.tailTable(events)},
+		}, nil
+	}
+
+	aggregates := AggregateEvents(events)
+
+	layout := component.NewFlexLayout("")
+	layout.AddSections(component.FlexLayoutSection{
+		{Width: component.WidthFull, View: m.filterText(filter)},
+	})
+	if timeline := warningTimeline(aggregates); timeline != nil {
+		layout.AddSections(component.FlexLayoutSection{
+			{Width: component.WidthFull, View: timeline},
+		})
+	}
+	layout.AddSections(component.FlexLayoutSection{
+		{Width: component.WidthFull, View: m.aggregateTable(aggregates)},
+	})
+
+	return component.ContentResponse{
+		Title:      title,
+		Components: []component.Component{layout},
+	}, nil
+}
+
+// listEvents lists events in a namespace, or all namespaces if namespace is empty,
+// which match a filter. Events are sorted by the time they were last seen, most recent first.
+func (m *Module) listEvents(ctx context.Context, namespace string, filter Filter) ([]corev1.Event, error) {
+	key := store.Key{
+		Namespace:  namespace,
+		APIVersion: "v1",
+		Kind:       "Event",
+	}
+
+	list, _, err := m.DashConfig.ObjectStore().List(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("list events: %w", err)
+	}
+
+	now := m.now()
+
+	var events []corev1.Event
+	for i := range list.Items {
+		event := corev1.Event{}
+		if err := kubernetes.FromUnstructured(&list.Items[i], &event); err != nil {
+			return nil, fmt.Errorf("convert event: %w", err)
+		}
+
+		if filter.Match(&event, now) {
+			events = append(events, event)
+		}
+	}
+
+	sortByLastSeen(events)
+
+	return events, nil
+}
+
+func (m *Module) filterText(filter Filter) component.Component {
+	return component.NewMarkdownText(fmt.Sprintf(
+		"Showing %s. Filter with `type`, `reason`, `kind` and `since`, e.g. `type:Warning` or `since:15m`.",
+		filter))
+}
+
+var aggregateCols = component.NewTableCols("Last Seen", "Type", "Reason", "Object", "Namespace", "Message", "Count", "First Seen")
+
+func (m *Module) aggregateTable(aggregates []Aggregate) *component.Table {
+	table := component.NewTable("Events", "There are no events matching the filters", aggregateCols)
+
+	for _, aggregate := range aggregates {
+		table.Add(component.TableRow{
+			"Last Seen":  component.NewTimestamp(aggregate.LastSeen),
+			"Type":       eventTypeText(aggregate.Type),
+			"Reason":     component.NewText(aggregate.Reason),
+			"Object":     m.objectLink(aggregate.InvolvedObject),
+			"Namespace":  component.NewText(aggregate.InvolvedObject.Namespace),
+			"Message":    component.NewText(aggregate.Message),
+			"Count":      component.NewText(fmt.Sprintf("%d", aggregate.Count)),
+			"First Seen": component.NewTimestamp(aggregate.FirstSeen),
+		})
+	}
+
+	return table
+}
+
+var tailCols = component.NewTableCols("Last Seen", "Type", "Reason", "Object", "Namespace", "Message", "Count", "From")
+
+func (m *Module) tailTable(events []corev1.Event) *component.Table {
+	table := component.NewTable("Live Tail", "Waiting for events matching the filters", tailCols)
+
+	if len(events) > tailSize {
+		events = events[:tailSize]
+	}
+
+	for i := range events {
+		event := &events[i]
+		table.Add(component.TableRow{
+			"Last Seen": component.NewTimestamp(lastSeen(event)),
+			"Type":      eventTypeText(event.Type),
+			"Reason":    component.NewText(event.Reason),
+			"Object":    m.objectLink(event.InvolvedObject),
+			"Namespace": component.NewText(event.InvolvedObject.Namespace),
+			"Message":   component.NewText(event.Message),
+			"Count":     component.NewText(fmt.Sprintf("%d", count(event))),
+			"From":      component.NewText(event.Source.Component),
+		})
+	}
+
+	return table
+}
+
+// warningTimeline creates a timeline of the most recent warnings. It returns nil if
+// there are no warnings.
+func warningTimeline(aggregates []Aggregate) component.Component {
+	var steps []component.TimelineStep
+	for _, aggregate := range aggregates {
+		if aggregate.Type != corev1.EventTypeWarning {
+			continue
+		}
+
+		steps = append(steps, component.TimelineStep{
+			State:       component.TimelineStepError,
+			Header:      aggregate.LastSeen.UTC().Format("15:04:05"),
+			Title:       fmt.Sprintf("%s %s/%s (%d)", aggregate.Reason, aggregate.InvolvedObject.Kind, aggregate.InvolvedObject.Name, aggregate.Count),
+			Description: aggregate.Message,
+		})
+
+		if len(steps) == timelineSize {
+			break
+		}
+	}
+
+	if len(steps) == 0 {
+		return nil
+	}
+
+	card := component.NewCard(component.TitleFromString("Recent Warnings"))
+	card.SetBody(component.NewTimeline(steps, true))
+	return card
+}
+
+func (m *Module) objectLink(ref corev1.ObjectReference) component.Component {
+	text := fmt.Sprintf("%s/%s", ref.Kind, ref.Name)
+
+	objectPath, err := m.DashConfig.ObjectPath(ref.Namespace, ref.APIVersion, ref.Kind, ref.Name)
+	if err != nil || objectPath == "" {
+		return component.NewText(text)
+	}
+
+	return component.NewLink("", text, objectPath)
+}
+
+func eventTypeText(eventType string) *component.Text {
+	text := component.NewText(eventType)
+	if eventType == corev1.EventTypeWarning {
+		text.Config.Status = component.TextStatusWarning
+	}
+	return text
+}
+
+// parsePath returns the namespace of a content path and whether it is the live tail.
+func parsePath(contentPath string) (namespace string, tail bool, ok bool) {
+	parts := strings.Split(strings.Trim(path.Clean("/"+contentPath), "/"), "/")
+	if len(parts) == 1 && parts[0] == "" {
+		return "", false, true
+	}
+
+	if parts[len(parts)-1] == tailPath {
+		tail = true
+		parts = parts[:len(parts)-1]
+	}
+
+	switch {
+	case len(parts) == 0:
+		return "", tail, true
+	case len(parts) == 2 && parts[0] == "namespace" && parts[1] != "":
+		return parts[1], tail, true
+	default:
+		return "", false, false
+	}
+}
+
+// ContentPath returns the content path for this module.
+func (m *Module) ContentPath() string {
+	return m.Name()
+}
+
+// Navigation returns navigation entries for the module.
+func (m *Module) Navigation(ctx context.Context, namespace, root string) ([]navigation.Navigation, error) {
+	rootNav := navigation.Navigation{
+		Title:    "Event Explorer",
+		Path:     m.ContentPath(),
+		IconName: icon.Events,
+		Children: []navigation.Navigation{
+			{
+				Title: "All Namespaces",
+				Path:  m.ContentPath(),
+			},
+			{
+				Title: "Live Tail",
+				Path:  path.Join(m.ContentPath(), tailPath),
+			},
+		},
+	}
+
+	if namespace != "" {
+		rootNav.Children = append(rootNav.Children, navigation.Navigation{
+			Title: "Current Namespace",
+			Path:  path_util.NamespacedPath(m.ContentPath(), namespace),
+		})
+	}
+
+	return []navigation.Navigation{rootNav}, nil
+}
+
+// SetNamespace is a no-op.
+func (m *Module) SetNamespace(namespace string) error {
+	return nil
+}
+
+// Start is a no-op.
+func (m *Module) Start() error {
+	return nil
+}
+
+// Stop is a no-op.
+func (m *Module) Stop() {
+}
+
+// SetContext is a no-op.
+func (m *Module) SetContext(ctx context.Context, contextName string) error {
+	return nil
+}
+
+// Generators returns nil.
+func (m *Module) Generators() []octant.Generator {
+	return nil
+}
+
+// SupportedGroupVersionKind returns nil.
+func (m *Module) SupportedGroupVersionKind() []schema.GroupVersionKind {
+	return nil
+}
+
+// GroupVersionKindPath returns an error as this module does not own any objects.
+func (m *Module) GroupVersionKindPath(namespace, apiVersion, kind, name string) (string, error) {
+	return "", fmt.Errorf("not supported")
+}
+
+// AddCRD is a no-op.
+func (m *Module) AddCRD(ctx context.Context, crd *unstructured.Unstructured) error {
+	return nil
+}
+
+// RemoveCRD is a no-op.
+func (m *Module) RemoveCRD(ctx context.Context, crd *unstructured.Unstructured) error {
+	return nil
+}
+
+// ResetCRDs is a no-op.
+func (m *Module) ResetCRDs(ctx context.Context) error {
+	return nil
+}
+
+// GvkFromPath returns an error as this module does not own any objects.
+func (m *Module) GvkFromPath(contentPath, namespace string) (schema.GroupVersionKind, error) {
+	return schema.GroupVersionKind{}, errors.Errorf("not supported")
+}
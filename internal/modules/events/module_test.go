@@ -0,0 +1,176 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package events
+
+import (
+	"context"
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	configFake "github.com/vmware-tanzu/octant/internal/config/fake"
+	"github.com/vmware-tanzu/octant/internal/module"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	storeFake "github.com/vmware-tanzu/octant/pkg/store/fake"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+func newTestModule(t *testing.T, controller *gomock.Controller, namespace string, events ...corev1.Event) *Module {
+	list := &unstructured.UnstructuredList{}
+	for i := range events {
+		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(&events[i])
+		require.NoError(t, err)
+		list.Items = append(list.Items, unstructured.Unstructured{Object: m})
+	}
+
+	objectStore := storeFake.NewMockStore(controller)
+	objectStore.EXPECT().
+		List(gomock.Any(), store.Key{Namespace: namespace, APIVersion: "v1", Kind: "Event"}).
+		Return(list, false, nil).
+		AnyTimes()
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().ObjectStore().Return(objectStore).AnyTimes()
+	dashConfig.EXPECT().
+		ObjectPath(gomock.Any(), "v1", "Pod", gomock.Any()).
+		DoAndReturn(func(namespace, apiVersion, kind, name string) (string, error) {
+			return fmt.Sprintf("/overview/namespace/%s/workloads/pods/%s", namespace, name), nil
+		}).
+		AnyTimes()
+	dashConfig.EXPECT().ObjectPath(gomock.Any(), "v1", "Node", gomock.Any()).Return("", fmt.Errorf("unknown")).AnyTimes()
+
+	m := New(context.Background(), Options{DashConfig: dashConfig})
+	m.now = func() time.Time { return time.Date(2021, 3, 1, 12, 10, 0, 0, time.UTC) }
+	return m
+}
+
+func testEvents() []corev1.Event {
+	base := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+	minute := func(n int) time.Time { return base.Add(time.Duration(n) * time.Minute) }
+
+	return []corev1.Event{
+		newEvent("default", "Pod", "web", corev1.EventTypeWarning, "BackOff", "back-off", 4, minute(0), minute(8)),
+		newEvent("default", "Pod", "web", corev1.EventTypeNormal, "Pulled", "pulled image", 1, minute(1), minute(1)),
+		newEvent("", "Node", "node-1", corev1.EventTypeWarning, "NodeNotReady", "not ready", 1, minute(9), minute(9)),
+	}
+}
+
+func findLayoutItem(t *testing.T, response component.ContentResponse, fn func(component.Component) bool) component.Component {
+	require.Len(t, response.Components, 1)
+	layout, ok := response.Components[0].(*component.FlexLayout)
+	require.True(t, ok)
+
+	for _, section := range layout.Config.Sections {
+		for _, item := range section {
+			if fn(item.View) {
+				return item.View
+			}
+		}
+	}
+	return nil
+}
+
+func TestModule_Content(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := newTestModule(t, controller, "", testEvents()...)
+
+	got, err := m.Content(context.Background(), "", module.ContentOptions{})
+	require.NoError(t, err)
+
+	table, ok := findLayoutItem(t, got, func(c component.Component) bool {
+		_, ok := c.(*component.Table)
+		return ok
+	}).(*component.Table)
+	require.True(t, ok)
+
+	rows := table.Rows()
+	require.Len(t, rows, 3)
+	assert.Equal(t, component.NewText("Node/node-1"), rows[0]["Object"])
+	assert.Equal(t, component.NewLink("", "Pod/web", "/overview/namespace/default/workloads/pods/web"), rows[1]["Object"])
+	assert.Equal(t, component.NewText("4"), rows[1]["Count"])
+
+	card, ok := findLayoutItem(t, got, func(c component.Component) bool {
+		_, ok := c.(*component.Card)
+		return ok
+	}).(*component.Card)
+	require.True(t, ok)
+
+	timeline, ok := card.Config.Body.(*component.Timeline)
+	require.True(t, ok)
+	require.Len(t, timeline.Config.Steps, 2)
+	assert.Equal(t, "NodeNotReady Node/node-1 (1)", timeline.Config.Steps[0].Title)
+	assert.Equal(t, "12:09:00", timeline.Config.Steps[0].Header)
+	assert.Equal(t, "BackOff Pod/web (4)", timeline.Config.Steps[1].Title)
+}
+
+func TestModule_Content_filtered(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := newTestModule(t, controller, "default", testEvents()...)
+
+	got, err := m.Content(context.Background(), "/namespace/default/tail", module.ContentOptions{
+		LabelSet: &labels.Set{"since": "5m", "kind": "pod"},
+	})
+	require.NoError(t, err)
+
+	require.Len(t, got.Components, 2)
+	table, ok := got.Components[1].(*component.Table)
+	require.True(t, ok)
+
+	rows := table.Rows()
+	require.Len(t, rows, 1)
+	assert.Equal(t, component.NewText("BackOff"), rows[0]["Reason"])
+}
+
+func TestModule_Content_errors(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	m := newTestModule(t, controller, "")
+
+	_, err := m.Content(context.Background(), "/unknown", module.ContentOptions{})
+	assert.Error(t, err)
+
+	_, err = m.Content(context.Background(), "/", module.ContentOptions{LabelSet: &labels.Set{"since": "soon"}})
+	assert.Error(t, err)
+}
+
+func Test_parsePath(t *testing.T) {
+	cases := []struct {
+		contentPath string
+		namespace   string
+		tail        bool
+		ok          bool
+	}{
+		{contentPath: "", ok: true},
+		{contentPath: "/", ok: true},
+		{contentPath: "/tail", tail: true, ok: true},
+		{contentPath: "/namespace/default", namespace: "default", ok: true},
+		{contentPath: "/namespace/default/tail", namespace: "default", tail: true, ok: true},
+		{contentPath: "/namespace"},
+		{contentPath: "/pods/tail"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.contentPath, func(t *testing.T) {
+			namespace, tail, ok := parsePath(tc.contentPath)
+			assert.Equal(t, tc.namespace, namespace)
+			assert.Equal(t, tc.tail, tail)
+			assert.Equal(t, tc.ok, ok)
+		})
+	}
+}
@@ -14,9 +14,19 @@ func NewLogEntry(container, line string) logEntry {
 	}
 }
 
+// NewPodLogEntry returns a log entry tagged with the pod it was read from.
+func NewPodLogEntry(pod, container, line string) logEntry {
+	return logEntry{
+		pod:       pod,
+		container: container,
+		line:      line,
+	}
+}
+
 type logEntry struct {
 	line      string
 	container string
+	pod       string
 }
 
 func (l logEntry) Line() string {
@@ -26,3 +36,7 @@ func (l logEntry) Line() string {
 func (l logEntry) Container() string {
 	return l.container
 }
+
+func (l logEntry) Pod() string {
+	return l.pod
+}
@@ -0,0 +1,178 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package container
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+	"time"
+
+	"github.com/vmware-tanzu/octant/internal/util/json"
+)
+
+// Severity is the severity of a log line.
+type Severity int
+
+const (
+	// SeverityUnknown is the severity of lines which don't declare one.
+	SeverityUnknown Severity = iota
+	SeverityDebug
+	SeverityInfo
+	SeverityWarning
+	SeverityError
+	SeverityFatal
+)
+
+var severityNames = map[Severity]string{
+	SeverityDebug:   "debug",
+	SeverityInfo:    "info",
+	SeverityWarning: "warning",
+	SeverityError:   "error",
+	SeverityFatal:   "fatal",
+}
+
+// severityAliases maps the level names used by common logging libraries to a severity.
+var severityAliases = map[string]Severity{
+	"trace":     SeverityDebug,
+	"debug":     SeverityDebug,
+	"info":      SeverityInfo,
+	"notice":    SeverityInfo,
+	"warn":      SeverityWarning,
+	"warning":   SeverityWarning,
+	"error":     SeverityError,
+	"err":       SeverityError,
+	"critical":  SeverityFatal,
+	"fatal":     SeverityFatal,
+	"panic":     SeverityFatal,
+	"emergency": SeverityFatal,
+}
+
+// severityKeys are the JSON keys which are checked for a severity, in order.
+var severityKeys = []string{"level", "severity", "lvl", "loglevel", "log.level"}
+
+// String returns the name of the severity.
+func (s Severity) String() string {
+	return severityNames[s]
+}
+
+// ParseSeverity converts a severity name to a Severity. An empty name is SeverityUnknown.
+func ParseSeverity(name string) (Severity, error) {
+	if name == "" {
+		return SeverityUnknown, nil
+	}
+
+	severity, ok := severityAliases[strings.ToLower(name)]
+	if !ok {
+		return SeverityUnknown, fmt.Errorf("unknown severity %q", name)
+	}
+
+	return severity, nil
+}
+
+// DetectSeverity detects the severity of a structured JSON log line. Lines which aren't
+// JSON objects, or which don't contain a known level, are SeverityUnknown.
+func DetectSeverity(message string) Severity {
+	message = strings.TrimSpace(message)
+	if !strings.HasPrefix(message, "{") {
+		return SeverityUnknown
+	}
+
+	var fields map[string]interface{}
+	if err := json.Unmarshal([]byte(message), &fields); err != nil {
+		return SeverityUnknown
+	}
+
+	for _, key := range severityKeys {
+		value, ok := fields[key].(string)
+		if !ok {
+			continue
+		}
+
+		if severity, ok := severityAliases[strings.ToLower(value)]; ok {
+			return severity
+		}
+	}
+
+	return SeverityUnknown
+}
+
+// LogFilter filters log lines. The zero value matches every line.
+type LogFilter struct {
+	// Include only matches lines which match the expression.
+	Include *regexp.Regexp
+	// Exclude drops lines which match the expression.
+	Exclude *regexp.Regexp
+	// MinSeverity only matches lines with at least this severity. Lines with an unknown
+	// severity are dropped when it is set.
+	MinSeverity Severity
+	// Start only matches lines logged at or after this time.
+	Start time.Time
+	// End only matches lines logged at or before this time.
+	End time.Time
+}
+
+// NewLogFilter creates an instance of LogFilter. Empty arguments are ignored.
+func NewLogFilter(include, exclude, minSeverity string, start, end time.Time) (*LogFilter, error) {
+	f := &LogFilter{
+		Start: start,
+		End:   end,
+	}
+
+	var err error
+	if include != "" {
+		if f.Include, err = regexp.Compile(include); err != nil {
+			return nil, fmt.Errorf("invalid include expression: %w", err)
+		}
+	}
+
+	if exclude != "" {
+		if f.Exclude, err = regexp.Compile(exclude); err != nil {
+			return nil, fmt.Errorf("invalid exclude expression: %w", err)
+		}
+	}
+
+	if f.MinSeverity, err = ParseSeverity(minSeverity); err != nil {
+		return nil, err
+	}
+
+	if !start.IsZero() && !end.IsZero() && end.Before(start) {
+		return nil, fmt.Errorf("end time %s is before start time %s", end, start)
+	}
+
+	return f, nil
+}
+
+// Match returns true if the line should be kept. timestamp may be nil for lines
+// without a timestamp, in which case the time range is not checked.
+func (f *LogFilter) Match(message string, timestamp *time.Time) bool {
+	if f == nil {
+		return true
+	}
+
+	if timestamp != nil {
+		if !f.Start.IsZero() && timestamp.Before(f.Start) {
+			return false
+		}
+		if !f.End.IsZero() && timestamp.After(f.End) {
+			return false
+		}
+	}
+
+	if f.Include != nil && !f.Include.MatchString(message) {
+		return false
+	}
+
+	if f.Exclude != nil && f.Exclude.MatchString(message) {
+		return false
+	}
+
+	if f.MinSeverity != SeverityUnknown && DetectSeverity(message) < f.MinSeverity {
+		return false
+	}
+
+	return true
+}
@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package container
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestDetectSeverity(t *testing.T) {
+	cases := []struct {
+		name     string
+		message  string
+		expected Severity
+	}{
+		{name: "plain text", message: "error: something failed", expected: SeverityUnknown},
+		{name: "level", message: `{"level":"error","msg":"failed"}`, expected: SeverityError},
+		{name: "severity upper case", message: `{"severity":"WARNING"}`, expected: SeverityWarning},
+		{name: "lvl alias", message: ` {"lvl":"warn"}`, expected: SeverityWarning},
+		{name: "unknown level", message: `{"level":"chatty"}`, expected: SeverityUnknown},
+		{name: "numeric level", message: `{"level":30}`, expected: SeverityUnknown},
+		{name: "invalid json", message: `{"level":`, expected: SeverityUnknown},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			assert.Equal(t, tc.expected, DetectSeverity(tc.message))
+		})
+	}
+}
+
+func TestNewLogFilter_invalid(t *testing.T) {
+	now := time.Now()
+
+	_, err := NewLogFilter("(", "", "", time.Time{}, time.Time{})
+	require.Error(t, err)
+
+	_, err = NewLogFilter("", "(", "", time.Time{}, time.Time{})
+	require.Error(t, err)
+
+	_, err = NewLogFilter("", "", "loud", time.Time{}, time.Time{})
+	require.Error(t, err)
+
+	_, err = NewLogFilter("", "", "", now, now.Add(-time.Minute))
+	require.Error(t, err)
+}
+
+func TestLogFilter_Match(t *testing.T) {
+	start := time.Date(2021, 1, 1, 10, 0, 0, 0, time.UTC)
+	end := start.Add(time.Hour)
+	inRange := start.Add(time.Minute)
+	before := start.Add(-time.Minute)
+
+	cases := []struct {
+		name      string
+		include   string
+		exclude   string
+		severity  string
+		message   string
+		timestamp *time.Time
+		expected  bool
+	}{
+		{name: "no filter", message: "line", expected: true},
+		{name: "include matches", include: "^GET", message: "GET /healthz", expected: true},
+		{name: "include does not match", include: "^GET", message: "POST /", expected: false},
+		{name: "exclude matches", exclude: "healthz", message: "GET /healthz", expected: false},
+		{name: "severity at minimum", severity: "warn", message: `{"level":"warning"}`, expected: true},
+		{name: "severity above minimum", severity: "warn", message: `{"level":"error"}`, expected: true},
+		{name: "severity below minimum", severity: "warn", message: `{"level":"info"}`, expected: false},
+		{name: "unknown severity", severity: "warn", message: "plain", expected: false},
+		{name: "in range", message: "line", timestamp: &inRange, expected: true},
+		{name: "before range", message: "line", timestamp: &before, expected: false},
+		{name: "no timestamp", message: "line", expected: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			filter, err := NewLogFilter(tc.include, tc.exclude, tc.severity, start, end)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, filter.Match(tc.message, tc.timestamp))
+		})
+	}
+}
+
+func TestLogFilter_Match_nil(t *testing.T) {
+	var filter *LogFilter
+	assert.True(t, filter.Match("line", nil))
+}
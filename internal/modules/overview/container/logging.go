@@ -10,6 +10,9 @@ import "context"
 type LogEntry interface {
 	Line() string
 	Container() string
+	// Pod returns the pod the entry was read from. It is empty for
+	// single pod streams.
+	Pod() string
 }
 
 type LogStreamer interface {
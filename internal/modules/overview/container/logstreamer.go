@@ -15,6 +15,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	"github.com/vmware-tanzu/octant/internal/cluster"
 	"github.com/vmware-tanzu/octant/internal/config"
 	"github.com/vmware-tanzu/octant/internal/util/kubernetes"
 	"github.com/vmware-tanzu/octant/pkg/store"
@@ -26,6 +27,8 @@ type logStreamer struct {
 	containers   []string
 	sinceSeconds *int64
 	creationTime *v1.Time
+	previous     bool
+	tailLines    *int64
 	stream       chan LogEntry
 
 	ctx      context.Context
@@ -109,6 +112,18 @@ func shouldFetchContainerNames(containerNames []string) bool {
 	return false
 }
 
+// SetPrevious configures the streamer to return the logs of the previous terminated
+// instance of each container instead of following the current one.
+func (s *logStreamer) SetPrevious(previous bool) {
+	s.previous = previous
+}
+
+// SetTailLines configures the streamer to only return the last lines of each
+// container's log.
+func (s *logStreamer) SetTailLines(lines int64) {
+	s.tailLines = &lines
+}
+
 // Names returns a list of container names that the log streamer is streaming logs for.
 func (s *logStreamer) Names() []string {
 	if s.containers == nil {
@@ -158,19 +173,29 @@ func (s *logStreamer) Close(logCh chan<- LogEntry) {
 }
 
 func (s *logStreamer) containerStream(container string) (io.ReadCloser, error) {
-	client, err := s.config.ClusterClient().KubernetesClient()
+	clusterClient, err := cluster.ClientForContext(s.ctx, s.config.ClusterClient())
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := clusterClient.KubernetesClient()
 	if err != nil {
 		return nil, err
 	}
 
 	options := &corev1.PodLogOptions{
 		Container:  container,
-		Follow:     true,
+		Follow:     !s.previous,
+		Previous:   s.previous,
 		Timestamps: true,
+		TailLines:  s.tailLines,
 	}
-	if s.creationTime != nil {
+	switch {
+	case s.previous:
+		// The previous instance has terminated, so its log is read from the start.
+	case s.creationTime != nil:
 		options.SinceTime = s.creationTime
-	} else {
+	default:
 		options.SinceSeconds = s.sinceSeconds
 	}
 
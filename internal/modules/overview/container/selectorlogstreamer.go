@@ -0,0 +1,312 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package container
+
+import (
+	"bufio"
+	"context"
+	"fmt"
+	"io"
+	"sort"
+	"sync"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/octant/internal/cluster"
+	"github.com/vmware-tanzu/octant/internal/config"
+	"github.com/vmware-tanzu/octant/internal/gvk"
+	"github.com/vmware-tanzu/octant/internal/util/kubernetes"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+// DefaultPodResyncInterval is how often a selector log streamer looks for pods
+// which have been created or deleted.
+const DefaultPodResyncInterval = 5 * time.Second
+
+type selectorLogStreamer struct {
+	namespace      string
+	selector       *v1.LabelSelector
+	container      string
+	sinceSeconds   int64
+	previous       bool
+	resyncInterval time.Duration
+	config         config.Dash
+
+	mu         sync.Mutex
+	pods       map[string]context.CancelFunc
+	containers map[string]bool
+
+	ctx      context.Context
+	cancelFn context.CancelFunc
+	wg       sync.WaitGroup
+}
+
+var _ LogStreamer = (*selectorLogStreamer)(nil)
+
+// SelectorLogStreamerOption is an option for configuring a selector log streamer.
+type SelectorLogStreamerOption func(s *selectorLogStreamer)
+
+// WithPodResyncInterval sets how often the streamer looks for new or deleted pods.
+func WithPodResyncInterval(interval time.Duration) SelectorLogStreamerOption {
+	return func(s *selectorLogStreamer) {
+		if interval > 0 {
+			s.resyncInterval = interval
+		}
+	}
+}
+
+// WithPreviousLogs streams the logs of the previous terminated instance of each
+// container instead of following the current one.
+func WithPreviousLogs() SelectorLogStreamerOption {
+	return func(s *selectorLogStreamer) {
+		s.previous = true
+	}
+}
+
+// NewSelectorLogStreamer returns a log streamer which aggregates the logs for every pod
+// in namespace matching selector. Pods which are created while streaming are followed
+// from their creation and pods which are deleted stop being streamed. If containerName
+// is empty, all containers in each pod are streamed.
+func NewSelectorLogStreamer(ctx context.Context, dashConfig config.Dash, namespace string, selector *v1.LabelSelector, sinceSeconds int64, containerName string, options ...SelectorLogStreamerOption) (*selectorLogStreamer, error) {
+	if selector == nil {
+		return nil, fmt.Errorf("label selector is nil")
+	}
+
+	if _, err := v1.LabelSelectorAsSelector(selector); err != nil {
+		return nil, fmt.Errorf("invalid label selector: %w", err)
+	}
+
+	ctx, cancelFn := context.WithCancel(ctx)
+
+	s := &selectorLogStreamer{
+		namespace:      namespace,
+		selector:       selector,
+		container:      containerName,
+		sinceSeconds:   sinceSeconds,
+		resyncInterval: DefaultPodResyncInterval,
+		config:         dashConfig,
+		pods:           make(map[string]context.CancelFunc),
+		containers:     make(map[string]bool),
+		ctx:            ctx,
+		cancelFn:       cancelFn,
+	}
+
+	for _, option := range options {
+		option(s)
+	}
+
+	return s, nil
+}
+
+// Names returns a list of container names that the log streamer is streaming logs for.
+func (s *selectorLogStreamer) Names() []string {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	names := []string{}
+	for name := range s.containers {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	return names
+}
+
+// Stream streams logs for the matching pods until ctx is canceled. The log channel is
+// closed once all pod streams have finished.
+func (s *selectorLogStreamer) Stream(ctx context.Context, logCh chan<- LogEntry) {
+	ctx, cancel := context.WithCancel(ctx)
+
+	go func() {
+		defer cancel()
+
+		ticker := time.NewTicker(s.resyncInterval)
+		defer ticker.Stop()
+
+		initial := true
+		done := false
+		for !done {
+			if err := s.sync(ctx, logCh, initial); err != nil {
+				s.config.Logger().WithErr(err).Errorf("unable to sync log streams")
+			}
+			initial = false
+
+			select {
+			case <-ctx.Done():
+				done = true
+			case <-s.ctx.Done():
+				done = true
+			case <-ticker.C:
+			}
+		}
+
+		s.stopAll()
+		s.wg.Wait()
+		s.Close(logCh)
+	}()
+}
+
+// Close calls the cancel function and closes the stream.
+func (s *selectorLogStreamer) Close(logCh chan<- LogEntry) {
+	close(logCh)
+	s.cancelFn()
+}
+
+// sync starts streams for pods which aren't being streamed and stops streams for pods
+// which no longer exist. Pods found during the initial sync honor sinceSeconds, while
+// pods found later are streamed from their creation so no lines are missed.
+func (s *selectorLogStreamer) sync(ctx context.Context, logCh chan<- LogEntry, initial bool) error {
+	key := store.KeyFromGroupVersionKind(gvk.Pod)
+	key.Namespace = s.namespace
+	key.LabelSelector = s.selector
+
+	list, _, err := s.config.ObjectStore().List(ctx, key)
+	if err != nil {
+		return fmt.Errorf("list pods: %w", err)
+	}
+	if list == nil {
+		return nil
+	}
+
+	current := make(map[string]bool)
+	for i := range list.Items {
+		pod, err := toPod(&list.Items[i])
+		if err != nil {
+			return err
+		}
+
+		current[pod.Name] = true
+
+		// Containers in pending pods haven't started, so there are no logs yet. They
+		// will be picked up on a later sync.
+		if pod.Status.Phase == corev1.PodPending || pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		s.mu.Lock()
+		_, ok := s.pods[pod.Name]
+		s.mu.Unlock()
+		if ok {
+			continue
+		}
+
+		s.startPod(ctx, pod, logCh, initial)
+	}
+
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name, cancel := range s.pods {
+		if !current[name] {
+			cancel()
+			delete(s.pods, name)
+		}
+	}
+
+	return nil
+}
+
+func (s *selectorLogStreamer) startPod(ctx context.Context, pod *corev1.Pod, logCh chan<- LogEntry, initial bool) {
+	podCtx, cancel := context.WithCancel(ctx)
+
+	s.mu.Lock()
+	s.pods[pod.Name] = cancel
+	s.mu.Unlock()
+
+	started := 0
+	for _, c := range pod.Spec.Containers {
+		if s.container != "" && s.container != c.Name {
+			continue
+		}
+
+		options := &corev1.PodLogOptions{
+			Container:  c.Name,
+			Follow:     !s.previous,
+			Previous:   s.previous,
+			Timestamps: true,
+		}
+		switch {
+		case s.previous:
+			// The previous instance has terminated, so its whole log is returned.
+		case !initial || s.sinceSeconds < 0:
+			options.SinceTime = &v1.Time{Time: pod.CreationTimestamp.Time}
+		default:
+			sinceSeconds := s.sinceSeconds
+			options.SinceSeconds = &sinceSeconds
+		}
+
+		stream, err := s.podStream(podCtx, pod.Name, options)
+		if err != nil {
+			s.config.Logger().Errorf("unable to stream logs for %s/%s: %s", pod.Name, c.Name, err)
+			continue
+		}
+
+		s.mu.Lock()
+		s.containers[c.Name] = true
+		s.mu.Unlock()
+
+		started++
+		podName, containerName := pod.Name, c.Name
+		s.wg.Add(1)
+		go func() {
+			defer s.wg.Done()
+			defer stream.Close()
+
+			scanner := bufio.NewScanner(stream)
+			for podCtx.Err() == nil && scanner.Scan() {
+				select {
+				case logCh <- NewPodLogEntry(podName, containerName, scanner.Text()):
+				case <-podCtx.Done():
+					return
+				}
+			}
+		}()
+	}
+
+	// Nothing could be streamed, so forget the pod and retry on the next sync.
+	if started == 0 {
+		s.mu.Lock()
+		delete(s.pods, pod.Name)
+		s.mu.Unlock()
+		cancel()
+	}
+}
+
+func (s *selectorLogStreamer) stopAll() {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+
+	for name, cancel := range s.pods {
+		cancel()
+		delete(s.pods, name)
+	}
+}
+
+func (s *selectorLogStreamer) podStream(ctx context.Context, podName string, options *corev1.PodLogOptions) (io.ReadCloser, error) {
+	clusterClient, err := cluster.ClientForContext(ctx, s.config.ClusterClient())
+	if err != nil {
+		return nil, err
+	}
+
+	client, err := clusterClient.KubernetesClient()
+	if err != nil {
+		return nil, err
+	}
+
+	return client.CoreV1().Pods(s.namespace).GetLogs(podName, options).Stream(ctx)
+}
+
+func toPod(object *unstructured.Unstructured) (*corev1.Pod, error) {
+	pod := &corev1.Pod{}
+	if err := kubernetes.FromUnstructured(object, pod); err != nil {
+		return nil, fmt.Errorf("converting unstructured: %w", err)
+	}
+
+	return pod, nil
+}
@@ -0,0 +1,106 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package container
+
+import (
+	"context"
+	"sync/atomic"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	clusterFake "github.com/vmware-tanzu/octant/internal/cluster/fake"
+	configFake "github.com/vmware-tanzu/octant/internal/config/fake"
+	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/testutil"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	storeFake "github.com/vmware-tanzu/octant/pkg/store/fake"
+)
+
+func Test_selectorLogStreamer(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+
+	running := func(pod *corev1.Pod) {
+		pod.Labels = selector.MatchLabels
+		pod.Spec.Containers = []corev1.Container{{Name: "app"}, {Name: "sidecar"}}
+		pod.Status.Phase = corev1.PodRunning
+	}
+	podA := testutil.CreatePod("pod-a", running)
+	podB := testutil.CreatePod("pod-b", running)
+	pending := testutil.CreatePod("pod-c", running, func(pod *corev1.Pod) {
+		pod.Status.Phase = corev1.PodPending
+	})
+
+	clusterClient := clusterFake.NewMockClientInterface(controller)
+	clusterClient.EXPECT().KubernetesClient().Return(kubefake.NewSimpleClientset(), nil).AnyTimes()
+
+	// The first sync sees pod-a, later syncs see pod-b replacing it.
+	var syncs int32
+	objectStore := storeFake.NewMockStore(controller)
+	objectStore.EXPECT().
+		List(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(_ context.Context, key store.Key) (*unstructured.UnstructuredList, bool, error) {
+			assert.Equal(t, "Pod", key.Kind)
+			assert.Equal(t, "default", key.Namespace)
+			assert.Equal(t, selector, key.LabelSelector)
+
+			if atomic.AddInt32(&syncs, 1) == 1 {
+				return testutil.ToUnstructuredList(t, podA, pending), false, nil
+			}
+			return testutil.ToUnstructuredList(t, podB, pending), false, nil
+		}).
+		MinTimes(2)
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().ObjectStore().Return(objectStore).AnyTimes()
+	dashConfig.EXPECT().ClusterClient().Return(clusterClient).AnyTimes()
+	dashConfig.EXPECT().Logger().Return(log.NopLogger()).AnyTimes()
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	ls, err := NewSelectorLogStreamer(ctx, dashConfig, "default", selector, 300, "app",
+		WithPodResyncInterval(10*time.Millisecond))
+	require.NoError(t, err)
+
+	logCh := make(chan LogEntry)
+	ls.Stream(ctx, logCh)
+
+	seen := make(map[string]bool)
+	timeout := time.After(5 * time.Second)
+	for !seen["pod-a"] || !seen["pod-b"] {
+		select {
+		case entry := <-logCh:
+			assert.Equal(t, "app", entry.Container())
+			assert.NotEqual(t, "pod-c", entry.Pod())
+			seen[entry.Pod()] = true
+		case <-timeout:
+			t.Fatalf("timed out waiting for logs; saw %v", seen)
+		}
+	}
+
+	assert.Equal(t, []string{"app"}, ls.Names())
+
+	cancel()
+
+	for range logCh {
+	}
+}
+
+func Test_NewSelectorLogStreamer_nil_selector(t *testing.T) {
+	_, err := NewSelectorLogStreamer(context.Background(), nil, "default", nil, 300, "")
+	require.Error(t, err)
+}
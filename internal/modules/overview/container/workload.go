@@ -0,0 +1,77 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package container
+
+import (
+	"context"
+	"fmt"
+
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/vmware-tanzu/octant/internal/gvk"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+// workloadKinds are the workloads which can have their logs streamed.
+var workloadKinds = map[string]schema.GroupVersionKind{
+	gvk.Deployment.Kind:  gvk.Deployment,
+	gvk.StatefulSet.Kind: gvk.StatefulSet,
+	gvk.Job.Kind:         gvk.Job,
+}
+
+// IsLogWorkload returns true if logs can be streamed for workloads of kind.
+func IsLogWorkload(kind string) bool {
+	_, ok := workloadKinds[kind]
+	return ok
+}
+
+// WorkloadKey returns the object store key for a workload which can have its logs streamed.
+func WorkloadKey(namespace, kind, name string) (store.Key, error) {
+	groupVersionKind, ok := workloadKinds[kind]
+	if !ok {
+		return store.Key{}, fmt.Errorf("can't stream logs for a %s", kind)
+	}
+
+	key := store.KeyFromGroupVersionKind(groupVersionKind)
+	key.Namespace = namespace
+	key.Name = name
+
+	return key, nil
+}
+
+// WorkloadSelector returns the pod label selector for the workload with key.
+func WorkloadSelector(ctx context.Context, objectStore store.Store, key store.Key) (*v1.LabelSelector, error) {
+	object, err := objectStore.Get(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("get %s %s: %w", key.Kind, key.Name, err)
+	}
+	if object == nil {
+		return nil, fmt.Errorf("%s %s was not found", key.Kind, key.Name)
+	}
+
+	return SelectorFromObject(object)
+}
+
+// SelectorFromObject returns the pod label selector in an object's spec.selector.
+func SelectorFromObject(object *unstructured.Unstructured) (*v1.LabelSelector, error) {
+	m, found, err := unstructured.NestedMap(object.Object, "spec", "selector")
+	if err != nil {
+		return nil, fmt.Errorf("get spec.selector: %w", err)
+	}
+	if !found {
+		return nil, fmt.Errorf("%s %s does not have a selector", object.GetKind(), object.GetName())
+	}
+
+	selector := &v1.LabelSelector{}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, selector); err != nil {
+		return nil, fmt.Errorf("convert selector: %w", err)
+	}
+
+	return selector, nil
+}
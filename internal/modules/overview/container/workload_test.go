@@ -0,0 +1,58 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package container
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/octant/internal/gvk"
+	"github.com/vmware-tanzu/octant/internal/testutil"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	storeFake "github.com/vmware-tanzu/octant/pkg/store/fake"
+)
+
+func TestWorkloadKey(t *testing.T) {
+	key, err := WorkloadKey("default", "Deployment", "web")
+	require.NoError(t, err)
+
+	expected := store.KeyFromGroupVersionKind(gvk.Deployment)
+	expected.Namespace = "default"
+	expected.Name = "web"
+	assert.Equal(t, expected, key)
+
+	_, err = WorkloadKey("default", "ConfigMap", "web")
+	require.Error(t, err)
+}
+
+func TestWorkloadSelector(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	selector := &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}}
+	deployment := testutil.CreateDeployment("web")
+	deployment.Spec.Selector = selector
+
+	key, err := WorkloadKey("default", "Deployment", "web")
+	require.NoError(t, err)
+
+	objectStore := storeFake.NewMockStore(controller)
+	objectStore.EXPECT().Get(gomock.Any(), key).Return(testutil.ToUnstructured(t, deployment), nil)
+
+	got, err := WorkloadSelector(context.Background(), objectStore, key)
+	require.NoError(t, err)
+	assert.Equal(t, selector, got)
+}
+
+func TestSelectorFromObject_missing_selector(t *testing.T) {
+	_, err := SelectorFromObject(testutil.ToUnstructured(t, testutil.CreateConfigMap("config")))
+	require.Error(t, err)
+}
@@ -11,11 +11,13 @@ import (
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 
+	"github.com/vmware-tanzu/octant/internal/modules/overview/container"
 	"github.com/vmware-tanzu/octant/internal/util/kubernetes"
 	"github.com/vmware-tanzu/octant/pkg/view/component"
 )
 
-// ToComponent converts an object into a log viewer component.
+// ToComponent converts an object into a log viewer component. Pods show their
+// own logs, while workloads show the aggregated logs of all their pods.
 func ToComponent(object runtime.Object) (component.Component, error) {
 	if object == nil {
 		return nil, errors.Errorf("object is nil")
@@ -25,6 +27,9 @@ func ToComponent(object runtime.Object) (component.Component, error) {
 
 	switch t := object.(type) {
 	case *unstructured.Unstructured:
+		if container.IsLogWorkload(t.GetKind()) {
+			return workloadComponent(t)
+		}
 		if err := kubernetes.FromUnstructured(t, pod); err != nil {
 			return nil, err
 		}
@@ -35,24 +40,49 @@ func ToComponent(object runtime.Object) (component.Component, error) {
 	}
 
 	if pod == nil {
+		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+		if err == nil {
+			u := &unstructured.Unstructured{Object: m}
+			if container.IsLogWorkload(u.GetKind()) {
+				return workloadComponent(u)
+			}
+		}
 		return nil, errors.Errorf("can't fetch logs from a %T", object)
 	}
 
-	containerNames := []string{""}
+	logsComponent := component.NewLogs(pod.Namespace, pod.Name, containerNames(pod.Spec)...)
+
+	return logsComponent, nil
+}
 
-	for _, c := range pod.Spec.InitContainers {
-		containerNames = append(containerNames, c.Name)
+func workloadComponent(object *unstructured.Unstructured) (component.Component, error) {
+	podTemplate := &corev1.PodTemplateSpec{}
+	m, _, err := unstructured.NestedMap(object.Object, "spec", "template")
+	if err != nil {
+		return nil, errors.Wrap(err, "get pod template")
+	}
+	if err := runtime.DefaultUnstructuredConverter.FromUnstructured(m, podTemplate); err != nil {
+		return nil, errors.Wrap(err, "convert pod template")
 	}
 
-	for _, c := range pod.Spec.Containers {
-		containerNames = append(containerNames, c.Name)
+	return component.NewWorkloadLogs(object.GetNamespace(), object.GetKind(), object.GetName(),
+		containerNames(podTemplate.Spec)...), nil
+}
+
+func containerNames(spec corev1.PodSpec) []string {
+	names := []string{""}
+
+	for _, c := range spec.InitContainers {
+		names = append(names, c.Name)
 	}
 
-	for _, c := range pod.Spec.EphemeralContainers {
-		containerNames = append(containerNames, c.Name)
+	for _, c := range spec.Containers {
+		names = append(names, c.Name)
 	}
 
-	logsComponent := component.NewLogs(pod.Namespace, pod.Name, containerNames...)
+	for _, c := range spec.EphemeralContainers {
+		names = append(names, c.Name)
+	}
 
-	return logsComponent, nil
+	return names
 }
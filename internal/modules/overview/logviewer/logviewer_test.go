@@ -10,6 +10,7 @@ import (
 
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/runtime"
@@ -59,6 +60,26 @@ func Test_ToComponent(t *testing.T) {
 			},
 			expected: component.NewLogs("default", "pod", []string{"", "init", "one", "two"}...),
 		},
+		{
+			name: "deployment",
+			object: &appsv1.Deployment{
+				TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "Deployment"},
+				ObjectMeta: metav1.ObjectMeta{
+					Name:      "web",
+					Namespace: "default",
+				},
+				Spec: appsv1.DeploymentSpec{
+					Template: corev1.PodTemplateSpec{
+						Spec: corev1.PodSpec{
+							Containers: []corev1.Container{
+								{Name: "one"},
+							},
+						},
+					},
+				},
+			},
+			expected: component.NewWorkloadLogs("default", "Deployment", "web", []string{"", "one"}...),
+		},
 		{
 			name:   "nil",
 			object: nil,
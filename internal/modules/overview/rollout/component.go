@@ -0,0 +1,101 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rollout
+
+import (
+	"context"
+	"fmt"
+	"strconv"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	"k8s.io/apimachinery/pkg/runtime"
+	sigyaml "sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/octant/pkg/action"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+// ToComponent creates a history view for object. It lists the object's revisions
+// with a rollbackAction for each revision which is not the latest, and compares the
+// pod templates of any two revisions. Rollback actions are omitted if rollbackAction
+// is empty.
+func ToComponent(ctx context.Context, objectStore store.Store, object runtime.Object, rollbackAction string) (*component.FlexLayout, error) {
+	revisions, err := History(ctx, objectStore, object)
+	if err != nil {
+		return nil, err
+	}
+
+	accessor, err := meta.Accessor(object)
+	if err != nil {
+		return nil, fmt.Errorf("access object metadata: %w", err)
+	}
+	apiVersion, kind := object.GetObjectKind().GroupVersionKind().ToAPIVersionAndKind()
+
+	cols := component.NewTableCols("Revision", "Change Cause", "Source", "Age")
+	table := component.NewTable("Revisions", "There is no revision history", cols)
+
+	var versions []component.DiffVersion
+	for i, revision := range revisions {
+		number := strconv.FormatInt(revision.Number, 10)
+
+		row := component.TableRow{
+			"Revision":     component.NewText(number),
+			"Change Cause": component.NewText(revision.ChangeCause),
+			"Source":       component.NewText(fmt.Sprintf("%s %s", revision.SourceKind, revision.SourceName)),
+			"Age":          component.NewTimestamp(revision.CreationTimestamp.Time),
+		}
+
+		if i < len(revisions)-1 && rollbackAction != "" {
+			row.AddAction(component.GridAction{
+				Name:       "Rollback",
+				ActionPath: rollbackAction,
+				Payload: action.Payload{
+					"apiVersion": apiVersion,
+					"kind":       kind,
+					"namespace":  accessor.GetNamespace(),
+					"name":       accessor.GetName(),
+					"revision":   revision.Number,
+				},
+				Confirmation: &component.Confirmation{
+					Title: "Rollback",
+					Body:  fmt.Sprintf("Roll back %s %s to revision %d?", kind, accessor.GetName(), revision.Number),
+				},
+				Type: component.GridActionDanger,
+			})
+		}
+
+		table.Add(row)
+
+		template, err := sigyaml.Marshal(revision.Template)
+		if err != nil {
+			return nil, fmt.Errorf("marshal revision %d template: %w", revision.Number, err)
+		}
+		versions = append(versions, component.DiffVersion{
+			Name:  fmt.Sprintf("Revision %s", number),
+			Value: string(template),
+		})
+	}
+
+	// Show the latest revision first.
+	table.Reverse()
+
+	layout := component.NewFlexLayout("History")
+	layout.AddSections(component.FlexLayoutSection{
+		{Width: component.WidthFull, View: table},
+	})
+
+	if len(versions) > 0 {
+		diff := component.NewDiff(component.TitleFromString("Compare Revisions"), "", "")
+		diff.SetVersions(versions)
+
+		layout.AddSections(component.FlexLayoutSection{
+			{Width: component.WidthFull, View: diff},
+		})
+	}
+
+	return layout, nil
+}
@@ -0,0 +1,191 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rollout
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"sort"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+
+	"github.com/vmware-tanzu/octant/internal/gvk"
+	"github.com/vmware-tanzu/octant/internal/util/kubernetes"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+const (
+	// RevisionAnnotation is the annotation a deployment controller sets on replica sets
+	// to record their revision.
+	RevisionAnnotation = "deployment.kubernetes.io/revision"
+	// ChangeCauseAnnotation is the annotation which records the cause of a change.
+	ChangeCauseAnnotation = "kubernetes.io/change-cause"
+)
+
+// Revision is a revision of a workload's pod template.
+type Revision struct {
+	// Number is the revision number.
+	Number int64
+	// ChangeCause is the value of the change-cause annotation.
+	ChangeCause string
+	// CreationTimestamp is when the revision was created.
+	CreationTimestamp metav1.Time
+	// SourceKind and SourceName identify the ReplicaSet or ControllerRevision which
+	// stores the revision.
+	SourceKind string
+	SourceName string
+	// Template is the pod template of the revision.
+	Template corev1.PodTemplateSpec
+
+	// annotations are the annotations of the replica set storing a deployment revision.
+	annotations map[string]string
+	// patch is the patch stored by a controller revision.
+	patch []byte
+}
+
+// IsSupported returns true if object keeps a revision history.
+func IsSupported(object runtime.Object) bool {
+	switch object.(type) {
+	case *appsv1.Deployment, *appsv1.DaemonSet, *appsv1.StatefulSet:
+		return true
+	}
+	return false
+}
+
+// History returns the revisions of a deployment, daemon set or stateful set sorted by
+// revision number. Deployment revisions are stored in replica sets, while daemon set
+// and stateful set revisions are stored in controller revisions.
+func History(ctx context.Context, objectStore store.Store, object runtime.Object) ([]Revision, error) {
+	if objectStore == nil {
+		return nil, fmt.Errorf("object store is nil")
+	}
+
+	var revisions []Revision
+	var err error
+
+	switch t := object.(type) {
+	case *appsv1.Deployment:
+		revisions, err = deploymentHistory(ctx, objectStore, t)
+	case *appsv1.DaemonSet:
+		revisions, err = controllerRevisionHistory(ctx, objectStore, t)
+	case *appsv1.StatefulSet:
+		revisions, err = controllerRevisionHistory(ctx, objectStore, t)
+	default:
+		return nil, fmt.Errorf("%T does not have a revision history", object)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	sort.Slice(revisions, func(i, j int) bool {
+		return revisions[i].Number < revisions[j].Number
+	})
+
+	return revisions, nil
+}
+
+func deploymentHistory(ctx context.Context, objectStore store.Store, deployment *appsv1.Deployment) ([]Revision, error) {
+	list, err := listOwned(ctx, objectStore, gvk.AppReplicaSet, deployment)
+	if err != nil {
+		return nil, err
+	}
+
+	var revisions []Revision
+	for i := range list {
+		replicaSet := &appsv1.ReplicaSet{}
+		if err := kubernetes.FromUnstructured(&list[i], replicaSet); err != nil {
+			return nil, fmt.Errorf("convert replica set: %w", err)
+		}
+
+		number, err := strconv.ParseInt(replicaSet.Annotations[RevisionAnnotation], 10, 64)
+		if err != nil {
+			// The deployment controller has not assigned a revision yet.
+			continue
+		}
+
+		template := *replicaSet.Spec.Template.DeepCopy()
+		delete(template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+		revisions = append(revisions, Revision{
+			Number:            number,
+			ChangeCause:       replicaSet.Annotations[ChangeCauseAnnotation],
+			CreationTimestamp: replicaSet.CreationTimestamp,
+			SourceKind:        replicaSet.Kind,
+			SourceName:        replicaSet.Name,
+			Template:          template,
+			annotations:       replicaSet.Annotations,
+		})
+	}
+
+	return revisions, nil
+}
+
+// controllerRevisionData is the part of a controller revision's patch which contains
+// the pod template.
+type controllerRevisionData struct {
+	Spec struct {
+		Template corev1.PodTemplateSpec `json:"template"`
+	} `json:"spec"`
+}
+
+func controllerRevisionHistory(ctx context.Context, objectStore store.Store, owner metav1.Object) ([]Revision, error) {
+	list, err := listOwned(ctx, objectStore, gvk.ControllerRevision, owner)
+	if err != nil {
+		return nil, err
+	}
+
+	var revisions []Revision
+	for i := range list {
+		controllerRevision := &appsv1.ControllerRevision{}
+		if err := kubernetes.FromUnstructured(&list[i], controllerRevision); err != nil {
+			return nil, fmt.Errorf("convert controller revision: %w", err)
+		}
+
+		var data controllerRevisionData
+		if err := json.Unmarshal(controllerRevision.Data.Raw, &data); err != nil {
+			return nil, fmt.Errorf("decode controller revision %s: %w", controllerRevision.Name, err)
+		}
+
+		revisions = append(revisions, Revision{
+			Number:            controllerRevision.Revision,
+			ChangeCause:       controllerRevision.Annotations[ChangeCauseAnnotation],
+			CreationTimestamp: controllerRevision.CreationTimestamp,
+			SourceKind:        controllerRevision.Kind,
+			SourceName:        controllerRevision.Name,
+			Template:          data.Spec.Template,
+			patch:             controllerRevision.Data.Raw,
+		})
+	}
+
+	return revisions, nil
+}
+
+// listOwned lists the objects of a kind which are controlled by owner.
+func listOwned(ctx context.Context, objectStore store.Store, groupVersionKind schema.GroupVersionKind, owner metav1.Object) ([]unstructured.Unstructured, error) {
+	key := store.KeyFromGroupVersionKind(groupVersionKind)
+	key.Namespace = owner.GetNamespace()
+
+	list, _, err := objectStore.List(ctx, key)
+	if err != nil {
+		return nil, fmt.Errorf("list %s: %w", groupVersionKind.Kind, err)
+	}
+
+	var owned []unstructured.Unstructured
+	for i := range list.Items {
+		if metav1.IsControlledBy(&list.Items[i], owner) {
+			owned = append(owned, list.Items[i])
+		}
+	}
+
+	return owned, nil
+}
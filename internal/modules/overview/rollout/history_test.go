@@ -0,0 +1,182 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rollout
+
+import (
+	"context"
+	"encoding/json"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware-tanzu/octant/internal/gvk"
+	"github.com/vmware-tanzu/octant/internal/testutil"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	storeFake "github.com/vmware-tanzu/octant/pkg/store/fake"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+func podTemplate(image string) corev1.PodTemplateSpec {
+	return corev1.PodTemplateSpec{
+		ObjectMeta: metav1.ObjectMeta{Labels: map[string]string{"app": "app"}},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: "app", Image: image}},
+		},
+	}
+}
+
+func createReplicaSet(t *testing.T, deployment *appsv1.Deployment, name, revision, image string) unstructured.Unstructured {
+	replicaSet := testutil.CreateAppReplicaSet(name)
+	replicaSet.OwnerReferences = testutil.ToOwnerReferences(t, deployment)
+	replicaSet.Annotations = map[string]string{
+		RevisionAnnotation:    revision,
+		ChangeCauseAnnotation: "set image " + image,
+	}
+	replicaSet.Spec.Template = podTemplate(image)
+	replicaSet.Spec.Template.Labels[appsv1.DefaultDeploymentUniqueLabelKey] = name
+	return *testutil.ToUnstructured(t, replicaSet)
+}
+
+func createControllerRevision(t *testing.T, owner metav1.Object, name string, revision int64, image string) unstructured.Unstructured {
+	var data controllerRevisionData
+	data.Spec.Template = podTemplate(image)
+	raw, err := json.Marshal(&data)
+	require.NoError(t, err)
+
+	controllerRevision := &appsv1.ControllerRevision{
+		TypeMeta: metav1.TypeMeta{APIVersion: "apps/v1", Kind: "ControllerRevision"},
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      name,
+			Namespace: owner.GetNamespace(),
+			OwnerReferences: []metav1.OwnerReference{
+				*metav1.NewControllerRef(owner, gvk.DaemonSet),
+			},
+		},
+		Data:     runtime.RawExtension{Raw: raw},
+		Revision: revision,
+	}
+	return *testutil.ToUnstructured(t, controllerRevision)
+}
+
+func listKey(groupVersionKind string) store.Key {
+	key := store.Key{Namespace: "namespace", APIVersion: "apps/v1", Kind: groupVersionKind}
+	return key
+}
+
+func TestHistory_deployment(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	deployment := testutil.CreateDeployment("deployment")
+	other := testutil.CreateDeployment("other")
+
+	objectStore := storeFake.NewMockStore(controller)
+	objectStore.EXPECT().
+		List(gomock.Any(), listKey("ReplicaSet")).
+		Return(&unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+			createReplicaSet(t, deployment, "rs-10", "10", "app:10"),
+			createReplicaSet(t, deployment, "rs-9", "9", "app:9"),
+			createReplicaSet(t, other, "other", "11", "app:11"),
+		}}, false, nil)
+
+	got, err := History(context.Background(), objectStore, deployment)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	assert.Equal(t, int64(9), got[0].Number)
+	assert.Equal(t, int64(10), got[1].Number)
+	assert.Equal(t, "set image app:10", got[1].ChangeCause)
+	assert.Equal(t, "ReplicaSet", got[1].SourceKind)
+	assert.Equal(t, "rs-10", got[1].SourceName)
+	assert.Equal(t, podTemplate("app:10"), got[1].Template)
+}
+
+func TestHistory_daemonSet(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	daemonSet := testutil.CreateDaemonSet("daemonset")
+
+	objectStore := storeFake.NewMockStore(controller)
+	objectStore.EXPECT().
+		List(gomock.Any(), listKey("ControllerRevision")).
+		Return(&unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+			createControllerRevision(t, daemonSet, "cr-2", 2, "app:2"),
+			createControllerRevision(t, daemonSet, "cr-1", 1, "app:1"),
+		}}, false, nil)
+
+	got, err := History(context.Background(), objectStore, daemonSet)
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	assert.Equal(t, int64(1), got[0].Number)
+	assert.Equal(t, "cr-1", got[0].SourceName)
+	assert.Equal(t, podTemplate("app:1"), got[0].Template)
+	assert.NotEmpty(t, got[0].patch)
+}
+
+func TestHistory_unsupported(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	objectStore := storeFake.NewMockStore(controller)
+
+	_, err := History(context.Background(), objectStore, testutil.CreatePod("pod"))
+	require.Error(t, err)
+	assert.False(t, IsSupported(testutil.CreatePod("pod")))
+	assert.True(t, IsSupported(testutil.CreateStatefulSet("sts")))
+}
+
+func TestToComponent(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	deployment := testutil.CreateDeployment("deployment")
+
+	objectStore := storeFake.NewMockStore(controller)
+	objectStore.EXPECT().
+		List(gomock.Any(), listKey("ReplicaSet")).
+		Return(&unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+			createReplicaSet(t, deployment, "rs-1", "1", "app:1"),
+			createReplicaSet(t, deployment, "rs-2", "2", "app:2"),
+		}}, false, nil).
+		Times(2)
+
+	got, err := ToComponent(context.Background(), objectStore, deployment, "action.octant.dev/rollback")
+	require.NoError(t, err)
+	require.Len(t, got.Config.Sections, 2)
+
+	table, ok := got.Config.Sections[0][0].View.(*component.Table)
+	require.True(t, ok)
+	rows := table.Rows()
+	require.Len(t, rows, 2)
+	assert.Equal(t, component.NewText("2"), rows[0]["Revision"])
+	assert.Nil(t, rows[0][component.GridActionKey])
+
+	actions, ok := rows[1][component.GridActionKey].(*component.GridActions)
+	require.True(t, ok)
+	require.Len(t, actions.Config.Actions, 1)
+	assert.Equal(t, "action.octant.dev/rollback", actions.Config.Actions[0].ActionPath)
+	assert.Equal(t, int64(1), actions.Config.Actions[0].Payload["revision"])
+
+	diff, ok := got.Config.Sections[1][0].View.(*component.Diff)
+	require.True(t, ok)
+	require.Len(t, diff.Config.Versions, 2)
+	assert.Contains(t, diff.Config.Original, "app:1")
+	assert.Contains(t, diff.Config.Modified, "app:2")
+
+	readOnly, err := ToComponent(context.Background(), objectStore, deployment, "")
+	require.NoError(t, err)
+	readOnlyTable := readOnly.Config.Sections[0][0].View.(*component.Table)
+	assert.Nil(t, readOnlyTable.Rows()[1][component.GridActionKey])
+}
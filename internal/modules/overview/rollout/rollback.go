@@ -0,0 +1,154 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rollout
+
+import (
+	"context"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/kubernetes"
+
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+// ErrCurrentRevision is returned by Rollback if the object is already at the revision.
+var ErrCurrentRevision = errors.New("object is already at the revision")
+
+// deploymentAnnotationsToSkip are annotations which are not copied from a replica set
+// to its deployment on rollback. kubectl skips the same annotations.
+var deploymentAnnotationsToSkip = map[string]bool{
+	corev1.LastAppliedConfigAnnotation:          true,
+	RevisionAnnotation:                          true,
+	"deployment.kubernetes.io/revision-history": true,
+	"deployment.kubernetes.io/desired-replicas": true,
+	"deployment.kubernetes.io/max-replicas":     true,
+	appsv1.DeprecatedRollbackTo:                 true,
+}
+
+// Rollback rolls a deployment, daemon set or stateful set back to a revision, like
+// `kubectl rollout undo --to-revision`. If toRevision is 0, the object is rolled back
+// to the revision before the latest one. It returns the revision the object was
+// rolled back to. ErrCurrentRevision is returned with the revision if the object is
+// already at it.
+func Rollback(ctx context.Context, client kubernetes.Interface, objectStore store.Store, object runtime.Object, toRevision int64) (int64, error) {
+	revisions, err := History(ctx, objectStore, object)
+	if err != nil {
+		return 0, err
+	}
+
+	revision, err := findRevision(revisions, toRevision)
+	if err != nil {
+		return 0, err
+	}
+
+	if isCurrentRevision(object, revisions, revision) {
+		return revision.Number, ErrCurrentRevision
+	}
+
+	switch t := object.(type) {
+	case *appsv1.Deployment:
+		err = rollbackDeployment(ctx, client, t, revision)
+	case *appsv1.DaemonSet:
+		_, err = client.AppsV1().DaemonSets(t.Namespace).Patch(ctx, t.Name,
+			types.StrategicMergePatchType, revision.patch, metav1.PatchOptions{})
+	case *appsv1.StatefulSet:
+		_, err = client.AppsV1().StatefulSets(t.Namespace).Patch(ctx, t.Name,
+			types.StrategicMergePatchType, revision.patch, metav1.PatchOptions{})
+	}
+	if err != nil {
+		return 0, err
+	}
+
+	return revision.Number, nil
+}
+
+// isCurrentRevision returns true if revision is the revision of the object's pod
+// template. Revisions are compared by number or name rather than by template, since
+// the API server defaults fields in the object's template which revisions may not have.
+func isCurrentRevision(object runtime.Object, revisions []Revision, revision Revision) bool {
+	switch t := object.(type) {
+	case *appsv1.Deployment:
+		// The deployment controller copies the revision of the newest replica set to
+		// the deployment.
+		if number, err := strconv.ParseInt(t.Annotations[RevisionAnnotation], 10, 64); err == nil {
+			return revision.Number == number
+		}
+	case *appsv1.StatefulSet:
+		// The update revision is the revision of the template, even while pods are
+		// still being rolled out from the current revision.
+		if t.Status.UpdateRevision != "" {
+			return revision.SourceName == t.Status.UpdateRevision
+		}
+	}
+
+	// Controllers renumber the revision matching the template so it is the latest one.
+	return revision.Number == revisions[len(revisions)-1].Number
+}
+
+// findRevision finds a revision by number. If number is 0, the revision before the
+// latest revision is returned.
+func findRevision(revisions []Revision, number int64) (Revision, error) {
+	if len(revisions) == 0 {
+		return Revision{}, errors.New("no rollout history found")
+	}
+
+	if number == 0 {
+		if len(revisions) < 2 {
+			return Revision{}, errors.New("no previous revision to roll back to")
+		}
+		return revisions[len(revisions)-2], nil
+	}
+
+	for _, revision := range revisions {
+		if revision.Number == number {
+			return revision, nil
+		}
+	}
+
+	return Revision{}, fmt.Errorf("unable to find revision %d", number)
+}
+
+type jsonPatchOperation struct {
+	Op    string      `json:"op"`
+	Path  string      `json:"path"`
+	Value interface{} `json:"value"`
+}
+
+func rollbackDeployment(ctx context.Context, client kubernetes.Interface, deployment *appsv1.Deployment, revision Revision) error {
+	if deployment.Spec.Paused {
+		return fmt.Errorf("deployment %s is paused; resume it before rolling back", deployment.Name)
+	}
+
+	annotations := map[string]string{}
+	for k, v := range deployment.Annotations {
+		annotations[k] = v
+	}
+	for k, v := range revision.annotations {
+		if !deploymentAnnotationsToSkip[k] {
+			annotations[k] = v
+		}
+	}
+
+	patch, err := json.Marshal([]jsonPatchOperation{
+		{Op: "replace", Path: "/spec/template", Value: revision.Template},
+		{Op: "replace", Path: "/metadata/annotations", Value: annotations},
+	})
+	if err != nil {
+		return fmt.Errorf("create rollback patch: %w", err)
+	}
+
+	_, err = client.AppsV1().Deployments(deployment.Namespace).Patch(ctx, deployment.Name,
+		types.JSONPatchType, patch, metav1.PatchOptions{})
+	return err
+}
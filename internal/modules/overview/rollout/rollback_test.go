@@ -0,0 +1,236 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rollout
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+
+	"github.com/vmware-tanzu/octant/internal/testutil"
+	storeFake "github.com/vmware-tanzu/octant/pkg/store/fake"
+)
+
+func TestRollback_deployment(t *testing.T) {
+	cases := []struct {
+		name       string
+		toRevision int64
+		paused     bool
+		expected   int64
+		image      string
+		wantErr    error
+		isErr      bool
+	}{
+		{
+			name:     "previous revision",
+			expected: 2,
+			image:    "app:2",
+		},
+		{
+			name:       "to revision",
+			toRevision: 1,
+			expected:   1,
+			image:      "app:1",
+		},
+		{
+			name:       "current revision",
+			toRevision: 3,
+			expected:   3,
+			image:      "app:3",
+			wantErr:    ErrCurrentRevision,
+		},
+		{
+			name:       "missing revision",
+			toRevision: 4,
+			image:      "app:3",
+			isErr:      true,
+		},
+		{
+			name:   "paused",
+			paused: true,
+			image:  "app:3",
+			isErr:  true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			deployment := testutil.CreateDeployment("deployment")
+			deployment.Annotations = map[string]string{
+				RevisionAnnotation: "3",
+				"owner":            "team",
+			}
+			deployment.Spec.Paused = tc.paused
+			deployment.Spec.Template = podTemplate("app:3")
+			// The API server defaults fields in the deployment's template which the
+			// replica set's template doesn't have.
+			deployment.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyAlways
+
+			objectStore := storeFake.NewMockStore(controller)
+			objectStore.EXPECT().
+				List(gomock.Any(), listKey("ReplicaSet")).
+				Return(&unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+					createReplicaSet(t, deployment, "rs-1", "1", "app:1"),
+					createReplicaSet(t, deployment, "rs-2", "2", "app:2"),
+					createReplicaSet(t, deployment, "rs-3", "3", "app:3"),
+				}}, false, nil)
+
+			client := kubefake.NewSimpleClientset(deployment)
+			ctx := context.Background()
+
+			got, err := Rollback(ctx, client, objectStore, deployment, tc.toRevision)
+			switch {
+			case tc.wantErr != nil:
+				require.ErrorIs(t, err, tc.wantErr)
+			case tc.isErr:
+				require.Error(t, err)
+			default:
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, got)
+
+			updated, err := client.AppsV1().Deployments("namespace").Get(ctx, "deployment", metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.image, updated.Spec.Template.Spec.Containers[0].Image)
+			assert.NotContains(t, updated.Spec.Template.Labels, appsv1.DefaultDeploymentUniqueLabelKey)
+
+			if tc.image != "app:3" {
+				assert.Equal(t, map[string]string{
+					RevisionAnnotation:    "3",
+					"owner":               "team",
+					ChangeCauseAnnotation: "set image " + tc.image,
+				}, updated.Annotations)
+			}
+		})
+	}
+}
+
+func TestRollback_daemonSet(t *testing.T) {
+	cases := []struct {
+		name       string
+		toRevision int64
+		expected   int64
+		image      string
+		wantErr    error
+	}{
+		{
+			name:     "previous revision",
+			expected: 1,
+			image:    "app:1",
+		},
+		{
+			name:       "current revision",
+			toRevision: 2,
+			expected:   2,
+			image:      "app:2",
+			wantErr:    ErrCurrentRevision,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			daemonSet := testutil.CreateDaemonSet("daemonset")
+			daemonSet.Spec.Template = podTemplate("app:2")
+			daemonSet.Spec.Template.Spec.RestartPolicy = corev1.RestartPolicyAlways
+
+			objectStore := storeFake.NewMockStore(controller)
+			objectStore.EXPECT().
+				List(gomock.Any(), listKey("ControllerRevision")).
+				Return(&unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+					createControllerRevision(t, daemonSet, "cr-1", 1, "app:1"),
+					createControllerRevision(t, daemonSet, "cr-2", 2, "app:2"),
+				}}, false, nil)
+
+			client := kubefake.NewSimpleClientset(daemonSet)
+			ctx := context.Background()
+
+			got, err := Rollback(ctx, client, objectStore, daemonSet, tc.toRevision)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, got)
+
+			updated, err := client.AppsV1().DaemonSets("namespace").Get(ctx, "daemonset", metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.image, updated.Spec.Template.Spec.Containers[0].Image)
+		})
+	}
+}
+
+func TestRollback_statefulSet(t *testing.T) {
+	cases := []struct {
+		name       string
+		toRevision int64
+		expected   int64
+		image      string
+		wantErr    error
+	}{
+		{
+			name:       "current revision while rolling out",
+			toRevision: 1,
+			expected:   1,
+			image:      "app:1",
+		},
+		{
+			name:       "update revision",
+			toRevision: 2,
+			expected:   2,
+			image:      "app:2",
+			wantErr:    ErrCurrentRevision,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			statefulSet := testutil.CreateStatefulSet("statefulset")
+			statefulSet.Spec.Template = podTemplate("app:2")
+			statefulSet.Status.CurrentRevision = "cr-1"
+			statefulSet.Status.UpdateRevision = "cr-2"
+
+			objectStore := storeFake.NewMockStore(controller)
+			objectStore.EXPECT().
+				List(gomock.Any(), listKey("ControllerRevision")).
+				Return(&unstructured.UnstructuredList{Items: []unstructured.Unstructured{
+					createControllerRevision(t, statefulSet, "cr-1", 1, "app:1"),
+					createControllerRevision(t, statefulSet, "cr-2", 2, "app:2"),
+				}}, false, nil)
+
+			client := kubefake.NewSimpleClientset(statefulSet)
+			ctx := context.Background()
+
+			got, err := Rollback(ctx, client, objectStore, statefulSet, tc.toRevision)
+			if tc.wantErr != nil {
+				require.ErrorIs(t, err, tc.wantErr)
+			} else {
+				require.NoError(t, err)
+			}
+			assert.Equal(t, tc.expected, got)
+
+			updated, err := client.AppsV1().StatefulSets("namespace").Get(ctx, "statefulset", metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.image, updated.Spec.Template.Spec.Containers[0].Image)
+		})
+	}
+}
@@ -0,0 +1,41 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package terminalviewer
+
+import (
+	"fmt"
+
+	"github.com/vmware-tanzu/octant/internal/terminal"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+// RecordingsToComponent lists the terminal recordings for a pod. Each recording can be
+// played back from the list.
+func RecordingsToComponent(dir, namespace, pod string) (*component.Table, error) {
+	recordings, err := terminal.ListRecordings(dir, namespace, pod)
+	if err != nil {
+		return nil, fmt.Errorf("list terminal recordings: %w", err)
+	}
+
+	cols := component.NewTableCols("Container", "Command", "Started", "Duration", "Replay")
+	table := component.NewTable("Terminal Recordings", "There are no terminal recordings for this pod", cols)
+
+	for _, recording := range recordings {
+		replay := component.NewTerminalReplay(recording.Name,
+			terminal.RecordingURLPath(namespace, pod, recording.Name))
+		replay.SetSession(recording.Container, recording.Command)
+
+		table.Add(component.TableRow{
+			"Container": component.NewText(recording.Container),
+			"Command":   component.NewText(recording.Command),
+			"Started":   component.NewTimestamp(recording.StartedAt),
+			"Duration":  component.NewText(recording.Duration.String()),
+			"Replay":    replay,
+		})
+	}
+
+	return table, nil
+}
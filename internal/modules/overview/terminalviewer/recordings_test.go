@@ -0,0 +1,63 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package terminalviewer
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+func TestRecordingsToComponent(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recordings")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	podDir := filepath.Join(dir, "default", "pod")
+	require.NoError(t, os.MkdirAll(podDir, 0700))
+
+	started := time.Unix(1600000000, 0)
+	name := "app-1600000000000000000.cast"
+	path := filepath.Join(podDir, name)
+	data := `{"version":2,"width":80,"height":24,"timestamp":1600000000,"command":"bash"}` + "\n" + `[0.5,"o","$ "]` + "\n"
+	require.NoError(t, ioutil.WriteFile(path, []byte(data), 0600))
+	require.NoError(t, os.Chtimes(path, started.Add(time.Minute), started.Add(time.Minute)))
+
+	got, err := RecordingsToComponent(dir, "default", "pod")
+	require.NoError(t, err)
+
+	replay := component.NewTerminalReplay(name, "/terminal/recordings/namespace/default/pod/pod/"+name)
+	replay.SetSession("app", "bash")
+
+	cols := component.NewTableCols("Container", "Command", "Started", "Duration", "Replay")
+	expected := component.NewTable("Terminal Recordings", "There are no terminal recordings for this pod", cols)
+	expected.Add(component.TableRow{
+		"Container": component.NewText("app"),
+		"Command":   component.NewText("bash"),
+		"Started":   component.NewTimestamp(started),
+		"Duration":  component.NewText("1m0s"),
+		"Replay":    replay,
+	})
+
+	assert.Equal(t, expected, got)
+}
+
+func TestRecordingsToComponent_no_recordings(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recordings")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	got, err := RecordingsToComponent(dir, "default", "pod")
+	require.NoError(t, err)
+	assert.True(t, got.IsEmpty())
+}
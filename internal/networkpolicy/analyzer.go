@@ -0,0 +1,414 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package networkpolicy evaluates network policies against pods and namespaces
+// to find the traffic they allow. It follows the network policy API: a pod is
+// isolated for ingress or egress once a policy selects it for that direction,
+// and traffic between two pods is allowed if the source's egress and the
+// destination's ingress both allow it.
+//
+// IP blocks are assumed to be addresses outside the cluster, so they never match
+// pods.
+package networkpolicy
+
+import (
+	"context"
+	"fmt"
+	"net"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/vmware-tanzu/octant/internal/util/kubernetes"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+// Endpoint is the source or destination of traffic. It is a pod, or an address
+// outside the cluster.
+type Endpoint struct {
+	Pod *corev1.Pod
+	// IP is an address outside the cluster. It is empty for any address outside the cluster.
+	IP string
+}
+
+// PodEndpoint creates an endpoint for a pod.
+func PodEndpoint(pod *corev1.Pod) Endpoint {
+	return Endpoint{Pod: pod}
+}
+
+// ExternalEndpoint creates an endpoint for an address outside the cluster. An
+// empty address is any address outside the cluster.
+func ExternalEndpoint(ip string) Endpoint {
+	return Endpoint{IP: ip}
+}
+
+// String returns the pod's namespace and name, or the address outside the cluster.
+func (e Endpoint) String() string {
+	switch {
+	case e.Pod != nil:
+		return fmt.Sprintf("%s/%s", e.Pod.Namespace, e.Pod.Name)
+	case e.IP != "":
+		return e.IP
+	default:
+		return "outside the cluster"
+	}
+}
+
+// Verdict is the result of checking whether traffic is allowed.
+type Verdict struct {
+	Allowed bool
+	// Reason explains the verdict.
+	Reason string
+	// Policies are the policies isolating the source for egress and the
+	// destination for ingress.
+	Policies []string
+}
+
+// Analyzer evaluates network policies.
+type Analyzer struct {
+	policies   map[string][]networkingv1.NetworkPolicy
+	namespaces map[string]labels.Set
+	pods       []corev1.Pod
+}
+
+// NewAnalyzer creates an instance of Analyzer.
+func NewAnalyzer(policies []networkingv1.NetworkPolicy, namespaces []corev1.Namespace, pods []corev1.Pod) *Analyzer {
+	a := &Analyzer{
+		policies:   map[string][]networkingv1.NetworkPolicy{},
+		namespaces: map[string]labels.Set{},
+		pods:       pods,
+	}
+
+	for _, policy := range policies {
+		a.policies[policy.Namespace] = append(a.policies[policy.Namespace], policy)
+	}
+	for _, namespace := range namespaces {
+		a.namespaces[namespace.Name] = namespace.Labels
+	}
+
+	sort.SliceStable(a.pods, func(i, j int) bool {
+		if a.pods[i].Namespace != a.pods[j].Namespace {
+			return a.pods[i].Namespace < a.pods[j].Namespace
+		}
+		return a.pods[i].Name < a.pods[j].Name
+	})
+
+	return a
+}
+
+// Load creates an analyzer from the network policies, namespaces and pods in the
+// object store.
+func Load(ctx context.Context, objectStore store.Store) (*Analyzer, error) {
+	var policies []networkingv1.NetworkPolicy
+	if err := list(ctx, objectStore, store.Key{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy"}, func(object *unstructured.Unstructured) error {
+		var policy networkingv1.NetworkPolicy
+		if err := kubernetes.FromUnstructured(object, &policy); err != nil {
+			return err
+		}
+		policies = append(policies, policy)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var namespaces []corev1.Namespace
+	if err := list(ctx, objectStore, store.Key{APIVersion: "v1", Kind: "Namespace"}, func(object *unstructured.Unstructured) error {
+		var namespace corev1.Namespace
+		if err := kubernetes.FromUnstructured(object, &namespace); err != nil {
+			return err
+		}
+		namespaces = append(namespaces, namespace)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var pods []corev1.Pod
+	if err := list(ctx, objectStore, store.Key{APIVersion: "v1", Kind: "Pod"}, func(object *unstructured.Unstructured) error {
+		var pod corev1.Pod
+		if err := kubernetes.FromUnstructured(object, &pod); err != nil {
+			return err
+		}
+		pods = append(pods, pod)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return NewAnalyzer(policies, namespaces, pods), nil
+}
+
+// list lists objects in all namespaces.
+func list(ctx context.Context, objectStore store.Store, key store.Key, fn func(object *unstructured.Unstructured) error) error {
+	objects, _, err := objectStore.List(ctx, key)
+	if err != nil {
+		return fmt.Errorf("list %s: %w", key.Kind, err)
+	}
+
+	for i := range objects.Items {
+		if err := fn(&objects.Items[i]); err != nil {
+			return fmt.Errorf("convert %s: %w", key.Kind, err)
+		}
+	}
+
+	return nil
+}
+
+// Pods returns the running and pending pods in a namespace.
+func (a *Analyzer) Pods(namespace string) []corev1.Pod {
+	var pods []corev1.Pod
+	for _, pod := range a.pods {
+		if pod.Namespace != namespace ||
+			pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed {
+			continue
+		}
+		pods = append(pods, pod)
+	}
+	return pods
+}
+
+// ParseEndpoint parses an endpoint. It is a pod name in namespace, a namespace and
+// pod name separated by a slash, or an IP address outside the cluster. An empty
+// string is any address outside the cluster.
+func (a *Analyzer) ParseEndpoint(namespace, s string) (Endpoint, error) {
+	s = strings.TrimSpace(s)
+	switch {
+	case s == "":
+		return ExternalEndpoint(""), nil
+	case net.ParseIP(s) != nil:
+		return ExternalEndpoint(s), nil
+	}
+
+	name := s
+	if i := strings.Index(s, "/"); i >= 0 {
+		namespace, name = s[:i], s[i+1:]
+	}
+
+	for i := range a.pods {
+		pod := &a.pods[i]
+		if pod.Namespace == namespace && pod.Name == name {
+			return PodEndpoint(pod), nil
+		}
+	}
+
+	return Endpoint{}, fmt.Errorf("pod %s/%s was not found", namespace, name)
+}
+
+// Selects returns true if a policy selects a pod.
+func Selects(policy *networkingv1.NetworkPolicy, pod *corev1.Pod) bool {
+	return policy.Namespace == pod.Namespace && selectorMatches(&policy.Spec.PodSelector, pod.Labels)
+}
+
+// IsolatingPolicies returns the names of the policies which isolate a pod for
+// ingress or egress.
+func (a *Analyzer) IsolatingPolicies(pod *corev1.Pod, policyType networkingv1.PolicyType) []string {
+	var names []string
+	for _, policy := range a.isolating(pod, policyType) {
+		names = append(names, policy.Name)
+	}
+	return names
+}
+
+func (a *Analyzer) isolating(pod *corev1.Pod, policyType networkingv1.PolicyType) []networkingv1.NetworkPolicy {
+	var policies []networkingv1.NetworkPolicy
+	for i := range a.policies[pod.Namespace] {
+		policy := a.policies[pod.Namespace][i]
+		if hasPolicyType(&policy, policyType) && Selects(&policy, pod) {
+			policies = append(policies, policy)
+		}
+	}
+	return policies
+}
+
+// hasPolicyType returns true if a policy applies to ingress or egress. Policies
+// without policy types apply to ingress, and to egress if they have egress rules.
+func hasPolicyType(policy *networkingv1.NetworkPolicy, policyType networkingv1.PolicyType) bool {
+	if len(policy.Spec.PolicyTypes) == 0 {
+		return policyType == networkingv1.PolicyTypeIngress ||
+			(policyType == networkingv1.PolicyTypeEgress && len(policy.Spec.Egress) > 0)
+	}
+
+	for _, t := range policy.Spec.PolicyTypes {
+		if t == policyType {
+			return true
+		}
+	}
+	return false
+}
+
+// AllowedPorts returns the ports traffic from a source to a destination is allowed on.
+func (a *Analyzer) AllowedPorts(source, destination Endpoint) PortSet {
+	egress, _ := a.egress(source, destination)
+	ingress, _ := a.ingress(source, destination)
+	return egress.intersect(ingress)
+}
+
+// CanReach returns whether a source can send traffic to a destination on a port.
+// The protocol defaults to TCP.
+func (a *Analyzer) CanReach(source, destination Endpoint, port Port) Verdict {
+	if port.Protocol == "" {
+		port.Protocol = corev1.ProtocolTCP
+	}
+
+	egress, egressPolicies := a.egress(source, destination)
+	ingress, ingressPolicies := a.ingress(source, destination)
+
+	verdict := Verdict{
+		Policies: append(append([]string(nil), egressPolicies...), ingressPolicies...),
+	}
+
+	switch {
+	case !egress.Contains(port):
+		verdict.Reason = fmt.Sprintf("%s is isolated for egress by %s, which don't allow traffic to %s on %s",
+			source, strings.Join(egressPolicies, ", "), destination, port)
+	case !ingress.Contains(port):
+		verdict.Reason = fmt.Sprintf("%s is isolated for ingress by %s, which don't allow traffic from %s on %s",
+			destination, strings.Join(ingressPolicies, ", "), source, port)
+	default:
+		verdict.Allowed = true
+		verdict.Reason = fmt.Sprintf("%s; %s",
+			allowedReason("egress from", source, egressPolicies),
+			allowedReason("ingress to", destination, ingressPolicies))
+	}
+
+	return verdict
+}
+
+func allowedReason(direction string, endpoint Endpoint, policies []string) string {
+	switch {
+	case endpoint.Pod == nil:
+		return fmt.Sprintf("%s %s isn't restricted by network policies", direction, endpoint)
+	case len(policies) == 0:
+		return fmt.Sprintf("%s %s isn't isolated", direction, endpoint)
+	default:
+		return fmt.Sprintf("%s %s is allowed by %s", direction, endpoint, strings.Join(policies, ", "))
+	}
+}
+
+// egress returns the ports the source's egress policies allow traffic to a
+// destination on, and the names of the policies isolating the source.
+func (a *Analyzer) egress(source, destination Endpoint) (PortSet, []string) {
+	if source.Pod == nil {
+		return AllPorts(), nil
+	}
+
+	policies := a.isolating(source.Pod, networkingv1.PolicyTypeEgress)
+	if len(policies) == 0 {
+		return AllPorts(), nil
+	}
+
+	allowed := PortSet{}
+	var names []string
+	for _, policy := range policies {
+		names = append(names, policy.Name)
+		for _, rule := range policy.Spec.Egress {
+			if a.peersMatch(rule.To, policy.Namespace, destination) {
+				allowed.union(rulePorts(rule.Ports, destination.Pod))
+			}
+		}
+	}
+	return allowed, names
+}
+
+// ingress returns the ports the destination's ingress policies allow traffic from
+// a source on, and the names of the policies isolating the destination.
+func (a *Analyzer) ingress(source, destination Endpoint) (PortSet, []string) {
+	if destination.Pod == nil {
+		return AllPorts(), nil
+	}
+
+	policies := a.isolating(destination.Pod, networkingv1.PolicyTypeIngress)
+	if len(policies) == 0 {
+		return AllPorts(), nil
+	}
+
+	allowed := PortSet{}
+	var names []string
+	for _, policy := range policies {
+		names = append(names, policy.Name)
+		for _, rule := range policy.Spec.Ingress {
+			if a.peersMatch(rule.From, policy.Namespace, source) {
+				allowed.union(rulePorts(rule.Ports, destination.Pod))
+			}
+		}
+	}
+	return allowed, names
+}
+
+// peersMatch returns true if a rule's peers match an endpoint. A rule without
+// peers matches every endpoint.
+func (a *Analyzer) peersMatch(peers []networkingv1.NetworkPolicyPeer, policyNamespace string, endpoint Endpoint) bool {
+	if len(peers) == 0 {
+		return true
+	}
+
+	for _, peer := range peers {
+		if a.peerMatches(peer, policyNamespace, endpoint) {
+			return true
+		}
+	}
+	return false
+}
+
+func (a *Analyzer) peerMatches(peer networkingv1.NetworkPolicyPeer, policyNamespace string, endpoint Endpoint) bool {
+	if peer.IPBlock != nil {
+		return endpoint.Pod == nil && ipBlockMatches(peer.IPBlock, endpoint.IP)
+	}
+
+	pod := endpoint.Pod
+	if pod == nil {
+		return false
+	}
+
+	if peer.NamespaceSelector == nil {
+		if pod.Namespace != policyNamespace {
+			return false
+		}
+	} else if !selectorMatches(peer.NamespaceSelector, a.namespaces[pod.Namespace]) {
+		return false
+	}
+
+	return peer.PodSelector == nil || selectorMatches(peer.PodSelector, pod.Labels)
+}
+
+// ipBlockMatches returns true if an address is in an IP block. Every IP block
+// matches the empty address, which is any address outside the cluster.
+func ipBlockMatches(block *networkingv1.IPBlock, ip string) bool {
+	if ip == "" {
+		return true
+	}
+
+	address := net.ParseIP(ip)
+	if address == nil || !cidrContains(block.CIDR, address) {
+		return false
+	}
+
+	for _, except := range block.Except {
+		if cidrContains(except, address) {
+			return false
+		}
+	}
+	return true
+}
+
+func cidrContains(cidr string, ip net.IP) bool {
+	_, network, err := net.ParseCIDR(cidr)
+	return err == nil && network.Contains(ip)
+}
+
+// selectorMatches returns true if a label selector matches labels. Invalid
+// selectors don't match anything.
+func selectorMatches(selector *metav1.LabelSelector, set labels.Set) bool {
+	s, err := metav1.LabelSelectorAsSelector(selector)
+	if err != nil {
+		return false
+	}
+	return s.Matches(set)
+}
@@ -0,0 +1,326 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package networkpolicy
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+
+	"github.com/vmware-tanzu/octant/pkg/store"
+	storeFake "github.com/vmware-tanzu/octant/pkg/store/fake"
+)
+
+func createPod(namespace, name, app string, ports ...corev1.ContainerPort) corev1.Pod {
+	isController := true
+	return corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: namespace,
+			Name:      name,
+			Labels:    map[string]string{"app": app},
+			OwnerReferences: []metav1.OwnerReference{
+				{Kind: "ReplicaSet", Name: app, UID: types.UID(namespace + "/" + app), Controller: &isController},
+			},
+		},
+		Spec: corev1.PodSpec{
+			Containers: []corev1.Container{{Name: app, Ports: ports}},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+}
+
+func appSelector(app string) *metav1.LabelSelector {
+	return &metav1.LabelSelector{MatchLabels: map[string]string{"app": app}}
+}
+
+func tcp(port int) networkingv1.NetworkPolicyPort {
+	p := intstr.FromInt(port)
+	return networkingv1.NetworkPolicyPort{Port: &p}
+}
+
+func named(name string) networkingv1.NetworkPolicyPort {
+	p := intstr.FromString(name)
+	return networkingv1.NetworkPolicyPort{Port: &p}
+}
+
+// createAnalyzer creates an analyzer for a namespace where:
+//   - web is reachable on its http port by api pods in default and pods in monitoring
+//   - db only accepts TCP/5432 from api
+//   - api can only send traffic to db, and to 10.0.0.0/8 on TCP/443
+func createAnalyzer() (*Analyzer, map[string]*corev1.Pod) {
+	pods := []corev1.Pod{
+		createPod("default", "web-1", "web", corev1.ContainerPort{Name: "http", ContainerPort: 8080}),
+		createPod("default", "web-2", "web", corev1.ContainerPort{Name: "http", ContainerPort: 8080}),
+		createPod("default", "api-1", "api"),
+		createPod("default", "db-1", "db"),
+		createPod("monitoring", "prometheus-1", "prometheus"),
+	}
+
+	namespaces := []corev1.Namespace{
+		{ObjectMeta: metav1.ObjectMeta{Name: "default"}},
+		{ObjectMeta: metav1.ObjectMeta{Name: "monitoring", Labels: map[string]string{"team": "monitoring"}}},
+	}
+
+	policies := []networkingv1.NetworkPolicy{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: *appSelector("web"),
+				Ingress: []networkingv1.NetworkPolicyIngressRule{
+					{
+						From: []networkingv1.NetworkPolicyPeer{
+							{PodSelector: appSelector("api")},
+							{NamespaceSelector: &metav1.LabelSelector{MatchLabels: map[string]string{"team": "monitoring"}}},
+						},
+						Ports: []networkingv1.NetworkPolicyPort{named("http")},
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "db"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: *appSelector("db"),
+				Ingress: []networkingv1.NetworkPolicyIngressRule{
+					{
+						From:  []networkingv1.NetworkPolicyPeer{{PodSelector: appSelector("api")}},
+						Ports: []networkingv1.NetworkPolicyPort{tcp(5432)},
+					},
+				},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "api-egress"},
+			Spec: networkingv1.NetworkPolicySpec{
+				PodSelector: *appSelector("api"),
+				PolicyTypes: []networkingv1.PolicyType{networkingv1.PolicyTypeEgress},
+				Egress: []networkingv1.NetworkPolicyEgressRule{
+					{To: []networkingv1.NetworkPolicyPeer{{PodSelector: appSelector("db")}}},
+					{
+						To: []networkingv1.NetworkPolicyPeer{
+							{IPBlock: &networkingv1.IPBlock{CIDR: "10.0.0.0/8", Except: []string{"10.1.0.0/16"}}},
+						},
+						Ports: []networkingv1.NetworkPolicyPort{tcp(443)},
+					},
+				},
+			},
+		},
+	}
+
+	a := NewAnalyzer(policies, namespaces, pods)
+
+	byName := map[string]*corev1.Pod{}
+	for i := range a.pods {
+		byName[a.pods[i].Name] = &a.pods[i]
+	}
+
+	return a, byName
+}
+
+func TestAnalyzer_CanReach(t *testing.T) {
+	a, pods := createAnalyzer()
+
+	cases := []struct {
+		name        string
+		source      Endpoint
+		destination Endpoint
+		port        Port
+		allowed     bool
+		reason      string
+	}{
+		{
+			name:        "monitoring to web on named port",
+			source:      PodEndpoint(pods["prometheus-1"]),
+			destination: PodEndpoint(pods["web-1"]),
+			port:        Port{Port: 8080},
+			allowed:     true,
+			reason:      "egress from monitoring/prometheus-1 isn't isolated; ingress to default/web-1 is allowed by web",
+		},
+		{
+			name:        "monitoring to web on other port",
+			source:      PodEndpoint(pods["prometheus-1"]),
+			destination: PodEndpoint(pods["web-1"]),
+			port:        Port{Port: 9090},
+			reason:      "default/web-1 is isolated for ingress by web, which don't allow traffic from monitoring/prometheus-1 on TCP/9090",
+		},
+		{
+			name:        "api to web is blocked by egress",
+			source:      PodEndpoint(pods["api-1"]),
+			destination: PodEndpoint(pods["web-1"]),
+			port:        Port{Port: 8080},
+			reason:      "default/api-1 is isolated for egress by api-egress, which don't allow traffic to default/web-1 on TCP/8080",
+		},
+		{
+			name:        "api to db",
+			source:      PodEndpoint(pods["api-1"]),
+			destination: PodEndpoint(pods["db-1"]),
+			port:        Port{Protocol: corev1.ProtocolTCP, Port: 5432},
+			allowed:     true,
+			reason:      "egress from default/api-1 is allowed by api-egress; ingress to default/db-1 is allowed by db",
+		},
+		{
+			name:        "web to db",
+			source:      PodEndpoint(pods["web-1"]),
+			destination: PodEndpoint(pods["db-1"]),
+			port:        Port{Port: 5432},
+			reason:      "default/db-1 is isolated for ingress by db, which don't allow traffic from default/web-1 on TCP/5432",
+		},
+		{
+			name:        "api to ip block",
+			source:      PodEndpoint(pods["api-1"]),
+			destination: ExternalEndpoint("10.2.3.4"),
+			port:        Port{Port: 443},
+			allowed:     true,
+			reason:      "egress from default/api-1 is allowed by api-egress; ingress to 10.2.3.4 isn't restricted by network policies",
+		},
+		{
+			name:        "api to ip block exception",
+			source:      PodEndpoint(pods["api-1"]),
+			destination: ExternalEndpoint("10.1.3.4"),
+			port:        Port{Port: 443},
+			reason:      "default/api-1 is isolated for egress by api-egress, which don't allow traffic to 10.1.3.4 on TCP/443",
+		},
+		{
+			name:        "outside to web",
+			source:      ExternalEndpoint(""),
+			destination: PodEndpoint(pods["web-1"]),
+			port:        Port{Port: 8080},
+			reason:      "default/web-1 is isolated for ingress by web, which don't allow traffic from outside the cluster on TCP/8080",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := a.CanReach(tc.source, tc.destination, tc.port)
+			assert.Equal(t, tc.allowed, got.Allowed)
+			assert.Equal(t, tc.reason, got.Reason)
+		})
+	}
+}
+
+func TestAnalyzer_AllowedPorts(t *testing.T) {
+	a, pods := createAnalyzer()
+
+	got := a.AllowedPorts(PodEndpoint(pods["api-1"]), ExternalEndpoint(""))
+	assert.Equal(t, "TCP/443", got.String())
+
+	got = a.AllowedPorts(PodEndpoint(pods["web-1"]), PodEndpoint(pods["api-1"]))
+	assert.True(t, got.IsAll())
+
+	got = a.AllowedPorts(PodEndpoint(pods["web-1"]), PodEndpoint(pods["db-1"]))
+	assert.True(t, got.IsEmpty())
+}
+
+func TestAnalyzer_ParseEndpoint(t *testing.T) {
+	a, pods := createAnalyzer()
+
+	cases := []struct {
+		name      string
+		input     string
+		expected  Endpoint
+		wantError bool
+	}{
+		{name: "pod", input: "web-1", expected: PodEndpoint(pods["web-1"])},
+		{name: "pod in namespace", input: "monitoring/prometheus-1", expected: PodEndpoint(pods["prometheus-1"])},
+		{name: "address", input: "10.0.0.1", expected: ExternalEndpoint("10.0.0.1")},
+		{name: "outside the cluster", input: " ", expected: ExternalEndpoint("")},
+		{name: "missing pod", input: "prometheus-1", wantError: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got, err := a.ParseEndpoint("default", tc.input)
+			if tc.wantError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestParsePort(t *testing.T) {
+	cases := []struct {
+		input     string
+		expected  Port
+		wantError bool
+	}{
+		{input: "8080", expected: Port{Protocol: corev1.ProtocolTCP, Port: 8080}},
+		{input: "udp/53", expected: Port{Protocol: corev1.ProtocolUDP, Port: 53}},
+		{input: "TCP/0", wantError: true},
+		{input: "HTTP/80", wantError: true},
+		{input: "http", wantError: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.input, func(t *testing.T) {
+			got, err := ParsePort(tc.input)
+			if tc.wantError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestPortSet(t *testing.T) {
+	s := NewPortSet(Port{Protocol: corev1.ProtocolTCP, Port: 80}, Port{Protocol: corev1.ProtocolUDP, Port: 53})
+	s.add(Port{Protocol: corev1.ProtocolTCP})
+	assert.Equal(t, "TCP/*, UDP/53", s.String())
+
+	got := s.intersect(NewPortSet(Port{Protocol: corev1.ProtocolTCP, Port: 443}, Port{Protocol: corev1.ProtocolUDP, Port: 54}))
+	assert.Equal(t, "TCP/443", got.String())
+
+	assert.Equal(t, s, s.intersect(AllPorts()))
+}
+
+func TestLoad(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	toList := func(objects ...runtime.Object) *unstructured.UnstructuredList {
+		list := &unstructured.UnstructuredList{}
+		for _, object := range objects {
+			m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+			require.NoError(t, err)
+			list.Items = append(list.Items, unstructured.Unstructured{Object: m})
+		}
+		return list
+	}
+
+	pod := createPod("default", "web-1", "web")
+	policy := &networkingv1.NetworkPolicy{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "deny-all"},
+	}
+
+	o := storeFake.NewMockStore(controller)
+	o.EXPECT().List(gomock.Any(), store.Key{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy"}).Return(toList(policy), false, nil)
+	o.EXPECT().List(gomock.Any(), store.Key{APIVersion: "v1", Kind: "Namespace"}).Return(toList(), false, nil)
+	o.EXPECT().List(gomock.Any(), store.Key{APIVersion: "v1", Kind: "Pod"}).Return(toList(&pod), false, nil)
+
+	a, err := Load(context.Background(), o)
+	require.NoError(t, err)
+
+	pods := a.Pods("default")
+	require.Len(t, pods, 1)
+	assert.Equal(t, []string{"deny-all"}, a.IsolatingPolicies(&pods[0], networkingv1.PolicyTypeIngress))
+	assert.Empty(t, a.IsolatingPolicies(&pods[0], networkingv1.PolicyTypeEgress))
+}
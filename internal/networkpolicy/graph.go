@@ -0,0 +1,188 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package networkpolicy
+
+import (
+	"fmt"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+)
+
+// ExternalNodeID is the ID of the graph node for addresses outside the cluster.
+const ExternalNodeID = "external"
+
+// GraphNode is a group of pods with the same controller, or addresses outside
+// the cluster. Pods with the same controller share labels, so policies treat
+// them alike.
+type GraphNode struct {
+	ID   string
+	Name string
+	// Pods are the names of the pods in the group.
+	Pods            []string
+	IngressIsolated bool
+	EgressIsolated  bool
+	// Highlighted nodes are the subject of the graph, e.g. the pods a policy selects.
+	Highlighted bool
+
+	endpoint Endpoint
+}
+
+// GraphEdge is traffic allowed from one node to another.
+type GraphEdge struct {
+	From  string
+	To    string
+	Ports PortSet
+}
+
+// Graph is the reachability between the pods in a namespace, and between the pods
+// and addresses outside the cluster. Pods in other namespaces aren't included.
+type Graph struct {
+	Namespace string
+	Nodes     []GraphNode
+	Edges     []GraphEdge
+}
+
+// Graph creates the reachability graph for a namespace. Nodes containing pods
+// highlight returns true for are highlighted. highlight can be nil.
+func (a *Analyzer) Graph(namespace string, highlight func(pod *corev1.Pod) bool) Graph {
+	g := Graph{Namespace: namespace}
+
+	g.Nodes = append(g.Nodes, GraphNode{
+		ID:       ExternalNodeID,
+		Name:     "Outside the cluster",
+		endpoint: ExternalEndpoint(""),
+	})
+
+	index := map[string]int{}
+	pods := a.Pods(namespace)
+	for i := range pods {
+		pod := &pods[i]
+		key, name := podGroup(pod)
+
+		n, ok := index[key]
+		if !ok {
+			n = len(g.Nodes)
+			index[key] = n
+			g.Nodes = append(g.Nodes, GraphNode{
+				ID:              fmt.Sprintf("pods-%d", n),
+				Name:            name,
+				IngressIsolated: len(a.isolating(pod, networkingv1.PolicyTypeIngress)) > 0,
+				EgressIsolated:  len(a.isolating(pod, networkingv1.PolicyTypeEgress)) > 0,
+				endpoint:        PodEndpoint(pod),
+			})
+		}
+
+		node := &g.Nodes[n]
+		node.Pods = append(node.Pods, pod.Name)
+		if highlight != nil && highlight(pod) {
+			node.Highlighted = true
+		}
+	}
+
+	for _, from := range g.Nodes {
+		for _, to := range g.Nodes {
+			if from.ID == to.ID || (from.endpoint.Pod == nil && to.endpoint.Pod == nil) {
+				continue
+			}
+
+			ports := a.AllowedPorts(from.endpoint, to.endpoint)
+			if ports.IsEmpty() {
+				continue
+			}
+			g.Edges = append(g.Edges, GraphEdge{From: from.ID, To: to.ID, Ports: ports})
+		}
+	}
+
+	return g
+}
+
+// podGroup returns the key and name of a pod's group. Pods are grouped by their
+// controller.
+func podGroup(pod *corev1.Pod) (string, string) {
+	if ref := metav1.GetControllerOf(pod); ref != nil {
+		return string(ref.UID), fmt.Sprintf("%s %s", ref.Kind, ref.Name)
+	}
+	return "pod/" + pod.Name, fmt.Sprintf("Pod %s", pod.Name)
+}
+
+// Node returns a node by ID.
+func (g Graph) Node(id string) (GraphNode, bool) {
+	for _, node := range g.Nodes {
+		if node.ID == id {
+			return node, true
+		}
+	}
+	return GraphNode{}, false
+}
+
+// PodNode returns the node containing a pod.
+func (g Graph) PodNode(name string) (GraphNode, bool) {
+	for _, node := range g.Nodes {
+		for _, pod := range node.Pods {
+			if pod == name {
+				return node, true
+			}
+		}
+	}
+	return GraphNode{}, false
+}
+
+// DOT returns the graph in the graphviz DOT language.
+func (g Graph) DOT() string {
+	var sb strings.Builder
+
+	sb.WriteString("digraph {\n")
+	sb.WriteString("  rankdir=LR;\n")
+	sb.WriteString("  node [shape=box, style=rounded];\n")
+
+	for _, node := range g.Nodes {
+		lines := []string{node.Name}
+		if len(node.Pods) > 1 {
+			lines = append(lines, fmt.Sprintf("%d pods", len(node.Pods)))
+		}
+
+		var isolated []string
+		if node.IngressIsolated {
+			isolated = append(isolated, "ingress")
+		}
+		if node.EgressIsolated {
+			isolated = append(isolated, "egress")
+		}
+		if len(isolated) > 0 {
+			lines = append(lines, fmt.Sprintf("isolated: %s", strings.Join(isolated, ", ")))
+		}
+
+		var attrs []string
+		attrs = append(attrs, fmt.Sprintf("label=%s", dotQuote(strings.Join(lines, "\n"))))
+		switch {
+		case node.ID == ExternalNodeID:
+			attrs = append(attrs, "shape=ellipse")
+		case node.Highlighted:
+			attrs = append(attrs, `style="rounded,bold"`)
+		}
+
+		fmt.Fprintf(&sb, "  %s [%s];\n", dotQuote(node.ID), strings.Join(attrs, ", "))
+	}
+
+	for _, edge := range g.Edges {
+		fmt.Fprintf(&sb, "  %s -> %s [label=%s];\n", dotQuote(edge.From), dotQuote(edge.To), dotQuote(edge.Ports.String()))
+	}
+
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// dotQuote quotes a DOT identifier. New lines become line breaks in labels.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
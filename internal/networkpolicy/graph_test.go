@@ -0,0 +1,68 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package networkpolicy
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	corev1 "k8s.io/api/core/v1"
+)
+
+func TestAnalyzer_Graph(t *testing.T) {
+	a, _ := createAnalyzer()
+
+	g := a.Graph("default", func(pod *corev1.Pod) bool {
+		return pod.Labels["app"] == "db"
+	})
+
+	var names []string
+	for _, node := range g.Nodes {
+		names = append(names, node.Name)
+	}
+	assert.Equal(t, []string{"Outside the cluster", "ReplicaSet api", "ReplicaSet db", "ReplicaSet web"}, names)
+
+	web, _ := g.Node("pods-3")
+	assert.Equal(t, []string{"web-1", "web-2"}, web.Pods)
+	assert.True(t, web.IngressIsolated)
+	assert.False(t, web.EgressIsolated)
+
+	var edges []string
+	for _, edge := range g.Edges {
+		from, _ := g.Node(edge.From)
+		to, _ := g.Node(edge.To)
+		edges = append(edges, from.Name+" -> "+to.Name+": "+edge.Ports.String())
+	}
+
+	expected := []string{
+		"Outside the cluster -> ReplicaSet api: all ports",
+		"ReplicaSet api -> Outside the cluster: TCP/443",
+		"ReplicaSet api -> ReplicaSet db: TCP/5432",
+		"ReplicaSet db -> Outside the cluster: all ports",
+		"ReplicaSet db -> ReplicaSet api: all ports",
+		"ReplicaSet web -> Outside the cluster: all ports",
+		"ReplicaSet web -> ReplicaSet api: all ports",
+	}
+	assert.Equal(t, expected, edges)
+
+	expectedDOT := `digraph {
+  rankdir=LR;
+  node [shape=box, style=rounded];
+  "external" [label="Outside the cluster", shape=ellipse];
+  "pods-1" [label="ReplicaSet api\nisolated: egress"];
+  "pods-2" [label="ReplicaSet db\nisolated: ingress", style="rounded,bold"];
+  "pods-3" [label="ReplicaSet web\n2 pods\nisolated: ingress"];
+  "external" -> "pods-1" [label="all ports"];
+  "pods-1" -> "external" [label="TCP/443"];
+  "pods-1" -> "pods-2" [label="TCP/5432"];
+  "pods-2" -> "external" [label="all ports"];
+  "pods-2" -> "pods-1" [label="all ports"];
+  "pods-3" -> "external" [label="all ports"];
+  "pods-3" -> "pods-1" [label="all ports"];
+}
+`
+	assert.Equal(t, expectedDOT, g.DOT())
+}
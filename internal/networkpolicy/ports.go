@@ -0,0 +1,229 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package networkpolicy
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/util/intstr"
+)
+
+// Port is a protocol and port number. A port number of 0 is every port.
+type Port struct {
+	Protocol corev1.Protocol
+	Port     int32
+}
+
+// String returns the port in the format protocol/port, e.g. TCP/8080.
+func (p Port) String() string {
+	if p.Port == 0 {
+		return fmt.Sprintf("%s/*", p.Protocol)
+	}
+	return fmt.Sprintf("%s/%d", p.Protocol, p.Port)
+}
+
+// ParsePort parses a port in the format protocol/port or port, e.g. TCP/8080 or
+// 8080. The protocol defaults to TCP.
+func ParsePort(s string) (Port, error) {
+	s = strings.TrimSpace(s)
+
+	port := Port{Protocol: corev1.ProtocolTCP}
+	if i := strings.Index(s, "/"); i >= 0 {
+		port.Protocol = corev1.Protocol(strings.ToUpper(s[:i]))
+		s = s[i+1:]
+	}
+
+	switch port.Protocol {
+	case corev1.ProtocolTCP, corev1.ProtocolUDP, corev1.ProtocolSCTP:
+	default:
+		return Port{}, fmt.Errorf("unknown protocol %q", port.Protocol)
+	}
+
+	n, err := strconv.ParseInt(s, 10, 32)
+	if err != nil || n < 1 || n > 65535 {
+		return Port{}, fmt.Errorf("invalid port number %q", s)
+	}
+	port.Port = int32(n)
+
+	return port, nil
+}
+
+// contains returns true if the port is p or is in p if p is every port.
+func (p Port) contains(port Port) bool {
+	return p.Protocol == port.Protocol && (p.Port == 0 || p.Port == port.Port)
+}
+
+// PortSet is a set of ports traffic is allowed on.
+type PortSet struct {
+	all   bool
+	ports []Port
+}
+
+// AllPorts is a set containing every port.
+func AllPorts() PortSet {
+	return PortSet{all: true}
+}
+
+// NewPortSet creates a port set.
+func NewPortSet(ports ...Port) PortSet {
+	s := PortSet{}
+	for _, p := range ports {
+		s.add(p)
+	}
+	return s
+}
+
+// IsAll returns true if the set contains every port.
+func (s PortSet) IsAll() bool {
+	return s.all
+}
+
+// IsEmpty returns true if the set contains no ports.
+func (s PortSet) IsEmpty() bool {
+	return !s.all && len(s.ports) == 0
+}
+
+// Contains returns true if the set contains a port.
+func (s PortSet) Contains(port Port) bool {
+	if s.all {
+		return true
+	}
+	for _, p := range s.ports {
+		if p.contains(port) {
+			return true
+		}
+	}
+	return false
+}
+
+// Ports returns the ports in the set. It returns nil for a set containing every port.
+func (s PortSet) Ports() []Port {
+	return s.ports
+}
+
+// String returns the ports in the set, e.g. TCP/80, TCP/443.
+func (s PortSet) String() string {
+	if s.all {
+		return "all ports"
+	}
+	if len(s.ports) == 0 {
+		return "no ports"
+	}
+
+	var ports []string
+	for _, p := range s.ports {
+		ports = append(ports, p.String())
+	}
+	return strings.Join(ports, ", ")
+}
+
+func (s *PortSet) add(port Port) {
+	if s.all || s.Contains(port) {
+		return
+	}
+
+	// A port for every port number replaces the ports it contains.
+	var ports []Port
+	for _, p := range s.ports {
+		if !port.contains(p) {
+			ports = append(ports, p)
+		}
+	}
+	ports = append(ports, port)
+
+	sort.Slice(ports, func(i, j int) bool {
+		if ports[i].Protocol != ports[j].Protocol {
+			return ports[i].Protocol < ports[j].Protocol
+		}
+		return ports[i].Port < ports[j].Port
+	})
+	s.ports = ports
+}
+
+func (s *PortSet) union(other PortSet) {
+	if other.all {
+		*s = AllPorts()
+		return
+	}
+	for _, p := range other.ports {
+		s.add(p)
+	}
+}
+
+func (s PortSet) intersect(other PortSet) PortSet {
+	switch {
+	case s.all:
+		return other
+	case other.all:
+		return s
+	}
+
+	out := PortSet{}
+	for _, a := range s.ports {
+		for _, b := range other.ports {
+			switch {
+			case a.contains(b):
+				out.add(b)
+			case b.contains(a):
+				out.add(a)
+			}
+		}
+	}
+	return out
+}
+
+// rulePorts returns the ports a policy rule allows traffic to a destination on.
+// Named ports are resolved against the destination's container ports. They don't
+// match destinations outside the cluster.
+func rulePorts(ports []networkingv1.NetworkPolicyPort, destination *corev1.Pod) PortSet {
+	if len(ports) == 0 {
+		return AllPorts()
+	}
+
+	s := PortSet{}
+	for _, p := range ports {
+		protocol := corev1.ProtocolTCP
+		if p.Protocol != nil {
+			protocol = *p.Protocol
+		}
+
+		switch {
+		case p.Port == nil:
+			s.add(Port{Protocol: protocol})
+		case p.Port.Type == intstr.Int:
+			s.add(Port{Protocol: protocol, Port: p.Port.IntVal})
+		default:
+			if number, ok := namedPort(destination, p.Port.StrVal, protocol); ok {
+				s.add(Port{Protocol: protocol, Port: number})
+			}
+		}
+	}
+	return s
+}
+
+func namedPort(pod *corev1.Pod, name string, protocol corev1.Protocol) (int32, bool) {
+	if pod == nil {
+		return 0, false
+	}
+
+	for _, c := range pod.Spec.Containers {
+		for _, p := range c.Ports {
+			portProtocol := p.Protocol
+			if portProtocol == "" {
+				portProtocol = corev1.ProtocolTCP
+			}
+			if p.Name == name && portProtocol == protocol {
+				return p.ContainerPort, true
+			}
+		}
+	}
+	return 0, false
+}
@@ -0,0 +1,93 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package networkpolicy
+
+import (
+	"strings"
+
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+// ToComponent creates the network reachability view for a graph. If nodeID is not
+// empty, the view lists the traffic allowed to and from that node.
+func ToComponent(g Graph, nodeID string) *component.FlexLayout {
+	layout := component.NewFlexLayout("Network Reachability")
+
+	layout.AddSections(component.FlexLayoutSection{
+		{
+			Width: component.WidthFull,
+			View: component.NewMarkdownText("Traffic network policies allow between pods in the namespace, " +
+				"and to and from outside the cluster. Pods are grouped by controller. **Bold** pods are selected."),
+		},
+		{Width: component.WidthFull, View: component.NewGraphviz(g.DOT())},
+	})
+
+	if node, ok := g.Node(nodeID); ok {
+		layout.AddSections(component.FlexLayoutSection{
+			{Width: component.WidthFull, View: connectionsTable(g, node)},
+		})
+	}
+
+	return layout
+}
+
+func connectionsTable(g Graph, node GraphNode) *component.Table {
+	cols := component.NewTableCols("Direction", "Peer", "Pods", "Ports")
+	table := component.NewTable("Connections", "Network policies don't allow any traffic to or from these pods", cols)
+
+	for _, edge := range g.Edges {
+		direction, peerID := "", ""
+		switch node.ID {
+		case edge.From:
+			direction, peerID = "Outbound", edge.To
+		case edge.To:
+			direction, peerID = "Inbound", edge.From
+		default:
+			continue
+		}
+
+		peer, _ := g.Node(peerID)
+		table.Add(component.TableRow{
+			"Direction": component.NewText(direction),
+			"Peer":      component.NewText(peer.Name),
+			"Pods":      component.NewText(strings.Join(peer.Pods, ", ")),
+			"Ports":     component.NewText(edge.Ports.String()),
+		})
+	}
+
+	return table
+}
+
+// QueryModal creates a form which checks whether a source can reach a destination
+// on a port with the action at actionPath. source is the initial source.
+func QueryModal(actionPath, namespace, source string) *component.Modal {
+	sourceField := component.NewFormFieldText("Source", "source", source)
+	sourceField.AddValidator("pod, namespace/pod or IP address", "", nil)
+
+	destinationField := component.NewFormFieldText("Destination", "destination", "")
+	destinationField.AddValidator("pod, namespace/pod or IP address", "", nil)
+
+	portField := component.NewFormFieldText("Port", "port", "")
+	portField.AddValidator("TCP/8080", "Port is required", []string{"required"})
+
+	form := component.Form{
+		Fields: []component.FormField{
+			component.NewFormFieldHidden("namespace", namespace),
+			sourceField,
+			destinationField,
+			portField,
+		},
+		Action: actionPath,
+	}
+
+	modal := component.NewModal(component.TitleFromString("Check Reachability"))
+	modal.SetBody(component.NewMarkdownText("Checks whether network policies allow traffic from the source " +
+		"to the destination. Pods without a namespace are in `" + namespace + "`. " +
+		"An empty source or destination is any address outside the cluster."))
+	modal.AddForm(form)
+
+	return modal
+}
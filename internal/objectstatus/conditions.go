@@ -0,0 +1,239 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package objectstatus
+
+import (
+	"context"
+	"fmt"
+	"io/ioutil"
+	"strings"
+	"sync"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	sigyaml "sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/octant/pkg/store"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+// Condition types and phases with well known meanings. They are used for kinds
+// without a condition override.
+var (
+	// defaultReadyConditions are true when an object is healthy.
+	defaultReadyConditions = []string{"Ready", "Available"}
+	// defaultDegradedConditions are true when an object is unhealthy.
+	defaultDegradedConditions = []string{"Degraded", "Failed", "Stalled"}
+	defaultErrorPhases        = []string{"Failed", "Error", "Lost"}
+	defaultWarningPhases      = []string{"Pending", "Unknown", "Terminating"}
+)
+
+// ConditionOverride changes how the status of a kind is read from its conditions
+// and phase. Empty fields use the defaults.
+type ConditionOverride struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	// ReadyConditions are condition types which are true when the object is
+	// healthy. Defaults to Ready and Available.
+	ReadyConditions []string `json:"readyConditions,omitempty"`
+	// DegradedConditions are condition types which are true when the object is
+	// unhealthy. Defaults to Degraded, Failed and Stalled.
+	DegradedConditions []string `json:"degradedConditions,omitempty"`
+	// ErrorPhases are values of status.phase for unhealthy objects. Defaults to
+	// Failed, Error and Lost.
+	ErrorPhases []string `json:"errorPhases,omitempty"`
+	// WarningPhases are values of status.phase for objects which aren't ready yet.
+	// Defaults to Pending, Unknown and Terminating.
+	WarningPhases []string `json:"warningPhases,omitempty"`
+	// IgnoreGeneration disables the warning for objects whose controller hasn't
+	// observed their latest generation. Use it for kinds whose status.observedGeneration
+	// means something else.
+	IgnoreGeneration bool `json:"ignoreGeneration,omitempty"`
+}
+
+func (co ConditionOverride) withDefaults() ConditionOverride {
+	if len(co.ReadyConditions) == 0 {
+		co.ReadyConditions = defaultReadyConditions
+	}
+	if len(co.DegradedConditions) == 0 {
+		co.DegradedConditions = defaultDegradedConditions
+	}
+	if len(co.ErrorPhases) == 0 {
+		co.ErrorPhases = defaultErrorPhases
+	}
+	if len(co.WarningPhases) == 0 {
+		co.WarningPhases = defaultWarningPhases
+	}
+	return co
+}
+
+var conditionOverrides = struct {
+	sync.RWMutex
+	m map[statusKey]ConditionOverride
+}{}
+
+// SetConditionOverrides replaces the condition overrides.
+func SetConditionOverrides(overrides []ConditionOverride) {
+	m := make(map[statusKey]ConditionOverride)
+	for _, override := range overrides {
+		m[statusKey{apiVersion: override.APIVersion, kind: override.Kind}] = override
+	}
+
+	conditionOverrides.Lock()
+	defer conditionOverrides.Unlock()
+	conditionOverrides.m = m
+}
+
+func conditionOverride(apiVersion, kind string) ConditionOverride {
+	conditionOverrides.RLock()
+	defer conditionOverrides.RUnlock()
+	return conditionOverrides.m[statusKey{apiVersion: apiVersion, kind: kind}].withDefaults()
+}
+
+// LoadConditionOverrides loads condition overrides from a YAML or JSON file
+// containing a list of overrides.
+func LoadConditionOverrides(path string) ([]ConditionOverride, error) {
+	data, err := ioutil.ReadFile(path)
+	if err != nil {
+		return nil, errors.Wrap(err, "read condition overrides")
+	}
+
+	var overrides []ConditionOverride
+	if err := sigyaml.Unmarshal(data, &overrides); err != nil {
+		return nil, errors.Wrapf(err, "decode condition overrides in %s", path)
+	}
+
+	for i, override := range overrides {
+		if override.APIVersion == "" || override.Kind == "" {
+			return nil, errors.Errorf("condition override %d in %s requires apiVersion and kind", i, path)
+		}
+	}
+
+	return overrides, nil
+}
+
+// conditions creates an ObjectStatus for any object from its status.conditions,
+// status.phase and status.observedGeneration. It is used for kinds without a
+// status function, e.g. custom resources.
+func conditions(_ context.Context, object runtime.Object, _ store.Store) (ObjectStatus, error) {
+	u, ok := object.(*unstructured.Unstructured)
+	if !ok {
+		m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+		if err != nil {
+			return ObjectStatus{}, errors.Wrap(err, "convert object to unstructured")
+		}
+		u = &unstructured.Unstructured{Object: m}
+	}
+
+	apiVersion, kind := object.GetObjectKind().GroupVersionKind().ToAPIVersionAndKind()
+	override := conditionOverride(apiVersion, kind)
+
+	status := ObjectStatus{nodeStatus: component.NodeStatusOK}
+	generation := u.GetGeneration()
+
+	if observed, found, _ := unstructured.NestedInt64(u.Object, "status", "observedGeneration"); found &&
+		!override.IgnoreGeneration && observed < generation {
+		status.SetWarning()
+		status.AddDetailf("%s is being reconciled: generation %d hasn't been observed", kind, generation)
+	}
+
+	if phase, _, _ := unstructured.NestedString(u.Object, "status", "phase"); phase != "" {
+		switch {
+		case containsString(override.ErrorPhases, phase):
+			status.SetError()
+			status.AddDetailf("%s is %s", kind, phase)
+		case containsString(override.WarningPhases, phase):
+			status.SetWarning()
+			status.AddDetailf("%s is %s", kind, phase)
+		}
+	}
+
+	list, _, _ := unstructured.NestedSlice(u.Object, "status", "conditions")
+	for _, item := range list {
+		c, ok := item.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		checkCondition(&status, c, generation, override)
+	}
+
+	if status.Status() == component.NodeStatusOK {
+		status.Details = append([]component.Component{component.NewTextf("%s %s is OK", apiVersion, kind)}, status.Details...)
+	}
+
+	return status, nil
+}
+
+// checkCondition updates an ObjectStatus with a condition.
+func checkCondition(status *ObjectStatus, c map[string]interface{}, generation int64, override ConditionOverride) {
+	conditionType, _, _ := unstructured.NestedString(c, "type")
+	conditionStatus, _, _ := unstructured.NestedString(c, "status")
+
+	switch {
+	case containsString(override.ReadyConditions, conditionType):
+		switch conditionStatus {
+		case "False":
+			status.SetError()
+		case "Unknown":
+			status.SetWarning()
+		default:
+			// A ready condition from an earlier generation may no longer be true.
+			observed, found, _ := unstructured.NestedInt64(c, "observedGeneration")
+			if !found || override.IgnoreGeneration || observed >= generation {
+				return
+			}
+			status.SetWarning()
+			status.AddDetailf("%s condition is from generation %d", conditionType, observed)
+			return
+		}
+	case containsString(override.DegradedConditions, conditionType):
+		if conditionStatus != "True" {
+			return
+		}
+		status.SetError()
+	case conditionType == "Progressing":
+		if conditionStatus != "False" {
+			return
+		}
+		status.SetWarning()
+	case conditionType == "Reconciling":
+		if conditionStatus != "True" {
+			return
+		}
+		status.SetWarning()
+	default:
+		return
+	}
+
+	status.AddDetail(describeCondition(c))
+}
+
+// describeCondition describes a condition, e.g. "Ready is False: NotFound: secret not found".
+func describeCondition(c map[string]interface{}) string {
+	conditionType, _, _ := unstructured.NestedString(c, "type")
+	conditionStatus, _, _ := unstructured.NestedString(c, "status")
+	reason, _, _ := unstructured.NestedString(c, "reason")
+	message, _, _ := unstructured.NestedString(c, "message")
+
+	parts := []string{fmt.Sprintf("%s is %s", conditionType, conditionStatus)}
+	if reason != "" {
+		parts = append(parts, reason)
+	}
+	if message != "" {
+		parts = append(parts, message)
+	}
+	return strings.Join(parts, ": ")
+}
+
+func containsString(list []string, s string) bool {
+	for _, item := range list {
+		if item == s {
+			return true
+		}
+	}
+	return false
+}
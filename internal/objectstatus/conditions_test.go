@@ -0,0 +1,215 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package objectstatus
+
+import (
+	"context"
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+func createCustomResource(generation int64, status map[string]interface{}) *unstructured.Unstructured {
+	u := &unstructured.Unstructured{Object: map[string]interface{}{
+		"apiVersion": "example.com/v1",
+		"kind":       "Database",
+		"metadata": map[string]interface{}{
+			"namespace":  "default",
+			"name":       "db",
+			"generation": generation,
+		},
+	}}
+	if status != nil {
+		u.Object["status"] = status
+	}
+	return u
+}
+
+func condition(conditionType, status, reason, message string) map[string]interface{} {
+	return map[string]interface{}{
+		"type":    conditionType,
+		"status":  status,
+		"reason":  reason,
+		"message": message,
+	}
+}
+
+func Test_conditions(t *testing.T) {
+	cases := []struct {
+		name      string
+		object    *unstructured.Unstructured
+		overrides []ConditionOverride
+		expected  ObjectStatus
+	}{
+		{
+			name:   "no status",
+			object: createCustomResource(1, nil),
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusOK,
+				Details:    []component.Component{component.NewText("example.com/v1 Database is OK")},
+			},
+		},
+		{
+			name: "ready",
+			object: createCustomResource(2, map[string]interface{}{
+				"observedGeneration": int64(2),
+				"conditions": []interface{}{
+					condition("Ready", "True", "Provisioned", ""),
+					condition("Progressing", "True", "", ""),
+				},
+			}),
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusOK,
+				Details:    []component.Component{component.NewText("example.com/v1 Database is OK")},
+			},
+		},
+		{
+			name: "not ready",
+			object: createCustomResource(1, map[string]interface{}{
+				"conditions": []interface{}{
+					condition("Ready", "False", "SecretNotFound", "secret db-credentials not found"),
+				},
+			}),
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusError,
+				Details: []component.Component{
+					component.NewText("Ready is False: SecretNotFound: secret db-credentials not found"),
+				},
+			},
+		},
+		{
+			name: "degraded",
+			object: createCustomResource(1, map[string]interface{}{
+				"conditions": []interface{}{
+					condition("Available", "True", "", ""),
+					condition("Degraded", "True", "ReplicaLost", ""),
+				},
+			}),
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusError,
+				Details:    []component.Component{component.NewText("Degraded is True: ReplicaLost")},
+			},
+		},
+		{
+			name: "generation not observed",
+			object: createCustomResource(3, map[string]interface{}{
+				"observedGeneration": int64(2),
+				"conditions": []interface{}{
+					condition("Ready", "True", "", ""),
+				},
+			}),
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusWarning,
+				Details:    []component.Component{component.NewText("Database is being reconciled: generation 3 hasn't been observed")},
+			},
+		},
+		{
+			name: "stale ready condition",
+			object: createCustomResource(3, map[string]interface{}{
+				"conditions": []interface{}{
+					map[string]interface{}{"type": "Ready", "status": "True", "observedGeneration": int64(2)},
+				},
+			}),
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusWarning,
+				Details:    []component.Component{component.NewText("Ready condition is from generation 2")},
+			},
+		},
+		{
+			name: "failed phase",
+			object: createCustomResource(1, map[string]interface{}{
+				"phase": "Failed",
+			}),
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusError,
+				Details:    []component.Component{component.NewText("Database is Failed")},
+			},
+		},
+		{
+			name: "override",
+			object: createCustomResource(3, map[string]interface{}{
+				"observedGeneration": int64(1),
+				"phase":              "Failed",
+				"conditions": []interface{}{
+					condition("Ready", "False", "", ""),
+					condition("Healthy", "False", "BackupFailed", ""),
+				},
+			}),
+			overrides: []ConditionOverride{
+				{
+					APIVersion:       "example.com/v1",
+					Kind:             "Database",
+					ReadyConditions:  []string{"Healthy"},
+					ErrorPhases:      []string{"Broken"},
+					WarningPhases:    []string{"Failed"},
+					IgnoreGeneration: true,
+				},
+			},
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusError,
+				Details: []component.Component{
+					component.NewText("Database is Failed"),
+					component.NewText("Healthy is False: BackupFailed"),
+				},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			SetConditionOverrides(tc.overrides)
+			defer SetConditionOverrides(nil)
+
+			got, err := conditions(context.Background(), tc.object, nil)
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestStatus_conditionsFallback(t *testing.T) {
+	object := createCustomResource(1, map[string]interface{}{
+		"conditions": []interface{}{condition("Ready", "False", "", "")},
+	})
+
+	got, err := Status(context.Background(), object, nil)
+	require.NoError(t, err)
+	assert.Equal(t, component.NodeStatusError, got.Status())
+}
+
+func TestLoadConditionOverrides(t *testing.T) {
+	dir := t.TempDir()
+
+	path := filepath.Join(dir, "overrides.yaml")
+	data := `
+- apiVersion: example.com/v1
+  kind: Database
+  readyConditions: [Healthy]
+  ignoreGeneration: true
+`
+	require.NoError(t, ioutil.WriteFile(path, []byte(data), 0600))
+
+	got, err := LoadConditionOverrides(path)
+	require.NoError(t, err)
+
+	expected := []ConditionOverride{
+		{APIVersion: "example.com/v1", Kind: "Database", ReadyConditions: []string{"Healthy"}, IgnoreGeneration: true},
+	}
+	assert.Equal(t, expected, got)
+
+	invalid := filepath.Join(dir, "invalid.yaml")
+	require.NoError(t, ioutil.WriteFile(invalid, []byte("- kind: Database\n"), 0600))
+
+	_, err = LoadConditionOverrides(invalid)
+	require.Error(t, err)
+}
@@ -0,0 +1,85 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package objectstatus
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	discoveryv1beta1 "k8s.io/api/discovery/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/vmware-tanzu/octant/pkg/store"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+// endpoints creates status for v1 endpoints.
+func endpoints(_ context.Context, object runtime.Object, _ store.Store) (ObjectStatus, error) {
+	if object == nil {
+		return ObjectStatus{}, errors.Errorf("endpoints is nil")
+	}
+
+	e := &corev1.Endpoints{}
+
+	if err := scheme.Scheme.Convert(object, e, 0); err != nil {
+		return ObjectStatus{}, errors.Wrap(err, "convert object to endpoints")
+	}
+
+	ready, notReady := 0, 0
+	for _, subset := range e.Subsets {
+		ready += len(subset.Addresses)
+		notReady += len(subset.NotReadyAddresses)
+	}
+
+	return readyAddressesStatus("Endpoints", ready, notReady), nil
+}
+
+// endpointSlice creates status for a discovery.k8s.io/v1beta1 endpoint slice.
+func endpointSlice(_ context.Context, object runtime.Object, _ store.Store) (ObjectStatus, error) {
+	if object == nil {
+		return ObjectStatus{}, errors.Errorf("endpoint slice is nil")
+	}
+
+	slice := &discoveryv1beta1.EndpointSlice{}
+
+	if err := scheme.Scheme.Convert(object, slice, 0); err != nil {
+		return ObjectStatus{}, errors.Wrap(err, "convert object to endpoint slice")
+	}
+
+	ready, notReady := 0, 0
+	for _, endpoint := range slice.Endpoints {
+		// An unknown ready condition means the endpoint is ready.
+		if endpoint.Conditions.Ready == nil || *endpoint.Conditions.Ready {
+			ready++
+		} else {
+			notReady++
+		}
+	}
+
+	return readyAddressesStatus("Endpoint Slice", ready, notReady), nil
+}
+
+func readyAddressesStatus(name string, ready, notReady int) ObjectStatus {
+	switch {
+	case ready == 0 && notReady > 0:
+		return ObjectStatus{
+			nodeStatus: component.NodeStatusWarning,
+			Details:    []component.Component{component.NewTextf("%s has no ready addresses (%d not ready)", name, notReady)},
+		}
+	case ready == 0:
+		return ObjectStatus{
+			nodeStatus: component.NodeStatusWarning,
+			Details:    []component.Component{component.NewTextf("%s has no addresses", name)},
+		}
+	default:
+		return ObjectStatus{
+			nodeStatus: component.NodeStatusOK,
+			Details:    []component.Component{component.NewTextf("%s is OK", name)},
+		}
+	}
+}
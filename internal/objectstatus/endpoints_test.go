@@ -0,0 +1,166 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package objectstatus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware-tanzu/octant/internal/testutil"
+	storefake "github.com/vmware-tanzu/octant/pkg/store/fake"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+func Test_endpoints(t *testing.T) {
+	cases := []struct {
+		name     string
+		init     func(*testing.T, *storefake.MockStore) runtime.Object
+		expected ObjectStatus
+		isErr    bool
+	}{
+		{
+			name: "in general",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				objectFile := "endpoints_ok.yaml"
+				return testutil.LoadObjectFromFile(t, objectFile)
+			},
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusOK,
+				Details:    []component.Component{component.NewText("Endpoints is OK")},
+			},
+		},
+		{
+			name: "no ready addresses",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				objectFile := "endpoints_not_ready.yaml"
+				return testutil.LoadObjectFromFile(t, objectFile)
+			},
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusWarning,
+				Details:    []component.Component{component.NewText("Endpoints has no ready addresses (2 not ready)")},
+			},
+		},
+		{
+			name: "no subsets",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				objectFile := "endpoints_no_subsets.yaml"
+				return testutil.LoadObjectFromFile(t, objectFile)
+			},
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusWarning,
+				Details:    []component.Component{component.NewText("Endpoints has no addresses")},
+			},
+		},
+		{
+			name: "object is nil",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				return nil
+			},
+			isErr: true,
+		},
+		{
+			name: "object is not a endpoints",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				return &unstructured.Unstructured{}
+			},
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			o := storefake.NewMockStore(controller)
+
+			object := tc.init(t, o)
+
+			ctx := context.Background()
+			status, err := endpoints(ctx, object, o)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, status)
+		})
+	}
+}
+
+func Test_endpointSlice(t *testing.T) {
+	cases := []struct {
+		name     string
+		init     func(*testing.T, *storefake.MockStore) runtime.Object
+		expected ObjectStatus
+		isErr    bool
+	}{
+		{
+			name: "in general",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				objectFile := "endpointslice_ok.yaml"
+				return testutil.LoadObjectFromFile(t, objectFile)
+			},
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusOK,
+				Details:    []component.Component{component.NewText("Endpoint Slice is OK")},
+			},
+		},
+		{
+			name: "no ready endpoints",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				objectFile := "endpointslice_not_ready.yaml"
+				return testutil.LoadObjectFromFile(t, objectFile)
+			},
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusWarning,
+				Details:    []component.Component{component.NewText("Endpoint Slice has no ready addresses (2 not ready)")},
+			},
+		},
+		{
+			name: "object is nil",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				return nil
+			},
+			isErr: true,
+		},
+		{
+			name: "object is not a endpoint slice",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				return &unstructured.Unstructured{}
+			},
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			o := storefake.NewMockStore(controller)
+
+			object := tc.init(t, o)
+
+			ctx := context.Background()
+			status, err := endpointSlice(ctx, object, o)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, status)
+		})
+	}
+}
@@ -0,0 +1,98 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package objectstatus
+
+import (
+	"context"
+	"encoding/json"
+
+	"github.com/pkg/errors"
+	autoscalingv1 "k8s.io/api/autoscaling/v1"
+	autoscalingv2beta2 "k8s.io/api/autoscaling/v2beta2"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/vmware-tanzu/octant/pkg/store"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+// hpaConditionsAnnotation holds the conditions of an autoscaling/v1 horizontal
+// pod autoscaler.
+const hpaConditionsAnnotation = "autoscaling.alpha.kubernetes.io/conditions"
+
+// horizontalPodAutoscalerV1 creates status for an autoscaling/v1 horizontal pod autoscaler.
+func horizontalPodAutoscalerV1(_ context.Context, object runtime.Object, _ store.Store) (ObjectStatus, error) {
+	if object == nil {
+		return ObjectStatus{}, errors.Errorf("horizontal pod autoscaler is nil")
+	}
+
+	hpa := &autoscalingv1.HorizontalPodAutoscaler{}
+
+	if err := scheme.Scheme.Convert(object, hpa, 0); err != nil {
+		return ObjectStatus{}, errors.Wrap(err, "convert object to horizontal pod autoscaler")
+	}
+
+	var conditions []autoscalingv2beta2.HorizontalPodAutoscalerCondition
+	if s, ok := hpa.Annotations[hpaConditionsAnnotation]; ok {
+		if err := json.Unmarshal([]byte(s), &conditions); err != nil {
+			return ObjectStatus{}, errors.Wrap(err, "decode horizontal pod autoscaler conditions")
+		}
+	}
+
+	return horizontalPodAutoscalerStatus(hpa.Status.CurrentReplicas, hpa.Spec.MaxReplicas, conditions), nil
+}
+
+// horizontalPodAutoscalerV2beta2 creates status for an autoscaling/v2beta2 horizontal pod autoscaler.
+func horizontalPodAutoscalerV2beta2(_ context.Context, object runtime.Object, _ store.Store) (ObjectStatus, error) {
+	if object == nil {
+		return ObjectStatus{}, errors.Errorf("horizontal pod autoscaler is nil")
+	}
+
+	hpa := &autoscalingv2beta2.HorizontalPodAutoscaler{}
+
+	if err := scheme.Scheme.Convert(object, hpa, 0); err != nil {
+		return ObjectStatus{}, errors.Wrap(err, "convert object to horizontal pod autoscaler")
+	}
+
+	return horizontalPodAutoscalerStatus(hpa.Status.CurrentReplicas, hpa.Spec.MaxReplicas, hpa.Status.Conditions), nil
+}
+
+func horizontalPodAutoscalerStatus(currentReplicas, maxReplicas int32, conditions []autoscalingv2beta2.HorizontalPodAutoscalerCondition) ObjectStatus {
+	status := ObjectStatus{nodeStatus: component.NodeStatusOK}
+
+	for _, condition := range conditions {
+		if condition.Status != corev1.ConditionFalse {
+			continue
+		}
+
+		switch condition.Type {
+		case autoscalingv2beta2.AbleToScale:
+			status.SetError()
+			status.AddDetailf("Horizontal Pod Autoscaler is unable to scale: %s", condition.Message)
+		case autoscalingv2beta2.ScalingActive:
+			// ScalingActive is false when metrics can't be fetched, or when the
+			// target was scaled to zero on purpose.
+			if condition.Reason == "ScalingDisabled" {
+				status.AddDetailf("Horizontal Pod Autoscaler is disabled: %s", condition.Message)
+				continue
+			}
+			status.SetError()
+			status.AddDetailf("Horizontal Pod Autoscaler isn't active: %s", condition.Message)
+		}
+	}
+
+	if maxReplicas > 0 && currentReplicas >= maxReplicas {
+		status.SetWarning()
+		status.AddDetailf("Horizontal Pod Autoscaler is at its maximum of %d replicas", maxReplicas)
+	}
+
+	if status.Status() == component.NodeStatusOK && len(status.Details) == 0 {
+		status.AddDetail("Horizontal Pod Autoscaler is OK")
+	}
+
+	return status
+}
@@ -0,0 +1,166 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package objectstatus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware-tanzu/octant/internal/testutil"
+	storefake "github.com/vmware-tanzu/octant/pkg/store/fake"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+func Test_horizontalPodAutoscalerV1(t *testing.T) {
+	cases := []struct {
+		name     string
+		init     func(*testing.T, *storefake.MockStore) runtime.Object
+		expected ObjectStatus
+		isErr    bool
+	}{
+		{
+			name: "in general",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				objectFile := "horizontalpodautoscaler_ok.yaml"
+				return testutil.LoadObjectFromFile(t, objectFile)
+			},
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusOK,
+				Details:    []component.Component{component.NewText("Horizontal Pod Autoscaler is OK")},
+			},
+		},
+		{
+			name: "at max replicas",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				objectFile := "horizontalpodautoscaler_max_replicas.yaml"
+				return testutil.LoadObjectFromFile(t, objectFile)
+			},
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusWarning,
+				Details:    []component.Component{component.NewText("Horizontal Pod Autoscaler is at its maximum of 10 replicas")},
+			},
+		},
+		{
+			name: "unable to scale",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				objectFile := "horizontalpodautoscaler_unable_to_scale.yaml"
+				return testutil.LoadObjectFromFile(t, objectFile)
+			},
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusError,
+				Details:    []component.Component{component.NewText("Horizontal Pod Autoscaler is unable to scale: the HPA controller was unable to get the target's current scale: deployments/scale.apps \"web\" not found")},
+			},
+		},
+		{
+			name: "object is nil",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				return nil
+			},
+			isErr: true,
+		},
+		{
+			name: "object is not a horizontal pod autoscaler",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				return &unstructured.Unstructured{}
+			},
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			o := storefake.NewMockStore(controller)
+
+			object := tc.init(t, o)
+
+			ctx := context.Background()
+			status, err := horizontalPodAutoscalerV1(ctx, object, o)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, status)
+		})
+	}
+}
+
+func Test_horizontalPodAutoscalerV2beta2(t *testing.T) {
+	cases := []struct {
+		name     string
+		init     func(*testing.T, *storefake.MockStore) runtime.Object
+		expected ObjectStatus
+		isErr    bool
+	}{
+		{
+			name: "missing metrics",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				objectFile := "horizontalpodautoscaler_missing_metrics.yaml"
+				return testutil.LoadObjectFromFile(t, objectFile)
+			},
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusError,
+				Details:    []component.Component{component.NewText("Horizontal Pod Autoscaler isn't active: the HPA was unable to compute the replica count: unable to get metrics for resource memory: no metrics returned from resource metrics API")},
+			},
+		},
+		{
+			name: "scaling disabled",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				objectFile := "horizontalpodautoscaler_scaling_disabled.yaml"
+				return testutil.LoadObjectFromFile(t, objectFile)
+			},
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusOK,
+				Details:    []component.Component{component.NewText("Horizontal Pod Autoscaler is disabled: scaling is disabled since the replica count of the target is zero")},
+			},
+		},
+		{
+			name: "object is nil",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				return nil
+			},
+			isErr: true,
+		},
+		{
+			name: "object is not a horizontal pod autoscaler",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				return &unstructured.Unstructured{}
+			},
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			o := storefake.NewMockStore(controller)
+
+			object := tc.init(t, o)
+
+			ctx := context.Background()
+			status, err := horizontalPodAutoscalerV2beta2(ctx, object, o)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, status)
+		})
+	}
+}
@@ -0,0 +1,78 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package objectstatus
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/vmware-tanzu/octant/pkg/store"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+// nodePressureConditions are node conditions which are true when a node is
+// running out of a resource.
+var nodePressureConditions = []corev1.NodeConditionType{
+	corev1.NodeMemoryPressure,
+	corev1.NodeDiskPressure,
+	corev1.NodePIDPressure,
+	corev1.NodeNetworkUnavailable,
+}
+
+// node creates status for a v1 node.
+func node(_ context.Context, object runtime.Object, _ store.Store) (ObjectStatus, error) {
+	if object == nil {
+		return ObjectStatus{}, errors.Errorf("node is nil")
+	}
+
+	n := &corev1.Node{}
+
+	if err := scheme.Scheme.Convert(object, n, 0); err != nil {
+		return ObjectStatus{}, errors.Wrap(err, "convert object to node")
+	}
+
+	status := ObjectStatus{nodeStatus: component.NodeStatusOK}
+
+	conditions := map[corev1.NodeConditionType]corev1.NodeCondition{}
+	for _, condition := range n.Status.Conditions {
+		conditions[condition.Type] = condition
+	}
+
+	ready, ok := conditions[corev1.NodeReady]
+	switch {
+	case !ok:
+		status.SetWarning()
+		status.AddDetail("Node has not reported whether it is ready")
+	case ready.Status == corev1.ConditionFalse:
+		status.SetError()
+		status.AddDetailf("Node is not ready: %s", ready.Message)
+	case ready.Status == corev1.ConditionUnknown:
+		status.SetError()
+		status.AddDetailf("Node is not ready: %s", ready.Message)
+	}
+
+	for _, conditionType := range nodePressureConditions {
+		if condition, ok := conditions[conditionType]; ok && condition.Status == corev1.ConditionTrue {
+			status.SetWarning()
+			status.AddDetailf("Node has %s: %s", conditionType, condition.Message)
+		}
+	}
+
+	if n.Spec.Unschedulable {
+		status.SetWarning()
+		status.AddDetail("Node is cordoned")
+	}
+
+	if status.Status() == component.NodeStatusOK {
+		status.AddDetail("Node is OK")
+	}
+
+	return status, nil
+}
@@ -28,18 +28,25 @@ type statusLookup map[statusKey]statusFunc
 
 var (
 	defaultStatusLookup = statusLookup{
-		{apiVersion: "batch/v1beta1", kind: "CronJob"}:                cronJob,
-		{apiVersion: "apps/v1", kind: "DaemonSet"}:                    daemonSet,
-		{apiVersion: "apps/v1", kind: "Deployment"}:                   deploymentAppsV1,
-		{apiVersion: "apps/v1", kind: "ReplicaSet"}:                   replicaSetAppsV1,
-		{apiVersion: "apps/v1", kind: "StatefulSet"}:                  statefulSet,
-		{apiVersion: "batch/v1", kind: "Job"}:                         runJobStatus,
-		{apiVersion: "v1", kind: "Pod"}:                               pod,
-		{apiVersion: "v1", kind: "ReplicationController"}:             replicationController,
-		{apiVersion: "v1", kind: "Service"}:                           service,
-		{apiVersion: "v1", kind: "PersistentVolume"}:                  persistentVolume,
-		{apiVersion: "networking.k8s.io/v1", kind: "Ingress"}:         runIngressStatus,
-		{apiVersion: "apiregistration.k8s.io/v1", kind: "APIService"}: apiService,
+		{apiVersion: "batch/v1beta1", kind: "CronJob"}:                       cronJob,
+		{apiVersion: "apps/v1", kind: "DaemonSet"}:                           daemonSet,
+		{apiVersion: "apps/v1", kind: "Deployment"}:                          deploymentAppsV1,
+		{apiVersion: "apps/v1", kind: "ReplicaSet"}:                          replicaSetAppsV1,
+		{apiVersion: "apps/v1", kind: "StatefulSet"}:                         statefulSet,
+		{apiVersion: "batch/v1", kind: "Job"}:                                runJobStatus,
+		{apiVersion: "v1", kind: "Pod"}:                                      pod,
+		{apiVersion: "v1", kind: "ReplicationController"}:                    replicationController,
+		{apiVersion: "v1", kind: "Service"}:                                  service,
+		{apiVersion: "v1", kind: "PersistentVolume"}:                         persistentVolume,
+		{apiVersion: "v1", kind: "PersistentVolumeClaim"}:                    persistentVolumeClaim,
+		{apiVersion: "v1", kind: "Node"}:                                     node,
+		{apiVersion: "v1", kind: "Endpoints"}:                                endpoints,
+		{apiVersion: "discovery.k8s.io/v1beta1", kind: "EndpointSlice"}:      endpointSlice,
+		{apiVersion: "autoscaling/v1", kind: "HorizontalPodAutoscaler"}:      horizontalPodAutoscalerV1,
+		{apiVersion: "autoscaling/v2beta2", kind: "HorizontalPodAutoscaler"}: horizontalPodAutoscalerV2beta2,
+		{apiVersion: "policy/v1beta1", kind: "PodDisruptionBudget"}:          podDisruptionBudget,
+		{apiVersion: "networking.k8s.io/v1", kind: "Ingress"}:                runIngressStatus,
+		{apiVersion: "apiregistration.k8s.io/v1", kind: "APIService"}:        apiService,
 	}
 )
 
@@ -110,11 +117,9 @@ func status(ctx context.Context, object runtime.Object, o store.Store, lookup st
 
 	fn, ok := lookup[statusKey{apiVersion: apiVersion, kind: kind}]
 	if !ok {
-		return ObjectStatus{
-			nodeStatus: component.NodeStatusOK,
-			Details:    []component.Component{component.NewText(fmt.Sprintf("%s %s is OK", apiVersion, kind))},
-		}, nil
-
+		// Kinds without a status function, including custom resources, report
+		// their status with conditions.
+		fn = conditions
 	}
 
 	return fn(ctx, object, o)
@@ -0,0 +1,60 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package objectstatus
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/vmware-tanzu/octant/pkg/store"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+// persistentVolumeClaim creates status for a v1 persistent volume claim.
+func persistentVolumeClaim(_ context.Context, object runtime.Object, _ store.Store) (ObjectStatus, error) {
+	if object == nil {
+		return ObjectStatus{}, errors.Errorf("persistent volume claim is nil")
+	}
+
+	pvc := &corev1.PersistentVolumeClaim{}
+
+	if err := scheme.Scheme.Convert(object, pvc, 0); err != nil {
+		return ObjectStatus{}, errors.Wrap(err, "convert object to persistent volume claim")
+	}
+
+	switch pvc.Status.Phase {
+	case corev1.ClaimPending:
+		return ObjectStatus{
+			nodeStatus: component.NodeStatusWarning,
+			Details:    []component.Component{component.NewText("Persistent Volume Claim is not bound to a volume")},
+		}, nil
+	case corev1.ClaimLost:
+		return ObjectStatus{
+			nodeStatus: component.NodeStatusError,
+			Details:    []component.Component{component.NewTextf("Persistent Volume Claim lost its volume %s", pvc.Spec.VolumeName)},
+		}, nil
+	}
+
+	requested, hasRequest := pvc.Spec.Resources.Requests[corev1.ResourceStorage]
+	capacity, hasCapacity := pvc.Status.Capacity[corev1.ResourceStorage]
+	if hasRequest && hasCapacity && capacity.Cmp(requested) < 0 {
+		return ObjectStatus{
+			nodeStatus: component.NodeStatusWarning,
+			Details: []component.Component{
+				component.NewTextf("Persistent Volume Claim requests %s, but its capacity is %s", requested.String(), capacity.String()),
+			},
+		}, nil
+	}
+
+	return ObjectStatus{
+		nodeStatus: component.NodeStatusOK,
+		Details:    []component.Component{component.NewText("Persistent Volume Claim is OK")},
+	}, nil
+}
@@ -0,0 +1,110 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package objectstatus
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware-tanzu/octant/internal/testutil"
+	storefake "github.com/vmware-tanzu/octant/pkg/store/fake"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+func Test_persistentVolumeClaim(t *testing.T) {
+	cases := []struct {
+		name     string
+		init     func(*testing.T, *storefake.MockStore) runtime.Object
+		expected ObjectStatus
+		isErr    bool
+	}{
+		{
+			name: "in general",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				objectFile := "persistentvolumeclaim_bound.yaml"
+				return testutil.LoadObjectFromFile(t, objectFile)
+			},
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusOK,
+				Details:    []component.Component{component.NewText("Persistent Volume Claim is OK")},
+			},
+		},
+		{
+			name: "pending",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				objectFile := "persistentvolumeclaim_pending.yaml"
+				return testutil.LoadObjectFromFile(t, objectFile)
+			},
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusWarning,
+				Details:    []component.Component{component.NewText("Persistent Volume Claim is not bound to a volume")},
+			},
+		},
+		{
+			name: "lost",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				objectFile := "persistentvolumeclaim_lost.yaml"
+				return testutil.LoadObjectFromFile(t, objectFile)
+			},
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusError,
+				Details:    []component.Component{component.NewText("Persistent Volume Claim lost its volume pvc-0f3c1a7e")},
+			},
+		},
+		{
+			name: "capacity is less than requested",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				objectFile := "persistentvolumeclaim_resizing.yaml"
+				return testutil.LoadObjectFromFile(t, objectFile)
+			},
+			expected: ObjectStatus{
+				nodeStatus: component.NodeStatusWarning,
+				Details:    []component.Component{component.NewText("Persistent Volume Claim requests 20Gi, but its capacity is 10Gi")},
+			},
+		},
+		{
+			name: "object is nil",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				return nil
+			},
+			isErr: true,
+		},
+		{
+			name: "object is not a persistent volume claim",
+			init: func(t *testing.T, o *storefake.MockStore) runtime.Object {
+				return &unstructured.Unstructured{}
+			},
+			isErr: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			o := storefake.NewMockStore(controller)
+
+			object := tc.init(t, o)
+
+			ctx := context.Background()
+			status, err := persistentVolumeClaim(ctx, object, o)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+
+			assert.Equal(t, tc.expected, status)
+		})
+	}
+}
@@ -13,6 +13,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/client-go/kubernetes/scheme"
 
+	"github.com/vmware-tanzu/octant/internal/scheduling"
 	"github.com/vmware-tanzu/octant/pkg/store"
 	"github.com/vmware-tanzu/octant/pkg/view/component"
 )
@@ -48,5 +49,13 @@ func pod(ctx context.Context, object runtime.Object, o store.Store) (ObjectStatu
 		status.Details = append(status.Details, component.NewText("Ephemeral container is running"))
 	}
 
+	// Diagnoses share the nodes and pods they list when ctx has a scheduling state
+	// cache, so lists of pending pods don't list them for every pod.
+	if scheduling.NeedsDiagnosis(pod) {
+		if diagnosis, err := scheduling.Diagnose(ctx, o, pod); err == nil {
+			status.Details = append(status.Details, component.NewText(diagnosis.Summary()))
+		}
+	}
+
 	return status, nil
 }
@@ -0,0 +1,54 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package objectstatus
+
+import (
+	"context"
+
+	"github.com/pkg/errors"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/client-go/kubernetes/scheme"
+
+	"github.com/vmware-tanzu/octant/pkg/store"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+// podDisruptionBudget creates status for a policy/v1beta1 pod disruption budget.
+func podDisruptionBudget(_ context.Context, object runtime.Object, _ store.Store) (ObjectStatus, error) {
+	if object == nil {
+		return ObjectStatus{}, errors.Errorf("pod disruption budget is nil")
+	}
+
+	pdb := &policyv1beta1.PodDisruptionBudget{}
+
+	if err := scheme.Scheme.Convert(object, pdb, 0); err != nil {
+		return ObjectStatus{}, errors.Wrap(err, "convert object to pod disruption budget")
+	}
+
+	status := pdb.Status
+
+	switch {
+	case status.ExpectedPods == 0:
+		return ObjectStatus{
+			nodeStatus: component.NodeStatusWarning,
+			Details:    []component.Component{component.NewText("Pod Disruption Budget doesn't select any pods")},
+		}, nil
+	case status.DisruptionsAllowed == 0:
+		return ObjectStatus{
+			nodeStatus: component.NodeStatusWarning,
+			Details: []component.Component{
+				component.NewTextf("Pod Disruption Budget allows no disruptions: %d of %d pods are healthy and %d are required",
+					status.CurrentHealthy, status.ExpectedPods, status.DesiredHealthy),
+			},
+		}, nil
+	default:
+		return ObjectStatus{
+			nodeStatus: component.NodeStatusOK,
+			Details:    []component.Component{component.NewText("Pod Disruption Budget is OK")},
+		}, nil
+	}
+}
@@ -12,10 +12,12 @@ import (
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
 	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/vmware-tanzu/octant/internal/testutil"
+	"github.com/vmware-tanzu/octant/pkg/store"
 	storefake "github.com/vmware-tanzu/octant/pkg/store/fake"
 	"github.com/vmware-tanzu/octant/pkg/view/component"
 )
@@ -117,3 +119,32 @@ func Test_pod(t *testing.T) {
 		})
 	}
 }
+
+func Test_pod_unscheduled(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	object := testutil.CreatePod("pod")
+	object.Status.Phase = corev1.PodPending
+	object.Status.Message = "pending"
+
+	o := storefake.NewMockStore(controller)
+	o.EXPECT().
+		List(gomock.Any(), store.Key{APIVersion: "v1", Kind: "Node"}).
+		Return(&unstructured.UnstructuredList{}, false, nil)
+	o.EXPECT().
+		List(gomock.Any(), store.Key{APIVersion: "v1", Kind: "Pod"}).
+		Return(&unstructured.UnstructuredList{}, false, nil)
+
+	status, err := pod(context.Background(), object, o)
+	require.NoError(t, err)
+
+	expected := ObjectStatus{
+		nodeStatus: component.NodeStatusWarning,
+		Details: []component.Component{
+			component.NewText("pending"),
+			component.NewText("0/0 nodes are available."),
+		},
+	}
+	assert.Equal(t, expected, status)
+}
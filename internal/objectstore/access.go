@@ -81,6 +81,8 @@ type ResourceAccess interface {
 type resourceAccess struct {
 	client cluster.ClientInterface
 	cache  *accessCache
+	// userCaches caches access for clients which impersonate users.
+	userCaches map[cluster.ClientInterface]*accessCache
 
 	mu sync.RWMutex
 }
@@ -89,8 +91,9 @@ var _ ResourceAccess = (*resourceAccess)(nil)
 
 func NewResourceAccess(client cluster.ClientInterface) ResourceAccess {
 	return &resourceAccess{
-		client: client,
-		cache:  newAccessCache(),
+		client:     client,
+		cache:      newAccessCache(),
+		userCaches: make(map[cluster.ClientInterface]*accessCache),
 	}
 }
 
@@ -99,11 +102,17 @@ func (r *resourceAccess) UpdateClient(client cluster.ClientInterface) {
 	defer r.mu.Unlock()
 
 	r.client = client
+	r.userCaches = make(map[cluster.ClientInterface]*accessCache)
 }
 
 // Reset resets the resource access cache.
 func (r *resourceAccess) Reset() {
 	r.cache.reset()
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	r.userCaches = make(map[cluster.ClientInterface]*accessCache)
 }
 
 // Get returns the value and if it was found for an AccessKey.
@@ -117,7 +126,7 @@ func (r *resourceAccess) Set(key AccessKey, v bool) {
 }
 
 // HasAccess returns an error if the current user does not have access to perform the verb action
-// for the given key.
+// for the given key. If ctx contains an authenticated user, access is checked for that user.
 func (r *resourceAccess) HasAccess(ctx context.Context, key store.Key, verb string) error {
 	_, span := trace.StartSpan(ctx, "resourceAccessHasAccess")
 	defer span.End()
@@ -127,16 +136,21 @@ func (r *resourceAccess) HasAccess(ctx context.Context, key store.Key, verb stri
 		return err
 	}
 
-	access, ok := r.cache.get(aKey)
+	client, cache, err := r.forContext(ctx)
+	if err != nil {
+		return err
+	}
+
+	access, ok := cache.get(aKey)
 
 	if !ok {
 		span.Annotate([]trace.Attribute{}, "fetch access start")
-		val, err := r.fetchAccess(aKey, verb)
+		val, err := r.fetchAccess(client, aKey, verb)
 		if err != nil {
 			return fmt.Errorf("fetch access: %+v: %w", aKey, err)
 		}
 
-		r.cache.set(aKey, val)
+		cache.set(aKey, val)
 		access = val
 		span.Annotate([]trace.Attribute{}, "fetch access finish")
 	}
@@ -148,6 +162,29 @@ func (r *resourceAccess) HasAccess(ctx context.Context, key store.Key, verb stri
 	return nil
 }
 
+// forContext returns the client and access cache for the user authenticated in ctx.
+func (r *resourceAccess) forContext(ctx context.Context) (cluster.ClientInterface, *accessCache, error) {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	client, err := cluster.ClientForContext(ctx, r.client)
+	if err != nil {
+		return nil, nil, fmt.Errorf("client for user: %w", err)
+	}
+
+	if client == r.client {
+		return client, r.cache, nil
+	}
+
+	cache, ok := r.userCaches[client]
+	if !ok {
+		cache = newAccessCache()
+		r.userCaches[client] = cache
+	}
+
+	return client, cache, nil
+}
+
 func (r *resourceAccess) keyToAccessKey(key store.Key, verb string) (AccessKey, error) {
 	r.mu.RLock()
 	defer r.mu.RUnlock()
@@ -172,11 +209,11 @@ func (r *resourceAccess) keyToAccessKey(key store.Key, verb string) (AccessKey,
 	return aKey, nil
 }
 
-func (r *resourceAccess) fetchAccess(key AccessKey, verb string) (bool, error) {
+func (r *resourceAccess) fetchAccess(client cluster.ClientInterface, key AccessKey, verb string) (bool, error) {
 	r.mu.Lock()
 	defer r.mu.Unlock()
 
-	k8sClient, err := r.client.KubernetesClient()
+	k8sClient, err := client.KubernetesClient()
 	if err != nil {
 		return false, fmt.Errorf("client kubernetes: %w", err)
 	}
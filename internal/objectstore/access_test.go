@@ -6,8 +6,13 @@ import (
 
 	"github.com/golang/mock/gomock"
 	"github.com/stretchr/testify/require"
+	authorizationv1 "k8s.io/api/authorization/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/runtime/schema"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
 
+	"github.com/vmware-tanzu/octant/internal/auth"
 	clusterfake "github.com/vmware-tanzu/octant/internal/cluster/fake"
 	"github.com/vmware-tanzu/octant/pkg/store"
 )
@@ -129,3 +134,36 @@ func Test_ResourceAccess_HasAccess(t *testing.T) {
 		})
 	}
 }
+
+func Test_ResourceAccess_HasAccess_user(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	client := clusterfake.NewMockClientInterface(controller)
+	userClient := clusterfake.NewMockClientInterface(controller)
+
+	podGVR := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+	client.EXPECT().Resource(gomock.Any()).Return(podGVR, true, nil).AnyTimes()
+	client.EXPECT().ForUser("user", []string{"group"}).Return(userClient, nil).AnyTimes()
+
+	var reviews []*authorizationv1.SelfSubjectAccessReview
+	clientset := kubefake.NewSimpleClientset()
+	clientset.PrependReactor("create", "selfsubjectaccessreviews", func(a ktesting.Action) (bool, runtime.Object, error) {
+		review := a.(ktesting.CreateAction).GetObject().(*authorizationv1.SelfSubjectAccessReview)
+		reviews = append(reviews, review)
+		review.Status.Allowed = false
+		return true, review, nil
+	})
+	userClient.EXPECT().KubernetesClient().Return(clientset, nil)
+
+	r := NewResourceAccess(client)
+	r.Set(AccessKey{Resource: "pods", Verb: "get"}, true)
+
+	key := store.Key{APIVersion: "v1", Kind: "Pod"}
+	require.NoError(t, r.HasAccess(context.Background(), key, "get"))
+
+	ctx := auth.WithUser(context.Background(), &auth.User{Name: "user", Groups: []string{"group"}})
+	require.Error(t, r.HasAccess(ctx, key, "get"))
+	require.Error(t, r.HasAccess(ctx, key, "get"))
+	require.Len(t, reviews, 1)
+}
@@ -6,16 +6,13 @@ SPDX-License-Identifier: Apache-2.0
 package objectstore
 
 import (
-	"bytes"
 	"context"
 	"errors"
 	"fmt"
-	"io"
 	"sync"
 	"time"
 
 	"k8s.io/apimachinery/pkg/types"
-	"k8s.io/apimachinery/pkg/util/yaml"
 
 	"go.opencensus.io/trace"
 	kerrors "k8s.io/apimachinery/pkg/api/errors"
@@ -32,6 +29,7 @@ import (
 
 	"github.com/vmware-tanzu/octant/internal/cluster"
 	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/search"
 	"github.com/vmware-tanzu/octant/pkg/store"
 )
 
@@ -57,6 +55,22 @@ func Access(resourceAccess ResourceAccess) DynamicCacheOpt {
 	}
 }
 
+// SearchIndex keeps a search index current with the objects held by a DynamicCache's informers.
+func SearchIndex(index *search.Index) DynamicCacheOpt {
+	return func(dc *DynamicCache) {
+		dc.index = index
+	}
+}
+
+// informerContext stops all of a DynamicCache's informers when ctx is done.
+func informerContext(ctx context.Context) DynamicCacheOpt {
+	return func(dc *DynamicCache) {
+		dc.initFactoryFunc = func(_ context.Context, client cluster.ClientInterface, namespace string) (InformerFactory, error) {
+			return initInformerFactory(ctx, client, namespace)
+		}
+	}
+}
+
 // DynamicCache is a cache based on the dynamic shared informer factory.
 type DynamicCache struct {
 	initFactoryFunc func(context.Context, cluster.ClientInterface, string) (InformerFactory, error)
@@ -69,6 +83,10 @@ type DynamicCache struct {
 	updateFns       []store.UpdateFn
 	updateMu        sync.Mutex
 
+	index   *search.Index
+	indexed map[kcache.SharedIndexInformer]bool
+	indexMu sync.Mutex
+
 	syncTimeoutFunc func(context.Context, store.Key, chan bool)
 	waitForSyncFunc func(context.Context, store.Key, *DynamicCache, informers.GenericInformer, chan bool)
 }
@@ -107,12 +125,17 @@ func NewDynamicCache(ctx context.Context, client cluster.ClientInterface, option
 		client:          client,
 		seenGVKs:        initSeenGVKsCache(),
 		informerSynced:  initInformerSynced(),
+		indexed:         make(map[kcache.SharedIndexInformer]bool),
 	}
 
 	for _, option := range options {
 		option(c)
 	}
 
+	if c.index != nil {
+		c.index.SetAccess(c.access)
+	}
+
 	logger := log.From(ctx).With("component", "DynamicCache")
 
 	c.factories = initFactoriesCache()
@@ -164,12 +187,31 @@ func (dc *DynamicCache) currentInformer(ctx context.Context, key store.Key) (inf
 		return nil, false, fmt.Errorf("find informer for %s: %w", gvk, err)
 	}
 
+	dc.addIndexHandler(informer)
 	dc.checkKeySynced(ctx, informer, key)
 	dc.seenGVKs.setSeen(key.Namespace, gvk, true)
 
 	return informer, dc.informerSynced.hasSynced(key), nil
 }
 
+// addIndexHandler adds the search index's handler to an informer the first time the informer is used.
+func (dc *DynamicCache) addIndexHandler(informer informers.GenericInformer) {
+	if dc.index == nil {
+		return
+	}
+
+	dc.indexMu.Lock()
+	defer dc.indexMu.Unlock()
+
+	sharedInformer := informer.Informer()
+	if dc.indexed[sharedInformer] {
+		return
+	}
+
+	sharedInformer.AddEventHandler(dc.index.Handler())
+	dc.indexed[sharedInformer] = true
+}
+
 func (dc *DynamicCache) checkKeySynced(ctx context.Context, informer informers.GenericInformer, key store.Key) {
 	dc.updateMu.Lock()
 	defer dc.updateMu.Unlock()
@@ -471,6 +513,12 @@ func (dc *DynamicCache) Unwatch(ctx context.Context, groupVersionKinds ...schema
 		}
 	}
 
+	if dc.index != nil {
+		for _, groupVersionKind := range groupVersionKinds {
+			dc.index.DeleteKind(groupVersionKind)
+		}
+	}
+
 	return nil
 }
 
@@ -528,6 +576,15 @@ func (dc *DynamicCache) UpdateClusterClient(ctx context.Context, client cluster.
 	dc.access = NewResourceAccess(client)
 	dc.updateMu.Unlock()
 
+	if dc.index != nil {
+		dc.indexMu.Lock()
+		dc.indexed = make(map[kcache.SharedIndexInformer]bool)
+		dc.indexMu.Unlock()
+
+		dc.index.Reset()
+		dc.index.SetAccess(dc.access)
+	}
+
 	for _, fn := range dc.updateFns {
 		fn(dc)
 	}
@@ -652,23 +709,16 @@ func CreateOrUpdateFromHandler(
 	clusterClient cluster.ClientInterface,
 ) ([]string, error) {
 	withDoc := func(cb func(doc map[string]interface{}) error) error {
-		d := yaml.NewYAMLOrJSONDecoder(bytes.NewBufferString(input), 4096)
-		for {
-			doc := map[string]interface{}{}
-			if err := d.Decode(&doc); err != nil {
-				if err == io.EOF {
-					return nil
-				}
-				return fmt.Errorf("unable to parse yaml: %w", err)
-			}
-			if len(doc) == 0 {
-				// skip empty documents
-				continue
-			}
+		docs, err := decodeDocuments(input)
+		if err != nil {
+			return err
+		}
+		for _, doc := range docs {
 			if err := cb(doc); err != nil {
 				return err
 			}
 		}
+		return nil
 	}
 
 	logger := log.From(ctx)
@@ -728,7 +778,7 @@ func CreateOrUpdateFromHandler(
 				key.Name,
 				types.ApplyPatchType,
 				unstructuredYaml,
-				metav1.PatchOptions{FieldManager: "octant", Force: &withForce},
+				metav1.PatchOptions{FieldManager: FieldManager, Force: &withForce},
 			)
 			if err != nil {
 				return fmt.Errorf("unable to patch resource: %w", err)
@@ -739,7 +789,7 @@ func CreateOrUpdateFromHandler(
 				key.Name,
 				types.ApplyPatchType,
 				unstructuredYaml,
-				metav1.PatchOptions{FieldManager: "octant", Force: &withForce},
+				metav1.PatchOptions{FieldManager: FieldManager, Force: &withForce},
 			)
 			if err != nil {
 				return fmt.Errorf("unable to patch resource: %w", err)
@@ -0,0 +1,310 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package objectstore
+
+import (
+	"context"
+	"fmt"
+	"sync"
+	"time"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kcache "k8s.io/client-go/tools/cache"
+
+	"github.com/vmware-tanzu/octant/internal/auth"
+	"github.com/vmware-tanzu/octant/internal/cluster"
+	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+const (
+	// defaultPartitionIdleTimeout is how long a user's store is kept after it was last used.
+	defaultPartitionIdleTimeout = 30 * time.Minute
+)
+
+// partition is the store for a single user.
+type partition struct {
+	user     auth.User
+	store    store.Store
+	cancel   context.CancelFunc
+	lastUsed time.Time
+}
+
+// Attacher is a store which keeps a user's store while a long-lived client, such as
+// a websocket, is attached.
+type Attacher interface {
+	// Attach keeps the store for the user authenticated in ctx until ctx is done.
+	Attach(ctx context.Context)
+}
+
+// PartitionedStoreOpt is an option for configuring PartitionedStore.
+type PartitionedStoreOpt func(*PartitionedStore)
+
+// PartitionIdleTimeout sets how long a user's store is kept after it was last used.
+func PartitionIdleTimeout(timeout time.Duration) PartitionedStoreOpt {
+	return func(ps *PartitionedStore) {
+		ps.idleTimeout = timeout
+	}
+}
+
+// PartitionFactory sets the function which creates a user's store.
+func PartitionFactory(fn func(context.Context, cluster.ClientInterface) (store.Store, error)) PartitionedStoreOpt {
+	return func(ps *PartitionedStore) {
+		ps.newStore = fn
+	}
+}
+
+// PartitionedStore is a store which keeps a separate store for each authenticated user.
+// Each user's store uses a cluster client which impersonates the user, so its cache
+// only contains objects the user can see. Requests without a user, or for users the
+// cluster client does not impersonate, use the base store. A user's store is removed
+// when it has been idle and no client is attached for the user.
+type PartitionedStore struct {
+	ctx         context.Context
+	base        store.Store
+	newStore    func(context.Context, cluster.ClientInterface) (store.Store, error)
+	idleTimeout time.Duration
+	now         func() time.Time
+
+	mu         sync.Mutex
+	client     cluster.ClientInterface
+	partitions map[cluster.ClientInterface]*partition
+	attached   map[string]int
+}
+
+var _ store.Store = (*PartitionedStore)(nil)
+var _ Attacher = (*PartitionedStore)(nil)
+
+// NewPartitionedStore creates an instance of PartitionedStore. Users' stores are stopped
+// when ctx is done.
+func NewPartitionedStore(ctx context.Context, client cluster.ClientInterface, base store.Store, options ...PartitionedStoreOpt) *PartitionedStore {
+	ps := &PartitionedStore{
+		ctx:         ctx,
+		base:        base,
+		newStore:    newUserDynamicCache,
+		idleTimeout: defaultPartitionIdleTimeout,
+		now:         time.Now,
+		client:      client,
+		partitions:  make(map[cluster.ClientInterface]*partition),
+		attached:    make(map[string]int),
+	}
+
+	for _, option := range options {
+		option(ps)
+	}
+
+	return ps
+}
+
+// newUserDynamicCache creates a dynamic cache whose informers stop when ctx is done.
+func newUserDynamicCache(ctx context.Context, client cluster.ClientInterface) (store.Store, error) {
+	return NewDynamicCache(ctx, client,
+		Access(NewResourceAccess(client)),
+		informerContext(ctx))
+}
+
+// storeFor returns the store for the user authenticated in ctx.
+func (ps *PartitionedStore) storeFor(ctx context.Context) (store.Store, error) {
+	ps.mu.Lock()
+	defer ps.mu.Unlock()
+
+	client, err := cluster.ClientForContext(ctx, ps.client)
+	if err != nil {
+		return nil, fmt.Errorf("client for user: %w", err)
+	}
+
+	if client == ps.client {
+		return ps.base, nil
+	}
+
+	user, _ := auth.UserFrom(ctx)
+
+	now := ps.now()
+	ps.removeIdle(now)
+
+	p, ok := ps.partitions[client]
+	if !ok {
+		partitionCtx, cancel := context.WithCancel(ps.ctx)
+		s, err := ps.newStore(partitionCtx, client)
+		if err != nil {
+			cancel()
+			return nil, fmt.Errorf("create store for user: %w", err)
+		}
+
+		p = &partition{user: *user, store: s, cancel: cancel}
+		ps.partitions[client] = p
+	}
+
+	p.lastUsed = now
+
+	return p.store, nil
+}
+
+// Attach keeps the store for the user authenticated in ctx until ctx is done. The
+// store's idle time starts when the last attached client is done.
+func (ps *PartitionedStore) Attach(ctx context.Context) {
+	user, ok := auth.UserFrom(ctx)
+	if !ok {
+		return
+	}
+
+	key := cluster.IdentityKey(user.Name, user.Groups)
+
+	ps.mu.Lock()
+	ps.attached[key]++
+	ps.mu.Unlock()
+
+	go func() {
+		<-ctx.Done()
+
+		ps.mu.Lock()
+		defer ps.mu.Unlock()
+
+		ps.attached[key]--
+		if ps.attached[key] == 0 {
+			delete(ps.attached, key)
+		}
+
+		now := ps.now()
+		for _, p := range ps.partitions {
+			if cluster.IdentityKey(p.user.Name, p.user.Groups) == key {
+				p.lastUsed = now
+			}
+		}
+	}()
+}
+
+func (ps *PartitionedStore) removeIdle(now time.Time) {
+	for client, p := range ps.partitions {
+		if ps.attached[cluster.IdentityKey(p.user.Name, p.user.Groups)] > 0 {
+			continue
+		}
+		if now.Sub(p.lastUsed) > ps.idleTimeout {
+			ps.remove(client, p)
+		}
+	}
+}
+
+// remove stops a user's store and releases the client which impersonates the user.
+func (ps *PartitionedStore) remove(client cluster.ClientInterface, p *partition) {
+	p.cancel()
+	delete(ps.partitions, client)
+	ps.client.ReleaseUser(p.user.Name, p.user.Groups)
+}
+
+// List lists objects using the user's store.
+func (ps *PartitionedStore) List(ctx context.Context, key store.Key) (*unstructured.UnstructuredList, bool, error) {
+	s, err := ps.storeFor(ctx)
+	if err != nil {
+		return nil, false, err
+	}
+
+	return s.List(ctx, key)
+}
+
+// Get gets an object using the user's store.
+func (ps *PartitionedStore) Get(ctx context.Context, key store.Key) (*unstructured.Unstructured, error) {
+	s, err := ps.storeFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.Get(ctx, key)
+}
+
+// Delete deletes an object using the user's store.
+func (ps *PartitionedStore) Delete(ctx context.Context, key store.Key) error {
+	s, err := ps.storeFor(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.Delete(ctx, key)
+}
+
+// Watch watches a key using the user's store.
+func (ps *PartitionedStore) Watch(ctx context.Context, key store.Key, handler kcache.ResourceEventHandler) error {
+	s, err := ps.storeFor(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.Watch(ctx, key, handler)
+}
+
+// Unwatch un-watches group version kinds in the user's store.
+func (ps *PartitionedStore) Unwatch(ctx context.Context, groupVersionKinds ...schema.GroupVersionKind) error {
+	s, err := ps.storeFor(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.Unwatch(ctx, groupVersionKinds...)
+}
+
+// UpdateClusterClient updates the base store's cluster client. Users' stores are
+// stopped and recreated with the new client when they are next used.
+func (ps *PartitionedStore) UpdateClusterClient(ctx context.Context, client cluster.ClientInterface) error {
+	ps.mu.Lock()
+	for key, p := range ps.partitions {
+		ps.remove(key, p)
+	}
+	ps.client = client
+	ps.mu.Unlock()
+
+	log.From(ctx).Debugf("removed user stores after cluster client update")
+
+	return ps.base.UpdateClusterClient(ctx, client)
+}
+
+// RegisterOnUpdate registers a function that is called with the partitioned store
+// when the base store updates its client.
+func (ps *PartitionedStore) RegisterOnUpdate(fn store.UpdateFn) {
+	ps.base.RegisterOnUpdate(func(store.Store) {
+		fn(ps)
+	})
+}
+
+// Update updates an object using the user's store.
+func (ps *PartitionedStore) Update(ctx context.Context, key store.Key, updater func(*unstructured.Unstructured) error) error {
+	s, err := ps.storeFor(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.Update(ctx, key, updater)
+}
+
+// IsLoading returns true if the user's store is loading the key.
+func (ps *PartitionedStore) IsLoading(ctx context.Context, key store.Key) bool {
+	s, err := ps.storeFor(ctx)
+	if err != nil {
+		return false
+	}
+
+	return s.IsLoading(ctx, key)
+}
+
+// Create creates an object using the user's store.
+func (ps *PartitionedStore) Create(ctx context.Context, object *unstructured.Unstructured) error {
+	s, err := ps.storeFor(ctx)
+	if err != nil {
+		return err
+	}
+
+	return s.Create(ctx, object)
+}
+
+// CreateOrUpdateFromYAML creates or updates objects from YAML using the user's store.
+func (ps *PartitionedStore) CreateOrUpdateFromYAML(ctx context.Context, namespace, input string) ([]string, error) {
+	s, err := ps.storeFor(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	return s.CreateOrUpdateFromYAML(ctx, namespace, input)
+}
@@ -0,0 +1,200 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package objectstore
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/octant/internal/auth"
+	"github.com/vmware-tanzu/octant/internal/cluster"
+	clusterfake "github.com/vmware-tanzu/octant/internal/cluster/fake"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	storefake "github.com/vmware-tanzu/octant/pkg/store/fake"
+)
+
+func TestPartitionedStore(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	client := clusterfake.NewMockClientInterface(controller)
+	aliceClient := clusterfake.NewMockClientInterface(controller)
+	bobClient := clusterfake.NewMockClientInterface(controller)
+	client.EXPECT().ForUser("alice", gomock.Any()).Return(aliceClient, nil).AnyTimes()
+	client.EXPECT().ForUser("bob", gomock.Any()).Return(bobClient, nil).AnyTimes()
+	client.EXPECT().ForUser("self", gomock.Any()).Return(client, nil).AnyTimes()
+
+	base := storefake.NewMockStore(controller)
+	aliceStore := storefake.NewMockStore(controller)
+	bobStore := storefake.NewMockStore(controller)
+
+	created := map[cluster.ClientInterface]int{}
+	partitionCtxs := map[cluster.ClientInterface]context.Context{}
+	factory := func(ctx context.Context, c cluster.ClientInterface) (store.Store, error) {
+		created[c]++
+		partitionCtxs[c] = ctx
+		switch c {
+		case aliceClient:
+			return aliceStore, nil
+		case bobClient:
+			return bobStore, nil
+		}
+		t.Fatalf("unexpected client")
+		return nil, nil
+	}
+
+	now := time.Unix(1600000000, 0)
+	ps := NewPartitionedStore(context.Background(), client, base,
+		PartitionFactory(factory), PartitionIdleTimeout(time.Minute))
+	ps.now = func() time.Time { return now }
+
+	key := store.Key{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "pod"}
+	object := &unstructured.Unstructured{}
+
+	ctx := context.Background()
+	aliceCtx := auth.WithUser(ctx, &auth.User{Name: "alice"})
+	bobCtx := auth.WithUser(ctx, &auth.User{Name: "bob"})
+	selfCtx := auth.WithUser(ctx, &auth.User{Name: "self"})
+
+	base.EXPECT().Get(ctx, key).Return(object, nil)
+	base.EXPECT().Get(selfCtx, key).Return(object, nil)
+	aliceStore.EXPECT().Get(aliceCtx, key).Return(object, nil).Times(2)
+	bobStore.EXPECT().Get(bobCtx, key).Return(object, nil)
+
+	for _, c := range []context.Context{ctx, selfCtx, aliceCtx, aliceCtx, bobCtx} {
+		_, err := ps.Get(c, key)
+		require.NoError(t, err)
+	}
+
+	assert.Equal(t, 1, created[aliceClient])
+	assert.Equal(t, 1, created[bobClient])
+
+	// bob's store is removed after it has been idle.
+	now = now.Add(30 * time.Second)
+	aliceStore.EXPECT().Get(aliceCtx, key).Return(object, nil)
+	_, err := ps.Get(aliceCtx, key)
+	require.NoError(t, err)
+
+	now = now.Add(45 * time.Second)
+	client.EXPECT().ReleaseUser("bob", gomock.Nil())
+	aliceStore.EXPECT().Get(aliceCtx, key).Return(object, nil)
+	_, err = ps.Get(aliceCtx, key)
+	require.NoError(t, err)
+	assert.Error(t, partitionCtxs[bobClient].Err())
+	assert.NoError(t, partitionCtxs[aliceClient].Err())
+
+	// All users' stores are removed when the cluster client changes.
+	client.EXPECT().ReleaseUser("alice", gomock.Nil())
+	base.EXPECT().UpdateClusterClient(ctx, client).Return(nil)
+	require.NoError(t, ps.UpdateClusterClient(ctx, client))
+	assert.Error(t, partitionCtxs[aliceClient].Err())
+
+	aliceStore.EXPECT().Get(aliceCtx, key).Return(object, nil)
+	_, err = ps.Get(aliceCtx, key)
+	require.NoError(t, err)
+	assert.Equal(t, 2, created[aliceClient])
+}
+
+func TestPartitionedStore_RegisterOnUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	client := clusterfake.NewMockClientInterface(controller)
+	base := storefake.NewMockStore(controller)
+
+	var registered store.UpdateFn
+	base.EXPECT().RegisterOnUpdate(gomock.Any()).Do(func(fn store.UpdateFn) {
+		registered = fn
+	})
+
+	ps := NewPartitionedStore(context.Background(), client, base)
+
+	var got store.Store
+	ps.RegisterOnUpdate(func(s store.Store) {
+		got = s
+	})
+
+	registered(base)
+	assert.Equal(t, ps, got)
+}
+
+func TestPartitionedStore_Attach(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	client := clusterfake.NewMockClientInterface(controller)
+	aliceClient := clusterfake.NewMockClientInterface(controller)
+	client.EXPECT().ForUser("alice", gomock.Any()).Return(aliceClient, nil).AnyTimes()
+
+	base := storefake.NewMockStore(controller)
+	aliceStore := storefake.NewMockStore(controller)
+
+	var partitionCtx context.Context
+	factory := func(ctx context.Context, c cluster.ClientInterface) (store.Store, error) {
+		partitionCtx = ctx
+		return aliceStore, nil
+	}
+
+	var mu sync.Mutex
+	now := time.Unix(1600000000, 0)
+	ps := NewPartitionedStore(context.Background(), client, base,
+		PartitionFactory(factory), PartitionIdleTimeout(time.Minute))
+	ps.now = func() time.Time {
+		mu.Lock()
+		defer mu.Unlock()
+		return now
+	}
+	advance := func(d time.Duration) {
+		mu.Lock()
+		defer mu.Unlock()
+		now = now.Add(d)
+	}
+
+	key := store.Key{APIVersion: "v1", Kind: "Pod", Namespace: "default", Name: "pod"}
+	object := &unstructured.Unstructured{}
+
+	aliceCtx := auth.WithUser(context.Background(), &auth.User{Name: "alice", Groups: []string{"group"}})
+	attachCtx, detach := context.WithCancel(aliceCtx)
+	ps.Attach(attachCtx)
+
+	aliceStore.EXPECT().Get(aliceCtx, key).Return(object, nil).Times(2)
+	_, err := ps.Get(aliceCtx, key)
+	require.NoError(t, err)
+
+	// alice's store is kept while a client is attached.
+	advance(time.Hour)
+	_, err = ps.Get(aliceCtx, key)
+	require.NoError(t, err)
+	assert.NoError(t, partitionCtx.Err())
+
+	// alice's store is idle once the client is done.
+	detach()
+	require.Eventually(t, func() bool {
+		ps.mu.Lock()
+		defer ps.mu.Unlock()
+		return len(ps.attached) == 0
+	}, time.Second, 10*time.Millisecond)
+
+	advance(30 * time.Second)
+	ps.mu.Lock()
+	ps.removeIdle(ps.now())
+	ps.mu.Unlock()
+	assert.NoError(t, partitionCtx.Err())
+
+	client.EXPECT().ReleaseUser("alice", []string{"group"})
+	advance(45 * time.Second)
+	ps.mu.Lock()
+	ps.removeIdle(ps.now())
+	ps.mu.Unlock()
+	assert.Error(t, partitionCtx.Err())
+}
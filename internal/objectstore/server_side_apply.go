@@ -0,0 +1,326 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package objectstore
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"reflect"
+	"sort"
+	"strings"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/client-go/dynamic"
+	sigyaml "sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/octant/internal/cluster"
+	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+// FieldManager is the field manager Octant uses for server-side apply.
+const FieldManager = "octant"
+
+// ApplyOptions configures ServerSideApply.
+type ApplyOptions struct {
+	// DryRun applies objects with dryRun=All, so nothing is persisted.
+	DryRun bool
+	// Force takes ownership of fields which are managed by other field managers.
+	Force bool
+}
+
+// ApplyConflict is a field which is owned by another field manager.
+type ApplyConflict struct {
+	Field   string
+	Message string
+}
+
+// ApplyResult is the result of applying a single object.
+type ApplyResult struct {
+	Key        store.Key
+	Namespaced bool
+	// DryRun is true if the object was applied with dryRun=All, so it was not persisted.
+	DryRun bool
+	// Live is the object before it was applied. It is nil if the object did not exist.
+	Live *unstructured.Unstructured
+	// Applied is the object returned by the API server. It is nil if the object had
+	// conflicts.
+	Applied *unstructured.Unstructured
+	// Conflicts are the fields other field managers own. The object was not applied
+	// if there are conflicts.
+	Conflicts []ApplyConflict
+}
+
+// Created returns true if the object did not exist before it was applied.
+func (r ApplyResult) Created() bool {
+	return r.Live == nil
+}
+
+// Changes returns the paths of the fields which are different in the live and
+// applied objects.
+func (r ApplyResult) Changes() []string {
+	if r.Applied == nil {
+		return nil
+	}
+
+	var live map[string]interface{}
+	if r.Live != nil {
+		live = r.Live.Object
+	}
+
+	var changes []string
+	diffFields("", live, r.Applied.Object, &changes)
+	sort.Strings(changes)
+
+	return changes
+}
+
+// ServerSideApply applies the objects in input using server-side apply with Octant's
+// field manager. Namespaced objects without a namespace are applied to namespace.
+// Managed fields are removed from the live and applied objects in the results since
+// they differ on every apply.
+//
+// Unless options.DryRun is set, every object is first applied with dryRun=All, and
+// objects are only persisted if all of them pass. If the dry run fails or has
+// conflicts, the dry run results are returned. If persisting an object fails, the
+// results of the objects persisted before it are returned with the error.
+func ServerSideApply(ctx context.Context, client cluster.ClientInterface, namespace, input string, options ApplyOptions) ([]ApplyResult, error) {
+	if client == nil {
+		return nil, fmt.Errorf("cluster client is nil")
+	}
+
+	targets, err := applyTargets(ctx, client, namespace, input)
+	if err != nil {
+		return nil, err
+	}
+
+	dryRunOptions := options
+	dryRunOptions.DryRun = true
+
+	results, err := applyAll(ctx, targets, dryRunOptions)
+	if err != nil {
+		return results, fmt.Errorf("dry run: %w", err)
+	}
+	if options.DryRun || HasApplyConflicts(results) {
+		return results, nil
+	}
+
+	return applyAll(ctx, targets, options)
+}
+
+// applyTarget is an object to apply and the resource it is applied with.
+type applyTarget struct {
+	key        store.Key
+	namespaced bool
+	resource   dynamic.ResourceInterface
+	live       *unstructured.Unstructured
+	data       []byte
+}
+
+// applyTargets decodes the objects in input and gets their live objects.
+func applyTargets(ctx context.Context, client cluster.ClientInterface, namespace, input string) ([]applyTarget, error) {
+	docs, err := decodeDocuments(input)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := client.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("unable to get dynamic client: %w", err)
+	}
+
+	var targets []applyTarget
+	for _, doc := range docs {
+		object := &unstructured.Unstructured{Object: doc}
+		key, err := store.KeyFromObject(object)
+		if err != nil {
+			return nil, err
+		}
+
+		gvr, namespaced, err := client.Resource(key.GroupVersionKind().GroupKind())
+		if err != nil {
+			return nil, fmt.Errorf("unable to discover resource: %w", err)
+		}
+
+		var resource dynamic.ResourceInterface = dynamicClient.Resource(gvr)
+		if namespaced {
+			if key.Namespace == "" {
+				object.SetNamespace(namespace)
+				key.Namespace = namespace
+			}
+			resource = dynamicClient.Resource(gvr).Namespace(key.Namespace)
+		}
+
+		target := applyTarget{key: key, namespaced: namespaced, resource: resource}
+
+		live, err := resource.Get(ctx, key.Name, metav1.GetOptions{})
+		if err != nil {
+			if !kerrors.IsNotFound(err) {
+				return nil, fmt.Errorf("unable to get resource: %w", err)
+			}
+		} else {
+			target.live = withoutManagedFields(live)
+		}
+
+		// Apply requests may not set managed fields.
+		object.SetManagedFields(nil)
+
+		target.data, err = sigyaml.Marshal(object.Object)
+		if err != nil {
+			return nil, fmt.Errorf("unable to marshal resource as yaml: %w", err)
+		}
+
+		targets = append(targets, target)
+	}
+
+	return targets, nil
+}
+
+// applyAll applies targets in order. It stops at the first target which can't be
+// applied for a reason other than conflicts.
+func applyAll(ctx context.Context, targets []applyTarget, options ApplyOptions) ([]ApplyResult, error) {
+	logger := log.From(ctx)
+
+	patchOptions := metav1.PatchOptions{
+		FieldManager: FieldManager,
+		Force:        &options.Force,
+	}
+	if options.DryRun {
+		patchOptions.DryRun = []string{metav1.DryRunAll}
+	}
+
+	var results []ApplyResult
+	for _, target := range targets {
+		result := ApplyResult{
+			Key:        target.key,
+			Namespaced: target.namespaced,
+			DryRun:     options.DryRun,
+			Live:       target.live,
+		}
+
+		logger.With("key", target.key.String(), "dryRun", options.DryRun).Debugf("server-side apply")
+
+		applied, err := target.resource.Patch(ctx, target.key.Name, types.ApplyPatchType, target.data, patchOptions)
+		if err != nil {
+			conflicts, ok := applyConflicts(err)
+			if !ok {
+				return results, fmt.Errorf("unable to apply %s: %w", target.key, err)
+			}
+
+			result.Conflicts = conflicts
+		} else {
+			result.Applied = withoutManagedFields(applied)
+		}
+
+		results = append(results, result)
+	}
+
+	return results, nil
+}
+
+// HasApplyConflicts returns true if any of results has conflicts.
+func HasApplyConflicts(results []ApplyResult) bool {
+	for _, result := range results {
+		if len(result.Conflicts) > 0 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// decodeDocuments decodes the non-empty YAML or JSON documents in input.
+func decodeDocuments(input string) ([]map[string]interface{}, error) {
+	d := yaml.NewYAMLOrJSONDecoder(bytes.NewBufferString(input), 4096)
+
+	var docs []map[string]interface{}
+	for {
+		doc := map[string]interface{}{}
+		if err := d.Decode(&doc); err != nil {
+			if err == io.EOF {
+				return docs, nil
+			}
+			return nil, fmt.Errorf("unable to parse yaml: %w", err)
+		}
+		if len(doc) == 0 {
+			// skip empty documents
+			continue
+		}
+		docs = append(docs, doc)
+	}
+}
+
+// applyConflicts returns the field manager conflicts in an apply error. It returns
+// false if err is not caused by fields owned by other field managers.
+func applyConflicts(err error) ([]ApplyConflict, bool) {
+	if !kerrors.IsConflict(err) {
+		return nil, false
+	}
+
+	status, ok := err.(kerrors.APIStatus)
+	if !ok || status.Status().Details == nil {
+		return nil, false
+	}
+
+	var conflicts []ApplyConflict
+	for _, cause := range status.Status().Details.Causes {
+		if cause.Type != metav1.CauseTypeFieldManagerConflict {
+			continue
+		}
+		conflicts = append(conflicts, ApplyConflict{
+			Field:   cause.Field,
+			Message: cause.Message,
+		})
+	}
+
+	return conflicts, len(conflicts) > 0
+}
+
+func withoutManagedFields(object *unstructured.Unstructured) *unstructured.Unstructured {
+	object = object.DeepCopy()
+	object.SetManagedFields(nil)
+	return object
+}
+
+// diffFields appends the paths of the fields which differ in a and b to changes.
+// Lists are compared as a whole.
+func diffFields(prefix string, a, b map[string]interface{}, changes *[]string) {
+	keys := map[string]bool{}
+	for k := range a {
+		keys[k] = true
+	}
+	for k := range b {
+		keys[k] = true
+	}
+
+	for k := range keys {
+		path := strings.TrimPrefix(prefix+"."+k, ".")
+
+		av, aOK := a[k]
+		bv, bOK := b[k]
+		if !aOK || !bOK {
+			*changes = append(*changes, path)
+			continue
+		}
+
+		am, aIsMap := av.(map[string]interface{})
+		bm, bIsMap := bv.(map[string]interface{})
+		if aIsMap && bIsMap {
+			diffFields(path, am, bm, changes)
+			continue
+		}
+
+		if !reflect.DeepEqual(av, bv) {
+			*changes = append(*changes, path)
+		}
+	}
+}
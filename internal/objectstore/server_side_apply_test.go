@@ -0,0 +1,287 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package objectstore
+
+import (
+	"context"
+	"encoding/json"
+	"io/ioutil"
+	"net/http"
+	"net/http/httptest"
+	"strconv"
+	"strings"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/rest"
+	sigyaml "sigs.k8s.io/yaml"
+
+	clusterfake "github.com/vmware-tanzu/octant/internal/cluster/fake"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+const applyInput = `---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: existing
+data:
+  key: new
+---
+apiVersion: v1
+kind: ConfigMap
+metadata:
+  name: created
+  namespace: other
+data:
+  key: value
+`
+
+func TestServerSideApply(t *testing.T) {
+	cases := []struct {
+		name     string
+		options  ApplyOptions
+		conflict bool
+		dryRuns  []string
+	}{
+		{
+			name:    "dry run",
+			options: ApplyOptions{DryRun: true},
+			dryRuns: []string{metav1.DryRunAll, metav1.DryRunAll},
+		},
+		{
+			name:    "force",
+			options: ApplyOptions{Force: true},
+			dryRuns: []string{metav1.DryRunAll, metav1.DryRunAll, "", ""},
+		},
+		{
+			name:     "conflict",
+			options:  ApplyOptions{DryRun: true},
+			conflict: true,
+			dryRuns:  []string{metav1.DryRunAll, metav1.DryRunAll},
+		},
+		{
+			name:     "apply conflict",
+			options:  ApplyOptions{},
+			conflict: true,
+			dryRuns:  []string{metav1.DryRunAll, metav1.DryRunAll},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			var patched, dryRuns []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch r.Method {
+				case http.MethodGet:
+					if r.URL.Path != "/api/v1/namespaces/default/configmaps/existing" {
+						writeStatus(t, w, kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "created"))
+						return
+					}
+					writeObject(t, w, map[string]interface{}{
+						"apiVersion": "v1",
+						"kind":       "ConfigMap",
+						"metadata": map[string]interface{}{
+							"name":          "existing",
+							"namespace":     "default",
+							"managedFields": []interface{}{map[string]interface{}{"manager": "kubectl"}},
+						},
+						"data": map[string]interface{}{"key": "old"},
+					})
+				case http.MethodPatch:
+					assert.Equal(t, string(types.ApplyPatchType), r.Header.Get("Content-Type"))
+
+					query := r.URL.Query()
+					assert.Equal(t, FieldManager, query.Get("fieldManager"))
+					assert.Equal(t, strconv.FormatBool(tc.options.Force), query.Get("force"))
+
+					patched = append(patched, r.URL.Path)
+					dryRuns = append(dryRuns, query.Get("dryRun"))
+
+					if tc.conflict && strings.HasSuffix(r.URL.Path, "/existing") {
+						writeStatus(t, w, kerrors.NewApplyConflict([]metav1.StatusCause{
+							{
+								Type:    metav1.CauseTypeFieldManagerConflict,
+								Field:   ".data.key",
+								Message: `conflict with "kubectl"`,
+							},
+						}, "conflict"))
+						return
+					}
+
+					data, err := ioutil.ReadAll(r.Body)
+					require.NoError(t, err)
+					object := map[string]interface{}{}
+					require.NoError(t, sigyaml.Unmarshal(data, &object))
+					object["metadata"].(map[string]interface{})["managedFields"] = []interface{}{
+						map[string]interface{}{"manager": FieldManager},
+					}
+					writeObject(t, w, object)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			dynamicClient, err := dynamic.NewForConfig(&rest.Config{Host: server.URL})
+			require.NoError(t, err)
+
+			client := clusterfake.NewMockClientInterface(controller)
+			client.EXPECT().DynamicClient().Return(dynamicClient, nil)
+			client.EXPECT().
+				Resource(schema.GroupKind{Kind: "ConfigMap"}).
+				Return(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, true, nil).
+				Times(2)
+
+			results, err := ServerSideApply(context.Background(), client, "default", applyInput, tc.options)
+			require.NoError(t, err)
+			require.Len(t, results, 2)
+
+			paths := []string{
+				"/api/v1/namespaces/default/configmaps/existing",
+				"/api/v1/namespaces/other/configmaps/created",
+			}
+			var expected []string
+			for range tc.dryRuns {
+				expected = append(expected, paths[len(expected)%len(paths)])
+			}
+			assert.Equal(t, expected, patched)
+			assert.Equal(t, tc.dryRuns, dryRuns)
+
+			persisted := dryRuns[len(dryRuns)-1] == ""
+			for _, result := range results {
+				assert.Equal(t, !persisted, result.DryRun)
+			}
+
+			updated := results[0]
+			assert.Equal(t, store.Key{Namespace: "default", APIVersion: "v1", Kind: "ConfigMap", Name: "existing"}, updated.Key)
+			assert.True(t, updated.Namespaced)
+			assert.False(t, updated.Created())
+			assert.Empty(t, updated.Live.GetManagedFields())
+
+			if tc.conflict {
+				assert.Nil(t, updated.Applied)
+				assert.Equal(t, []ApplyConflict{{Field: ".data.key", Message: `conflict with "kubectl"`}}, updated.Conflicts)
+				assert.True(t, HasApplyConflicts(results))
+			} else {
+				assert.Empty(t, updated.Applied.GetManagedFields())
+				assert.Equal(t, []string{"data.key"}, updated.Changes())
+				assert.False(t, HasApplyConflicts(results))
+			}
+
+			created := results[1]
+			assert.Equal(t, "other", created.Key.Namespace)
+			assert.True(t, created.Created())
+			assert.Equal(t, []string{"apiVersion", "data", "kind", "metadata"}, created.Changes())
+		})
+	}
+}
+
+func TestServerSideApply_failure(t *testing.T) {
+	cases := []struct {
+		name    string
+		dryRun  bool
+		dryRuns []string
+	}{
+		{
+			name:    "dry run fails",
+			dryRun:  true,
+			dryRuns: []string{metav1.DryRunAll, metav1.DryRunAll},
+		},
+		{
+			name:    "apply fails",
+			dryRuns: []string{metav1.DryRunAll, metav1.DryRunAll, "", ""},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			var dryRuns []string
+			server := httptest.NewServer(http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				w.Header().Set("Content-Type", "application/json")
+
+				switch r.Method {
+				case http.MethodGet:
+					writeStatus(t, w, kerrors.NewNotFound(schema.GroupResource{Resource: "configmaps"}, "configmap"))
+				case http.MethodPatch:
+					dryRun := r.URL.Query().Get("dryRun")
+					dryRuns = append(dryRuns, dryRun)
+
+					if strings.HasSuffix(r.URL.Path, "/created") && (dryRun != "") == tc.dryRun {
+						writeStatus(t, w, kerrors.NewInvalid(schema.GroupKind{Kind: "ConfigMap"}, "created", nil))
+						return
+					}
+
+					data, err := ioutil.ReadAll(r.Body)
+					require.NoError(t, err)
+					object := map[string]interface{}{}
+					require.NoError(t, sigyaml.Unmarshal(data, &object))
+					writeObject(t, w, object)
+				default:
+					t.Errorf("unexpected request %s %s", r.Method, r.URL.Path)
+				}
+			}))
+			defer server.Close()
+
+			dynamicClient, err := dynamic.NewForConfig(&rest.Config{Host: server.URL})
+			require.NoError(t, err)
+
+			client := clusterfake.NewMockClientInterface(controller)
+			client.EXPECT().DynamicClient().Return(dynamicClient, nil)
+			client.EXPECT().
+				Resource(schema.GroupKind{Kind: "ConfigMap"}).
+				Return(schema.GroupVersionResource{Version: "v1", Resource: "configmaps"}, true, nil).
+				Times(2)
+
+			results, err := ServerSideApply(context.Background(), client, "default", applyInput, ApplyOptions{})
+			require.Error(t, err)
+			assert.Equal(t, tc.dryRuns, dryRuns)
+
+			// Only the objects applied before the failure are in the results.
+			require.Len(t, results, 1)
+			assert.Equal(t, "existing", results[0].Key.Name)
+			assert.Equal(t, tc.dryRun, results[0].DryRun)
+			assert.NotNil(t, results[0].Applied)
+		})
+	}
+}
+
+func TestServerSideApply_invalidYAML(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	client := clusterfake.NewMockClientInterface(controller)
+
+	_, err := ServerSideApply(context.Background(), client, "default", "kind: [", ApplyOptions{})
+	require.Error(t, err)
+}
+
+func writeObject(t *testing.T, w http.ResponseWriter, object map[string]interface{}) {
+	require.NoError(t, json.NewEncoder(w).Encode(object))
+}
+
+func writeStatus(t *testing.T, w http.ResponseWriter, err *kerrors.StatusError) {
+	status := err.Status()
+	status.Kind = "Status"
+	status.APIVersion = "v1"
+	w.WriteHeader(int(status.Code))
+	require.NoError(t, json.NewEncoder(w).Encode(status))
+}
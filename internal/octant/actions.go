@@ -15,16 +15,38 @@ const (
 	ActionDeleteObject            = "action.octant.dev/deleteObject"
 	ActionOverviewCordon          = "action.octant.dev/cordon"
 	ActionOverviewUncordon        = "action.octant.dev/uncordon"
+	ActionOverviewDrain           = "action.octant.dev/drain"
 	ActionOverviewContainerEditor = "action.octant.dev/containerEditor"
 	ActionOverviewCronjob         = "action.octant.dev/cronJob"
 	ActionOverviewSuspendCronjob  = "action.octant.dev/suspendCronJob"
 	ActionOverviewResumeCronjob   = "action.octant.dev/resumeCronJob"
 	ActionOverviewServiceEditor   = "action.octant.dev/serviceEditor"
 	ActionDeploymentConfiguration = "action.octant.dev/deploymentConfiguration"
+	ActionRollback                = "action.octant.dev/rollback"
 	ActionUpdateObject            = "action.octant.dev/update"
 	ActionApplyYaml               = "action.octant.dev/apply"
+	ActionResourceViewerExport    = "action.octant.dev/resourceViewerExport"
+	ActionNetworkReachability     = "action.octant.dev/networkReachability"
 )
 
+// MutatingActions are the actions which change objects in the cluster. They are
+// disabled in read-only mode.
+var MutatingActions = []string{
+	ActionDeleteObject,
+	ActionOverviewCordon,
+	ActionOverviewUncordon,
+	ActionOverviewDrain,
+	ActionOverviewContainerEditor,
+	ActionOverviewCronjob,
+	ActionOverviewSuspendCronjob,
+	ActionOverviewResumeCronjob,
+	ActionOverviewServiceEditor,
+	ActionDeploymentConfiguration,
+	ActionRollback,
+	ActionUpdateObject,
+	ActionApplyYaml,
+}
+
 func sendAlert(alerter action.Alerter, alertType action.AlertType, message string, expiration *time.Time) {
 	alert := action.Alert{
 		Type:       alertType,
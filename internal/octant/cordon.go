@@ -75,7 +75,7 @@ func (c *Cordon) Handle(ctx context.Context, alerter action.Alerter, payload act
 
 	message := fmt.Sprintf("Node %q marked as unschedulable", key.Name)
 	alertType := action.AlertTypeInfo
-	if err := c.Cordon(node); err != nil {
+	if err := c.Cordon(ctx, node); err != nil {
 		message = fmt.Sprintf("Unable to cordon node %q: %s", key.Name, err)
 		alertType = action.AlertTypeWarning
 		logger := log.From(ctx)
@@ -87,17 +87,22 @@ func (c *Cordon) Handle(ctx context.Context, alerter action.Alerter, payload act
 }
 
 // Cordon marks a node as unschedulable
-func (c *Cordon) Cordon(node *corev1.Node) error {
+func (c *Cordon) Cordon(ctx context.Context, node *corev1.Node) error {
 	if node == nil {
 		return errors.New("nil node")
 	}
 
-	client, err := c.clusterClient.KubernetesClient()
+	clusterClient, err := cluster.ClientForContext(ctx, c.clusterClient)
 	if err != nil {
 		return err
 	}
 
-	currentNode, err := client.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+	client, err := clusterClient.KubernetesClient()
+	if err != nil {
+		return err
+	}
+
+	currentNode, err := client.CoreV1().Nodes().Get(ctx, node.Name, metav1.GetOptions{})
 	if err != nil {
 		return errors.Wrapf(err, "unable to find node %q", node.Name)
 	}
@@ -120,9 +125,9 @@ func (c *Cordon) Cordon(node *corev1.Node) error {
 
 	patchBytes, patchErr := strategicpatch.CreateTwoWayMergePatch(originalNode, modifiedNode, node)
 	if patchErr != nil {
-		_, err = client.CoreV1().Nodes().Patch(context.TODO(), node.Name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+		_, err = client.CoreV1().Nodes().Patch(ctx, node.Name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
 	} else {
-		_, err = client.CoreV1().Nodes().Update(context.TODO(), currentNode, metav1.UpdateOptions{})
+		_, err = client.CoreV1().Nodes().Update(ctx, currentNode, metav1.UpdateOptions{})
 		return errors.Wrapf(err, "failed to cordon %q", node.Name)
 	}
 
@@ -178,7 +183,7 @@ func (u *Uncordon) Handle(ctx context.Context, alerter action.Alerter, payload a
 
 	message := fmt.Sprintf("Node %q marked as schedulable", key.Name)
 	alertType := action.AlertTypeInfo
-	if err := u.Uncordon(node); err != nil {
+	if err := u.Uncordon(ctx, node); err != nil {
 		message = fmt.Sprintf("Unable to uncordon node %q: %s", key.Name, err)
 		alertType = action.AlertTypeWarning
 		logger := log.From(ctx)
@@ -190,17 +195,22 @@ func (u *Uncordon) Handle(ctx context.Context, alerter action.Alerter, payload a
 }
 
 // Uncordon marks a node as schedulable
-func (u *Uncordon) Uncordon(node *corev1.Node) error {
+func (u *Uncordon) Uncordon(ctx context.Context, node *corev1.Node) error {
 	if node == nil {
 		return errors.New("nil node")
 	}
 
-	client, err := u.clusterClient.KubernetesClient()
+	clusterClient, err := cluster.ClientForContext(ctx, u.clusterClient)
+	if err != nil {
+		return err
+	}
+
+	client, err := clusterClient.KubernetesClient()
 	if err != nil {
 		return err
 	}
 
-	currentNode, err := client.CoreV1().Nodes().Get(context.TODO(), node.Name, metav1.GetOptions{})
+	currentNode, err := client.CoreV1().Nodes().Get(ctx, node.Name, metav1.GetOptions{})
 	if err != nil {
 		return errors.Wrapf(err, "unable to find node %q", node.Name)
 	}
@@ -223,9 +233,9 @@ func (u *Uncordon) Uncordon(node *corev1.Node) error {
 
 	patchBytes, patchErr := strategicpatch.CreateTwoWayMergePatch(originalNode, modifiedNode, node)
 	if patchErr != nil {
-		_, err = client.CoreV1().Nodes().Patch(context.TODO(), node.Name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
+		_, err = client.CoreV1().Nodes().Patch(ctx, node.Name, types.StrategicMergePatchType, patchBytes, metav1.PatchOptions{})
 	} else {
-		_, err = client.CoreV1().Nodes().Update(context.TODO(), currentNode, metav1.UpdateOptions{})
+		_, err = client.CoreV1().Nodes().Update(ctx, currentNode, metav1.UpdateOptions{})
 		return errors.Wrapf(err, "failed to uncordon %q", node.Name)
 	}
 
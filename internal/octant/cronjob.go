@@ -76,7 +76,7 @@ func (c *CronJobTrigger) Handle(ctx context.Context, alerter action.Alerter, pay
 
 	var message string
 	alertType := action.AlertTypeInfo
-	if err := c.Trigger(newJobName, cronjob); err != nil {
+	if err := c.Trigger(ctx, newJobName, cronjob); err != nil {
 		message = fmt.Sprintf("Unable to create job %q: %s", key.Name, err)
 		logger := log.From(ctx)
 		logger.WithErr(err).Errorf("trigger cronjob")
@@ -88,12 +88,17 @@ func (c *CronJobTrigger) Handle(ctx context.Context, alerter action.Alerter, pay
 }
 
 // Trigger manually creates a new job
-func (c *CronJobTrigger) Trigger(name string, cronJob *batchv1beta1.CronJob) error {
+func (c *CronJobTrigger) Trigger(ctx context.Context, name string, cronJob *batchv1beta1.CronJob) error {
 	if cronJob == nil {
 		return errors.New("nil cronjob")
 	}
 
-	client, err := c.clusterClient.KubernetesClient()
+	clusterClient, err := cluster.ClientForContext(ctx, c.clusterClient)
+	if err != nil {
+		return err
+	}
+
+	client, err := clusterClient.KubernetesClient()
 	if err != nil {
 		return err
 	}
@@ -116,7 +121,7 @@ func (c *CronJobTrigger) Trigger(name string, cronJob *batchv1beta1.CronJob) err
 		Spec: cronJob.Spec.JobTemplate.Spec,
 	}
 
-	_, err = client.BatchV1().Jobs(cronJob.Namespace).Create(context.TODO(), jobToCreate, metav1.CreateOptions{})
+	_, err = client.BatchV1().Jobs(cronJob.Namespace).Create(ctx, jobToCreate, metav1.CreateOptions{})
 	if err != nil {
 		return err
 	}
@@ -4,3 +4,378 @@
  */
 
 package octant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	"github.com/pkg/errors"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubernetesclient "k8s.io/client-go/kubernetes"
+
+	"github.com/vmware-tanzu/octant/internal/cluster"
+	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/util/kubernetes"
+	"github.com/vmware-tanzu/octant/pkg/action"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+const (
+	// mirrorPodAnnotation marks static pods managed by the kubelet. They can't be evicted.
+	mirrorPodAnnotation = "kubernetes.io/config.mirror"
+
+	defaultDrainTimeout      = 5 * time.Minute
+	defaultDrainPollInterval = 2 * time.Second
+)
+
+// DrainOptions configures how a node is drained.
+type DrainOptions struct {
+	// IgnoreDaemonSets skips pods managed by a DaemonSet instead of aborting the drain.
+	IgnoreDaemonSets bool
+	// DeleteEmptyDirData allows evicting pods using emptyDir volumes. The data in those volumes is lost.
+	DeleteEmptyDirData bool
+	// Force allows evicting pods which are not managed by a controller.
+	Force bool
+	// GracePeriodSeconds overrides the pod termination grace period. A negative value uses the
+	// grace period defined by the pod.
+	GracePeriodSeconds int64
+	// Timeout is how long to wait for all pods to be evicted.
+	Timeout time.Duration
+	// PollInterval is the interval used when retrying evictions blocked by a disruption budget
+	// and when waiting for evicted pods to be deleted.
+	PollInterval time.Duration
+}
+
+// DrainOptionsFromPayload creates drain options from an action payload.
+func DrainOptionsFromPayload(payload action.Payload) (DrainOptions, error) {
+	options := DrainOptions{
+		GracePeriodSeconds: -1,
+		Timeout:            defaultDrainTimeout,
+		PollInterval:       defaultDrainPollInterval,
+	}
+
+	var err error
+	if options.IgnoreDaemonSets, err = optionalBool(payload, "ignoreDaemonSets"); err != nil {
+		return DrainOptions{}, err
+	}
+	if options.DeleteEmptyDirData, err = optionalBool(payload, "deleteEmptyDirData"); err != nil {
+		return DrainOptions{}, err
+	}
+	if options.Force, err = optionalBool(payload, "force"); err != nil {
+		return DrainOptions{}, err
+	}
+
+	if _, ok := payload["gracePeriod"]; ok {
+		if options.GracePeriodSeconds, err = payload.Int64("gracePeriod"); err != nil {
+			return DrainOptions{}, err
+		}
+	}
+
+	if _, ok := payload["timeout"]; ok {
+		timeout, err := payload.Int64("timeout")
+		if err != nil {
+			return DrainOptions{}, err
+		}
+		if timeout > 0 {
+			options.Timeout = time.Duration(timeout) * time.Second
+		}
+	}
+
+	return options, nil
+}
+
+func optionalBool(payload action.Payload, key string) (bool, error) {
+	if _, ok := payload[key]; !ok {
+		return false, nil
+	}
+
+	return payload.Bool(key)
+}
+
+// Drain cordons a node and evicts its pods
+type Drain struct {
+	store         store.Store
+	clusterClient cluster.ClientInterface
+}
+
+var _ action.Dispatcher = (*Drain)(nil)
+
+// NewDrain creates an instance of Drain
+func NewDrain(objectStore store.Store, clusterClient cluster.ClientInterface) *Drain {
+	drain := &Drain{
+		store:         objectStore,
+		clusterClient: clusterClient,
+	}
+
+	return drain
+}
+
+// ActionName returns the name of this action
+func (d *Drain) ActionName() string {
+	return ActionOverviewDrain
+}
+
+// Handle executing drain. Draining can take a long time, so the pods are evicted in the
+// background and progress is reported to the client using alerts.
+func (d *Drain) Handle(ctx context.Context, alerter action.Alerter, payload action.Payload) error {
+	logger := log.From(ctx).With("actionName", d.ActionName())
+	logger.With("payload", payload).Infof("received action payload")
+
+	key, err := store.KeyFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	options, err := DrainOptionsFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	object, err := d.store.Get(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	if object == nil {
+		return errors.New("object store cannot get node")
+	}
+
+	node := &corev1.Node{}
+	if err := kubernetes.FromUnstructured(object, node); err != nil {
+		return err
+	}
+
+	go func() {
+		message := fmt.Sprintf("Node %q drained", key.Name)
+		alertType := action.AlertTypeSuccess
+		if err := d.Drain(ctx, node, options, alerter); err != nil {
+			message = fmt.Sprintf("Unable to drain node %q: %s", key.Name, err)
+			alertType = action.AlertTypeWarning
+			logger.WithErr(err).Errorf("drain node")
+		}
+		alert := action.CreateAlert(alertType, message, action.DefaultAlertExpiration)
+		alerter.SendAlert(alert)
+	}()
+
+	return nil
+}
+
+// Drain cordons a node and evicts the pods running on it. Evictions go through the
+// Eviction API, so PodDisruptionBudgets are honored. Evictions blocked by a disruption
+// budget are retried until the drain times out. Progress for each pod is sent to alerter.
+func (d *Drain) Drain(ctx context.Context, node *corev1.Node, options DrainOptions, alerter action.Alerter) error {
+	if node == nil {
+		return errors.New("nil node")
+	}
+
+	if options.Timeout <= 0 {
+		options.Timeout = defaultDrainTimeout
+	}
+	if options.PollInterval <= 0 {
+		options.PollInterval = defaultDrainPollInterval
+	}
+
+	clusterClient, err := cluster.ClientForContext(ctx, d.clusterClient)
+	if err != nil {
+		return err
+	}
+
+	client, err := clusterClient.KubernetesClient()
+	if err != nil {
+		return err
+	}
+
+	pods, err := podsToEvict(ctx, client, node.Name, options, alerter)
+	if err != nil {
+		return err
+	}
+
+	if !node.Spec.Unschedulable {
+		cordon := NewCordon(d.store, d.clusterClient)
+		if err := cordon.Cordon(ctx, node); err != nil {
+			return err
+		}
+		sendDrainAlert(alerter, action.AlertTypeInfo, "Node %q marked as unschedulable", node.Name)
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, options.Timeout)
+	defer cancel()
+
+	for i := range pods {
+		if err := evictPod(ctx, client, pods[i], options, alerter); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+// podsToEvict lists the pods on a node and returns the ones which should be evicted. It returns
+// an error describing every pod blocking the drain given the supplied options.
+func podsToEvict(ctx context.Context, client kubernetesclient.Interface, nodeName string, options DrainOptions, alerter action.Alerter) ([]corev1.Pod, error) {
+	listOptions := metav1.ListOptions{
+		FieldSelector: fields.OneTermEqualSelector("spec.nodeName", nodeName).String(),
+	}
+	podList, err := client.CoreV1().Pods(metav1.NamespaceAll).List(ctx, listOptions)
+	if err != nil {
+		return nil, errors.Wrapf(err, "list pods on node %q", nodeName)
+	}
+
+	var pods []corev1.Pod
+	var blockers []string
+
+	for _, pod := range podList.Items {
+		// field selectors aren't guaranteed to be honored by every client
+		if pod.Spec.NodeName != nodeName {
+			continue
+		}
+
+		if _, ok := pod.Annotations[mirrorPodAnnotation]; ok {
+			continue
+		}
+
+		name := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+		isFinished := pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+
+		controllerRef := metav1.GetControllerOf(&pod)
+		if controllerRef != nil && controllerRef.Kind == "DaemonSet" {
+			if options.IgnoreDaemonSets {
+				sendDrainAlert(alerter, action.AlertTypeInfo, "Ignoring DaemonSet managed pod %q", name)
+				continue
+			}
+			blockers = append(blockers, fmt.Sprintf("%s is managed by a DaemonSet", name))
+			continue
+		}
+
+		if controllerRef == nil && !isFinished && !options.Force {
+			blockers = append(blockers, fmt.Sprintf("%s is not managed by a controller", name))
+			continue
+		}
+
+		if hasEmptyDir(pod) && !isFinished && !options.DeleteEmptyDirData {
+			blockers = append(blockers, fmt.Sprintf("%s uses emptyDir data", name))
+			continue
+		}
+
+		pods = append(pods, pod)
+	}
+
+	if len(blockers) > 0 {
+		return nil, errors.Errorf("cannot evict pods: %s", strings.Join(blockers, ", "))
+	}
+
+	return pods, nil
+}
+
+func hasEmptyDir(pod corev1.Pod) bool {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.EmptyDir != nil {
+			return true
+		}
+	}
+
+	return false
+}
+
+// evictPod evicts a pod and waits for it to be deleted.
+func evictPod(ctx context.Context, client kubernetesclient.Interface, pod corev1.Pod, options DrainOptions, alerter action.Alerter) error {
+	name := fmt.Sprintf("%s/%s", pod.Namespace, pod.Name)
+
+	eviction := &policyv1beta1.Eviction{
+		ObjectMeta: metav1.ObjectMeta{
+			Name:      pod.Name,
+			Namespace: pod.Namespace,
+		},
+	}
+	if options.GracePeriodSeconds >= 0 {
+		gracePeriod := options.GracePeriodSeconds
+		eviction.DeleteOptions = &metav1.DeleteOptions{GracePeriodSeconds: &gracePeriod}
+	}
+
+	sendDrainAlert(alerter, action.AlertTypeInfo, "Evicting pod %q", name)
+
+	blocked := false
+	err := wait.PollImmediateUntil(options.PollInterval, func() (bool, error) {
+		err := client.CoreV1().Pods(pod.Namespace).Evict(ctx, eviction)
+		switch {
+		case err == nil, kerrors.IsNotFound(err):
+			return true, nil
+		case kerrors.IsTooManyRequests(err):
+			if !blocked {
+				blocked = true
+				sendDrainAlert(alerter, action.AlertTypeWarning,
+					"Eviction of pod %q is blocked by %s; retrying", name, disruptionBudgetsFor(ctx, client, pod))
+			}
+			return false, nil
+		default:
+			return false, err
+		}
+	}, ctx.Done())
+	if err != nil {
+		if err == wait.ErrWaitTimeout {
+			return errors.Errorf("timed out evicting pod %q", name)
+		}
+		return errors.Wrapf(err, "evict pod %q", name)
+	}
+
+	err = wait.PollImmediateUntil(options.PollInterval, func() (bool, error) {
+		current, err := client.CoreV1().Pods(pod.Namespace).Get(ctx, pod.Name, metav1.GetOptions{})
+		if kerrors.IsNotFound(err) {
+			return true, nil
+		}
+		if err != nil {
+			return false, err
+		}
+
+		return current.UID != pod.UID, nil
+	}, ctx.Done())
+	if err != nil {
+		if err == wait.ErrWaitTimeout {
+			return errors.Errorf("timed out waiting for pod %q to be deleted", name)
+		}
+		return errors.Wrapf(err, "wait for pod %q to be deleted", name)
+	}
+
+	sendDrainAlert(alerter, action.AlertTypeSuccess, "Evicted pod %q", name)
+	return nil
+}
+
+// disruptionBudgetsFor describes the PodDisruptionBudgets selecting a pod.
+func disruptionBudgetsFor(ctx context.Context, client kubernetesclient.Interface, pod corev1.Pod) string {
+	const fallback = "a PodDisruptionBudget"
+
+	list, err := client.PolicyV1beta1().PodDisruptionBudgets(pod.Namespace).List(ctx, metav1.ListOptions{})
+	if err != nil {
+		return fallback
+	}
+
+	var names []string
+	for _, pdb := range list.Items {
+		selector, err := metav1.LabelSelectorAsSelector(pdb.Spec.Selector)
+		if err != nil || selector.Empty() {
+			continue
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			names = append(names, fmt.Sprintf("PodDisruptionBudget %q", pdb.Name))
+		}
+	}
+
+	if len(names) == 0 {
+		return fallback
+	}
+
+	return strings.Join(names, ", ")
+}
+
+func sendDrainAlert(alerter action.Alerter, alertType action.AlertType, format string, args ...interface{}) {
+	alert := action.CreateAlert(alertType, fmt.Sprintf(format, args...), action.DefaultAlertExpiration)
+	alerter.SendAlert(alert)
+}
@@ -0,0 +1,331 @@
+/*
+ * Copyright (c) 2020 the Octant contributors. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package octant_test
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	policyv1beta1 "k8s.io/api/policy/v1beta1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	testClient "k8s.io/client-go/kubernetes/fake"
+	k8stesting "k8s.io/client-go/testing"
+
+	clusterFake "github.com/vmware-tanzu/octant/internal/cluster/fake"
+	"github.com/vmware-tanzu/octant/internal/octant"
+	"github.com/vmware-tanzu/octant/internal/testutil"
+	"github.com/vmware-tanzu/octant/pkg/action"
+	actionFake "github.com/vmware-tanzu/octant/pkg/action/fake"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	"github.com/vmware-tanzu/octant/pkg/store/fake"
+)
+
+func Test_Drain(t *testing.T) {
+	nodeName := "node"
+
+	replicaSet := testutil.CreateAppReplicaSet("rs")
+	daemonSet := testutil.CreateDaemonSet("ds")
+
+	managedPod := testutil.CreatePod("managed", onNode(nodeName), ownedBy(t, replicaSet))
+	daemonSetPod := testutil.CreatePod("daemon", onNode(nodeName), ownedBy(t, daemonSet))
+	mirrorPod := testutil.CreatePod("mirror", onNode(nodeName), func(pod *corev1.Pod) {
+		pod.Annotations = map[string]string{"kubernetes.io/config.mirror": "mirror"}
+	})
+	emptyDirPod := testutil.CreatePod("empty-dir", onNode(nodeName), ownedBy(t, replicaSet), func(pod *corev1.Pod) {
+		pod.Spec.Volumes = []corev1.Volume{
+			{Name: "scratch", VolumeSource: corev1.VolumeSource{EmptyDir: &corev1.EmptyDirVolumeSource{}}},
+		}
+	})
+	unmanagedPod := testutil.CreatePod("unmanaged", onNode(nodeName))
+	otherNodePod := testutil.CreatePod("other", onNode("other"), ownedBy(t, replicaSet))
+
+	cases := []struct {
+		name       string
+		pods       []*corev1.Pod
+		options    octant.DrainOptions
+		blockedBy  int
+		evicted    []string
+		remaining  []string
+		wantErr    bool
+		wantCordon bool
+	}{
+		{
+			name:       "evicts managed pods and ignores daemon set pods",
+			pods:       []*corev1.Pod{managedPod, daemonSetPod, mirrorPod, otherNodePod},
+			options:    octant.DrainOptions{IgnoreDaemonSets: true},
+			evicted:    []string{"managed"},
+			remaining:  []string{"daemon", "mirror", "other"},
+			wantCordon: true,
+		},
+		{
+			name:      "daemon set pods block drain",
+			pods:      []*corev1.Pod{managedPod, daemonSetPod},
+			remaining: []string{"managed", "daemon"},
+			wantErr:   true,
+		},
+		{
+			name:      "emptyDir pods block drain",
+			pods:      []*corev1.Pod{emptyDirPod},
+			remaining: []string{"empty-dir"},
+			wantErr:   true,
+		},
+		{
+			name:       "emptyDir pods are evicted when data can be deleted",
+			pods:       []*corev1.Pod{emptyDirPod},
+			options:    octant.DrainOptions{DeleteEmptyDirData: true},
+			evicted:    []string{"empty-dir"},
+			wantCordon: true,
+		},
+		{
+			name:      "unmanaged pods block drain",
+			pods:      []*corev1.Pod{unmanagedPod},
+			remaining: []string{"unmanaged"},
+			wantErr:   true,
+		},
+		{
+			name:       "unmanaged pods are evicted when forced",
+			pods:       []*corev1.Pod{unmanagedPod},
+			options:    octant.DrainOptions{Force: true},
+			evicted:    []string{"unmanaged"},
+			wantCordon: true,
+		},
+		{
+			name:       "retries evictions blocked by a disruption budget",
+			pods:       []*corev1.Pod{managedPod},
+			blockedBy:  2,
+			evicted:    []string{"managed"},
+			wantCordon: true,
+		},
+		{
+			name:       "times out when a disruption budget never allows eviction",
+			pods:       []*corev1.Pod{managedPod},
+			options:    octant.DrainOptions{Timeout: 50 * time.Millisecond},
+			blockedBy:  -1,
+			remaining:  []string{"managed"},
+			wantErr:    true,
+			wantCordon: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			node := testutil.CreateNode(nodeName)
+
+			objects := []runtime.Object{node}
+			for _, pod := range tc.pods {
+				objects = append(objects, pod.DeepCopy())
+			}
+			fakeClientset := testClient.NewSimpleClientset(objects...)
+			attempts := evictionReactor(fakeClientset, tc.blockedBy)
+
+			kubernetesClient := clusterFake.NewMockKubernetesInterface(controller)
+			kubernetesClient.EXPECT().CoreV1().AnyTimes().Return(fakeClientset.CoreV1())
+			kubernetesClient.EXPECT().PolicyV1beta1().AnyTimes().Return(fakeClientset.PolicyV1beta1())
+			clusterClient := clusterFake.NewMockClientInterface(controller)
+			clusterClient.EXPECT().KubernetesClient().AnyTimes().Return(kubernetesClient, nil)
+
+			alerter := actionFake.NewMockAlerter(controller)
+			alerter.EXPECT().SendAlert(gomock.Any()).AnyTimes()
+
+			objectStore := fake.NewMockStore(controller)
+			drain := octant.NewDrain(objectStore, clusterClient)
+
+			options := tc.options
+			options.GracePeriodSeconds = -1
+			options.PollInterval = time.Millisecond
+			if options.Timeout == 0 {
+				options.Timeout = time.Second
+			}
+
+			err := drain.Drain(context.Background(), node, options, alerter)
+			testutil.RequireErrorOrNot(t, tc.wantErr, err)
+
+			current, err := fakeClientset.CoreV1().Nodes().Get(context.Background(), nodeName, metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.wantCordon, current.Spec.Unschedulable)
+
+			for _, name := range tc.evicted {
+				_, err := fakeClientset.CoreV1().Pods(testutil.DefaultNamespace).Get(context.Background(), name, metav1.GetOptions{})
+				assert.True(t, kerrors.IsNotFound(err), "expected pod %q to be evicted", name)
+			}
+			for _, name := range tc.remaining {
+				_, err := fakeClientset.CoreV1().Pods(testutil.DefaultNamespace).Get(context.Background(), name, metav1.GetOptions{})
+				assert.NoError(t, err, "expected pod %q to remain", name)
+			}
+
+			if tc.blockedBy > 0 {
+				assert.Equal(t, tc.blockedBy+1, attempts())
+			}
+		})
+	}
+}
+
+func Test_Drain_Handle(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	node := testutil.CreateNode("node")
+	pod := testutil.CreatePod("pod", onNode(node.Name), ownedBy(t, testutil.CreateAppReplicaSet("rs")))
+
+	fakeClientset := testClient.NewSimpleClientset(node, pod)
+	evictionReactor(fakeClientset, 0)
+
+	kubernetesClient := clusterFake.NewMockKubernetesInterface(controller)
+	kubernetesClient.EXPECT().CoreV1().AnyTimes().Return(fakeClientset.CoreV1())
+	clusterClient := clusterFake.NewMockClientInterface(controller)
+	clusterClient.EXPECT().KubernetesClient().AnyTimes().Return(kubernetesClient, nil)
+
+	key := store.Key{APIVersion: "v1", Kind: "Node", Name: node.Name}
+	objectStore := fake.NewMockStore(controller)
+	objectStore.EXPECT().
+		Get(gomock.Any(), gomock.Eq(key)).
+		Return(testutil.ToUnstructured(t, node), nil)
+
+	done := make(chan action.Alert, 1)
+	var messages []string
+	alerter := actionFake.NewMockAlerter(controller)
+	alerter.EXPECT().
+		SendAlert(gomock.Any()).
+		DoAndReturn(func(alert action.Alert) {
+			messages = append(messages, alert.Message)
+			if alert.Message == `Node "node" drained` {
+				done <- alert
+			}
+		}).
+		AnyTimes()
+
+	drain := octant.NewDrain(objectStore, clusterClient)
+	assert.Equal(t, octant.ActionOverviewDrain, drain.ActionName())
+
+	payload := action.CreatePayload(octant.ActionOverviewDrain, map[string]interface{}{
+		"apiVersion": "v1",
+		"kind":       "Node",
+		"name":       node.Name,
+	})
+	require.NoError(t, drain.Handle(context.Background(), alerter, payload))
+
+	select {
+	case alert := <-done:
+		assert.Equal(t, action.AlertTypeSuccess, alert.Type)
+	case <-time.After(5 * time.Second):
+		t.Fatal("timed out waiting for drain to complete")
+	}
+
+	expected := []string{
+		`Node "node" marked as unschedulable`,
+		`Evicting pod "namespace/pod"`,
+		`Evicted pod "namespace/pod"`,
+		`Node "node" drained`,
+	}
+	assert.Equal(t, expected, messages)
+}
+
+func TestDrainOptionsFromPayload(t *testing.T) {
+	tests := []struct {
+		name    string
+		payload action.Payload
+		want    octant.DrainOptions
+		wantErr bool
+	}{
+		{
+			name:    "defaults",
+			payload: action.Payload{},
+			want: octant.DrainOptions{
+				GracePeriodSeconds: -1,
+				Timeout:            5 * time.Minute,
+				PollInterval:       2 * time.Second,
+			},
+		},
+		{
+			name: "all options",
+			payload: action.Payload{
+				"ignoreDaemonSets":   true,
+				"deleteEmptyDirData": true,
+				"force":              true,
+				"gracePeriod":        float64(30),
+				"timeout":            float64(60),
+			},
+			want: octant.DrainOptions{
+				IgnoreDaemonSets:   true,
+				DeleteEmptyDirData: true,
+				Force:              true,
+				GracePeriodSeconds: 30,
+				Timeout:            time.Minute,
+				PollInterval:       2 * time.Second,
+			},
+		},
+		{
+			name:    "invalid option",
+			payload: action.Payload{"force": "yes"},
+			wantErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := octant.DrainOptionsFromPayload(tt.payload)
+			testutil.RequireErrorOrNot(t, tt.wantErr, err, func() {
+				assert.Equal(t, tt.want, got)
+			})
+		})
+	}
+}
+
+// evictionReactor deletes pods when they are evicted. The first blockedBy evictions fail as if
+// a disruption budget disallowed them; a negative value blocks every eviction.
+func evictionReactor(clientset *testClient.Clientset, blockedBy int) func() int {
+	var mu sync.Mutex
+	attempts := 0
+
+	clientset.PrependReactor("create", "pods", func(a k8stesting.Action) (bool, runtime.Object, error) {
+		if a.GetSubresource() != "eviction" {
+			return false, nil, nil
+		}
+
+		mu.Lock()
+		attempts++
+		current := attempts
+		mu.Unlock()
+
+		eviction := a.(k8stesting.CreateAction).GetObject().(*policyv1beta1.Eviction)
+		if blockedBy < 0 || current <= blockedBy {
+			return true, nil, kerrors.NewTooManyRequests("disruption budget", 0)
+		}
+
+		gvr := schema.GroupVersionResource{Version: "v1", Resource: "pods"}
+		return true, nil, clientset.Tracker().Delete(gvr, eviction.Namespace, eviction.Name)
+	})
+
+	return func() int {
+		mu.Lock()
+		defer mu.Unlock()
+		return attempts
+	}
+}
+
+func onNode(nodeName string) testutil.PodOption {
+	return func(pod *corev1.Pod) {
+		pod.Spec.NodeName = nodeName
+	}
+}
+
+func ownedBy(t *testing.T, owner runtime.Object) testutil.PodOption {
+	return func(pod *corev1.Pod) {
+		pod.OwnerReferences = testutil.ToOwnerReferences(t, owner)
+	}
+}
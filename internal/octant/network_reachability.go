@@ -0,0 +1,102 @@
+/*
+ * Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package octant
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/networkpolicy"
+	"github.com/vmware-tanzu/octant/pkg/action"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+// NetworkReachability checks whether network policies allow traffic from a source
+// to a destination on a port, and sends the verdict to the client as an alert.
+type NetworkReachability struct {
+	store store.Store
+}
+
+var _ action.Dispatcher = (*NetworkReachability)(nil)
+
+// NewNetworkReachability creates an instance of NetworkReachability.
+func NewNetworkReachability(objectStore store.Store) *NetworkReachability {
+	return &NetworkReachability{
+		store: objectStore,
+	}
+}
+
+// ActionName returns the name of this action.
+func (n *NetworkReachability) ActionName() string {
+	return ActionNetworkReachability
+}
+
+// Handle checks whether the payload's source can reach its destination on its port.
+func (n *NetworkReachability) Handle(ctx context.Context, alerter action.Alerter, payload action.Payload) error {
+	logger := log.From(ctx).With("actionName", n.ActionName())
+	logger.With("payload", payload).Debugf("received action payload")
+
+	verdict, err := n.check(ctx, payload)
+	if err != nil {
+		message := fmt.Sprintf("Unable to check reachability: %s", err)
+		alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning, message, action.DefaultAlertExpiration))
+		return nil
+	}
+
+	alertType, result := action.AlertTypeInfo, "Allowed"
+	if !verdict.Allowed {
+		alertType, result = action.AlertTypeWarning, "Denied"
+	}
+	alerter.SendAlert(action.CreateAlert(alertType, fmt.Sprintf("%s: %s", result, verdict.Reason), action.DefaultAlertExpiration))
+
+	return nil
+}
+
+func (n *NetworkReachability) check(ctx context.Context, payload action.Payload) (networkpolicy.Verdict, error) {
+	namespace, err := payload.String("namespace")
+	if err != nil {
+		return networkpolicy.Verdict{}, err
+	}
+	source, err := payload.OptionalString("source")
+	if err != nil {
+		return networkpolicy.Verdict{}, err
+	}
+	destination, err := payload.OptionalString("destination")
+	if err != nil {
+		return networkpolicy.Verdict{}, err
+	}
+	portString, err := payload.String("port")
+	if err != nil {
+		return networkpolicy.Verdict{}, err
+	}
+
+	if strings.TrimSpace(source) == "" && strings.TrimSpace(destination) == "" {
+		return networkpolicy.Verdict{}, fmt.Errorf("source or destination must be a pod")
+	}
+
+	port, err := networkpolicy.ParsePort(portString)
+	if err != nil {
+		return networkpolicy.Verdict{}, err
+	}
+
+	analyzer, err := networkpolicy.Load(ctx, n.store)
+	if err != nil {
+		return networkpolicy.Verdict{}, fmt.Errorf("load network policies: %w", err)
+	}
+
+	sourceEndpoint, err := analyzer.ParseEndpoint(namespace, source)
+	if err != nil {
+		return networkpolicy.Verdict{}, fmt.Errorf("source: %w", err)
+	}
+	destinationEndpoint, err := analyzer.ParseEndpoint(namespace, destination)
+	if err != nil {
+		return networkpolicy.Verdict{}, fmt.Errorf("destination: %w", err)
+	}
+
+	return analyzer.CanReach(sourceEndpoint, destinationEndpoint, port), nil
+}
@@ -0,0 +1,103 @@
+/*
+ * Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package octant_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+
+	"github.com/vmware-tanzu/octant/internal/octant"
+	"github.com/vmware-tanzu/octant/internal/testutil"
+	"github.com/vmware-tanzu/octant/pkg/action"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	"github.com/vmware-tanzu/octant/pkg/store/fake"
+)
+
+func TestNetworkReachability(t *testing.T) {
+	web := testutil.CreatePod("web")
+	web.Labels = map[string]string{"app": "web"}
+	api := testutil.CreatePod("api")
+
+	denyAll := &networkingv1.NetworkPolicy{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: web.Namespace, Name: "deny-web"},
+		Spec: networkingv1.NetworkPolicySpec{
+			PodSelector: metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+		},
+	}
+
+	cases := []struct {
+		name      string
+		payload   action.Payload
+		alertType action.AlertType
+		message   string
+	}{
+		{
+			name: "allowed",
+			payload: action.Payload{
+				"namespace":   web.Namespace,
+				"source":      web.Name,
+				"destination": api.Name,
+				"port":        "8080",
+			},
+			alertType: action.AlertTypeInfo,
+			message:   "Allowed: ",
+		},
+		{
+			name: "denied",
+			payload: action.Payload{
+				"namespace":   web.Namespace,
+				"source":      api.Name,
+				"destination": web.Name,
+				"port":        "TCP/8080",
+			},
+			alertType: action.AlertTypeWarning,
+			message:   "Denied: " + web.Namespace + "/web is isolated for ingress by deny-web",
+		},
+		{
+			name: "missing pod",
+			payload: action.Payload{
+				"namespace":   web.Namespace,
+				"source":      "missing",
+				"destination": web.Name,
+				"port":        "8080",
+			},
+			alertType: action.AlertTypeWarning,
+			message:   "Unable to check reachability: source: pod " + web.Namespace + "/missing was not found",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			objectStore := fake.NewMockStore(controller)
+			objectStore.EXPECT().List(gomock.Any(), store.Key{APIVersion: "networking.k8s.io/v1", Kind: "NetworkPolicy"}).
+				Return(testutil.ToUnstructuredList(t, denyAll), false, nil)
+			objectStore.EXPECT().List(gomock.Any(), store.Key{APIVersion: "v1", Kind: "Namespace"}).
+				Return(testutil.ToUnstructuredList(t), false, nil)
+			objectStore.EXPECT().List(gomock.Any(), store.Key{APIVersion: "v1", Kind: "Pod"}).
+				Return(testutil.ToUnstructuredList(t, web, api), false, nil)
+
+			reachability := octant.NewNetworkReachability(objectStore)
+			assert.Equal(t, octant.ActionNetworkReachability, reachability.ActionName())
+
+			alerter := &eventAlerter{}
+			require.NoError(t, reachability.Handle(context.Background(), alerter, tc.payload))
+
+			require.Len(t, alerter.alerts, 1)
+			assert.Equal(t, tc.alertType, alerter.alerts[0].Type)
+			assert.Contains(t, alerter.alerts[0].Message, tc.message)
+		})
+	}
+}
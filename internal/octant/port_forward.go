@@ -7,6 +7,9 @@ package octant
 
 import (
 	"context"
+	"fmt"
+	"strconv"
+	"strings"
 
 	"github.com/pkg/errors"
 	"k8s.io/apimachinery/pkg/runtime/schema"
@@ -91,6 +94,192 @@ func (p *PortForwardDelete) Handle(ctx context.Context, alerter action.Alerter,
 	return nil
 }
 
+// PortForwardSave saves a port forward so it is restored when Octant starts and
+// when the context changes.
+type PortForwardSave struct {
+	logger        log.Logger
+	portForwarder portforward.PortForwarder
+}
+
+var _ action.Dispatcher = (*PortForwardSave)(nil)
+
+// NewPortForwardSave creates an instance of PortForwardSave
+func NewPortForwardSave(logger log.Logger, portForwarder portforward.PortForwarder) *PortForwardSave {
+	return &PortForwardSave{
+		logger:        logger,
+		portForwarder: portForwarder,
+	}
+}
+
+// ActionName returns the name of this action
+func (p *PortForwardSave) ActionName() string {
+	return "overview/savePortForward"
+}
+
+// Handle saves a port forward. If the payload has the ID of a running port forward
+// it is replaced by the saved one, so the saved one can use its local ports.
+func (p *PortForwardSave) Handle(ctx context.Context, alerter action.Alerter, payload action.Payload) error {
+	p.logger.With("payload", payload).Debugf("received action payload")
+	definition, err := portForwardDefinitionFromPayload(payload)
+	if err != nil {
+		return errors.Wrap(err, "convert payload to saved port forward")
+	}
+
+	forwardID, err := payload.OptionalString("forwardID")
+	if err != nil {
+		return errors.Wrap(err, "convert payload to saved port forward")
+	}
+	if forwardID != "" {
+		p.portForwarder.StopForwarder(forwardID)
+	}
+
+	definition, err = p.portForwarder.Save(ctx, definition)
+	if err != nil {
+		return errors.Wrap(err, "save port forward")
+	}
+
+	message := fmt.Sprintf("Saved port forward to %s", definition.String())
+	alerter.SendAlert(action.CreateAlert(action.AlertTypeInfo, message, action.DefaultAlertExpiration))
+	return nil
+}
+
+// PortForwardRemoveSaved removes a saved port forward
+type PortForwardRemoveSaved struct {
+	logger        log.Logger
+	portForwarder portforward.PortForwarder
+}
+
+var _ action.Dispatcher = (*PortForwardRemoveSaved)(nil)
+
+// NewPortForwardRemoveSaved creates an instance of PortForwardRemoveSaved
+func NewPortForwardRemoveSaved(logger log.Logger, portForwarder portforward.PortForwarder) *PortForwardRemoveSaved {
+	return &PortForwardRemoveSaved{
+		logger:        logger,
+		portForwarder: portForwarder,
+	}
+}
+
+// ActionName returns the name of this action
+func (p *PortForwardRemoveSaved) ActionName() string {
+	return "overview/removeSavedPortForward"
+}
+
+// Handle removes a saved port forward
+func (p *PortForwardRemoveSaved) Handle(ctx context.Context, alerter action.Alerter, payload action.Payload) error {
+	p.logger.With("payload", payload).Debugf("received action payload")
+	id, err := payload.String("id")
+	if err != nil {
+		return errors.Wrap(err, "convert payload to remove saved port forward request")
+	}
+
+	return p.portForwarder.RemoveSaved(id)
+}
+
+// savedPortForwardAPIVersions are the API versions of the kinds a saved port forward
+// targets. They are used when a payload doesn't have an API version.
+var savedPortForwardAPIVersions = map[string]string{
+	"Service":    "v1",
+	"Deployment": "apps/v1",
+	"Pod":        "v1",
+}
+
+// portForwardDefinitionFromPayload converts a payload to a saved port forward. Services and
+// deployments are targeted by name and pods by selector. Ports are either a comma separated
+// list of local:remote or remote ports in "ports", or a single port in "port" and "localPort".
+func portForwardDefinitionFromPayload(payload action.Payload) (portforward.Definition, error) {
+	definition := portforward.Definition{}
+
+	var err error
+	if definition.Namespace, err = payload.String("namespace"); err != nil {
+		return definition, err
+	}
+	if definition.Kind, err = payload.String("kind"); err != nil {
+		return definition, err
+	}
+	if definition.APIVersion, err = payload.OptionalString("apiVersion"); err != nil {
+		return definition, err
+	}
+	if definition.APIVersion == "" {
+		definition.APIVersion = savedPortForwardAPIVersions[definition.Kind]
+	}
+	if definition.Name, err = payload.OptionalString("name"); err != nil {
+		return definition, err
+	}
+	if definition.Selector, err = payload.OptionalString("selector"); err != nil {
+		return definition, err
+	}
+
+	if definition.Ports, err = portSpecsFromPayload(payload); err != nil {
+		return definition, err
+	}
+
+	if err := definition.Validate(); err != nil {
+		return definition, err
+	}
+
+	return definition, nil
+}
+
+func portSpecsFromPayload(payload action.Payload) ([]portforward.PortForwardPortSpec, error) {
+	if _, ok := payload["ports"]; ok {
+		ports, err := payload.String("ports")
+		if err != nil {
+			return nil, err
+		}
+		return parsePortSpecs(ports)
+	}
+
+	port, err := payload.Uint16("port")
+	if err != nil {
+		return nil, err
+	}
+
+	spec := portforward.PortForwardPortSpec{Remote: port}
+	if _, ok := payload["localPort"]; ok {
+		if spec.Local, err = payload.Uint16("localPort"); err != nil {
+			return nil, err
+		}
+	}
+
+	return []portforward.PortForwardPortSpec{spec}, nil
+}
+
+// parsePortSpecs parses a comma separated list of ports. Each port is either a remote
+// port, or local:remote like kubectl port-forward.
+func parsePortSpecs(s string) ([]portforward.PortForwardPortSpec, error) {
+	var specs []portforward.PortForwardPortSpec
+	for _, field := range strings.Split(s, ",") {
+		field = strings.TrimSpace(field)
+		if field == "" {
+			continue
+		}
+
+		local, remote := "", field
+		if i := strings.Index(field, ":"); i >= 0 {
+			local, remote = field[:i], field[i+1:]
+		}
+
+		var spec portforward.PortForwardPortSpec
+		r, err := strconv.ParseUint(remote, 10, 16)
+		if err != nil {
+			return nil, errors.Wrapf(err, "invalid remote port in %q", field)
+		}
+		spec.Remote = uint16(r)
+
+		if local != "" {
+			l, err := strconv.ParseUint(local, 10, 16)
+			if err != nil {
+				return nil, errors.Wrapf(err, "invalid local port in %q", field)
+			}
+			spec.Local = uint16(l)
+		}
+
+		specs = append(specs, spec)
+	}
+
+	return specs, nil
+}
+
 type portForwardCreateRequest struct {
 	APIVersion string `json:"apiVersion,omitempty"`
 	Kind       string `json:"kind,omitempty"`
@@ -0,0 +1,155 @@
+/*
+ * Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package octant_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/octant"
+	"github.com/vmware-tanzu/octant/internal/portforward"
+	portForwardFake "github.com/vmware-tanzu/octant/internal/portforward/fake"
+	"github.com/vmware-tanzu/octant/pkg/action"
+	actionFake "github.com/vmware-tanzu/octant/pkg/action/fake"
+)
+
+func TestPortForwardSave(t *testing.T) {
+	cases := []struct {
+		name      string
+		payload   action.Payload
+		stop      string
+		expected  portforward.Definition
+		wantError bool
+	}{
+		{
+			name: "running port forward",
+			payload: action.Payload{
+				"forwardID":  "forward",
+				"namespace":  "default",
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"name":       "web",
+				"ports":      "8080:80,9090:90",
+			},
+			stop: "forward",
+			expected: portforward.Definition{
+				Namespace:  "default",
+				APIVersion: "v1",
+				Kind:       "Service",
+				Name:       "web",
+				Ports: []portforward.PortForwardPortSpec{
+					{Local: 8080, Remote: 80},
+					{Local: 9090, Remote: 90},
+				},
+			},
+		},
+		{
+			name: "deployment from form",
+			payload: action.Payload{
+				"namespace": "default",
+				"kind":      "Deployment",
+				"name":      "web",
+				"selector":  "",
+				"ports":     "80, 9090:90",
+			},
+			expected: portforward.Definition{
+				Namespace:  "default",
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "web",
+				Ports: []portforward.PortForwardPortSpec{
+					{Remote: 80},
+					{Local: 9090, Remote: 90},
+				},
+			},
+		},
+		{
+			name: "pod selector from form",
+			payload: action.Payload{
+				"namespace": "default",
+				"kind":      "Pod",
+				"name":      "",
+				"selector":  "app=web",
+				"ports":     "80",
+			},
+			expected: portforward.Definition{
+				Namespace:  "default",
+				APIVersion: "v1",
+				Kind:       "Pod",
+				Selector:   "app=web",
+				Ports:      []portforward.PortForwardPortSpec{{Remote: 80}},
+			},
+		},
+		{
+			name: "single port",
+			payload: action.Payload{
+				"namespace":  "default",
+				"apiVersion": "v1",
+				"kind":       "Service",
+				"name":       "web",
+				"port":       float64(80),
+				"localPort":  float64(8080),
+			},
+			expected: portforward.Definition{
+				Namespace:  "default",
+				APIVersion: "v1",
+				Kind:       "Service",
+				Name:       "web",
+				Ports:      []portforward.PortForwardPortSpec{{Local: 8080, Remote: 80}},
+			},
+		},
+		{
+			name: "invalid port",
+			payload: action.Payload{
+				"forwardID": "forward",
+				"namespace": "default",
+				"kind":      "Service",
+				"name":      "web",
+				"ports":     "8080:http",
+			},
+			wantError: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			portForwarder := portForwardFake.NewMockPortForwarder(controller)
+			alerter := actionFake.NewMockAlerter(controller)
+
+			if !tc.wantError {
+				var stopped bool
+				if tc.stop != "" {
+					portForwarder.EXPECT().StopForwarder(tc.stop).Do(func(string) {
+						stopped = true
+					})
+				}
+				portForwarder.EXPECT().Save(gomock.Any(), tc.expected).
+					DoAndReturn(func(ctx context.Context, definition portforward.Definition) (portforward.Definition, error) {
+						// The running port forward is stopped first so its local ports are free.
+						assert.Equal(t, tc.stop != "", stopped)
+						return definition, nil
+					})
+				alerter.EXPECT().SendAlert(gomock.Any())
+			}
+
+			save := octant.NewPortForwardSave(log.NopLogger(), portForwarder)
+			err := save.Handle(context.Background(), alerter, tc.payload)
+			if tc.wantError {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package octant
+
+import (
+	"context"
+	"fmt"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/pkg/action"
+	"github.com/vmware-tanzu/octant/pkg/event"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+// EventSender sends events to a client. Actions which return data to the client
+// that dispatched them send it with the alerter they are handled with, if it is
+// an EventSender.
+type EventSender interface {
+	SendEvent(ev event.Event)
+}
+
+// ResourceViewerExporter exports the resource viewer graph of an object. An empty
+// format is the exporter's default format.
+type ResourceViewerExporter func(ctx context.Context, object *unstructured.Unstructured, format string) (ResourceViewerExportResult, error)
+
+// ResourceViewerExportResult is an exported resource viewer graph.
+type ResourceViewerExportResult struct {
+	Format      string `json:"format"`
+	ContentType string `json:"contentType"`
+	Data        string `json:"data"`
+}
+
+// ResourceViewerExportResponse is the data of a resource viewer export event.
+type ResourceViewerExportResponse struct {
+	Namespace  string `json:"namespace,omitempty"`
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Name       string `json:"name"`
+	ResourceViewerExportResult
+}
+
+// ResourceViewerExport exports the resource viewer graph of an object and sends it
+// to the client.
+type ResourceViewerExport struct {
+	store  store.Store
+	export ResourceViewerExporter
+}
+
+var _ action.Dispatcher = (*ResourceViewerExport)(nil)
+
+// NewResourceViewerExport creates an instance of ResourceViewerExport.
+func NewResourceViewerExport(objectStore store.Store, export ResourceViewerExporter) *ResourceViewerExport {
+	return &ResourceViewerExport{
+		store:  objectStore,
+		export: export,
+	}
+}
+
+// ActionName returns the name of this action.
+func (r *ResourceViewerExport) ActionName() string {
+	return ActionResourceViewerExport
+}
+
+// Handle exports the resource viewer graph of the object in the payload in the
+// payload's format, and sends it to the client as a resource viewer export event.
+func (r *ResourceViewerExport) Handle(ctx context.Context, alerter action.Alerter, payload action.Payload) error {
+	logger := log.From(ctx).With("actionName", r.ActionName())
+	logger.With("payload", payload).Debugf("received action payload")
+
+	sender, ok := alerter.(EventSender)
+	if !ok {
+		return fmt.Errorf("unable to send resource viewer export to client")
+	}
+
+	key, err := store.KeyFromPayload(payload)
+	if err != nil {
+		return err
+	}
+	if key.Name == "" {
+		return fmt.Errorf("name is required")
+	}
+
+	format, err := payload.OptionalString("format")
+	if err != nil {
+		return err
+	}
+
+	result, err := r.Export(ctx, key, format)
+	if err != nil {
+		logger.WithErr(err).Errorf("export resource viewer")
+		message := fmt.Sprintf("Unable to export resource viewer for %s %q: %s", key.Kind, key.Name, err)
+		alerter.SendAlert(action.CreateAlert(action.AlertTypeWarning, message, action.DefaultAlertExpiration))
+		return nil
+	}
+
+	sender.SendEvent(event.Event{
+		Type: event.EventTypeResourceViewerExport,
+		Data: ResourceViewerExportResponse{
+			Namespace:                  key.Namespace,
+			APIVersion:                 key.APIVersion,
+			Kind:                       key.Kind,
+			Name:                       key.Name,
+			ResourceViewerExportResult: result,
+		},
+	})
+
+	return nil
+}
+
+// Export exports the resource viewer graph of the object with key in a format.
+func (r *ResourceViewerExport) Export(ctx context.Context, key store.Key, format string) (ResourceViewerExportResult, error) {
+	object, err := r.store.Get(ctx, key)
+	if err != nil {
+		return ResourceViewerExportResult{}, err
+	}
+	if object == nil {
+		return ResourceViewerExportResult{}, fmt.Errorf("%s %q was not found", key.Kind, key.Name)
+	}
+
+	return r.export(ctx, object, format)
+}
@@ -0,0 +1,139 @@
+/*
+ * Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package octant_test
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/octant/internal/octant"
+	"github.com/vmware-tanzu/octant/internal/testutil"
+	"github.com/vmware-tanzu/octant/pkg/action"
+	actionFake "github.com/vmware-tanzu/octant/pkg/action/fake"
+	"github.com/vmware-tanzu/octant/pkg/event"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	"github.com/vmware-tanzu/octant/pkg/store/fake"
+)
+
+// eventAlerter is an alerter which records the events and alerts sent to it.
+type eventAlerter struct {
+	events []event.Event
+	alerts []action.Alert
+}
+
+var _ octant.EventSender = (*eventAlerter)(nil)
+
+func (a *eventAlerter) SendAlert(alert action.Alert) {
+	a.alerts = append(a.alerts, alert)
+}
+
+func (a *eventAlerter) SendEvent(ev event.Event) {
+	a.events = append(a.events, ev)
+}
+
+func Test_ResourceViewerExport(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	deployment := testutil.ToUnstructured(t, testutil.CreateDeployment("deployment"))
+	key := store.Key{Namespace: "namespace", APIVersion: "apps/v1", Kind: "Deployment", Name: "deployment"}
+
+	objectStore := fake.NewMockStore(controller)
+	objectStore.EXPECT().Get(gomock.Any(), key).Return(deployment, nil)
+
+	exporter := func(ctx context.Context, object *unstructured.Unstructured, format string) (octant.ResourceViewerExportResult, error) {
+		assert.Equal(t, deployment, object)
+		assert.Equal(t, "dot", format)
+		return octant.ResourceViewerExportResult{
+			Format:      format,
+			ContentType: "text/vnd.graphviz; charset=utf-8",
+			Data:        "digraph {}\n",
+		}, nil
+	}
+
+	rve := octant.NewResourceViewerExport(objectStore, exporter)
+	assert.Equal(t, octant.ActionResourceViewerExport, rve.ActionName())
+
+	alerter := &eventAlerter{}
+	payload := action.Payload{
+		"namespace":  "namespace",
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"name":       "deployment",
+		"format":     "dot",
+	}
+	require.NoError(t, rve.Handle(context.Background(), alerter, payload))
+
+	expected := []event.Event{
+		{
+			Type: event.EventTypeResourceViewerExport,
+			Data: octant.ResourceViewerExportResponse{
+				Namespace:  "namespace",
+				APIVersion: "apps/v1",
+				Kind:       "Deployment",
+				Name:       "deployment",
+				ResourceViewerExportResult: octant.ResourceViewerExportResult{
+					Format:      "dot",
+					ContentType: "text/vnd.graphviz; charset=utf-8",
+					Data:        "digraph {}\n",
+				},
+			},
+		},
+	}
+	assert.Equal(t, expected, alerter.events)
+	assert.Empty(t, alerter.alerts)
+}
+
+func Test_ResourceViewerExport_failure(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	objectStore := fake.NewMockStore(controller)
+	objectStore.EXPECT().Get(gomock.Any(), gomock.Any()).Return(nil, nil)
+
+	exporter := func(ctx context.Context, object *unstructured.Unstructured, format string) (octant.ResourceViewerExportResult, error) {
+		return octant.ResourceViewerExportResult{}, fmt.Errorf("unexpected export")
+	}
+
+	rve := octant.NewResourceViewerExport(objectStore, exporter)
+
+	alerter := &eventAlerter{}
+	payload := action.Payload{
+		"namespace":  "namespace",
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"name":       "deployment",
+	}
+	require.NoError(t, rve.Handle(context.Background(), alerter, payload))
+
+	assert.Empty(t, alerter.events)
+	require.Len(t, alerter.alerts, 1)
+	assert.Equal(t, action.AlertTypeWarning, alerter.alerts[0].Type)
+	assert.Equal(t, `Unable to export resource viewer for Deployment "deployment": Deployment "deployment" was not found`, alerter.alerts[0].Message)
+}
+
+func Test_ResourceViewerExport_withoutEventSender(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	objectStore := fake.NewMockStore(controller)
+	alerter := actionFake.NewMockAlerter(controller)
+
+	rve := octant.NewResourceViewerExport(objectStore, nil)
+
+	payload := action.Payload{
+		"apiVersion": "apps/v1",
+		"kind":       "Deployment",
+		"name":       "deployment",
+	}
+	assert.Error(t, rve.Handle(context.Background(), alerter, payload))
+}
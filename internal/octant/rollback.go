@@ -0,0 +1,122 @@
+/*
+ * Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package octant
+
+import (
+	"context"
+	"errors"
+	"fmt"
+
+	appsv1 "k8s.io/api/apps/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware-tanzu/octant/internal/cluster"
+	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/modules/overview/rollout"
+	"github.com/vmware-tanzu/octant/internal/util/kubernetes"
+	"github.com/vmware-tanzu/octant/pkg/action"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+// Rollback rolls a deployment, daemon set or stateful set back to a previous revision.
+type Rollback struct {
+	store         store.Store
+	clusterClient cluster.ClientInterface
+}
+
+var _ action.Dispatcher = (*Rollback)(nil)
+
+// NewRollback creates an instance of Rollback.
+func NewRollback(objectStore store.Store, clusterClient cluster.ClientInterface) *Rollback {
+	return &Rollback{
+		store:         objectStore,
+		clusterClient: clusterClient,
+	}
+}
+
+// ActionName returns the name of this action.
+func (r *Rollback) ActionName() string {
+	return ActionRollback
+}
+
+// Handle rolls an object back to the revision in the payload. The object is rolled
+// back to the previous revision if the payload doesn't have a revision.
+func (r *Rollback) Handle(ctx context.Context, alerter action.Alerter, payload action.Payload) error {
+	logger := log.From(ctx).With("actionName", r.ActionName())
+	logger.With("payload", payload).Infof("received action payload")
+
+	key, err := store.KeyFromPayload(payload)
+	if err != nil {
+		return err
+	}
+
+	var toRevision int64
+	if _, ok := payload["revision"]; ok {
+		revision, err := payload.Float64("revision")
+		if err != nil {
+			return err
+		}
+		toRevision = roundToInt(revision)
+	}
+
+	alertType := action.AlertTypeInfo
+	revision, err := r.Rollback(ctx, key, toRevision)
+	var message string
+	switch {
+	case errors.Is(err, rollout.ErrCurrentRevision):
+		message = fmt.Sprintf("Skipped rollback of %s %q: already at revision %d", key.Kind, key.Name, revision)
+	case err != nil:
+		alertType = action.AlertTypeWarning
+		message = fmt.Sprintf("Unable to roll back %s %q: %s", key.Kind, key.Name, err)
+		logger.WithErr(err).Errorf("rollback")
+	default:
+		message = fmt.Sprintf("Rolled back %s %q to revision %d", key.Kind, key.Name, revision)
+	}
+
+	alert := action.CreateAlert(alertType, message, action.DefaultAlertExpiration)
+	alerter.SendAlert(alert)
+	return nil
+}
+
+// Rollback rolls the object with key back to a revision. It returns the revision the
+// object was rolled back to.
+func (r *Rollback) Rollback(ctx context.Context, key store.Key, toRevision int64) (int64, error) {
+	u, err := r.store.Get(ctx, key)
+	if err != nil {
+		return 0, err
+	}
+	if u == nil {
+		return 0, fmt.Errorf("%s %q was not found", key.Kind, key.Name)
+	}
+
+	var object runtime.Object
+	switch key.Kind {
+	case "Deployment":
+		object = &appsv1.Deployment{}
+	case "DaemonSet":
+		object = &appsv1.DaemonSet{}
+	case "StatefulSet":
+		object = &appsv1.StatefulSet{}
+	default:
+		return 0, fmt.Errorf("%s does not support rollback", key.Kind)
+	}
+
+	if err := kubernetes.FromUnstructured(u, object); err != nil {
+		return 0, err
+	}
+
+	clusterClient, err := cluster.ClientForContext(ctx, r.clusterClient)
+	if err != nil {
+		return 0, err
+	}
+
+	client, err := clusterClient.KubernetesClient()
+	if err != nil {
+		return 0, err
+	}
+
+	return rollout.Rollback(ctx, client, r.store, object, toRevision)
+}
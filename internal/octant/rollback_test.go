@@ -0,0 +1,126 @@
+/*
+ * Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+package octant_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	testClient "k8s.io/client-go/kubernetes/fake"
+
+	clusterFake "github.com/vmware-tanzu/octant/internal/cluster/fake"
+	"github.com/vmware-tanzu/octant/internal/modules/overview/rollout"
+	"github.com/vmware-tanzu/octant/internal/octant"
+	"github.com/vmware-tanzu/octant/internal/testutil"
+	"github.com/vmware-tanzu/octant/pkg/action"
+	actionFake "github.com/vmware-tanzu/octant/pkg/action/fake"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	"github.com/vmware-tanzu/octant/pkg/store/fake"
+)
+
+func Test_Rollback(t *testing.T) {
+	cases := []struct {
+		name      string
+		revision  interface{}
+		message   string
+		alertType action.AlertType
+		image     string
+	}{
+		{
+			name:      "previous revision",
+			message:   `Rolled back Deployment "deployment" to revision 1`,
+			alertType: action.AlertTypeInfo,
+			image:     "app:1",
+		},
+		{
+			name:      "current revision",
+			revision:  float64(2),
+			message:   `Skipped rollback of Deployment "deployment": already at revision 2`,
+			alertType: action.AlertTypeInfo,
+			image:     "app:2",
+		},
+		{
+			name:      "missing revision",
+			revision:  float64(5),
+			message:   `Unable to roll back Deployment "deployment": unable to find revision 5`,
+			alertType: action.AlertTypeWarning,
+			image:     "app:2",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			controller := gomock.NewController(t)
+			defer controller.Finish()
+
+			ctx := context.Background()
+
+			template := func(image string) corev1.PodTemplateSpec {
+				return corev1.PodTemplateSpec{
+					Spec: corev1.PodSpec{Containers: []corev1.Container{{Name: "app", Image: image}}},
+				}
+			}
+
+			deployment := testutil.CreateDeployment("deployment")
+			deployment.Spec.Template = template("app:2")
+
+			rs1 := testutil.CreateAppReplicaSet("rs-1")
+			rs1.OwnerReferences = testutil.ToOwnerReferences(t, deployment)
+			rs1.Annotations = map[string]string{rollout.RevisionAnnotation: "1"}
+			rs1.Spec.Template = template("app:1")
+
+			rs2 := testutil.CreateAppReplicaSet("rs-2")
+			rs2.OwnerReferences = testutil.ToOwnerReferences(t, deployment)
+			rs2.Annotations = map[string]string{rollout.RevisionAnnotation: "2"}
+			rs2.Spec.Template = template("app:2")
+
+			key := store.Key{Namespace: "namespace", APIVersion: "apps/v1", Kind: "Deployment", Name: "deployment"}
+
+			objectStore := fake.NewMockStore(controller)
+			objectStore.EXPECT().Get(ctx, key).Return(testutil.ToUnstructured(t, deployment), nil)
+			objectStore.EXPECT().
+				List(ctx, store.Key{Namespace: "namespace", APIVersion: "apps/v1", Kind: "ReplicaSet"}).
+				Return(testutil.ToUnstructuredList(t, rs1, rs2), false, nil)
+
+			fakeClientset := testClient.NewSimpleClientset(deployment)
+			clusterClient := clusterFake.NewMockClientInterface(controller)
+			clusterClient.EXPECT().KubernetesClient().Return(fakeClientset, nil)
+
+			alerter := actionFake.NewMockAlerter(controller)
+			alerter.EXPECT().
+				SendAlert(gomock.Any()).
+				DoAndReturn(func(alert action.Alert) {
+					assert.Equal(t, tc.alertType, alert.Type)
+					assert.Equal(t, tc.message, alert.Message)
+				})
+
+			rollback := octant.NewRollback(objectStore, clusterClient)
+			assert.Equal(t, octant.ActionRollback, rollback.ActionName())
+
+			fields := map[string]interface{}{
+				"apiVersion": "apps/v1",
+				"kind":       "Deployment",
+				"namespace":  "namespace",
+				"name":       "deployment",
+			}
+			if tc.revision != nil {
+				fields["revision"] = tc.revision
+			}
+
+			payload := action.CreatePayload(octant.ActionRollback, fields)
+			require.NoError(t, rollback.Handle(ctx, alerter, payload))
+
+			updated, err := fakeClientset.AppsV1().Deployments("namespace").Get(ctx, "deployment", metav1.GetOptions{})
+			require.NoError(t, err)
+			assert.Equal(t, tc.image, updated.Spec.Template.Spec.Containers[0].Image)
+		})
+	}
+}
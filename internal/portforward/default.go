@@ -15,17 +15,21 @@ import (
 	"github.com/pkg/errors"
 )
 
-// Default create a port forward instance.
-func Default(ctx context.Context, client cluster.ClientInterface, objectStore store.Store) (PortForwarder, error) {
+// Default create a port forward instance. Saved port forwards for contextName are
+// restored from definitions if it isn't nil.
+func Default(ctx context.Context, client cluster.ClientInterface, objectStore store.Store, contextName string, definitions DefinitionStore) (PortForwarder, error) {
 	restClient, err := client.RESTClient()
 	if err != nil {
 		return nil, errors.Wrap(err, "fetching RESTClient")
 	}
 
 	pfOpts := ServiceOptions{
-		RESTClient:  restClient,
-		Config:      client.RESTConfig(),
-		ObjectStore: objectStore,
+		RESTClient:    restClient,
+		Config:        client.RESTConfig(),
+		ClusterClient: client,
+		ObjectStore:   objectStore,
+		Definitions:   definitions,
+		Context:       contextName,
 		PortForwarder: &DefaultPortForwarder{
 			IOStreams: IOStreams{
 				In:     os.Stdin,
@@ -37,5 +41,9 @@ func Default(ctx context.Context, client cluster.ClientInterface, objectStore st
 
 	svc := New(ctx, pfOpts)
 
+	if err := svc.Restore(ctx); err != nil {
+		return nil, errors.Wrap(err, "restoring saved port forwards")
+	}
+
 	return svc, nil
 }
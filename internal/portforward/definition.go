@@ -0,0 +1,152 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package portforward
+
+import (
+	"fmt"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"strings"
+
+	"github.com/pkg/errors"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/vmware-tanzu/octant/internal/auth"
+	"github.com/vmware-tanzu/octant/internal/util/json"
+)
+
+// Definition is a saved port forward. It targets a service, a deployment or a pod
+// selector rather than a pod, so it can be restored after the pod it forwards to
+// is replaced.
+type Definition struct {
+	ID string `json:"id"`
+	// Context is the kube config context the port forward is restored in.
+	Context    string `json:"context"`
+	Namespace  string `json:"namespace"`
+	APIVersion string `json:"apiVersion"`
+	// Kind is Service, Deployment or Pod. Pod definitions forward to a pod matching Selector.
+	Kind     string                `json:"kind"`
+	Name     string                `json:"name,omitempty"`
+	Selector string                `json:"selector,omitempty"`
+	Ports    []PortForwardPortSpec `json:"ports"`
+	// User is the authenticated user who saved the port forward. It forwards with
+	// the user's credentials.
+	User *auth.User `json:"user,omitempty"`
+}
+
+// Validate returns an error if the definition can't be restored.
+func (d Definition) Validate() error {
+	if d.Namespace == "" {
+		return errors.New("namespace field required")
+	}
+
+	switch {
+	case d.APIVersion == "v1" && d.Kind == "Service",
+		d.APIVersion == "apps/v1" && d.Kind == "Deployment":
+		if d.Name == "" {
+			return errors.New("name field required")
+		}
+	case d.APIVersion == "v1" && d.Kind == "Pod":
+		if d.Selector == "" {
+			return errors.New("selector field required")
+		}
+		if _, err := labels.Parse(d.Selector); err != nil {
+			return errors.Wrap(err, "invalid selector")
+		}
+	default:
+		return errors.Errorf("saved port forwards only work with services, deployments & pod selectors")
+	}
+
+	if len(d.Ports) == 0 {
+		return errors.New("ports field required")
+	}
+	for _, p := range d.Ports {
+		if p.Remote < 1 {
+			return errors.Errorf("remote port out of range: %v", p.Remote)
+		}
+	}
+
+	return nil
+}
+
+// String returns the target of the definition, e.g. service/web or pods/app=web.
+func (d Definition) String() string {
+	if d.Kind == "Pod" {
+		return fmt.Sprintf("pods/%s", d.Selector)
+	}
+	return fmt.Sprintf("%s/%s", strings.ToLower(d.Kind), d.Name)
+}
+
+// DefinitionStore persists saved port forwards.
+type DefinitionStore interface {
+	Load() ([]Definition, error)
+	Save(definitions []Definition) error
+}
+
+// FileDefinitionStore stores saved port forwards in a JSON file.
+type FileDefinitionStore struct {
+	path string
+}
+
+var _ DefinitionStore = (*FileDefinitionStore)(nil)
+
+// NewFileDefinitionStore creates an instance of FileDefinitionStore.
+func NewFileDefinitionStore(path string) *FileDefinitionStore {
+	return &FileDefinitionStore{path: path}
+}
+
+// DefaultDefinitionsPath returns the default path of the saved port forwards file
+// in the user's config directory.
+func DefaultDefinitionsPath() (string, error) {
+	dir, err := os.UserConfigDir()
+	if err != nil {
+		return "", err
+	}
+	return filepath.Join(dir, "octant", "port-forwards.json"), nil
+}
+
+// Load loads saved port forwards. A missing file has no port forwards.
+func (s *FileDefinitionStore) Load() ([]Definition, error) {
+	data, err := ioutil.ReadFile(s.path)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, errors.Wrap(err, "read saved port forwards")
+	}
+
+	var definitions []Definition
+	if err := json.Unmarshal(data, &definitions); err != nil {
+		return nil, errors.Wrapf(err, "decode saved port forwards in %s", s.path)
+	}
+
+	return definitions, nil
+}
+
+// Save saves port forwards, replacing the ones previously saved.
+func (s *FileDefinitionStore) Save(definitions []Definition) error {
+	if definitions == nil {
+		definitions = []Definition{}
+	}
+
+	data, err := json.MarshalIndent(definitions, "", "  ")
+	if err != nil {
+		return errors.Wrap(err, "encode saved port forwards")
+	}
+
+	if err := os.MkdirAll(filepath.Dir(s.path), 0700); err != nil {
+		return errors.Wrap(err, "create saved port forwards directory")
+	}
+
+	// Write to a temporary file first so a failed write doesn't lose saved port forwards.
+	tmp := s.path + ".tmp"
+	if err := ioutil.WriteFile(tmp, data, 0600); err != nil {
+		return errors.Wrap(err, "write saved port forwards")
+	}
+
+	return os.Rename(tmp, s.path)
+}
@@ -11,6 +11,7 @@ import (
 	gomock "github.com/golang/mock/gomock"
 	schema "k8s.io/apimachinery/pkg/runtime/schema"
 
+	cluster "github.com/vmware-tanzu/octant/internal/cluster"
 	portforward "github.com/vmware-tanzu/octant/internal/portforward"
 	action "github.com/vmware-tanzu/octant/pkg/action"
 )
@@ -135,3 +136,60 @@ func (mr *MockPortForwarderMockRecorder) StopForwarder(id interface{}) *gomock.C
 	mr.mock.ctrl.T.Helper()
 	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "StopForwarder", reflect.TypeOf((*MockPortForwarder)(nil).StopForwarder), id)
 }
+
+// Save mocks base method
+func (m *MockPortForwarder) Save(ctx context.Context, definition portforward.Definition) (portforward.Definition, error) {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Save", ctx, definition)
+	ret0, _ := ret[0].(portforward.Definition)
+	ret1, _ := ret[1].(error)
+	return ret0, ret1
+}
+
+// Save indicates an expected call of Save
+func (mr *MockPortForwarderMockRecorder) Save(ctx, definition interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Save", reflect.TypeOf((*MockPortForwarder)(nil).Save), ctx, definition)
+}
+
+// RemoveSaved mocks base method
+func (m *MockPortForwarder) RemoveSaved(id string) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "RemoveSaved", id)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// RemoveSaved indicates an expected call of RemoveSaved
+func (mr *MockPortForwarderMockRecorder) RemoveSaved(id interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "RemoveSaved", reflect.TypeOf((*MockPortForwarder)(nil).RemoveSaved), id)
+}
+
+// Saved mocks base method
+func (m *MockPortForwarder) Saved() []portforward.SavedState {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "Saved")
+	ret0, _ := ret[0].([]portforward.SavedState)
+	return ret0
+}
+
+// Saved indicates an expected call of Saved
+func (mr *MockPortForwarderMockRecorder) Saved() *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "Saved", reflect.TypeOf((*MockPortForwarder)(nil).Saved))
+}
+
+// UpdateClusterClient mocks base method
+func (m *MockPortForwarder) UpdateClusterClient(ctx context.Context, contextName string, client cluster.ClientInterface) error {
+	m.ctrl.T.Helper()
+	ret := m.ctrl.Call(m, "UpdateClusterClient", ctx, contextName, client)
+	ret0, _ := ret[0].(error)
+	return ret0
+}
+
+// UpdateClusterClient indicates an expected call of UpdateClusterClient
+func (mr *MockPortForwarderMockRecorder) UpdateClusterClient(ctx, contextName, client interface{}) *gomock.Call {
+	mr.mock.ctrl.T.Helper()
+	return mr.mock.ctrl.RecordCallWithMethodType(mr.mock, "UpdateClusterClient", reflect.TypeOf((*MockPortForwarder)(nil).UpdateClusterClient), ctx, contextName, client)
+}
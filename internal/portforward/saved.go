@@ -0,0 +1,520 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package portforward
+
+import (
+	"context"
+	"sort"
+	"sync"
+	"time"
+
+	"github.com/google/uuid"
+	"github.com/pkg/errors"
+	appsv1 "k8s.io/api/apps/v1"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+
+	"github.com/vmware-tanzu/octant/internal/auth"
+	"github.com/vmware-tanzu/octant/internal/util/kubernetes"
+	"github.com/vmware-tanzu/octant/pkg/action"
+	"github.com/vmware-tanzu/octant/pkg/log"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+const (
+	// DefaultMinBackoff is the initial delay before a saved port forward reconnects.
+	DefaultMinBackoff = time.Second
+	// DefaultMaxBackoff is the longest delay before a saved port forward reconnects.
+	DefaultMaxBackoff = 30 * time.Second
+	// DefaultHealthCheckInterval is how often a saved port forward checks its pod is ready.
+	DefaultHealthCheckInterval = 5 * time.Second
+)
+
+// Health is the health of a saved port forward.
+type Health string
+
+const (
+	// HealthConnecting is a saved port forward which hasn't connected yet.
+	HealthConnecting Health = "Connecting"
+	// HealthActive is a saved port forward forwarding to a ready pod.
+	HealthActive Health = "Active"
+	// HealthReconnecting is a saved port forward waiting to reconnect to a new ready pod.
+	HealthReconnecting Health = "Reconnecting"
+	// HealthInactive is a saved port forward for another context.
+	HealthInactive Health = "Inactive"
+)
+
+// SavedState describes a saved port forward and its health.
+type SavedState struct {
+	Definition Definition
+	Health     Health
+	// ForwardID is the ID of the active port forward.
+	ForwardID string
+	// Pod is the name of the pod being forwarded to.
+	Pod   string
+	Ports []ForwardedPort
+	// Reconnects is the number of times the port forward has reconnected.
+	Reconnects int
+	LastError  string
+	Since      time.Time
+}
+
+// savedForwards are the saved port forwards and the supervisors restoring them.
+type savedForwards struct {
+	sync.Mutex
+	definitions []Definition
+	supervisors map[string]*supervisor
+}
+
+// supervisor keeps a saved port forward connected to a ready pod.
+type supervisor struct {
+	mu     sync.Mutex
+	state  SavedState
+	cancel context.CancelFunc
+	done   chan struct{}
+}
+
+func (sv *supervisor) update(fn func(state *SavedState)) {
+	sv.mu.Lock()
+	defer sv.mu.Unlock()
+	fn(&sv.state)
+}
+
+func (sv *supervisor) snapshot() SavedState {
+	sv.mu.Lock()
+	defer sv.mu.Unlock()
+
+	state := sv.state
+	state.Ports = append([]ForwardedPort(nil), sv.state.Ports...)
+	return state
+}
+
+func (sv *supervisor) stop() {
+	sv.cancel()
+	<-sv.done
+}
+
+// logAlerter logs alerts for port forwards which weren't started by a user.
+type logAlerter struct {
+	logger log.Logger
+}
+
+var _ action.Alerter = (*logAlerter)(nil)
+
+func (a *logAlerter) SendAlert(alert action.Alert) {
+	a.logger.Warnf("%s", alert.Message)
+}
+
+// Restore loads the saved port forwards and starts the ones for the current context.
+func (s *Service) Restore(ctx context.Context) error {
+	opts := s.options()
+	if opts.Definitions == nil {
+		return nil
+	}
+
+	definitions, err := opts.Definitions.Load()
+	if err != nil {
+		return err
+	}
+
+	s.saved.Lock()
+	defer s.saved.Unlock()
+
+	s.saved.definitions = definitions
+	for _, definition := range definitions {
+		if err := definition.Validate(); err != nil {
+			s.logger.With("id", definition.ID).Warnf("skipping saved port forward: %s", err)
+			continue
+		}
+		if definition.Context != opts.Context {
+			continue
+		}
+		s.startSupervisor(definition)
+	}
+
+	return nil
+}
+
+// Save saves a port forward definition and starts it. The definition is saved for the
+// current context if it doesn't name one.
+func (s *Service) Save(ctx context.Context, definition Definition) (Definition, error) {
+	if err := definition.Validate(); err != nil {
+		return Definition{}, errors.Wrap(err, "invalid saved port forward")
+	}
+
+	if definition.ID == "" {
+		id, err := uuid.NewRandom()
+		if err != nil {
+			return Definition{}, errors.Wrap(err, "generating uuid")
+		}
+		definition.ID = id.String()
+	}
+	if definition.Context == "" {
+		definition.Context = s.options().Context
+	}
+	if user, ok := auth.UserFrom(ctx); ok {
+		definition.User = user
+	}
+
+	s.saved.Lock()
+	defer s.saved.Unlock()
+
+	definitions := make([]Definition, 0, len(s.saved.definitions)+1)
+	for _, d := range s.saved.definitions {
+		if d.ID != definition.ID {
+			definitions = append(definitions, d)
+		}
+	}
+	definitions = append(definitions, definition)
+
+	if err := s.persist(definitions); err != nil {
+		return Definition{}, err
+	}
+	s.saved.definitions = definitions
+
+	if sv, ok := s.saved.supervisors[definition.ID]; ok {
+		sv.stop()
+		delete(s.saved.supervisors, definition.ID)
+	}
+	if definition.Context == s.options().Context {
+		s.startSupervisor(definition)
+	}
+
+	return definition, nil
+}
+
+// RemoveSaved stops a saved port forward and removes its definition.
+func (s *Service) RemoveSaved(id string) error {
+	s.saved.Lock()
+	defer s.saved.Unlock()
+
+	definitions := make([]Definition, 0, len(s.saved.definitions))
+	for _, d := range s.saved.definitions {
+		if d.ID != id {
+			definitions = append(definitions, d)
+		}
+	}
+	if len(definitions) == len(s.saved.definitions) {
+		return &notFound{}
+	}
+
+	if err := s.persist(definitions); err != nil {
+		return err
+	}
+	s.saved.definitions = definitions
+
+	if sv, ok := s.saved.supervisors[id]; ok {
+		sv.stop()
+		delete(s.saved.supervisors, id)
+	}
+
+	return nil
+}
+
+// Saved lists the saved port forwards and their health.
+func (s *Service) Saved() []SavedState {
+	s.saved.Lock()
+	defer s.saved.Unlock()
+
+	result := make([]SavedState, 0, len(s.saved.definitions))
+	for _, definition := range s.saved.definitions {
+		if sv, ok := s.saved.supervisors[definition.ID]; ok {
+			result = append(result, sv.snapshot())
+			continue
+		}
+		result = append(result, SavedState{Definition: definition, Health: HealthInactive})
+	}
+
+	sort.Slice(result, func(i, j int) bool {
+		if result[i].Definition.Namespace != result[j].Definition.Namespace {
+			return result[i].Definition.Namespace < result[j].Definition.Namespace
+		}
+		return result[i].Definition.String() < result[j].Definition.String()
+	})
+
+	return result
+}
+
+// stopSupervisors stops every saved port forward. The saved lock must be held.
+func (s *Service) stopSupervisors() {
+	for id, sv := range s.saved.supervisors {
+		sv.stop()
+		delete(s.saved.supervisors, id)
+	}
+}
+
+func (s *Service) persist(definitions []Definition) error {
+	definitionStore := s.options().Definitions
+	if definitionStore == nil {
+		return nil
+	}
+	return errors.Wrap(definitionStore.Save(definitions), "save port forwards")
+}
+
+// startSupervisor starts a saved port forward. The saved lock must be held.
+func (s *Service) startSupervisor(definition Definition) {
+	ctx, cancel := context.WithCancel(s.ctx)
+	if definition.User != nil {
+		ctx = auth.WithUser(ctx, definition.User)
+	}
+
+	sv := &supervisor{
+		state: SavedState{
+			Definition: definition,
+			Health:     HealthConnecting,
+			Since:      time.Now(),
+		},
+		cancel: cancel,
+		done:   make(chan struct{}),
+	}
+	if s.saved.supervisors == nil {
+		s.saved.supervisors = make(map[string]*supervisor)
+	}
+	s.saved.supervisors[definition.ID] = sv
+
+	go func() {
+		defer close(sv.done)
+		s.supervise(ctx, sv, definition)
+	}()
+}
+
+// supervise forwards to a ready pod for the definition. When the pod goes away, it
+// reconnects to a new ready pod with backoff.
+func (s *Service) supervise(ctx context.Context, sv *supervisor, definition Definition) {
+	logger := s.logger.With("context", "PortForwardService.supervise", "id", definition.ID, "target", definition.String())
+	alerter := &logAlerter{logger: logger}
+
+	opts := s.options()
+	minBackoff, maxBackoff := opts.MinBackoff, opts.MaxBackoff
+	if minBackoff <= 0 {
+		minBackoff = DefaultMinBackoff
+	}
+	if maxBackoff < minBackoff {
+		maxBackoff = DefaultMaxBackoff
+	}
+	delay := minBackoff
+
+	// Local ports are kept across reconnects, so clients can keep using them.
+	ports := append([]PortForwardPortSpec(nil), definition.Ports...)
+
+	for attempt := 0; ; attempt++ {
+		err := func() error {
+			pod, err := s.findReadyPod(ctx, definition)
+			if err != nil {
+				return err
+			}
+
+			podRequest := CreateRequest{
+				Namespace:  definition.Namespace,
+				APIVersion: "v1",
+				Kind:       "Pod",
+				Name:       pod.Name,
+				Ports:      ports,
+			}
+
+			// Pod selectors don't name an object, so the pod is the target.
+			targetRequest := podRequest
+			if definition.Kind != "Pod" {
+				targetRequest.APIVersion = definition.APIVersion
+				targetRequest.Kind = definition.Kind
+				targetRequest.Name = definition.Name
+			}
+
+			id, err := s.createForwarder(ctx, alerter, targetRequest, podRequest)
+			if err != nil {
+				return err
+			}
+			defer s.StopForwarder(id)
+
+			state, _ := s.Get(id)
+			for i := range ports {
+				for _, forwarded := range state.Ports {
+					if forwarded.Remote == ports[i].Remote {
+						ports[i].Local = forwarded.Local
+					}
+				}
+			}
+
+			sv.update(func(saved *SavedState) {
+				saved.Health = HealthActive
+				saved.ForwardID = id
+				saved.Pod = pod.Name
+				saved.Ports = state.Ports
+				saved.Since = time.Now()
+				if attempt > 0 {
+					saved.Reconnects++
+				}
+			})
+			logger.With("pod", pod.Name).Debugf("saved port forward connected")
+			delay = minBackoff
+
+			return s.watchForwarder(ctx, id, definition.Namespace, pod.Name)
+		}()
+
+		if ctx.Err() != nil {
+			return
+		}
+
+		logger.With("delay", delay).Debugf("saved port forward reconnecting: %v", err)
+		sv.update(func(saved *SavedState) {
+			saved.Health = HealthReconnecting
+			saved.ForwardID = ""
+			saved.Pod = ""
+			saved.Ports = nil
+			saved.Since = time.Now()
+			if err != nil {
+				saved.LastError = err.Error()
+			}
+		})
+
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(delay):
+		}
+
+		delay *= 2
+		if delay > maxBackoff {
+			delay = maxBackoff
+		}
+	}
+}
+
+// watchForwarder blocks until the port forward terminates or its pod is no longer ready.
+func (s *Service) watchForwarder(ctx context.Context, id, namespace, podName string) error {
+	interval := s.options().HealthCheckInterval
+	if interval <= 0 {
+		interval = DefaultHealthCheckInterval
+	}
+
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+
+	for {
+		select {
+		case <-ctx.Done():
+			return nil
+		case <-ticker.C:
+		}
+
+		if _, ok := s.Get(id); !ok {
+			return errors.New("port forward terminated")
+		}
+
+		pod, err := s.getPod(ctx, namespace, podName)
+		if err != nil {
+			return err
+		}
+		if pod == nil || !isPodReady(pod) {
+			return errors.Errorf("pod %q is no longer ready", podName)
+		}
+	}
+}
+
+// findReadyPod returns a ready pod for a definition's target.
+func (s *Service) findReadyPod(ctx context.Context, definition Definition) (*corev1.Pod, error) {
+	o := s.options().ObjectStore
+	if o == nil {
+		return nil, errors.New("nil objectstore")
+	}
+
+	var selector labels.Selector
+	switch definition.Kind {
+	case "Service":
+		var service corev1.Service
+		found, err := store.GetAs(ctx, o, store.Key{APIVersion: "v1", Kind: "Service", Namespace: definition.Namespace, Name: definition.Name}, &service)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, errors.Errorf("service %q not found", definition.Name)
+		}
+		if len(service.Spec.Selector) == 0 {
+			return nil, errors.Errorf("service %q has no selector", definition.Name)
+		}
+		selector = labels.SelectorFromSet(service.Spec.Selector)
+	case "Deployment":
+		var deployment appsv1.Deployment
+		found, err := store.GetAs(ctx, o, store.Key{APIVersion: "apps/v1", Kind: "Deployment", Namespace: definition.Namespace, Name: definition.Name}, &deployment)
+		if err != nil {
+			return nil, err
+		}
+		if !found {
+			return nil, errors.Errorf("deployment %q not found", definition.Name)
+		}
+		selector, err = metav1.LabelSelectorAsSelector(deployment.Spec.Selector)
+		if err != nil {
+			return nil, errors.Wrapf(err, "deployment %q selector", definition.Name)
+		}
+	default:
+		var err error
+		selector, err = labels.Parse(definition.Selector)
+		if err != nil {
+			return nil, errors.Wrap(err, "invalid selector")
+		}
+	}
+
+	list, _, err := o.List(ctx, store.Key{APIVersion: "v1", Kind: "Pod", Namespace: definition.Namespace})
+	if err != nil {
+		return nil, err
+	}
+
+	var ready []*corev1.Pod
+	for i := range list.Items {
+		if !selector.Matches(labels.Set(list.Items[i].GetLabels())) {
+			continue
+		}
+
+		pod := &corev1.Pod{}
+		if err := kubernetes.FromUnstructured(&list.Items[i], pod); err != nil {
+			return nil, err
+		}
+		if isPodReady(pod) {
+			ready = append(ready, pod)
+		}
+	}
+
+	if len(ready) == 0 {
+		return nil, errors.Errorf("no ready pod found for %s", definition.String())
+	}
+
+	// Prefer the oldest pod, since it is the least likely to be replaced soon.
+	sort.Slice(ready, func(i, j int) bool {
+		if !ready[i].CreationTimestamp.Equal(&ready[j].CreationTimestamp) {
+			return ready[i].CreationTimestamp.Before(&ready[j].CreationTimestamp)
+		}
+		return ready[i].Name < ready[j].Name
+	})
+
+	return ready[0], nil
+}
+
+func (s *Service) getPod(ctx context.Context, namespace, name string) (*corev1.Pod, error) {
+	var pod corev1.Pod
+	found, err := store.GetAs(ctx, s.options().ObjectStore, store.Key{APIVersion: "v1", Kind: "Pod", Namespace: namespace, Name: name}, &pod)
+	if err != nil {
+		return nil, err
+	}
+	if !found {
+		return nil, nil
+	}
+	return &pod, nil
+}
+
+func isPodReady(pod *corev1.Pod) bool {
+	if pod.Status.Phase != corev1.PodRunning || pod.DeletionTimestamp != nil {
+		return false
+	}
+
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == corev1.PodReady {
+			return condition.Status == corev1.ConditionTrue
+		}
+	}
+
+	return false
+}
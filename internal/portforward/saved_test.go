@@ -0,0 +1,475 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package portforward
+
+import (
+	"context"
+	"net/url"
+	"path/filepath"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/rest"
+	restfake "k8s.io/client-go/rest/fake"
+
+	"github.com/vmware-tanzu/octant/internal/auth"
+	clusterFake "github.com/vmware-tanzu/octant/internal/cluster/fake"
+	"github.com/vmware-tanzu/octant/pkg/action"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	storeFake "github.com/vmware-tanzu/octant/pkg/store/fake"
+)
+
+func TestFileDefinitionStore(t *testing.T) {
+	s := NewFileDefinitionStore(filepath.Join(t.TempDir(), "octant", "port-forwards.json"))
+
+	got, err := s.Load()
+	require.NoError(t, err)
+	assert.Empty(t, got)
+
+	definitions := []Definition{
+		{
+			ID:         "1",
+			Context:    "dev",
+			Namespace:  "default",
+			APIVersion: "v1",
+			Kind:       "Service",
+			Name:       "web",
+			Ports:      []PortForwardPortSpec{{Remote: 80, Local: 8080}},
+		},
+	}
+	require.NoError(t, s.Save(definitions))
+
+	got, err = s.Load()
+	require.NoError(t, err)
+	assert.Equal(t, definitions, got)
+}
+
+func TestDefinition_Validate(t *testing.T) {
+	ports := []PortForwardPortSpec{{Remote: 80}}
+
+	cases := []struct {
+		name       string
+		definition Definition
+		isErr      bool
+	}{
+		{
+			name:       "service",
+			definition: Definition{Namespace: "default", APIVersion: "v1", Kind: "Service", Name: "web", Ports: ports},
+		},
+		{
+			name:       "deployment",
+			definition: Definition{Namespace: "default", APIVersion: "apps/v1", Kind: "Deployment", Name: "web", Ports: ports},
+		},
+		{
+			name:       "pod selector",
+			definition: Definition{Namespace: "default", APIVersion: "v1", Kind: "Pod", Selector: "app=web", Ports: ports},
+		},
+		{
+			name:       "pod without selector",
+			definition: Definition{Namespace: "default", APIVersion: "v1", Kind: "Pod", Name: "web-1", Ports: ports},
+			isErr:      true,
+		},
+		{
+			name:       "unsupported kind",
+			definition: Definition{Namespace: "default", APIVersion: "apps/v1", Kind: "StatefulSet", Name: "web", Ports: ports},
+			isErr:      true,
+		},
+		{
+			name:       "no ports",
+			definition: Definition{Namespace: "default", APIVersion: "v1", Kind: "Service", Name: "web"},
+			isErr:      true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			err := tc.definition.Validate()
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+		})
+	}
+}
+
+// fakeForwarder forwards until it is stopped. It assigns local port 18080 to
+// ports without one.
+type fakeForwarder struct {
+	mu      sync.Mutex
+	ports   [][]string
+	clients []rest.Interface
+}
+
+func (f *fakeForwarder) ForwardPorts(alerter action.Alerter, method string, url *url.URL, opts Options) error {
+	f.mu.Lock()
+	f.ports = append(f.ports, opts.Ports)
+	f.clients = append(f.clients, opts.RESTClient)
+	f.mu.Unlock()
+
+	opts.PortsChannel <- []ForwardedPort{{Local: 18080, Remote: 80}}
+	<-opts.StopChannel
+	return nil
+}
+
+func (f *fakeForwarder) calls() [][]string {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([][]string(nil), f.ports...)
+}
+
+func (f *fakeForwarder) restClients() []rest.Interface {
+	f.mu.Lock()
+	defer f.mu.Unlock()
+	return append([]rest.Interface(nil), f.clients...)
+}
+
+// fakeCluster is a service and its pods, which can be changed while port forwarding.
+type fakeCluster struct {
+	mu   sync.Mutex
+	pods map[string]bool
+}
+
+func (c *fakeCluster) setReady(name string, ready bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	c.pods[name] = ready
+}
+
+func (c *fakeCluster) pod(t *testing.T, name string, ready bool) *unstructured.Unstructured {
+	status := corev1.ConditionFalse
+	if ready {
+		status = corev1.ConditionTrue
+	}
+
+	pod := &corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			Labels:    map[string]string{"app": "web"},
+		},
+		Status: corev1.PodStatus{
+			Phase:      corev1.PodRunning,
+			Conditions: []corev1.PodCondition{{Type: corev1.PodReady, Status: status}},
+		},
+	}
+
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+	require.NoError(t, err)
+	return &unstructured.Unstructured{Object: m}
+}
+
+func (c *fakeCluster) store(t *testing.T, controller *gomock.Controller) store.Store {
+	service := &corev1.Service{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Service"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "web"},
+		Spec:       corev1.ServiceSpec{Selector: map[string]string{"app": "web"}},
+	}
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(service)
+	require.NoError(t, err)
+
+	o := storeFake.NewMockStore(controller)
+	o.EXPECT().
+		Get(gomock.Any(), store.Key{APIVersion: "v1", Kind: "Service", Namespace: "default", Name: "web"}).
+		Return(&unstructured.Unstructured{Object: m}, nil).
+		AnyTimes()
+	o.EXPECT().
+		Get(gomock.Any(), gomock.Any()).
+		DoAndReturn(func(ctx context.Context, key store.Key) (*unstructured.Unstructured, error) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			ready, ok := c.pods[key.Name]
+			if !ok {
+				return nil, nil
+			}
+			return c.pod(t, key.Name, ready), nil
+		}).
+		AnyTimes()
+	o.EXPECT().
+		List(gomock.Any(), store.Key{APIVersion: "v1", Kind: "Pod", Namespace: "default"}).
+		DoAndReturn(func(ctx context.Context, key store.Key) (*unstructured.UnstructuredList, bool, error) {
+			c.mu.Lock()
+			defer c.mu.Unlock()
+			list := &unstructured.UnstructuredList{}
+			for name, ready := range c.pods {
+				list.Items = append(list.Items, *c.pod(t, name, ready))
+			}
+			return list, false, nil
+		}).
+		AnyTimes()
+
+	return o
+}
+
+type memoryDefinitionStore struct {
+	mu          sync.Mutex
+	definitions []Definition
+}
+
+func (s *memoryDefinitionStore) Load() ([]Definition, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.definitions, nil
+}
+
+func (s *memoryDefinitionStore) Save(definitions []Definition) error {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.definitions = definitions
+	return nil
+}
+
+func waitForHealth(t *testing.T, s *Service, health Health, pod string) SavedState {
+	var state SavedState
+	require.Eventually(t, func() bool {
+		saved := s.Saved()
+		if len(saved) != 1 {
+			return false
+		}
+		state = saved[0]
+		return state.Health == health && state.Pod == pod
+	}, 5*time.Second, 5*time.Millisecond)
+	return state
+}
+
+func TestService_Save_reconnects(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	cluster := &fakeCluster{pods: map[string]bool{"web-a": true, "web-b": false}}
+	forwarder := &fakeForwarder{}
+	definitions := &memoryDefinitionStore{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := New(ctx, ServiceOptions{
+		RESTClient:          &restfake.RESTClient{},
+		ObjectStore:         cluster.store(t, controller),
+		PortForwarder:       forwarder,
+		Definitions:         definitions,
+		Context:             "dev",
+		MinBackoff:          time.Millisecond,
+		MaxBackoff:          10 * time.Millisecond,
+		HealthCheckInterval: 5 * time.Millisecond,
+	})
+	defer s.Stop()
+
+	definition, err := s.Save(ctx, Definition{
+		Namespace:  "default",
+		APIVersion: "v1",
+		Kind:       "Service",
+		Name:       "web",
+		Ports:      []PortForwardPortSpec{{Remote: 80}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, "dev", definition.Context)
+	assert.Equal(t, []Definition{definition}, definitions.definitions)
+
+	state := waitForHealth(t, s, HealthActive, "web-a")
+	assert.Equal(t, []ForwardedPort{{Local: 18080, Remote: 80}}, state.Ports)
+	assert.Equal(t, 0, state.Reconnects)
+
+	forwards := s.List(ctx)
+	require.Len(t, forwards, 1)
+	assert.Equal(t, "Service", forwards[0].Target.GVK.Kind)
+	assert.Equal(t, "web-a", forwards[0].Pod.Name)
+
+	cluster.setReady("web-a", false)
+	cluster.setReady("web-b", true)
+
+	state = waitForHealth(t, s, HealthActive, "web-b")
+	assert.Equal(t, 1, state.Reconnects)
+	assert.Equal(t, `pod "web-a" is no longer ready`, state.LastError)
+
+	// The reconnected port forward keeps the local port.
+	calls := forwarder.calls()
+	require.Len(t, calls, 2)
+	assert.Equal(t, []string{"0:80"}, calls[0])
+	assert.Equal(t, []string{"18080:80"}, calls[1])
+
+	require.NoError(t, s.RemoveSaved(definition.ID))
+	assert.Empty(t, s.Saved())
+	assert.Empty(t, definitions.definitions)
+	assert.Empty(t, s.List(ctx))
+}
+
+func TestService_Restore(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	cluster := &fakeCluster{pods: map[string]bool{"web-a": true}}
+	definitions := &memoryDefinitionStore{
+		definitions: []Definition{
+			{ID: "1", Context: "dev", Namespace: "default", APIVersion: "v1", Kind: "Pod", Selector: "app=web", Ports: []PortForwardPortSpec{{Remote: 80}}},
+			{ID: "2", Context: "prod", Namespace: "default", APIVersion: "v1", Kind: "Service", Name: "web", Ports: []PortForwardPortSpec{{Remote: 80}}},
+		},
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := New(ctx, ServiceOptions{
+		RESTClient:    &restfake.RESTClient{},
+		ObjectStore:   cluster.store(t, controller),
+		PortForwarder: &fakeForwarder{},
+		Definitions:   definitions,
+		Context:       "dev",
+	})
+	defer s.Stop()
+
+	require.NoError(t, s.Restore(ctx))
+
+	require.Eventually(t, func() bool {
+		saved := s.Saved()
+		return len(saved) == 2 && saved[0].Health == HealthActive
+	}, 5*time.Second, 5*time.Millisecond)
+
+	saved := s.Saved()
+	assert.Equal(t, "pods/app=web", saved[0].Definition.String())
+	assert.Equal(t, "web-a", saved[0].Pod)
+	assert.Equal(t, HealthInactive, saved[1].Health)
+}
+
+func TestService_UpdateClusterClient_whileSaving(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	cluster := &fakeCluster{pods: map[string]bool{"web-a": true}}
+	definitions := &memoryDefinitionStore{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	s := New(ctx, ServiceOptions{
+		RESTClient:          &restfake.RESTClient{},
+		ObjectStore:         cluster.store(t, controller),
+		PortForwarder:       &fakeForwarder{},
+		Definitions:         definitions,
+		Context:             "dev",
+		MinBackoff:          time.Millisecond,
+		MaxBackoff:          10 * time.Millisecond,
+		HealthCheckInterval: 5 * time.Millisecond,
+	})
+	defer s.Stop()
+
+	client := clusterFake.NewMockClientInterface(controller)
+	client.EXPECT().RESTClient().Return(&restfake.RESTClient{}, nil).AnyTimes()
+	client.EXPECT().RESTConfig().Return(&rest.Config{}).AnyTimes()
+
+	var wg sync.WaitGroup
+	wg.Add(2)
+	go func() {
+		defer wg.Done()
+		for i := 0; i < 10; i++ {
+			_, err := s.Save(ctx, Definition{
+				Namespace:  "default",
+				APIVersion: "v1",
+				Kind:       "Service",
+				Name:       "web",
+				Ports:      []PortForwardPortSpec{{Remote: 80}},
+			})
+			assert.NoError(t, err)
+		}
+	}()
+	go func() {
+		defer wg.Done()
+		for _, contextName := range []string{"prod", "dev", "prod"} {
+			assert.NoError(t, s.UpdateClusterClient(ctx, contextName, client))
+		}
+	}()
+	wg.Wait()
+
+	assert.Equal(t, "prod", s.options().Context)
+
+	// Only port forwards saved for the current context are running.
+	require.Eventually(t, func() bool {
+		for _, state := range s.Saved() {
+			if state.Definition.Context == "prod" && state.Health != HealthActive {
+				return false
+			}
+		}
+		return true
+	}, 5*time.Second, 5*time.Millisecond)
+	for _, state := range s.Saved() {
+		if state.Definition.Context != "prod" {
+			assert.Equal(t, HealthInactive, state.Health, state.Definition.ID)
+		}
+	}
+}
+
+func TestService_impersonatedUser(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	cluster := &fakeCluster{pods: map[string]bool{"web-a": true}}
+	forwarder := &fakeForwarder{}
+	definitions := &memoryDefinitionStore{}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	defer cancel()
+
+	user := &auth.User{Name: "alice", Groups: []string{"dev"}}
+
+	octantRESTClient := &restfake.RESTClient{}
+	userRESTClient := &restfake.RESTClient{}
+
+	userClient := clusterFake.NewMockClientInterface(controller)
+	userClient.EXPECT().RESTClient().Return(userRESTClient, nil).AnyTimes()
+	userClient.EXPECT().RESTConfig().Return(&rest.Config{}).AnyTimes()
+
+	clusterClient := clusterFake.NewMockClientInterface(controller)
+	clusterClient.EXPECT().ForUser("alice", []string{"dev"}).Return(userClient, nil).AnyTimes()
+
+	s := New(ctx, ServiceOptions{
+		RESTClient:    octantRESTClient,
+		ClusterClient: clusterClient,
+		ObjectStore:   cluster.store(t, controller),
+		PortForwarder: forwarder,
+		Definitions:   definitions,
+		Context:       "dev",
+	})
+	defer s.Stop()
+
+	userCtx := auth.WithUser(ctx, user)
+
+	_, err := s.Create(userCtx, nil, schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, "web-a", "default", 80)
+	require.NoError(t, err)
+
+	_, err = s.Create(ctx, nil, schema.GroupVersionKind{Version: "v1", Kind: "Pod"}, "web-a", "default", 80)
+	require.NoError(t, err)
+
+	clients := forwarder.restClients()
+	require.Len(t, clients, 2)
+	assert.Same(t, userRESTClient, clients[0], "an impersonated request forwards with the user's client")
+	assert.Same(t, octantRESTClient, clients[1], "a request without a user forwards with Octant's client")
+
+	// Saved port forwards keep forwarding as the user who saved them.
+	definition, err := s.Save(userCtx, Definition{
+		Namespace:  "default",
+		APIVersion: "v1",
+		Kind:       "Service",
+		Name:       "web",
+		Ports:      []PortForwardPortSpec{{Remote: 80}},
+	})
+	require.NoError(t, err)
+	assert.Equal(t, user, definition.User)
+
+	waitForHealth(t, s, HealthActive, "web-a")
+	clients = forwarder.restClients()
+	require.Len(t, clients, 3)
+	assert.Same(t, userRESTClient, clients[2])
+}
@@ -22,6 +22,8 @@ import (
 	"k8s.io/client-go/rest"
 	restclient "k8s.io/client-go/rest"
 
+	"github.com/vmware-tanzu/octant/internal/auth"
+	"github.com/vmware-tanzu/octant/internal/cluster"
 	internalLog "github.com/vmware-tanzu/octant/internal/log"
 	"github.com/vmware-tanzu/octant/internal/util/kubernetes"
 	"github.com/vmware-tanzu/octant/pkg/log"
@@ -43,6 +45,10 @@ type PortForwarder interface {
 	FindPod(namespace string, gvk schema.GroupVersionKind, name string) ([]State, error)
 	Stop()
 	StopForwarder(id string)
+	Save(ctx context.Context, definition Definition) (Definition, error)
+	RemoveSaved(id string) error
+	Saved() []SavedState
+	UpdateClusterClient(ctx context.Context, contextName string, client cluster.ClientInterface) error
 }
 
 // PortForwardPortSpec describes a forwarded port.
@@ -108,10 +114,22 @@ type States struct {
 
 // ServiceOptions contains all the options for running a port-forward service
 type ServiceOptions struct {
-	RESTClient    rest.Interface
-	Config        *restclient.Config
+	RESTClient rest.Interface
+	Config     *restclient.Config
+	// ClusterClient creates clients for users authenticated in a request's context.
+	// Port forwards for those users are created with their credentials.
+	ClusterClient cluster.ClientInterface
 	ObjectStore   store.Store
 	PortForwarder portForwarder
+	// Definitions stores saved port forwards. Port forwards aren't saved if it is nil.
+	Definitions DefinitionStore
+	// Context is the current kube config context. Saved port forwards for it are restored.
+	Context string
+	// MinBackoff and MaxBackoff bound the delay before a saved port forward reconnects.
+	MinBackoff time.Duration
+	MaxBackoff time.Duration
+	// HealthCheckInterval is how often a saved port forward checks its pod is ready.
+	HealthCheckInterval time.Duration
 }
 
 type forwarderEvent struct {
@@ -121,12 +139,15 @@ type forwarderEvent struct {
 
 // Service is a port forwarding service.
 type Service struct {
-	logger   log.Logger
+	logger log.Logger
+	// optsMu guards opts, which change when the cluster client is updated.
+	optsMu   sync.RWMutex
 	opts     ServiceOptions
 	ctx      context.Context
 	cancel   context.CancelFunc
 	notifyCh chan forwarderEvent
 	state    States
+	saved    savedForwards
 }
 
 // Check that struct satisfies interface
@@ -147,6 +168,14 @@ func New(ctx context.Context, opts ServiceOptions) *Service {
 	}
 }
 
+// options returns a copy of the service's options.
+func (s *Service) options() ServiceOptions {
+	s.optsMu.RLock()
+	defer s.optsMu.RUnlock()
+
+	return s.opts
+}
+
 // Stop stops all forwarders. The portForwardService is invalid after calling stop.
 func (s *Service) Stop() {
 	// TODO wait on goroutines to complete after calling cancel. (GH#494)
@@ -181,7 +210,7 @@ func (s *Service) validateCreateRequest(r CreateRequest) error {
 // one will be chosen. A pod has to be active.
 // Returns: pod name or error.
 func (s *Service) resolvePod(ctx context.Context, r CreateRequest) (string, error) {
-	o := s.opts.ObjectStore
+	o := s.options().ObjectStore
 	if o == nil {
 		return "", errors.New("nil objectstore")
 	}
@@ -207,7 +236,7 @@ func (s *Service) resolvePod(ctx context.Context, r CreateRequest) (string, erro
 }
 
 func (s *Service) findPodForService(ctx context.Context, apiVersion, kind, namespace, name string) (*corev1.Pod, error) {
-	o := s.opts.ObjectStore
+	o := s.options().ObjectStore
 	if o == nil {
 		return nil, errors.New("nil objectstore")
 	}
@@ -256,7 +285,7 @@ func (s *Service) findPodForService(ctx context.Context, apiVersion, kind, names
 // verifyPod returns true if the specified pod can be found and is in the running phase.
 // Otherwise returns false and an error describing the cause.
 func (s *Service) verifyPod(ctx context.Context, namespace, name string) (bool, error) {
-	o := s.opts.ObjectStore
+	o := s.options().ObjectStore
 	if o == nil {
 		return false, errors.New("nil objectstore")
 	}
@@ -290,13 +319,19 @@ func (s *Service) verifyPod(ctx context.Context, namespace, name string) (bool,
 // createForwarder creates a port forwarder, forwards traffic, and blocks until
 // port state information is populated.
 // Returns forwarder id.
-func (s *Service) createForwarder(alerter action.Alerter, targetRequest, podRequest CreateRequest) (string, error) {
+func (s *Service) createForwarder(parent context.Context, alerter action.Alerter, targetRequest, podRequest CreateRequest) (string, error) {
 	logger := s.logger.With("context", "PortForwardService.createForwarder")
 
-	if s.opts.PortForwarder == nil {
+	o := s.options()
+	if o.PortForwarder == nil {
 		return "", errors.New("portforwarder is nil")
 	}
 
+	restClient, restConfig, err := restClientForContext(parent, o)
+	if err != nil {
+		return "", err
+	}
+
 	randomUUID, err := uuid.NewRandom()
 	if err != nil {
 		return "", errors.Wrap(err, "generating uuid")
@@ -324,15 +359,14 @@ func (s *Service) createForwarder(alerter action.Alerter, targetRequest, podRequ
 	podGvk := podGv.WithKind(podRequest.Kind)
 
 	// This child context will be cancelled if our parent context is cancelled
-	ctx, cancel := context.WithCancel(s.ctx)
+	ctx, cancel := context.WithCancel(parent)
 
 	// Spawns goroutine to update state as ports become available
 	portsChannel, portsReady := s.localPortsHandler(ctx, forwarderID)
 
-	o := &s.opts
 	opts := Options{
-		Config:        o.Config,
-		RESTClient:    o.RESTClient,
+		Config:        restConfig,
+		RESTClient:    restClient,
 		Address:       []string{"localhost"},
 		Ports:         ports,
 		PortForwarder: o.PortForwarder,
@@ -365,7 +399,7 @@ func (s *Service) createForwarder(alerter action.Alerter, targetRequest, podRequ
 	s.state.portForwards[forwarderID] = forwardState
 	s.state.Unlock()
 
-	req := o.RESTClient.Post().
+	req := restClient.Post().
 		Resource("pods").
 		Namespace(podRequest.Namespace).
 		Name(podRequest.Name).
@@ -374,7 +408,7 @@ func (s *Service) createForwarder(alerter action.Alerter, targetRequest, podRequ
 	go func() {
 		// Blocks until forwarder completes
 		logger.With("url", req.URL()).Debugf("starting port-forward")
-		err := s.opts.PortForwarder.ForwardPorts(alerter, "POST", req.URL(), opts)
+		err := o.PortForwarder.ForwardPorts(alerter, "POST", req.URL(), opts)
 
 		logger.Debugf("forwarding terminated: %v", err)
 
@@ -402,6 +436,26 @@ func (s *Service) createForwarder(alerter action.Alerter, targetRequest, podRequ
 	return forwarderID, nil
 }
 
+// restClientForContext returns the REST client and config for the user authenticated
+// in ctx. The service's own client is returned if ctx does not have a user.
+func restClientForContext(ctx context.Context, o ServiceOptions) (rest.Interface, *restclient.Config, error) {
+	if _, ok := auth.UserFrom(ctx); !ok || o.ClusterClient == nil {
+		return o.RESTClient, o.Config, nil
+	}
+
+	client, err := cluster.ClientForContext(ctx, o.ClusterClient)
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "client for user")
+	}
+
+	restClient, err := client.RESTClient()
+	if err != nil {
+		return nil, nil, errors.Wrap(err, "fetching RESTClient for user")
+	}
+
+	return restClient, client.RESTConfig(), nil
+}
+
 // responseForCreate creates a create response based on the state for the specified forward (by id)
 func (s *Service) responseForCreate(id string) (CreateResponse, error) {
 	var response CreateResponse
@@ -519,7 +573,13 @@ func (s *Service) Create(ctx context.Context, alerter action.Alerter, gvk schema
 	podReq.Name = podName
 	podReq.Kind = "Pod"
 
-	id, err := s.createForwarder(alerter, req, CreateRequest{
+	// The forward outlives the request, but is created with the request's user.
+	forwardCtx := s.ctx
+	if user, ok := auth.UserFrom(ctx); ok {
+		forwardCtx = auth.WithUser(forwardCtx, user)
+	}
+
+	id, err := s.createForwarder(forwardCtx, alerter, req, CreateRequest{
 		Namespace:  req.Namespace,
 		APIVersion: req.APIVersion,
 		Kind:       "Pod",
@@ -557,6 +617,40 @@ func (s *Service) StopForwarder(id string) {
 	delete(s.state.portForwards, id)
 }
 
+// UpdateClusterClient stops every port forward, since they forward to the previous
+// cluster, and restores the saved port forwards for the new context.
+func (s *Service) UpdateClusterClient(ctx context.Context, contextName string, client cluster.ClientInterface) error {
+	restClient, err := client.RESTClient()
+	if err != nil {
+		return errors.Wrap(err, "fetching RESTClient")
+	}
+
+	// The options are updated while saved port forwards are locked, so one saved
+	// meanwhile isn't started for the previous context.
+	s.saved.Lock()
+	s.stopSupervisors()
+	s.optsMu.Lock()
+	s.opts.RESTClient = restClient
+	s.opts.Config = client.RESTConfig()
+	s.opts.ClusterClient = client
+	s.opts.Context = contextName
+	s.optsMu.Unlock()
+	s.saved.Unlock()
+
+	s.state.Lock()
+	var ids []string
+	for id := range s.state.portForwards {
+		ids = append(ids, id)
+	}
+	s.state.Unlock()
+
+	for _, id := range ids {
+		s.StopForwarder(id)
+	}
+
+	return s.Restore(ctx)
+}
+
 type notFound struct{}
 
 // Check that struct satisfies interface
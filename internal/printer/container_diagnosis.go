@@ -0,0 +1,400 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package printer
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	corev1 "k8s.io/api/core/v1"
+
+	"github.com/vmware-tanzu/octant/internal/config"
+	"github.com/vmware-tanzu/octant/internal/modules/overview/container"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+const (
+	// diagnosisLogLines is the number of log lines shown for a failing container.
+	diagnosisLogLines = 20
+	// diagnosisEvents is the number of events shown for a failing container.
+	diagnosisEvents = 5
+	// diagnosisLogTimeout is how long to wait for a failing container's logs.
+	diagnosisLogTimeout = 5 * time.Second
+)
+
+// failingWaitingReasons are the waiting reasons of containers which can't start.
+var failingWaitingReasons = map[string]bool{
+	"CrashLoopBackOff":           true,
+	"ImagePullBackOff":           true,
+	"ErrImagePull":               true,
+	"InvalidImageName":           true,
+	"CreateContainerConfigError": true,
+	"CreateContainerError":       true,
+	"RunContainerError":          true,
+}
+
+// failingContainer is a container which is restarting or has failed.
+type failingContainer struct {
+	container *corev1.Container
+	status    corev1.ContainerStatus
+	isInit    bool
+}
+
+// findFailingContainers returns the pod's containers which are restarting or have failed.
+func findFailingContainers(pod *corev1.Pod) []failingContainer {
+	var failing []failingContainer
+
+	add := func(containers []corev1.Container, statuses []corev1.ContainerStatus, isInit bool) {
+		for i := range containers {
+			for _, status := range statuses {
+				if status.Name == containers[i].Name && isContainerFailing(status) {
+					failing = append(failing, failingContainer{
+						container: &containers[i],
+						status:    status,
+						isInit:    isInit,
+					})
+				}
+			}
+		}
+	}
+
+	add(pod.Spec.InitContainers, pod.Status.InitContainerStatuses, true)
+	add(pod.Spec.Containers, pod.Status.ContainerStatuses, false)
+
+	return failing
+}
+
+func isContainerFailing(status corev1.ContainerStatus) bool {
+	if waiting := status.State.Waiting; waiting != nil && failingWaitingReasons[waiting.Reason] {
+		return true
+	}
+
+	if terminated := status.State.Terminated; terminated != nil && terminated.ExitCode != 0 {
+		return true
+	}
+
+	if terminated := status.LastTerminationState.Terminated; status.RestartCount > 0 && terminated != nil {
+		return terminated.ExitCode != 0 || terminated.Reason == "OOMKilled" || !status.Ready
+	}
+
+	return false
+}
+
+// containerDiagnosis explains why a container is failing.
+type containerDiagnosis struct {
+	name   string
+	isInit bool
+	status corev1.ContainerStatus
+	// events are the container's recent events, newest first.
+	events []corev1.Event
+	// logs are the last lines logged by the terminated container.
+	logs   []string
+	probes []string
+	causes []string
+}
+
+func newContainerDiagnosis(pod *corev1.Pod, fc failingContainer, events []corev1.Event, logs []string) *containerDiagnosis {
+	d := &containerDiagnosis{
+		name:   fc.container.Name,
+		isInit: fc.isInit,
+		status: fc.status,
+		events: containerEvents(fc, events),
+		logs:   logs,
+	}
+
+	for _, probe := range []struct {
+		name  string
+		probe *corev1.Probe
+	}{
+		{name: "Liveness", probe: fc.container.LivenessProbe},
+		{name: "Readiness", probe: fc.container.ReadinessProbe},
+		{name: "Startup", probe: fc.container.StartupProbe},
+	} {
+		if probe.probe != nil {
+			d.probes = append(d.probes, fmt.Sprintf("%s: %s", probe.name, describeProbe(probe.probe)))
+		}
+	}
+
+	d.causes = likelyCauses(pod, fc, d.events)
+
+	return d
+}
+
+// containerEvents returns the recent events for a container, newest first. Warnings
+// for the pod which aren't about a specific container are included.
+func containerEvents(fc failingContainer, events []corev1.Event) []corev1.Event {
+	fieldPath := fmt.Sprintf("spec.containers{%s}", fc.container.Name)
+	if fc.isInit {
+		fieldPath = fmt.Sprintf("spec.initContainers{%s}", fc.container.Name)
+	}
+
+	var matched []corev1.Event
+	for _, event := range events {
+		path := event.InvolvedObject.FieldPath
+		if path == fieldPath || (path == "" && event.Type == corev1.EventTypeWarning) {
+			matched = append(matched, event)
+		}
+	}
+
+	sort.SliceStable(matched, func(i, j int) bool {
+		return eventLastSeen(&matched[i]).After(eventLastSeen(&matched[j]))
+	})
+
+	if len(matched) > diagnosisEvents {
+		matched = matched[:diagnosisEvents]
+	}
+
+	return matched
+}
+
+func eventLastSeen(event *corev1.Event) time.Time {
+	switch {
+	case !event.LastTimestamp.IsZero():
+		return event.LastTimestamp.Time
+	case !event.EventTime.IsZero():
+		return event.EventTime.Time
+	default:
+		return event.CreationTimestamp.Time
+	}
+}
+
+// likelyCauses suggests why a container is failing.
+func likelyCauses(pod *corev1.Pod, fc failingContainer, events []corev1.Event) []string {
+	var causes []string
+
+	terminated := fc.status.State.Terminated
+	if terminated == nil {
+		terminated = fc.status.LastTerminationState.Terminated
+	}
+
+	if waiting := fc.status.State.Waiting; waiting != nil {
+		switch waiting.Reason {
+		case "ErrImagePull", "ImagePullBackOff":
+			causes = append(causes, imagePullCause(pod, fc.container, waiting.Message, events))
+		case "InvalidImageName":
+			causes = append(causes, fmt.Sprintf("Image name %q is invalid.", fc.container.Image))
+		case "CreateContainerConfigError":
+			causes = append(causes, fmt.Sprintf("Container configuration is invalid: %s. A referenced ConfigMap or Secret may be missing.", waiting.Message))
+		case "CreateContainerError", "RunContainerError":
+			causes = append(causes, fmt.Sprintf("Container runtime couldn't start the container: %s.", waiting.Message))
+		}
+	}
+
+	if probe, message, ok := failingProbe(events); ok {
+		causes = append(causes, fmt.Sprintf("%s probe is failing (%s). Check the probe configuration, or increase its initial delay or failure threshold if the application starts slowly.", probe, message))
+	}
+
+	if terminated != nil {
+		switch {
+		case terminated.Reason == "OOMKilled":
+			cause := "Container ran out of memory and was killed."
+			if limit, ok := fc.container.Resources.Limits[corev1.ResourceMemory]; ok {
+				cause = fmt.Sprintf("Container ran out of memory and was killed. The memory limit of %s is likely too low.", limit.String())
+			}
+			causes = append(causes, cause)
+		case terminated.ExitCode == 126:
+			causes = append(causes, "Container command isn't executable (exit code 126).")
+		case terminated.ExitCode == 127:
+			causes = append(causes, "Container command wasn't found (exit code 127). Check the image's entrypoint and the container's command.")
+		case terminated.ExitCode == 0 && !fc.isInit && pod.Spec.RestartPolicy != corev1.RestartPolicyNever:
+			causes = append(causes, "Container exits successfully, but is restarted because the pod expects it to keep running.")
+		case terminated.ExitCode != 0 && len(causes) == 0:
+			causes = append(causes, fmt.Sprintf("Application exited with code %d. Check the logs of the terminated container for the error.", terminated.ExitCode))
+		}
+	}
+
+	return causes
+}
+
+var imagePullAuthMessages = []string{"unauthorized", "authentication required", "access denied", "denied", "401", "403"}
+
+func imagePullCause(pod *corev1.Pod, c *corev1.Container, message string, events []corev1.Event) string {
+	messages := []string{strings.ToLower(message)}
+	for _, event := range events {
+		messages = append(messages, strings.ToLower(event.Message))
+	}
+
+	for _, m := range messages {
+		for _, authMessage := range imagePullAuthMessages {
+			if strings.Contains(m, authMessage) {
+				if len(pod.Spec.ImagePullSecrets) == 0 {
+					return fmt.Sprintf("Registry refused to pull image %q. The pod has no image pull secrets.", c.Image)
+				}
+				return fmt.Sprintf("Registry refused to pull image %q. Check the pod's image pull secrets.", c.Image)
+			}
+		}
+	}
+
+	for _, m := range messages {
+		if strings.Contains(m, "not found") || strings.Contains(m, "manifest unknown") {
+			return fmt.Sprintf("Image %q doesn't exist. Check the image name and tag.", c.Image)
+		}
+	}
+
+	return fmt.Sprintf("Image %q couldn't be pulled.", c.Image)
+}
+
+// failingProbe returns the probe failing in a container's events.
+func failingProbe(events []corev1.Event) (string, string, bool) {
+	for _, event := range events {
+		for _, probe := range []string{"Liveness", "Startup"} {
+			prefix := probe + " probe failed"
+			if event.Reason == "Unhealthy" && strings.HasPrefix(event.Message, prefix) {
+				return probe, strings.TrimSpace(strings.TrimPrefix(strings.TrimPrefix(event.Message, prefix), ":")), true
+			}
+			if event.Reason == "Killing" && strings.Contains(event.Message, "failed "+strings.ToLower(probe)+" probe") {
+				return probe, event.Message, true
+			}
+		}
+	}
+
+	return "", "", false
+}
+
+func describeProbe(probe *corev1.Probe) string {
+	var action string
+	switch handler := probe.Handler; {
+	case handler.HTTPGet != nil:
+		action = fmt.Sprintf("http-get %s:%s%s", handler.HTTPGet.Host, handler.HTTPGet.Port.String(), handler.HTTPGet.Path)
+	case handler.TCPSocket != nil:
+		action = fmt.Sprintf("tcp-socket %s:%s", handler.TCPSocket.Host, handler.TCPSocket.Port.String())
+	case handler.Exec != nil:
+		action = fmt.Sprintf("exec %s", strings.Join(handler.Exec.Command, " "))
+	default:
+		action = "unknown"
+	}
+
+	return fmt.Sprintf("%s delay=%ds timeout=%ds period=%ds #success=%d #failure=%d",
+		action, probe.InitialDelaySeconds, probe.TimeoutSeconds, probe.PeriodSeconds,
+		probe.SuccessThreshold, probe.FailureThreshold)
+}
+
+// createContainerDiagnosisView creates a "Why is this failing" card for a container.
+func createContainerDiagnosisView(d *containerDiagnosis) *component.Card {
+	title := "Container"
+	if d.isInit {
+		title = "Init Container"
+	}
+	card := component.NewCard(component.TitleFromString(fmt.Sprintf("Why is %s %s failing?", strings.ToLower(title), d.name)))
+
+	if state, found := printContainerState(d.status.State); found {
+		card.SetAlert(component.NewAlert(component.AlertTypeWarning, fmt.Sprintf("%s %s is %s", title, d.name, describeFailingState(d.status.State, state))))
+	}
+
+	sections := component.SummarySections{}
+
+	if len(d.causes) > 0 {
+		sections.Add("Likely Causes", component.NewMarkdownText(markdownList(d.causes)))
+	}
+
+	if terminated := d.status.LastTerminationState.Terminated; terminated != nil {
+		sections.AddText("Last Termination", describeTermination(terminated))
+	}
+
+	sections.AddText("Restart Count", fmt.Sprintf("%d", d.status.RestartCount))
+
+	if len(d.probes) > 0 {
+		sections.Add("Probes", component.NewMarkdownText(markdownList(d.probes)))
+	}
+
+	if len(d.events) > 0 {
+		var lines []string
+		for _, event := range d.events {
+			lines = append(lines, fmt.Sprintf("%s %s (%d): %s", event.Type, event.Reason, event.Count, event.Message))
+		}
+		sections.Add("Recent Events", component.NewMarkdownText(markdownList(lines)))
+	}
+
+	if len(d.logs) > 0 {
+		sections.Add("Previous Logs", component.NewMarkdownText("```\n"+strings.Join(d.logs, "\n")+"\n```"))
+	}
+
+	card.SetBody(component.NewSummary("", sections...))
+
+	return card
+}
+
+func describeFailingState(state corev1.ContainerState, printed string) string {
+	if state.Waiting != nil && state.Waiting.Reason != "" {
+		if state.Waiting.Message == "" {
+			return fmt.Sprintf("waiting: %s", state.Waiting.Reason)
+		}
+		return fmt.Sprintf("waiting: %s: %s", state.Waiting.Reason, state.Waiting.Message)
+	}
+	return printed
+}
+
+func describeTermination(terminated *corev1.ContainerStateTerminated) string {
+	s := fmt.Sprintf("exit code %d", terminated.ExitCode)
+	if terminated.Reason != "" {
+		s += fmt.Sprintf(" (%s)", terminated.Reason)
+	}
+	if terminated.Signal != 0 {
+		s += fmt.Sprintf(", signal %d", terminated.Signal)
+	}
+	if !terminated.FinishedAt.IsZero() {
+		s += fmt.Sprintf(" at %s", terminated.FinishedAt.UTC().Format(time.RFC3339))
+	}
+	if terminated.Message != "" {
+		s += ": " + terminated.Message
+	}
+	return s
+}
+
+// terminatedContainerLogs returns the last lines logged by the container's terminated
+// instance. If the container has restarted, the previous instance's logs are returned.
+func terminatedContainerLogs(ctx context.Context, dashConfig config.Dash, pod *corev1.Pod, status corev1.ContainerStatus) ([]string, error) {
+	previous := status.LastTerminationState.Terminated != nil
+	if !previous && status.State.Terminated == nil {
+		return nil, nil
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, diagnosisLogTimeout)
+	defer cancel()
+
+	// The previous instance's log is read from the start, so the since seconds are only
+	// used for the current instance, which is read from when the pod was created.
+	sinceSeconds := int64(-1)
+	if previous {
+		sinceSeconds = 0
+	}
+
+	key := store.Key{Namespace: pod.Namespace, APIVersion: "v1", Kind: "Pod", Name: pod.Name}
+	streamer, err := container.NewLogStreamer(ctx, dashConfig, key, sinceSeconds, status.Name)
+	if err != nil {
+		return nil, fmt.Errorf("create log streamer: %w", err)
+	}
+	streamer.SetPrevious(previous)
+	streamer.SetTailLines(diagnosisLogLines)
+
+	logCh := make(chan container.LogEntry)
+	streamer.Stream(ctx, logCh)
+
+	var lines []string
+	for {
+		select {
+		case entry, ok := <-logCh:
+			if !ok {
+				return lines, nil
+			}
+			lines = append(lines, entry.Line())
+			if len(lines) > diagnosisLogLines {
+				lines = lines[1:]
+			}
+		case <-ctx.Done():
+			// Drain the stream so it can close once the request is cancelled.
+			go func() {
+				for range logCh {
+				}
+			}()
+			return lines, nil
+		}
+	}
+}
@@ -0,0 +1,264 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package printer
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	kubefake "k8s.io/client-go/kubernetes/fake"
+	ktesting "k8s.io/client-go/testing"
+
+	clusterFake "github.com/vmware-tanzu/octant/internal/cluster/fake"
+	configFake "github.com/vmware-tanzu/octant/internal/config/fake"
+	"github.com/vmware-tanzu/octant/internal/testutil"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+func createFailingPod(status corev1.ContainerStatus) *corev1.Pod {
+	pod := testutil.CreatePod("pod")
+	pod.Spec.Containers = []corev1.Container{
+		{
+			Name:  "app",
+			Image: "registry.example.com/app:1.0",
+			Resources: corev1.ResourceRequirements{
+				Limits: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("64Mi")},
+			},
+			LivenessProbe: &corev1.Probe{
+				Handler: corev1.Handler{
+					HTTPGet: &corev1.HTTPGetAction{Path: "/healthz", Port: intstr.FromInt(8080)},
+				},
+				TimeoutSeconds:   1,
+				PeriodSeconds:    10,
+				SuccessThreshold: 1,
+				FailureThreshold: 3,
+			},
+		},
+	}
+	status.Name = "app"
+	pod.Status.ContainerStatuses = []corev1.ContainerStatus{status}
+	return pod
+}
+
+func crashLoopStatus(exitCode int32, reason string) corev1.ContainerStatus {
+	return corev1.ContainerStatus{
+		RestartCount: 4,
+		State: corev1.ContainerState{
+			Waiting: &corev1.ContainerStateWaiting{Reason: "CrashLoopBackOff", Message: "back-off 40s restarting failed container"},
+		},
+		LastTerminationState: corev1.ContainerState{
+			Terminated: &corev1.ContainerStateTerminated{ExitCode: exitCode, Reason: reason},
+		},
+	}
+}
+
+func containerEvent(fieldPath, eventType, reason, message string, last time.Time) corev1.Event {
+	return corev1.Event{
+		InvolvedObject: corev1.ObjectReference{Kind: "Pod", Name: "pod", FieldPath: fieldPath},
+		Type:           eventType,
+		Reason:         reason,
+		Message:        message,
+		Count:          1,
+		LastTimestamp:  metav1.NewTime(last),
+	}
+}
+
+func Test_findFailingContainers(t *testing.T) {
+	cases := []struct {
+		name     string
+		status   corev1.ContainerStatus
+		expected bool
+	}{
+		{
+			name:   "running",
+			status: corev1.ContainerStatus{Ready: true, State: corev1.ContainerState{Running: &corev1.ContainerStateRunning{}}},
+		},
+		{
+			name:     "crash loop",
+			status:   crashLoopStatus(1, "Error"),
+			expected: true,
+		},
+		{
+			name: "image pull",
+			status: corev1.ContainerStatus{
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+			},
+			expected: true,
+		},
+		{
+			name: "failed",
+			status: corev1.ContainerStatus{
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 2}},
+			},
+			expected: true,
+		},
+		{
+			name: "completed",
+			status: corev1.ContainerStatus{
+				State: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 0}},
+			},
+		},
+		{
+			name: "restarted after OOM and ready",
+			status: corev1.ContainerStatus{
+				Ready:                true,
+				RestartCount:         1,
+				State:                corev1.ContainerState{Running: &corev1.ContainerStateRunning{}},
+				LastTerminationState: corev1.ContainerState{Terminated: &corev1.ContainerStateTerminated{ExitCode: 137, Reason: "OOMKilled"}},
+			},
+			expected: true,
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			got := findFailingContainers(createFailingPod(tc.status))
+			assert.Equal(t, tc.expected, len(got) == 1)
+		})
+	}
+}
+
+func Test_likelyCauses(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	cases := []struct {
+		name     string
+		status   corev1.ContainerStatus
+		secrets  []corev1.LocalObjectReference
+		events   []corev1.Event
+		expected []string
+	}{
+		{
+			name:     "memory limit too low",
+			status:   crashLoopStatus(137, "OOMKilled"),
+			expected: []string{"Container ran out of memory and was killed. The memory limit of 64Mi is likely too low."},
+		},
+		{
+			name:   "failing liveness probe",
+			status: crashLoopStatus(137, "Error"),
+			events: []corev1.Event{
+				containerEvent("spec.containers{app}", corev1.EventTypeWarning, "Unhealthy",
+					"Liveness probe failed: HTTP probe failed with statuscode: 500", now),
+			},
+			expected: []string{
+				"Liveness probe is failing (HTTP probe failed with statuscode: 500). Check the probe configuration, or increase its initial delay or failure threshold if the application starts slowly.",
+			},
+		},
+		{
+			name: "image pull auth",
+			status: corev1.ContainerStatus{
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ImagePullBackOff"}},
+			},
+			events: []corev1.Event{
+				containerEvent("spec.containers{app}", corev1.EventTypeWarning, "Failed",
+					"Failed to pull image: rpc error: code = Unknown desc = unauthorized: authentication required", now),
+			},
+			expected: []string{`Registry refused to pull image "registry.example.com/app:1.0". The pod has no image pull secrets.`},
+		},
+		{
+			name: "image not found",
+			status: corev1.ContainerStatus{
+				State: corev1.ContainerState{Waiting: &corev1.ContainerStateWaiting{Reason: "ErrImagePull", Message: "manifest unknown"}},
+			},
+			secrets:  []corev1.LocalObjectReference{{Name: "registry"}},
+			expected: []string{`Image "registry.example.com/app:1.0" doesn't exist. Check the image name and tag.`},
+		},
+		{
+			name:     "command not found",
+			status:   crashLoopStatus(127, "Error"),
+			expected: []string{"Container command wasn't found (exit code 127). Check the image's entrypoint and the container's command."},
+		},
+		{
+			name:     "application error",
+			status:   crashLoopStatus(1, "Error"),
+			expected: []string{"Application exited with code 1. Check the logs of the terminated container for the error."},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := createFailingPod(tc.status)
+			pod.Spec.ImagePullSecrets = tc.secrets
+
+			failing := findFailingContainers(pod)
+			require.Len(t, failing, 1)
+
+			assert.Equal(t, tc.expected, likelyCauses(pod, failing[0], tc.events))
+		})
+	}
+}
+
+func Test_createContainerDiagnosisView(t *testing.T) {
+	now := time.Date(2021, 3, 1, 12, 0, 0, 0, time.UTC)
+
+	pod := createFailingPod(crashLoopStatus(137, "OOMKilled"))
+	failing := findFailingContainers(pod)
+	require.Len(t, failing, 1)
+
+	events := []corev1.Event{
+		containerEvent("spec.containers{app}", corev1.EventTypeNormal, "Pulled", "pulled image", now.Add(-time.Minute)),
+		containerEvent("spec.containers{other}", corev1.EventTypeWarning, "BackOff", "back-off", now),
+		containerEvent("spec.containers{app}", corev1.EventTypeWarning, "BackOff", "back-off restarting failed container", now),
+	}
+
+	d := newContainerDiagnosis(pod, failing[0], events, []string{"starting", "allocating cache"})
+	got := createContainerDiagnosisView(d)
+
+	expected := component.NewCard(component.TitleFromString("Why is container app failing?"))
+	expected.SetAlert(component.NewAlert(component.AlertTypeWarning,
+		"Container app is waiting: CrashLoopBackOff: back-off 40s restarting failed container"))
+	expected.SetBody(component.NewSummary("", component.SummarySections{
+		{Header: "Likely Causes", Content: component.NewMarkdownText("* Container ran out of memory and was killed. The memory limit of 64Mi is likely too low.\n")},
+		{Header: "Last Termination", Content: component.NewText("exit code 137 (OOMKilled)")},
+		{Header: "Restart Count", Content: component.NewText("4")},
+		{Header: "Probes", Content: component.NewMarkdownText("* Liveness: http-get :8080/healthz delay=0s timeout=1s period=10s #success=1 #failure=3\n")},
+		{Header: "Recent Events", Content: component.NewMarkdownText(
+			"* Warning BackOff (1): back-off restarting failed container\n* Normal Pulled (1): pulled image\n")},
+		{Header: "Previous Logs", Content: component.NewMarkdownText("```\nstarting\nallocating cache\n```")},
+	}...))
+
+	assert.Equal(t, expected, got)
+}
+
+func Test_terminatedContainerLogs(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	pod := createFailingPod(crashLoopStatus(1, "Error"))
+
+	var options []*corev1.PodLogOptions
+	clientset := kubefake.NewSimpleClientset()
+	clientset.PrependReactor("get", "pods", func(a ktesting.Action) (bool, runtime.Object, error) {
+		if a.GetSubresource() == "log" {
+			options = append(options, a.(ktesting.GenericAction).GetValue().(*corev1.PodLogOptions))
+		}
+		return false, nil, nil
+	})
+
+	clusterClient := clusterFake.NewMockClientInterface(controller)
+	clusterClient.EXPECT().KubernetesClient().Return(clientset, nil)
+
+	dashConfig := configFake.NewMockDash(controller)
+	dashConfig.EXPECT().ClusterClient().Return(clusterClient)
+
+	lines, err := terminatedContainerLogs(context.Background(), dashConfig, pod, pod.Status.ContainerStatuses[0])
+	require.NoError(t, err)
+	assert.Equal(t, []string{"fake logs"}, lines)
+
+	require.Len(t, options, 1)
+	assert.True(t, options[0].Previous)
+	require.NotNil(t, options[0].TailLines, "only the shown lines are requested")
+	assert.Equal(t, int64(diagnosisLogLines), *options[0].TailLines)
+}
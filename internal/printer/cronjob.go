@@ -47,8 +47,10 @@ func CronJobListHandler(ctx context.Context, list *batchv1beta1.CronJobList, opt
 		ts := c.CreationTimestamp.Time
 		row["Age"] = component.NewTimestamp(ts)
 
-		if err := addCronJobActions(c, row); err != nil {
-			return nil, err
+		if !isReadOnly(opts) {
+			if err := addCronJobActions(c, row); err != nil {
+				return nil, err
+			}
 		}
 
 		if err := ot.AddRowForObject(ctx, &c, row); err != nil {
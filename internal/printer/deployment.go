@@ -76,7 +76,7 @@ func DeploymentHandler(ctx context.Context, deployment *appsv1.Deployment, optio
 		return nil, err
 	}
 
-	if err := dh.Config(); err != nil {
+	if err := dh.Config(options); err != nil {
 		return nil, errors.Wrap(err, "print deployment configuration")
 	}
 	if err := dh.Status(); err != nil {
@@ -284,7 +284,7 @@ func editDeploymentAction(deployment *appsv1.Deployment) ([]component.Action, er
 }
 
 type deploymentObject interface {
-	Config() error
+	Config(options Options) error
 	Status() error
 	Pods(ctx context.Context, object runtime.Object, options Options) error
 	Conditions() error
@@ -292,7 +292,7 @@ type deploymentObject interface {
 
 type deploymentHandler struct {
 	deployment     *appsv1.Deployment
-	configFunc     func(*appsv1.Deployment) (*component.Summary, error)
+	configFunc     func(*appsv1.Deployment, Options) (*component.Summary, error)
 	summaryFunc    func(*appsv1.Deployment) (*component.Summary, error)
 	podFunc        func(context.Context, []runtime.Object, Options) (component.Component, error)
 	conditionsFunc func(*appsv1.Deployment) (*component.Table, error)
@@ -322,8 +322,8 @@ func newDeploymentHandler(deployment *appsv1.Deployment, object *Object) (*deplo
 	return dh, nil
 }
 
-func (d *deploymentHandler) Config() error {
-	out, err := d.configFunc(d.deployment)
+func (d *deploymentHandler) Config(options Options) error {
+	out, err := d.configFunc(d.deployment, options)
 	if err != nil {
 		return err
 	}
@@ -332,8 +332,13 @@ func (d *deploymentHandler) Config() error {
 	return nil
 }
 
-func defaultDeploymentConfig(deployment *appsv1.Deployment) (*component.Summary, error) {
-	return NewDeploymentConfiguration(deployment).Create()
+func defaultDeploymentConfig(deployment *appsv1.Deployment, options Options) (*component.Summary, error) {
+	dc := NewDeploymentConfiguration(deployment)
+	if isReadOnly(options) {
+		dc.actionGenerators = nil
+	}
+
+	return dc.Create()
 }
 
 func (d *deploymentHandler) Status() error {
@@ -22,6 +22,7 @@ import (
 	corev1 "k8s.io/api/core/v1"
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
+	configFake "github.com/vmware-tanzu/octant/internal/config/fake"
 	"github.com/vmware-tanzu/octant/internal/conversion"
 	"github.com/vmware-tanzu/octant/internal/testutil"
 	"github.com/vmware-tanzu/octant/pkg/store"
@@ -222,6 +223,29 @@ func Test_deploymentConfiguration(t *testing.T) {
 	}
 }
 
+func Test_defaultDeploymentConfig_readOnly(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	deployment := testutil.CreateDeployment("deployment")
+	deployment.Spec.Replicas = pointer.Int32Ptr(3)
+
+	tpo := newTestPrinterOptions(controller)
+	options := tpo.ToOptions()
+
+	summary, err := defaultDeploymentConfig(deployment, options)
+	require.NoError(t, err)
+	require.Len(t, summary.Config.Actions, 1)
+
+	readOnlyConfig := configFake.NewMockDash(controller)
+	readOnlyConfig.EXPECT().ReadOnly().Return(true).AnyTimes()
+	options.DashConfig = readOnlyConfig
+
+	summary, err = defaultDeploymentConfig(deployment, options)
+	require.NoError(t, err)
+	require.Empty(t, summary.Config.Actions)
+}
+
 func Test_createDeploymentSummaryStatus(t *testing.T) {
 	deployment := testutil.CreateDeployment("deployment")
 	deployment.Status.AvailableReplicas = 1
@@ -80,7 +80,7 @@ func Test_HorizontalPodAutoscalerListHandler(t *testing.T) {
 	expected := component.NewTable("Horizontal Pod Autoscalers", "We couldn't find any horizontal pod autoscalers", cols)
 	expected.Add(component.TableRow{
 		"Name": component.NewLink("", "horizontalpodautoscaler", "/path",
-			genObjectStatus(component.TextStatusOK, []string{"autoscaling/v1 HorizontalPodAutoscaler is OK"})),
+			genObjectStatus(component.TextStatusOK, []string{"Horizontal Pod Autoscaler is OK"})),
 		"Labels":       component.NewLabels(objectLabels),
 		"Targets":      component.NewText("5/50%"),
 		"Minimum Pods": component.NewText("1"),
@@ -0,0 +1,59 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package printer
+
+import (
+	"github.com/vmware-tanzu/octant/internal/metrics"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+const bytesPerMebibyte = 1024 * 1024
+
+// createMetricsCharts creates CPU and memory history charts from metrics samples.
+// It returns nil if there are no samples.
+func createMetricsCharts(samples []metrics.Sample) []component.Component {
+	if len(samples) == 0 {
+		return nil
+	}
+
+	cpuSeries := component.LineChartSeries{Name: "CPU"}
+	memorySeries := component.LineChartSeries{Name: "Memory"}
+
+	for _, sample := range samples {
+		cpuSeries.Points = append(cpuSeries.Points,
+			component.NewLineChartPoint(sample.Timestamp, float64(sample.CPU)))
+		memorySeries.Points = append(memorySeries.Points,
+			component.NewLineChartPoint(sample.Timestamp, float64(sample.Memory)/bytesPerMebibyte))
+	}
+
+	cpu := component.NewLineChart("CPU Usage", "m")
+	cpu.SetArea(true)
+	cpu.AddSeries(cpuSeries)
+
+	memory := component.NewLineChart("Memory Usage", "Mi")
+	memory.SetArea(true)
+	memory.AddSeries(memorySeries)
+
+	return []component.Component{cpu, memory}
+}
+
+// registerMetricsCharts registers the metrics history charts with an object printer.
+func registerMetricsCharts(object *Object, samples []metrics.Sample) {
+	charts := createMetricsCharts(samples)
+	if len(charts) == 0 {
+		return
+	}
+
+	var items []ItemDescriptor
+	for i := range charts {
+		items = append(items, ItemDescriptor{
+			Width:     component.WidthHalf,
+			Component: charts[i],
+		})
+	}
+
+	object.RegisterItems(items...)
+}
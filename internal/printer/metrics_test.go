@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package printer
+
+import (
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+
+	"github.com/vmware-tanzu/octant/internal/metrics"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+func Test_createMetricsCharts(t *testing.T) {
+	now := time.Unix(1600000000, 0)
+
+	tests := []struct {
+		name     string
+		samples  []metrics.Sample
+		expected []component.Component
+	}{
+		{
+			name:     "no samples",
+			expected: nil,
+		},
+		{
+			name: "samples",
+			samples: []metrics.Sample{
+				{Timestamp: now, CPU: 100, Memory: 64 * bytesPerMebibyte},
+				{Timestamp: now.Add(30 * time.Second), CPU: 250, Memory: 96 * bytesPerMebibyte},
+			},
+			expected: []component.Component{
+				&component.LineChart{
+					Base: component.Base{Metadata: component.Metadata{Type: component.TypeLineChart}},
+					Config: component.LineChartConfig{
+						Title: "CPU Usage",
+						Unit:  "m",
+						Area:  true,
+						Series: []component.LineChartSeries{
+							{
+								Name: "CPU",
+								Points: []component.LineChartPoint{
+									{Timestamp: 1600000000, Value: 100},
+									{Timestamp: 1600000030, Value: 250},
+								},
+							},
+						},
+					},
+				},
+				&component.LineChart{
+					Base: component.Base{Metadata: component.Metadata{Type: component.TypeLineChart}},
+					Config: component.LineChartConfig{
+						Title: "Memory Usage",
+						Unit:  "Mi",
+						Area:  true,
+						Series: []component.LineChartSeries{
+							{
+								Name: "Memory",
+								Points: []component.LineChartPoint{
+									{Timestamp: 1600000000, Value: 64},
+									{Timestamp: 1600000030, Value: 96},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			got := createMetricsCharts(test.samples)
+			assert.Equal(t, test.expected, got)
+		})
+	}
+}
@@ -10,7 +10,9 @@ import (
 	"fmt"
 	"strings"
 
+	"github.com/vmware-tanzu/octant/internal/octant"
 	"github.com/vmware-tanzu/octant/internal/util/kubernetes"
+	"github.com/vmware-tanzu/octant/pkg/action"
 	"github.com/vmware-tanzu/octant/pkg/store"
 
 	"github.com/pkg/errors"
@@ -73,12 +75,20 @@ func NodeHandler(ctx context.Context, node *corev1.Node, options Options) (compo
 	if err := nh.Config(options); err != nil {
 		return nil, errors.Wrap(err, "print node configuration")
 	}
+	if !isReadOnly(options) {
+		if err := nh.Buttons(); err != nil {
+			return nil, errors.Wrap(err, "print node buttons")
+		}
+	}
 	if err := nh.Addresses(options); err != nil {
 		return nil, errors.Wrap(err, "print node addresses")
 	}
 	if err := nh.Resources(options); err != nil {
 		return nil, errors.Wrap(err, "print node resources")
 	}
+	if err := nh.Metrics(options); err != nil {
+		return nil, errors.Wrap(err, "print node metrics")
+	}
 	if err := nh.Conditions(options); err != nil {
 		return nil, errors.Wrap(err, "print node conditions")
 	}
@@ -410,8 +420,10 @@ type nodeObject interface {
 	Config(options Options) error
 	Addresses(options Options) error
 	Resources(options Options) error
+	Metrics(options Options) error
 	Conditions(options Options) error
 	Images(options Options) error
+	Buttons() error
 }
 
 type nodeHandler struct {
@@ -449,6 +461,37 @@ func newNodeHandler(node *corev1.Node, object *Object) (*nodeHandler, error) {
 	return nh, nil
 }
 
+// Buttons adds the node actions to the object.
+func (n *nodeHandler) Buttons() error {
+	key, err := store.KeyFromObject(n.node)
+	if err != nil {
+		return err
+	}
+
+	payload := key.ToActionPayload()
+	payload["ignoreDaemonSets"] = true
+	payload["deleteEmptyDirData"] = true
+
+	confirmation := component.WithButtonConfirmation(
+		"Drain Node",
+		fmt.Sprintf("Are you sure you want to drain node **%s**? The node will be cordoned and its pods evicted. "+
+			"DaemonSet managed pods are ignored and emptyDir data will be lost.", n.node.Name),
+	)
+	n.object.AddButton("Drain", action.CreatePayload(octant.ActionOverviewDrain, payload), confirmation)
+	return nil
+}
+
+// Metrics registers the node's CPU and memory history charts.
+func (n *nodeHandler) Metrics(options Options) error {
+	history := options.DashConfig.MetricsHistory()
+	if history == nil {
+		return nil
+	}
+
+	registerMetricsCharts(n.object, history.NodeSamples(n.node.Name))
+	return nil
+}
+
 func (n *nodeHandler) Config(options Options) error {
 	out, err := n.configFunc(n.node, options)
 	if err != nil {
@@ -55,11 +55,6 @@ type componentStatus interface {
 
 // AddRowForObject adds a row for an object to the table.
 func (ol *ObjectTable) AddRowForObject(ctx context.Context, object runtime.Object, row component.TableRow) error {
-	gridAction, err := objectDeleteAction(object)
-	if err != nil {
-		return fmt.Errorf("create object delete action: %w", err)
-	}
-
 	accessor, err := meta.Accessor(object)
 	if err != nil {
 		return fmt.Errorf("get accessor for object: %w", err)
@@ -82,7 +77,15 @@ func (ol *ObjectTable) AddRowForObject(ctx context.Context, object runtime.Objec
 		}
 	}
 
-	row.AddAction(gridAction)
+	// Objects can't be deleted if the store is read-only.
+	if !store.IsReadOnly(ol.store) {
+		gridAction, err := objectDeleteAction(object)
+		if err != nil {
+			return fmt.Errorf("create object delete action: %w", err)
+		}
+
+		row.AddAction(gridAction)
+	}
 
 	ol.rows = append(ol.rows, row)
 
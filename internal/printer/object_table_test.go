@@ -16,6 +16,7 @@ import (
 	"k8s.io/apimachinery/pkg/runtime"
 
 	"github.com/vmware-tanzu/octant/internal/testutil"
+	"github.com/vmware-tanzu/octant/pkg/store"
 	"github.com/vmware-tanzu/octant/pkg/store/fake"
 	"github.com/vmware-tanzu/octant/pkg/view/component"
 )
@@ -150,3 +151,30 @@ func TestObjectTable(t *testing.T) {
 		})
 	}
 }
+
+func TestObjectTable_ReadOnly(t *testing.T) {
+	ctx := context.Background()
+
+	ctrl := gomock.NewController(t)
+	defer ctrl.Finish()
+
+	objectStore := store.NewReadOnly(fake.NewMockStore(ctrl))
+
+	ot := NewObjectTable("table", "placeholder", component.NewTableCols("A"), objectStore)
+
+	pod := testutil.CreatePod("pod")
+	err := ot.AddRowForObject(ctx, pod, component.TableRow{
+		"A": component.NewLink("", pod.Name, "/"+pod.Name),
+	})
+	require.NoError(t, err)
+
+	actual, err := ot.ToComponent()
+	require.NoError(t, err)
+
+	table, ok := actual.(*component.Table)
+	require.True(t, ok)
+	require.Len(t, table.Rows(), 1)
+
+	_, hasActions := table.Rows()[0][component.GridActionKey]
+	require.False(t, hasActions)
+}
@@ -55,7 +55,7 @@ func Test_PersistentVolumeClaimListHandler(t *testing.T) {
 			expected: component.TableRow{
 				"Name": component.NewLink("", object.Name, "/pvc",
 					genObjectStatus(component.TextStatusOK, []string{
-						"v1 PersistentVolumeClaim is OK",
+						"Persistent Volume Claim is OK",
 					})),
 				"Status":        component.NewText("Bound"),
 				"Volume":        component.NewLink("", pv.GetName(), fmt.Sprintf("/%s", pv.GetName())),
@@ -73,7 +73,7 @@ func Test_PersistentVolumeClaimListHandler(t *testing.T) {
 			expected: component.TableRow{
 				"Name": component.NewLink("", object.Name, "/pvc",
 					genObjectStatus(component.TextStatusOK, []string{
-						"v1 PersistentVolumeClaim is OK",
+						"Persistent Volume Claim is OK",
 					})),
 				"Status":        component.NewText("Bound"),
 				"Volume":        component.NewText(""),
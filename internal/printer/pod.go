@@ -8,6 +8,7 @@ package printer
 import (
 	"context"
 	"fmt"
+	"strings"
 
 	"github.com/pkg/errors"
 	appsv1 "k8s.io/api/apps/v1"
@@ -20,6 +21,8 @@ import (
 	"k8s.io/apimachinery/pkg/types"
 
 	"github.com/vmware-tanzu/octant/internal/link"
+	"github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/scheduling"
 	"github.com/vmware-tanzu/octant/internal/util/kubernetes"
 	"github.com/vmware-tanzu/octant/pkg/store"
 	"github.com/vmware-tanzu/octant/pkg/view/component"
@@ -124,6 +127,15 @@ func PodHandler(ctx context.Context, pod *corev1.Pod, options Options) (componen
 	if err := ph.Conditions(options); err != nil {
 		return nil, errors.Wrap(err, "print pod conditions")
 	}
+	if err := ph.Scheduling(ctx, options); err != nil {
+		return nil, errors.Wrap(err, "print pod scheduling")
+	}
+	if err := ph.ContainerDiagnosis(ctx, options); err != nil {
+		return nil, errors.Wrap(err, "print pod container diagnosis")
+	}
+	if err := ph.Metrics(options); err != nil {
+		return nil, errors.Wrap(err, "print pod metrics")
+	}
 	if err := ph.InitContainers(ctx, options); err != nil {
 		return nil, errors.Wrap(err, "print pod init containers")
 	}
@@ -573,6 +585,9 @@ type podObject interface {
 	Config(options Options) error
 	Status(options Options) error
 	Conditions(options Options) error
+	Scheduling(ctx context.Context, options Options) error
+	ContainerDiagnosis(ctx context.Context, options Options) error
+	Metrics(options Options) error
 	InitContainers(ctx context.Context, options Options) error
 	Containers(ctx context.Context, options Options) error
 	Additional(options Options) error
@@ -583,6 +598,8 @@ type podHandler struct {
 	configFunc      func(*corev1.Pod, Options) (*component.Summary, error)
 	summaryFunc     func(*corev1.Pod, Options) (*component.Summary, error)
 	conditionsFunc  func(*corev1.Pod, Options) (*component.Table, error)
+	schedulingFunc  func(context.Context, *corev1.Pod, Options) (*component.Summary, error)
+	diagnosisFunc   func(context.Context, *corev1.Pod, failingContainer, Options) (*component.Card, error)
 	containerFunc   func(ctx context.Context, pod *corev1.Pod, container *corev1.Container, isInit bool, options Options) (*component.Summary, error)
 	additionalFuncs []func(*corev1.Pod, Options) ObjectPrinterFunc
 	object          *Object
@@ -627,6 +644,8 @@ func newPodHandler(pod *corev1.Pod, object *Object) (*podHandler, error) {
 		configFunc:      defaultPodConfig,
 		summaryFunc:     defaultPodSummary,
 		conditionsFunc:  defaultPodConditions,
+		schedulingFunc:  defaultPodScheduling,
+		diagnosisFunc:   defaultContainerDiagnosis,
 		containerFunc:   defaultPodContainers,
 		additionalFuncs: defaultPodHandlerAdditionalItems,
 		object:          object,
@@ -682,6 +701,149 @@ func defaultPodConditions(pod *corev1.Pod, options Options) (*component.Table, e
 	return createPodConditionsView(pod)
 }
 
+// Scheduling registers a diagnosis of why the pod hasn't been scheduled. It is only
+// shown for pending pods without a node.
+func (p *podHandler) Scheduling(ctx context.Context, options Options) error {
+	if p.pod == nil {
+		return errors.New("can't display scheduling for nil pod")
+	}
+
+	if !scheduling.NeedsDiagnosis(p.pod) {
+		return nil
+	}
+
+	p.object.RegisterItems(ItemDescriptor{
+		Width: component.WidthFull,
+		Func: func() (component.Component, error) {
+			return p.schedulingFunc(ctx, p.pod, options)
+		},
+	})
+
+	return nil
+}
+
+// maxSchedulingNodes is the number of nodes listed in the scheduling summary.
+const maxSchedulingNodes = 20
+
+func defaultPodScheduling(ctx context.Context, pod *corev1.Pod, options Options) (*component.Summary, error) {
+	summary := component.NewSummary("Scheduling")
+
+	diagnosis, err := scheduling.Diagnose(ctx, options.DashConfig.ObjectStore(), pod)
+	if err != nil {
+		summary.SetAlert(component.NewAlert(component.AlertTypeError, fmt.Sprintf("Unable to diagnose scheduling: %s", err)))
+		return summary, nil
+	}
+
+	return createPodSchedulingView(diagnosis), nil
+}
+
+func createPodSchedulingView(diagnosis *scheduling.Diagnosis) *component.Summary {
+	summary := component.NewSummary("Scheduling")
+
+	alertType := component.AlertTypeWarning
+	if len(diagnosis.FittingNodes()) > 0 && len(diagnosis.Issues) == 0 {
+		alertType = component.AlertTypeInfo
+	}
+	summary.SetAlert(component.NewAlert(alertType, diagnosis.Summary()))
+
+	if len(diagnosis.Issues) > 0 {
+		summary.AddSection("Issues", component.NewMarkdownText(markdownList(diagnosis.Issues)))
+	}
+
+	shown := 0
+	for _, result := range diagnosis.Nodes {
+		if result.Fits() {
+			continue
+		}
+
+		if shown == maxSchedulingNodes {
+			summary.AddSection("", component.NewText(fmt.Sprintf("... and %d more nodes",
+				len(diagnosis.Nodes)-len(diagnosis.FittingNodes())-shown)))
+			break
+		}
+
+		var messages []string
+		for _, failure := range result.Failures {
+			messages = append(messages, fmt.Sprintf("%s: %s", failure.Predicate, failure.Message))
+		}
+		summary.AddSection(result.Node, component.NewMarkdownText(markdownList(messages)))
+		shown++
+	}
+
+	if fitting := diagnosis.FittingNodes(); len(fitting) > 0 {
+		summary.AddSection("Available Nodes", component.NewText(strings.Join(fitting, ", ")))
+	}
+
+	return summary
+}
+
+// ContainerDiagnosis registers a card explaining why each restarting or failed
+// container is failing.
+func (p *podHandler) ContainerDiagnosis(ctx context.Context, options Options) error {
+	if p.pod == nil {
+		return errors.New("can't display container diagnosis for nil pod")
+	}
+
+	var itemDescriptors []ItemDescriptor
+	for _, fc := range findFailingContainers(p.pod) {
+		fc := fc
+		itemDescriptors = append(itemDescriptors, ItemDescriptor{
+			Width: component.WidthFull,
+			Func: func() (component.Component, error) {
+				return p.diagnosisFunc(ctx, p.pod, fc, options)
+			},
+		})
+	}
+
+	p.object.RegisterItems(itemDescriptors...)
+
+	return nil
+}
+
+func defaultContainerDiagnosis(ctx context.Context, pod *corev1.Pod, fc failingContainer, options Options) (*component.Card, error) {
+	logger := log.From(ctx)
+
+	var events []corev1.Event
+	eventList, err := eventsForObject(ctx, pod, options.DashConfig.ObjectStore())
+	if err != nil {
+		logger.Errorf("list events for container diagnosis: %s", err)
+	} else {
+		events = eventList.Items
+	}
+
+	logs, err := terminatedContainerLogs(ctx, options.DashConfig, pod, fc.status)
+	if err != nil {
+		logger.Errorf("read logs for container diagnosis: %s", err)
+	}
+
+	return createContainerDiagnosisView(newContainerDiagnosis(pod, fc, events, logs)), nil
+}
+
+func markdownList(items []string) string {
+	var sb strings.Builder
+	for _, item := range items {
+		sb.WriteString("* ")
+		sb.WriteString(item)
+		sb.WriteString("\n")
+	}
+	return sb.String()
+}
+
+// Metrics registers the pod's CPU and memory history charts.
+func (p *podHandler) Metrics(options Options) error {
+	if p.pod == nil {
+		return errors.New("can't display metrics for nil pod")
+	}
+
+	history := options.DashConfig.MetricsHistory()
+	if history == nil {
+		return nil
+	}
+
+	registerMetricsCharts(p.object, history.PodSamples(p.pod.Namespace, p.pod.Name))
+	return nil
+}
+
 func (p *podHandler) InitContainers(ctx context.Context, options Options) error {
 	return p.containers(ctx, p.pod.Spec.InitContainers, true, options)
 }
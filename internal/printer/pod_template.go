@@ -108,12 +108,17 @@ func podTemplateContainers(ctx context.Context, fl *flexlayout.FlexLayout, optio
 
 	width := component.WidthHalf
 
+	var actions []containerActionFunc
+	if !isReadOnly(options.printOptions) {
+		actions = append(actions, editContainerAction)
+	}
+
 	for index, container := range options.containers {
 		containerConfig := NewContainerConfiguration(
 			ctx, options.parent, &container, portForwarder,
 			IsInit(options.isInit),
 			WithPrintOptions(options.printOptions),
-			WithActions(editContainerAction),
+			WithActions(actions...),
 		)
 
 		summary, err := containerConfig.Create()
@@ -18,6 +18,7 @@ import (
 	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 
 	"github.com/vmware-tanzu/octant/internal/conversion"
+	"github.com/vmware-tanzu/octant/internal/scheduling"
 	"github.com/vmware-tanzu/octant/internal/testutil"
 	"github.com/vmware-tanzu/octant/pkg/view/component"
 )
@@ -387,6 +388,31 @@ func Test_createPodConditionsView(t *testing.T) {
 	component.AssertEqual(t, expected, got)
 }
 
+func Test_createPodSchedulingView(t *testing.T) {
+	diagnosis := &scheduling.Diagnosis{
+		Issues: []string{`persistentvolumeclaim "data" not found`},
+		Nodes: []scheduling.NodeResult{
+			{
+				Node: "node-1",
+				Failures: []scheduling.Failure{
+					{Predicate: scheduling.PredicateNodeResources, Reason: "Insufficient cpu", Message: "requested 2, 1 of 1 allocatable is free"},
+				},
+			},
+			{Node: "node-2"},
+		},
+	}
+
+	got := createPodSchedulingView(diagnosis)
+
+	expected := component.NewSummary("Scheduling")
+	expected.SetAlert(component.NewAlert(component.AlertTypeWarning, diagnosis.Summary()))
+	expected.AddSection("Issues", component.NewMarkdownText("* persistentvolumeclaim \"data\" not found\n"))
+	expected.AddSection("node-1", component.NewMarkdownText("* NodeResourcesFit: requested 2, 1 of 1 allocatable is free\n"))
+	expected.AddSection("Available Nodes", component.NewText("node-2"))
+
+	assert.Equal(t, expected, got)
+}
+
 func createPodWithPhase(name string, podLabels map[string]string, phase corev1.PodPhase, owner *metav1.OwnerReference) *corev1.Pod {
 	pod := testutil.CreatePod(name)
 	pod.Namespace = "testing"
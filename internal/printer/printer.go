@@ -32,6 +32,11 @@ type Options struct {
 	ObjectFactory ObjectFactory
 }
 
+// isReadOnly returns true if actions which change the cluster should not be printed.
+func isReadOnly(options Options) bool {
+	return options.DashConfig != nil && options.DashConfig.ReadOnly()
+}
+
 // Printer is an interface for printing runtime objects.
 type Printer interface {
 	// Print prints a runtime object.
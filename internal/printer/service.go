@@ -167,11 +167,13 @@ func (sc *ServiceConfiguration) Create(ctx context.Context, options Options) (*c
 
 	summary := component.NewSummary("Configuration", sections...)
 
-	configEditor, err := editServiceAction(ctx, service, options)
-	if err != nil {
-		return nil, err
+	if !isReadOnly(options) {
+		configEditor, err := editServiceAction(ctx, service, options)
+		if err != nil {
+			return nil, err
+		}
+		summary.AddAction(configEditor)
 	}
-	summary.AddAction(configEditor)
 
 	return summary, nil
 }
@@ -0,0 +1,352 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package rbac analyzes the cluster's roles and bindings. It resolves the
+// permissions granted to a user, group or service account, and finds the
+// subjects which can perform an action on a resource. Every result references
+// the binding which grants it.
+package rbac
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/octant/internal/util/kubernetes"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+const rbacAPIVersion = "rbac.authorization.k8s.io/v1"
+
+// Groups every authenticated user and service account is a member of.
+const (
+	groupAuthenticated   = "system:authenticated"
+	groupServiceAccounts = "system:serviceaccounts"
+)
+
+// Subject is a user, group or service account.
+type Subject struct {
+	// Kind is User, Group or ServiceAccount.
+	Kind string
+	Name string
+	// Namespace is the namespace of a service account.
+	Namespace string
+}
+
+// String returns the subject as it is shown to users, e.g. ServiceAccount kube-system/default.
+func (s Subject) String() string {
+	if s.Kind == rbacv1.ServiceAccountKind {
+		return fmt.Sprintf("%s %s/%s", s.Kind, s.Namespace, s.Name)
+	}
+	return fmt.Sprintf("%s %s", s.Kind, s.Name)
+}
+
+// groups returns the groups the subject is a member of, including itself if it
+// is a group. Service accounts and users are members of system:authenticated.
+func (s Subject) groups() []string {
+	switch s.Kind {
+	case rbacv1.GroupKind:
+		return []string{s.Name}
+	case rbacv1.ServiceAccountKind:
+		return []string{
+			groupAuthenticated,
+			groupServiceAccounts,
+			fmt.Sprintf("%s:%s", groupServiceAccounts, s.Namespace),
+		}
+	default:
+		return []string{groupAuthenticated}
+	}
+}
+
+// matches returns true if a binding's subject applies to the subject.
+func (s Subject) matches(bound Subject) bool {
+	switch bound.Kind {
+	case rbacv1.UserKind:
+		if s.Kind == rbacv1.UserKind {
+			return s.Name == bound.Name
+		}
+		// Service accounts authenticate as system:serviceaccount:<namespace>:<name>.
+		return s.Kind == rbacv1.ServiceAccountKind &&
+			bound.Name == fmt.Sprintf("system:serviceaccount:%s:%s", s.Namespace, s.Name)
+	case rbacv1.GroupKind:
+		for _, group := range s.groups() {
+			if group == bound.Name {
+				return true
+			}
+		}
+		return false
+	case rbacv1.ServiceAccountKind:
+		return s.Kind == rbacv1.ServiceAccountKind && s.Name == bound.Name && s.Namespace == bound.Namespace
+	default:
+		return false
+	}
+}
+
+// ObjectRef references a role or binding.
+type ObjectRef struct {
+	// Kind is Role, ClusterRole, RoleBinding or ClusterRoleBinding.
+	Kind      string
+	Namespace string
+	Name      string
+}
+
+// APIVersion returns the API version of the role or binding.
+func (r ObjectRef) APIVersion() string {
+	return rbacAPIVersion
+}
+
+// String returns the kind and name of the object, e.g. RoleBinding default/edit.
+func (r ObjectRef) String() string {
+	if r.Namespace != "" {
+		return fmt.Sprintf("%s %s/%s", r.Kind, r.Namespace, r.Name)
+	}
+	return fmt.Sprintf("%s %s", r.Kind, r.Name)
+}
+
+// Grant is a rule granted to a subject by a binding.
+type Grant struct {
+	// Subject is the subject named in the binding. It can be a group the subject
+	// of an analysis is a member of.
+	Subject Subject
+	Binding ObjectRef
+	Role    ObjectRef
+	// Namespace is the namespace the rule applies to. It is empty for rules which
+	// apply to the whole cluster.
+	Namespace string
+	Rule      rbacv1.PolicyRule
+}
+
+type binding struct {
+	ref      ObjectRef
+	roleRef  rbacv1.RoleRef
+	subjects []Subject
+}
+
+// Analyzer analyzes roles and bindings.
+type Analyzer struct {
+	rules    map[ObjectRef][]rbacv1.PolicyRule
+	bindings []binding
+}
+
+// NewAnalyzer creates an instance of Analyzer.
+func NewAnalyzer(roles []rbacv1.Role, clusterRoles []rbacv1.ClusterRole, roleBindings []rbacv1.RoleBinding, clusterRoleBindings []rbacv1.ClusterRoleBinding) *Analyzer {
+	a := &Analyzer{
+		rules: map[ObjectRef][]rbacv1.PolicyRule{},
+	}
+
+	for _, role := range roles {
+		ref := ObjectRef{Kind: "Role", Namespace: role.Namespace, Name: role.Name}
+		a.rules[ref] = role.Rules
+	}
+	// Aggregated cluster roles have the rules of the roles they aggregate.
+	for _, role := range clusterRoles {
+		ref := ObjectRef{Kind: "ClusterRole", Name: role.Name}
+		a.rules[ref] = role.Rules
+	}
+
+	for _, b := range roleBindings {
+		ref := ObjectRef{Kind: "RoleBinding", Namespace: b.Namespace, Name: b.Name}
+		a.bindings = append(a.bindings, newBinding(ref, b.RoleRef, b.Subjects))
+	}
+	for _, b := range clusterRoleBindings {
+		ref := ObjectRef{Kind: "ClusterRoleBinding", Name: b.Name}
+		a.bindings = append(a.bindings, newBinding(ref, b.RoleRef, b.Subjects))
+	}
+
+	sort.SliceStable(a.bindings, func(i, j int) bool {
+		return lessRef(a.bindings[i].ref, a.bindings[j].ref)
+	})
+
+	return a
+}
+
+func newBinding(ref ObjectRef, roleRef rbacv1.RoleRef, subjects []rbacv1.Subject) binding {
+	b := binding{ref: ref, roleRef: roleRef}
+	for _, s := range subjects {
+		subject := Subject{Kind: s.Kind, Name: s.Name, Namespace: s.Namespace}
+		if subject.Kind == rbacv1.ServiceAccountKind && subject.Namespace == "" {
+			subject.Namespace = ref.Namespace
+		}
+		b.subjects = append(b.subjects, subject)
+	}
+	return b
+}
+
+// Load creates an analyzer from the roles and bindings in the object store.
+func Load(ctx context.Context, objectStore store.Store) (*Analyzer, error) {
+	var roles []rbacv1.Role
+	if err := list(ctx, objectStore, "Role", func(object *unstructured.Unstructured) error {
+		var role rbacv1.Role
+		if err := kubernetes.FromUnstructured(object, &role); err != nil {
+			return err
+		}
+		roles = append(roles, role)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var clusterRoles []rbacv1.ClusterRole
+	if err := list(ctx, objectStore, "ClusterRole", func(object *unstructured.Unstructured) error {
+		var role rbacv1.ClusterRole
+		if err := kubernetes.FromUnstructured(object, &role); err != nil {
+			return err
+		}
+		clusterRoles = append(clusterRoles, role)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var roleBindings []rbacv1.RoleBinding
+	if err := list(ctx, objectStore, "RoleBinding", func(object *unstructured.Unstructured) error {
+		var b rbacv1.RoleBinding
+		if err := kubernetes.FromUnstructured(object, &b); err != nil {
+			return err
+		}
+		roleBindings = append(roleBindings, b)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	var clusterRoleBindings []rbacv1.ClusterRoleBinding
+	if err := list(ctx, objectStore, "ClusterRoleBinding", func(object *unstructured.Unstructured) error {
+		var b rbacv1.ClusterRoleBinding
+		if err := kubernetes.FromUnstructured(object, &b); err != nil {
+			return err
+		}
+		clusterRoleBindings = append(clusterRoleBindings, b)
+		return nil
+	}); err != nil {
+		return nil, err
+	}
+
+	return NewAnalyzer(roles, clusterRoles, roleBindings, clusterRoleBindings), nil
+}
+
+// list lists objects of a kind in all namespaces.
+func list(ctx context.Context, objectStore store.Store, kind string, fn func(object *unstructured.Unstructured) error) error {
+	key := store.Key{APIVersion: rbacAPIVersion, Kind: kind}
+	objects, _, err := objectStore.List(ctx, key)
+	if err != nil {
+		return fmt.Errorf("list %s: %w", kind, err)
+	}
+
+	for i := range objects.Items {
+		if err := fn(&objects.Items[i]); err != nil {
+			return fmt.Errorf("convert %s: %w", kind, err)
+		}
+	}
+
+	return nil
+}
+
+// Subjects returns the subjects named in bindings, sorted by kind, namespace and name.
+func (a *Analyzer) Subjects() []Subject {
+	seen := map[Subject]bool{}
+	var subjects []Subject
+	for _, b := range a.bindings {
+		for _, s := range b.subjects {
+			if !seen[s] {
+				seen[s] = true
+				subjects = append(subjects, s)
+			}
+		}
+	}
+
+	sort.Slice(subjects, func(i, j int) bool {
+		return lessSubject(subjects[i], subjects[j])
+	})
+
+	return subjects
+}
+
+// Grants returns the rules granted to a subject by every binding which applies to
+// it, either directly or through a group it is a member of.
+func (a *Analyzer) Grants(subject Subject) []Grant {
+	var grants []Grant
+	for _, b := range a.bindings {
+		for _, bound := range b.subjects {
+			if !subject.matches(bound) {
+				continue
+			}
+			grants = append(grants, a.grants(b, bound)...)
+		}
+	}
+	return grants
+}
+
+// WhoCan returns the grants which allow a verb on a resource in a namespace. An
+// empty namespace finds grants for the whole cluster, i.e. cluster role bindings.
+func (a *Analyzer) WhoCan(verb string, resource Resource, namespace string) []Grant {
+	var grants []Grant
+	for _, b := range a.bindings {
+		if b.ref.Kind == "RoleBinding" && b.ref.Namespace != namespace {
+			continue
+		}
+
+		for _, bound := range b.subjects {
+			for _, grant := range a.grants(b, bound) {
+				if ruleAllows(grant.Rule, verb, resource) {
+					grants = append(grants, grant)
+				}
+			}
+		}
+	}
+
+	sort.SliceStable(grants, func(i, j int) bool {
+		return lessSubject(grants[i].Subject, grants[j].Subject)
+	})
+
+	return grants
+}
+
+// grants returns the resource rules a binding grants to one of its subjects.
+// Bindings to roles which don't exist grant nothing.
+func (a *Analyzer) grants(b binding, subject Subject) []Grant {
+	role := ObjectRef{Kind: b.roleRef.Kind, Name: b.roleRef.Name}
+	if role.Kind == "Role" {
+		role.Namespace = b.ref.Namespace
+	}
+
+	var grants []Grant
+	for _, rule := range a.rules[role] {
+		if len(rule.Resources) == 0 {
+			continue
+		}
+		grants = append(grants, Grant{
+			Subject:   subject,
+			Binding:   b.ref,
+			Role:      role,
+			Namespace: b.ref.Namespace,
+			Rule:      rule,
+		})
+	}
+	return grants
+}
+
+func lessRef(a, b ObjectRef) bool {
+	if a.Kind != b.Kind {
+		return a.Kind < b.Kind
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
+
+func lessSubject(a, b Subject) bool {
+	if a.Kind != b.Kind {
+		return a.Kind < b.Kind
+	}
+	if a.Namespace != b.Namespace {
+		return a.Namespace < b.Namespace
+	}
+	return a.Name < b.Name
+}
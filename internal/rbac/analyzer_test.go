@@ -0,0 +1,213 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rbac
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+
+	"github.com/vmware-tanzu/octant/pkg/store"
+	storeFake "github.com/vmware-tanzu/octant/pkg/store/fake"
+)
+
+var (
+	readSecrets = rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"secrets"}, Verbs: []string{"get", "list"}}
+	editApps    = rbacv1.PolicyRule{APIGroups: []string{"apps"}, Resources: []string{"deployments", "deployments/scale"}, Verbs: []string{"*"}}
+	healthz     = rbacv1.PolicyRule{NonResourceURLs: []string{"/healthz"}, Verbs: []string{"get"}}
+)
+
+func createAnalyzer() *Analyzer {
+	roles := []rbacv1.Role{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "secret-reader"},
+			Rules:      []rbacv1.PolicyRule{readSecrets},
+		},
+	}
+	clusterRoles := []rbacv1.ClusterRole{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "app-editor"},
+			Rules:      []rbacv1.PolicyRule{editApps, healthz},
+		},
+	}
+	roleBindings := []rbacv1.RoleBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "read-secrets"},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "secret-reader"},
+			Subjects: []rbacv1.Subject{
+				{Kind: rbacv1.ServiceAccountKind, Name: "builder"},
+				{Kind: rbacv1.UserKind, Name: "alice"},
+			},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "staging", Name: "edit-apps"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "app-editor"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "system:serviceaccounts:default"}},
+		},
+		{
+			ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "missing-role"},
+			RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "missing"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+		},
+	}
+	clusterRoleBindings := []rbacv1.ClusterRoleBinding{
+		{
+			ObjectMeta: metav1.ObjectMeta{Name: "ops"},
+			RoleRef:    rbacv1.RoleRef{Kind: "ClusterRole", Name: "app-editor"},
+			Subjects:   []rbacv1.Subject{{Kind: rbacv1.GroupKind, Name: "ops"}},
+		},
+	}
+
+	return NewAnalyzer(roles, clusterRoles, roleBindings, clusterRoleBindings)
+}
+
+func TestAnalyzer_Grants(t *testing.T) {
+	a := createAnalyzer()
+
+	got := a.Grants(Subject{Kind: rbacv1.ServiceAccountKind, Namespace: "default", Name: "builder"})
+
+	expected := []Grant{
+		{
+			Subject:   Subject{Kind: rbacv1.ServiceAccountKind, Namespace: "default", Name: "builder"},
+			Binding:   ObjectRef{Kind: "RoleBinding", Namespace: "default", Name: "read-secrets"},
+			Role:      ObjectRef{Kind: "Role", Namespace: "default", Name: "secret-reader"},
+			Namespace: "default",
+			Rule:      readSecrets,
+		},
+		{
+			Subject:   Subject{Kind: rbacv1.GroupKind, Name: "system:serviceaccounts:default"},
+			Binding:   ObjectRef{Kind: "RoleBinding", Namespace: "staging", Name: "edit-apps"},
+			Role:      ObjectRef{Kind: "ClusterRole", Name: "app-editor"},
+			Namespace: "staging",
+			Rule:      editApps,
+		},
+	}
+	assert.Equal(t, expected, got)
+
+	assert.Empty(t, a.Grants(Subject{Kind: rbacv1.ServiceAccountKind, Namespace: "kube-system", Name: "builder"}))
+	assert.Len(t, a.Grants(Subject{Kind: rbacv1.UserKind, Name: "alice"}), 1)
+}
+
+func TestAnalyzer_WhoCan(t *testing.T) {
+	a := createAnalyzer()
+
+	cases := []struct {
+		name      string
+		verb      string
+		resource  string
+		namespace string
+		expected  []string
+	}{
+		{
+			name:      "namespaced",
+			verb:      "list",
+			resource:  "secrets",
+			namespace: "default",
+			expected: []string{
+				"ServiceAccount default/builder RoleBinding default/read-secrets",
+				"User alice RoleBinding default/read-secrets",
+			},
+		},
+		{
+			name:      "other namespace",
+			verb:      "list",
+			resource:  "secrets",
+			namespace: "staging",
+		},
+		{
+			name:      "wildcard verb",
+			verb:      "delete",
+			resource:  "deployments.apps",
+			namespace: "staging",
+			expected: []string{
+				"Group ops ClusterRoleBinding ops",
+				"Group system:serviceaccounts:default RoleBinding staging/edit-apps",
+			},
+		},
+		{
+			name:     "cluster wide",
+			verb:     "update",
+			resource: "deployments/scale",
+			expected: []string{"Group ops ClusterRoleBinding ops"},
+		},
+		{
+			name:     "wrong group",
+			verb:     "get",
+			resource: "deployments.extensions",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			resource, err := ParseResource(tc.resource)
+			require.NoError(t, err)
+
+			var got []string
+			for _, grant := range a.WhoCan(tc.verb, resource, tc.namespace) {
+				got = append(got, grant.Subject.String()+" "+grant.Binding.String())
+			}
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
+
+func TestAnalyzer_Subjects(t *testing.T) {
+	expected := []Subject{
+		{Kind: rbacv1.GroupKind, Name: "ops"},
+		{Kind: rbacv1.GroupKind, Name: "system:serviceaccounts:default"},
+		{Kind: rbacv1.ServiceAccountKind, Namespace: "default", Name: "builder"},
+		{Kind: rbacv1.UserKind, Name: "alice"},
+	}
+	assert.Equal(t, expected, createAnalyzer().Subjects())
+}
+
+func TestLoad(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	toList := func(objects ...runtime.Object) *unstructured.UnstructuredList {
+		list := &unstructured.UnstructuredList{}
+		for _, object := range objects {
+			m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+			require.NoError(t, err)
+			list.Items = append(list.Items, unstructured.Unstructured{Object: m})
+		}
+		return list
+	}
+
+	role := &rbacv1.Role{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacAPIVersion, Kind: "Role"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "secret-reader"},
+		Rules:      []rbacv1.PolicyRule{readSecrets},
+	}
+	roleBinding := &rbacv1.RoleBinding{
+		TypeMeta:   metav1.TypeMeta{APIVersion: rbacAPIVersion, Kind: "RoleBinding"},
+		ObjectMeta: metav1.ObjectMeta{Namespace: "default", Name: "read-secrets"},
+		RoleRef:    rbacv1.RoleRef{Kind: "Role", Name: "secret-reader"},
+		Subjects:   []rbacv1.Subject{{Kind: rbacv1.UserKind, Name: "alice"}},
+	}
+
+	o := storeFake.NewMockStore(controller)
+	o.EXPECT().List(gomock.Any(), store.Key{APIVersion: rbacAPIVersion, Kind: "Role"}).Return(toList(role), false, nil)
+	o.EXPECT().List(gomock.Any(), store.Key{APIVersion: rbacAPIVersion, Kind: "ClusterRole"}).Return(toList(), false, nil)
+	o.EXPECT().List(gomock.Any(), store.Key{APIVersion: rbacAPIVersion, Kind: "RoleBinding"}).Return(toList(roleBinding), false, nil)
+	o.EXPECT().List(gomock.Any(), store.Key{APIVersion: rbacAPIVersion, Kind: "ClusterRoleBinding"}).Return(toList(), false, nil)
+
+	a, err := Load(context.Background(), o)
+	require.NoError(t, err)
+
+	grants := a.Grants(Subject{Kind: rbacv1.UserKind, Name: "alice"})
+	require.Len(t, grants, 1)
+	assert.Equal(t, "RoleBinding default/read-secrets", grants[0].Binding.String())
+	assert.Equal(t, readSecrets, grants[0].Rule)
+}
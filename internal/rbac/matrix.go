@@ -0,0 +1,122 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rbac
+
+import (
+	"sort"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// StandardVerbs are the verbs supported by most resources. They are the first
+// columns of a permission matrix.
+var StandardVerbs = []string{"get", "list", "watch", "create", "update", "patch", "delete", "deletecollection"}
+
+// Matrix is the merged permissions of a subject. It has a row for each resource
+// in a namespace, and a column for each verb.
+type Matrix struct {
+	Verbs []string
+	Rows  []MatrixRow
+}
+
+// MatrixRow is the permissions for a resource in a namespace.
+type MatrixRow struct {
+	// Namespace is empty for permissions in the whole cluster.
+	Namespace string
+	Resource  Resource
+	// ResourceNames limits the permissions to named objects.
+	ResourceNames []string
+	// Grants are the grants which allow each verb.
+	Grants map[string][]Grant
+}
+
+type matrixKey struct {
+	namespace     string
+	resource      Resource
+	resourceNames string
+}
+
+// NewMatrix merges grants into a permission matrix. A grant of the * verb allows
+// every verb in the matrix.
+func NewMatrix(grants []Grant) Matrix {
+	verbs := append([]string(nil), StandardVerbs...)
+	seenVerbs := map[string]bool{}
+	for _, verb := range verbs {
+		seenVerbs[verb] = true
+	}
+
+	var extraVerbs []string
+	for _, grant := range grants {
+		for _, verb := range grant.Rule.Verbs {
+			if verb != rbacv1.VerbAll && !seenVerbs[verb] {
+				seenVerbs[verb] = true
+				extraVerbs = append(extraVerbs, verb)
+			}
+		}
+	}
+	sort.Strings(extraVerbs)
+	verbs = append(verbs, extraVerbs...)
+
+	rows := map[matrixKey]*MatrixRow{}
+	for _, grant := range grants {
+		for _, group := range grant.Rule.APIGroups {
+			for _, resource := range grant.Rule.Resources {
+				key := matrixKey{
+					namespace:     grant.Namespace,
+					resource:      Resource{Group: group, Resource: resource},
+					resourceNames: strings.Join(grant.Rule.ResourceNames, ","),
+				}
+
+				row, ok := rows[key]
+				if !ok {
+					row = &MatrixRow{
+						Namespace:     key.namespace,
+						Resource:      key.resource,
+						ResourceNames: grant.Rule.ResourceNames,
+						Grants:        map[string][]Grant{},
+					}
+					rows[key] = row
+				}
+
+				for _, verb := range grantedVerbs(grant.Rule.Verbs, verbs) {
+					row.Grants[verb] = append(row.Grants[verb], grant)
+				}
+			}
+		}
+	}
+
+	m := Matrix{Verbs: verbs}
+	for _, row := range rows {
+		m.Rows = append(m.Rows, *row)
+	}
+
+	sort.Slice(m.Rows, func(i, j int) bool {
+		a, b := m.Rows[i], m.Rows[j]
+		if a.Namespace != b.Namespace {
+			return a.Namespace < b.Namespace
+		}
+		if a.Resource.Group != b.Resource.Group {
+			return a.Resource.Group < b.Resource.Group
+		}
+		if a.Resource.Resource != b.Resource.Resource {
+			return a.Resource.Resource < b.Resource.Resource
+		}
+		return strings.Join(a.ResourceNames, ",") < strings.Join(b.ResourceNames, ",")
+	})
+
+	return m
+}
+
+// grantedVerbs returns the matrix verbs a rule's verbs grant.
+func grantedVerbs(ruleVerbs, verbs []string) []string {
+	for _, verb := range ruleVerbs {
+		if verb == rbacv1.VerbAll {
+			return verbs
+		}
+	}
+	return ruleVerbs
+}
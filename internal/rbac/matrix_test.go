@@ -0,0 +1,80 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rbac
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+func TestNewMatrix(t *testing.T) {
+	binding := ObjectRef{Kind: "RoleBinding", Namespace: "default", Name: "b"}
+	grants := []Grant{
+		{
+			Binding:   binding,
+			Namespace: "default",
+			Rule:      rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods", "secrets"}, Verbs: []string{"get", "list"}},
+		},
+		{
+			Binding:   binding,
+			Namespace: "default",
+			Rule:      rbacv1.PolicyRule{APIGroups: []string{""}, Resources: []string{"pods"}, Verbs: []string{"get", "escalate"}},
+		},
+		{
+			Binding: ObjectRef{Kind: "ClusterRoleBinding", Name: "c"},
+			Rule:    rbacv1.PolicyRule{APIGroups: []string{"apps"}, Resources: []string{"deployments"}, Verbs: []string{"*"}},
+		},
+	}
+
+	m := NewMatrix(grants)
+
+	assert.Equal(t, append(append([]string(nil), StandardVerbs...), "escalate"), m.Verbs)
+	require.Len(t, m.Rows, 3)
+
+	deployments := m.Rows[0]
+	assert.Equal(t, "", deployments.Namespace)
+	assert.Equal(t, "deployments.apps", deployments.Resource.String())
+	assert.Len(t, deployments.Grants, len(m.Verbs))
+
+	pods := m.Rows[1]
+	assert.Equal(t, "default", pods.Namespace)
+	assert.Equal(t, "pods", pods.Resource.String())
+	assert.Equal(t, []Grant{grants[0], grants[1]}, pods.Grants["get"])
+	assert.Equal(t, []Grant{grants[0]}, pods.Grants["list"])
+	assert.Equal(t, []Grant{grants[1]}, pods.Grants["escalate"])
+	assert.Empty(t, pods.Grants["delete"])
+
+	assert.Equal(t, "secrets", m.Rows[2].Resource.String())
+}
+
+func TestParseResource(t *testing.T) {
+	cases := []struct {
+		in       string
+		expected Resource
+		isErr    bool
+	}{
+		{in: "pods", expected: Resource{Group: "*", Resource: "pods"}},
+		{in: "deployments.apps", expected: Resource{Group: "apps", Resource: "deployments"}},
+		{in: "deployments/scale.apps", expected: Resource{Group: "apps", Resource: "deployments/scale"}},
+		{in: "certificatesigningrequests.certificates.k8s.io", expected: Resource{Group: "certificates.k8s.io", Resource: "certificatesigningrequests"}},
+		{in: " ", isErr: true},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.in, func(t *testing.T) {
+			got, err := ParseResource(tc.in)
+			if tc.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.Equal(t, tc.expected, got)
+		})
+	}
+}
@@ -0,0 +1,88 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package rbac
+
+import (
+	"fmt"
+	"strings"
+
+	rbacv1 "k8s.io/api/rbac/v1"
+)
+
+// Resource is an API resource in a group, e.g. deployments in apps. A resource
+// can be a subresource, e.g. pods/log. The group is * for a resource in any group.
+type Resource struct {
+	Group    string
+	Resource string
+}
+
+// ParseResource parses a resource in the format resource.group, e.g.
+// deployments.apps. A resource without a group, e.g. pods, is in any group.
+func ParseResource(s string) (Resource, error) {
+	s = strings.TrimSpace(s)
+	if s == "" {
+		return Resource{}, fmt.Errorf("resource is blank")
+	}
+
+	parts := strings.SplitN(s, ".", 2)
+	if len(parts) == 1 {
+		return Resource{Group: rbacv1.APIGroupAll, Resource: parts[0]}, nil
+	}
+
+	// Subresources come before the group, e.g. deployments/scale.apps.
+	return Resource{Group: parts[1], Resource: parts[0]}, nil
+}
+
+// String returns the resource in the format resource.group.
+func (r Resource) String() string {
+	if r.Group == "" {
+		return r.Resource
+	}
+	return fmt.Sprintf("%s.%s", r.Resource, r.Group)
+}
+
+// ruleAllows returns true if a rule allows a verb on a resource. It matches
+// wildcards the way the API server's authorizer does.
+func ruleAllows(rule rbacv1.PolicyRule, verb string, resource Resource) bool {
+	return verbMatches(rule, verb) &&
+		groupMatches(rule, resource.Group) &&
+		resourceMatches(rule, resource.Resource)
+}
+
+func verbMatches(rule rbacv1.PolicyRule, verb string) bool {
+	for _, v := range rule.Verbs {
+		if v == rbacv1.VerbAll || v == verb {
+			return true
+		}
+	}
+	return false
+}
+
+func groupMatches(rule rbacv1.PolicyRule, group string) bool {
+	for _, g := range rule.APIGroups {
+		if g == rbacv1.APIGroupAll || g == group || group == rbacv1.APIGroupAll {
+			return true
+		}
+	}
+	return false
+}
+
+func resourceMatches(rule rbacv1.PolicyRule, resource string) bool {
+	subresource := ""
+	if i := strings.Index(resource, "/"); i >= 0 {
+		subresource = resource[i+1:]
+	}
+
+	for _, r := range rule.Resources {
+		switch {
+		case r == rbacv1.ResourceAll, r == resource:
+			return true
+		case subresource != "" && r == "*/"+subresource:
+			return true
+		}
+	}
+	return false
+}
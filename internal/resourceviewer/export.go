@@ -0,0 +1,300 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resourceviewer
+
+import (
+	"bytes"
+	"context"
+	"fmt"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/octant/internal/config"
+	"github.com/vmware-tanzu/octant/internal/octant"
+	"github.com/vmware-tanzu/octant/internal/queryer"
+	"github.com/vmware-tanzu/octant/internal/util/json"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+// ExportRoute is the API route resource viewer graphs are exported from. The query
+// requires apiVersion, kind and name, and accepts namespace and format.
+const ExportRoute = "/resource-viewer/export"
+
+// ExportURLPath returns the path of an object's exported graph relative to the API
+// prefix. The format is added by the caller.
+func ExportURLPath(apiVersion, kind, namespace, name string) string {
+	query := url.Values{}
+	query.Set("apiVersion", apiVersion)
+	query.Set("kind", kind)
+	query.Set("namespace", namespace)
+	query.Set("name", name)
+	return ExportRoute + "?" + query.Encode()
+}
+
+// ExportFormat is a format a resource viewer graph can be exported to.
+type ExportFormat string
+
+const (
+	// ExportFormatDOT is the graphviz DOT language.
+	ExportFormatDOT ExportFormat = "dot"
+	// ExportFormatSVG is an SVG image.
+	ExportFormatSVG ExportFormat = "svg"
+	// ExportFormatJSON is the JSON form of Graph.
+	ExportFormatJSON ExportFormat = "json"
+)
+
+// ParseExportFormat parses an export format. An empty string is ExportFormatSVG.
+func ParseExportFormat(s string) (ExportFormat, error) {
+	switch format := ExportFormat(strings.ToLower(s)); format {
+	case "":
+		return ExportFormatSVG, nil
+	case ExportFormatDOT, ExportFormatSVG, ExportFormatJSON:
+		return format, nil
+	default:
+		return "", fmt.Errorf("unknown export format %q: use dot, svg or json", s)
+	}
+}
+
+// ContentType returns the content type of the format.
+func (f ExportFormat) ContentType() string {
+	switch f {
+	case ExportFormatDOT:
+		return "text/vnd.graphviz; charset=utf-8"
+	case ExportFormatSVG:
+		return "image/svg+xml"
+	default:
+		return "application/json; charset=utf-8"
+	}
+}
+
+// Graph is a resource viewer graph in a form which can be exported. Its JSON form
+// is the documented export format:
+//
+//	{
+//	  "selected": "<node id>",
+//	  "nodes": [
+//	    {
+//	      "id": "<node id>",
+//	      "name": "web",
+//	      "apiVersion": "apps/v1",
+//	      "kind": "Deployment",
+//	      "status": "ok | warning | error",
+//	      "details": ["Deployment is OK"],
+//	      "path": "/overview/namespace/default/workloads/deployments/web"
+//	    }
+//	  ],
+//	  "edges": [
+//	    {"from": "<node id>", "to": "<node id>", "type": "explicit | implicit"}
+//	  ]
+//	}
+//
+// Nodes are sorted by ID, and edges by the IDs of their nodes. Details and path
+// are omitted when empty.
+type Graph struct {
+	Selected string      `json:"selected,omitempty"`
+	Nodes    []GraphNode `json:"nodes"`
+	Edges    []GraphEdge `json:"edges"`
+}
+
+// GraphNode is an object, or a group of pods, in a Graph.
+type GraphNode struct {
+	ID         string               `json:"id"`
+	Name       string               `json:"name"`
+	APIVersion string               `json:"apiVersion"`
+	Kind       string               `json:"kind"`
+	Status     component.NodeStatus `json:"status"`
+	Details    []string             `json:"details,omitempty"`
+	Path       string               `json:"path,omitempty"`
+}
+
+// GraphEdge is a directed edge in a Graph.
+type GraphEdge struct {
+	From string             `json:"from"`
+	To   string             `json:"to"`
+	Type component.EdgeType `json:"type"`
+}
+
+// NewGraph creates a Graph from a resource viewer component.
+func NewGraph(rv *component.ResourceViewer) Graph {
+	g := Graph{
+		Selected: rv.Config.Selected,
+		Nodes:    []GraphNode{},
+		Edges:    []GraphEdge{},
+	}
+
+	for id, node := range rv.Config.Nodes {
+		gn := GraphNode{
+			ID:         id,
+			Name:       node.Name,
+			APIVersion: node.APIVersion,
+			Kind:       node.Kind,
+			Status:     node.Status,
+		}
+		for _, detail := range node.Details {
+			if detail == nil {
+				continue
+			}
+			gn.Details = append(gn.Details, detail.String())
+		}
+		if node.Path != nil {
+			gn.Path = node.Path.Ref()
+		}
+		g.Nodes = append(g.Nodes, gn)
+	}
+
+	for from, edges := range rv.Config.Edges {
+		for _, edge := range edges {
+			g.Edges = append(g.Edges, GraphEdge{From: from, To: edge.Node, Type: edge.Type})
+		}
+	}
+
+	sort.Slice(g.Nodes, func(i, j int) bool {
+		return g.Nodes[i].ID < g.Nodes[j].ID
+	})
+	sort.Slice(g.Edges, func(i, j int) bool {
+		if g.Edges[i].From != g.Edges[j].From {
+			return g.Edges[i].From < g.Edges[j].From
+		}
+		return g.Edges[i].To < g.Edges[j].To
+	})
+
+	return g
+}
+
+// Export writes a resource viewer component in a format.
+func Export(w io.Writer, rv *component.ResourceViewer, format ExportFormat) error {
+	g := NewGraph(rv)
+
+	switch format {
+	case ExportFormatDOT:
+		_, err := io.WriteString(w, g.DOT())
+		return err
+	case ExportFormatSVG:
+		_, err := io.WriteString(w, g.SVG())
+		return err
+	case ExportFormatJSON:
+		data, err := json.MarshalIndent(g, "", "  ")
+		if err != nil {
+			return fmt.Errorf("encode graph: %w", err)
+		}
+		_, err = w.Write(append(data, '\n'))
+		return err
+	default:
+		return fmt.Errorf("unknown export format %q", format)
+	}
+}
+
+// ExportObject creates the resource viewer graph of an object, with the object
+// selected, and exports it in a format.
+func ExportObject(ctx context.Context, dashConfig config.Dash, object *unstructured.Unstructured, format ExportFormat) ([]byte, error) {
+	discoveryClient, err := dashConfig.ClusterClient().DiscoveryClient()
+	if err != nil {
+		return nil, err
+	}
+
+	q := queryer.New(dashConfig.ObjectStore(), discoveryClient)
+
+	rv, err := Create(ctx, dashConfig, q, object)
+	if err != nil {
+		return nil, err
+	}
+
+	if _, ok := rv.Config.Nodes[string(object.GetUID())]; ok {
+		rv.Select(string(object.GetUID()))
+	}
+
+	var buf bytes.Buffer
+	if err := Export(&buf, rv, format); err != nil {
+		return nil, err
+	}
+
+	return buf.Bytes(), nil
+}
+
+// Exporter returns an exporter for the resource viewer export action.
+func Exporter(dashConfig config.Dash) octant.ResourceViewerExporter {
+	return func(ctx context.Context, object *unstructured.Unstructured, format string) (octant.ResourceViewerExportResult, error) {
+		exportFormat, err := ParseExportFormat(format)
+		if err != nil {
+			return octant.ResourceViewerExportResult{}, err
+		}
+
+		data, err := ExportObject(ctx, dashConfig, object, exportFormat)
+		if err != nil {
+			return octant.ResourceViewerExportResult{}, err
+		}
+
+		return octant.ResourceViewerExportResult{
+			Format:      string(exportFormat),
+			ContentType: exportFormat.ContentType(),
+			Data:        string(data),
+		}, nil
+	}
+}
+
+// statusColors are the fill colors for node statuses. They match the web UI.
+var statusColors = map[component.NodeStatus]string{
+	component.NodeStatusOK:      "#60b515",
+	component.NodeStatusWarning: "#f57600",
+	component.NodeStatusError:   "#e12200",
+}
+
+// edgeColor is the color of edges. It matches the web UI.
+const edgeColor = "#003d79"
+
+func statusColor(status component.NodeStatus) string {
+	if color, ok := statusColors[status]; ok {
+		return color
+	}
+	return statusColors[component.NodeStatusError]
+}
+
+// DOT returns the graph in the graphviz DOT language.
+func (g Graph) DOT() string {
+	var sb strings.Builder
+
+	sb.WriteString("digraph {\n")
+	sb.WriteString("  node [shape=box, style=filled, fontcolor=white];\n")
+
+	for _, node := range g.Nodes {
+		label := fmt.Sprintf("%s\n%s %s", node.Name, node.APIVersion, node.Kind)
+		attrs := []string{
+			fmt.Sprintf("label=%s", dotQuote(label)),
+			fmt.Sprintf("fillcolor=%s", dotQuote(statusColor(node.Status))),
+		}
+		if len(node.Details) > 0 {
+			attrs = append(attrs, fmt.Sprintf("tooltip=%s", dotQuote(strings.Join(node.Details, "\n"))))
+		}
+		if node.ID == g.Selected {
+			attrs = append(attrs, "penwidth=2")
+		}
+		fmt.Fprintf(&sb, "  %s [%s];\n", dotQuote(node.ID), strings.Join(attrs, ", "))
+	}
+
+	for _, edge := range g.Edges {
+		attrs := []string{fmt.Sprintf("color=%s", dotQuote(edgeColor))}
+		if edge.Type == component.EdgeTypeImplicit {
+			attrs = append(attrs, "style=dashed")
+		}
+		fmt.Fprintf(&sb, "  %s -> %s [%s];\n", dotQuote(edge.From), dotQuote(edge.To), strings.Join(attrs, ", "))
+	}
+
+	sb.WriteString("}\n")
+
+	return sb.String()
+}
+
+// dotQuote quotes a DOT identifier. New lines become line breaks in labels.
+func dotQuote(s string) string {
+	s = strings.ReplaceAll(s, `\`, `\\`)
+	s = strings.ReplaceAll(s, `"`, `\"`)
+	s = strings.ReplaceAll(s, "\n", `\n`)
+	return `"` + s + `"`
+}
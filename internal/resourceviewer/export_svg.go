@@ -0,0 +1,197 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resourceviewer
+
+import (
+	"fmt"
+	"html"
+	"strings"
+
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+// SVG layout sizes in pixels. Text width is estimated from the number of
+// characters, so the image doesn't depend on fonts installed on the server.
+const (
+	svgMargin      = 20
+	svgNodeHeight  = 44
+	svgNodePadding = 10
+	svgCharWidth   = 7
+	svgLineHeight  = 16
+	svgColumnGap   = 30
+	svgRowGap      = 60
+)
+
+// svgBox is the position of a node in the SVG image.
+type svgBox struct {
+	x, y, width int
+}
+
+func (b svgBox) centerX() int {
+	return b.x + b.width/2
+}
+
+// SVG returns the graph as an SVG image. Nodes are placed in rows, with each node
+// below the nodes with edges to it, like the web UI does.
+func (g Graph) SVG() string {
+	rows := g.layers()
+
+	boxes := map[string]svgBox{}
+	rowWidths := make([]int, len(rows))
+	imageWidth := 0
+	for i, row := range rows {
+		for j, node := range row {
+			if j > 0 {
+				rowWidths[i] += svgColumnGap
+			}
+			rowWidths[i] += svgNodeWidth(node)
+		}
+		if rowWidths[i] > imageWidth {
+			imageWidth = rowWidths[i]
+		}
+	}
+
+	for i, row := range rows {
+		// Rows are centered.
+		x := svgMargin + (imageWidth-rowWidths[i])/2
+		y := svgMargin + i*(svgNodeHeight+svgRowGap)
+		for _, node := range row {
+			width := svgNodeWidth(node)
+			boxes[node.ID] = svgBox{x: x, y: y, width: width}
+			x += width + svgColumnGap
+		}
+	}
+
+	imageWidth += 2 * svgMargin
+	imageHeight := 2*svgMargin + len(rows)*svgNodeHeight
+	if len(rows) > 1 {
+		imageHeight += (len(rows) - 1) * svgRowGap
+	}
+
+	var sb strings.Builder
+
+	fmt.Fprintf(&sb, `<svg xmlns="http://www.w3.org/2000/svg" width="%d" height="%d" viewBox="0 0 %d %d" font-family="sans-serif" font-size="12">`+"\n",
+		imageWidth, imageHeight, imageWidth, imageHeight)
+	fmt.Fprintf(&sb, `  <defs><marker id="arrow" viewBox="0 0 10 10" refX="10" refY="5" markerWidth="6" markerHeight="6" orient="auto"><path d="M 0 0 L 10 5 L 0 10 z" fill="%s"/></marker></defs>`+"\n",
+		edgeColor)
+
+	for _, edge := range g.Edges {
+		from, ok := boxes[edge.From]
+		if !ok {
+			continue
+		}
+		to, ok := boxes[edge.To]
+		if !ok {
+			continue
+		}
+
+		x1, y1, x2, y2 := from.centerX(), from.y+svgNodeHeight, to.centerX(), to.y
+		if to.y <= from.y {
+			// Edges to nodes in the same or an earlier row go from the top of the node.
+			y1, y2 = from.y, to.y+svgNodeHeight
+		}
+
+		dash := ""
+		if edge.Type == component.EdgeTypeImplicit {
+			dash = ` stroke-dasharray="4 4"`
+		}
+		fmt.Fprintf(&sb, `  <line x1="%d" y1="%d" x2="%d" y2="%d" stroke="%s" stroke-width="1.5"%s marker-end="url(#arrow)"/>`+"\n",
+			x1, y1, x2, y2, edgeColor, dash)
+	}
+
+	for _, row := range rows {
+		for _, node := range row {
+			box := boxes[node.ID]
+
+			stroke := ""
+			if node.ID == g.Selected {
+				stroke = ` stroke="#313131" stroke-width="2"`
+			}
+
+			sb.WriteString("  <g>\n")
+			if len(node.Details) > 0 {
+				fmt.Fprintf(&sb, "    <title>%s</title>\n", html.EscapeString(strings.Join(node.Details, "\n")))
+			}
+			fmt.Fprintf(&sb, `    <rect x="%d" y="%d" width="%d" height="%d" fill="%s"%s/>`+"\n",
+				box.x, box.y, box.width, svgNodeHeight, statusColor(node.Status), stroke)
+			fmt.Fprintf(&sb, `    <text x="%d" y="%d" fill="#fff" text-anchor="middle">`+"\n", box.centerX(), box.y)
+			fmt.Fprintf(&sb, `      <tspan x="%d" dy="%d" font-weight="bold">%s</tspan>`+"\n",
+				box.centerX(), svgNodePadding+svgLineHeight/2+2, html.EscapeString(node.Name))
+			fmt.Fprintf(&sb, `      <tspan x="%d" dy="%d">%s</tspan>`+"\n",
+				box.centerX(), svgLineHeight, html.EscapeString(node.APIVersion+" "+node.Kind))
+			sb.WriteString("    </text>\n")
+			sb.WriteString("  </g>\n")
+		}
+	}
+
+	sb.WriteString("</svg>\n")
+
+	return sb.String()
+}
+
+func svgNodeWidth(node GraphNode) int {
+	chars := len(node.Name)
+	if n := len(node.APIVersion) + 1 + len(node.Kind); n > chars {
+		chars = n
+	}
+	return chars*svgCharWidth + 2*svgNodePadding
+}
+
+// layers places the nodes in rows. Nodes without edges to them are in the first
+// row, and every other node is one row below the first node found with an edge
+// to it. Graphs with cycles start from the first node in a cycle.
+func (g Graph) layers() [][]GraphNode {
+	children := map[string][]string{}
+	hasParent := map[string]bool{}
+	for _, edge := range g.Edges {
+		children[edge.From] = append(children[edge.From], edge.To)
+		hasParent[edge.To] = true
+	}
+
+	layer := map[string]int{}
+	visit := func(roots []string) {
+		queue := roots
+		for _, id := range roots {
+			layer[id] = 0
+		}
+		for len(queue) > 0 {
+			id := queue[0]
+			queue = queue[1:]
+			for _, child := range children[id] {
+				if _, ok := layer[child]; ok {
+					continue
+				}
+				layer[child] = layer[id] + 1
+				queue = append(queue, child)
+			}
+		}
+	}
+
+	var roots []string
+	for _, node := range g.Nodes {
+		if !hasParent[node.ID] {
+			roots = append(roots, node.ID)
+		}
+	}
+	visit(roots)
+
+	for _, node := range g.Nodes {
+		if _, ok := layer[node.ID]; !ok {
+			visit([]string{node.ID})
+		}
+	}
+
+	var rows [][]GraphNode
+	for _, node := range g.Nodes {
+		i := layer[node.ID]
+		for len(rows) <= i {
+			rows = append(rows, nil)
+		}
+		rows[i] = append(rows[i], node)
+	}
+
+	return rows
+}
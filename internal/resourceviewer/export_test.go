@@ -0,0 +1,190 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package resourceviewer
+
+import (
+	"bytes"
+	"context"
+	"encoding/xml"
+	"io"
+	"strings"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/octant/internal/testutil"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+func createExportResourceViewer(t *testing.T) *component.ResourceViewer {
+	rv := component.NewResourceViewer("Resource Viewer")
+	rv.AddNode("deployment", component.Node{
+		Name:       "web",
+		APIVersion: "apps/v1",
+		Kind:       "Deployment",
+		Status:     component.NodeStatusOK,
+		Details:    []component.Component{component.NewText("Deployment is OK")},
+		Path:       component.NewLink("", "web", "/overview/namespace/default/workloads/deployments/web"),
+	})
+	rv.AddNode("replicaSet", component.Node{
+		Name:       "web-7d4b9c",
+		APIVersion: "apps/v1",
+		Kind:       "ReplicaSet",
+		Status:     component.NodeStatusWarning,
+		Details:    []component.Component{component.NewText(`Replica Set "web-7d4b9c" <2 of 3 ready>`)},
+	})
+	rv.AddNode("pods", component.Node{
+		Name:       "web-7d4b9c pods",
+		APIVersion: "v1",
+		Kind:       "Pod",
+		Status:     component.NodeStatusError,
+	})
+	rv.AddNode("service", component.Node{
+		Name:       "web",
+		APIVersion: "v1",
+		Kind:       "Service",
+		Status:     component.NodeStatusOK,
+	})
+	require.NoError(t, rv.AddEdge("deployment", "replicaSet", component.EdgeTypeExplicit))
+	require.NoError(t, rv.AddEdge("replicaSet", "pods", component.EdgeTypeExplicit))
+	require.NoError(t, rv.AddEdge("service", "pods", component.EdgeTypeImplicit))
+	rv.Select("deployment")
+
+	return rv
+}
+
+func TestNewGraph(t *testing.T) {
+	got := NewGraph(createExportResourceViewer(t))
+
+	expected := Graph{
+		Selected: "deployment",
+		Nodes: []GraphNode{
+			{
+				ID: "deployment", Name: "web", APIVersion: "apps/v1", Kind: "Deployment", Status: component.NodeStatusOK,
+				Details: []string{"Deployment is OK"}, Path: "/overview/namespace/default/workloads/deployments/web",
+			},
+			{ID: "pods", Name: "web-7d4b9c pods", APIVersion: "v1", Kind: "Pod", Status: component.NodeStatusError},
+			{
+				ID: "replicaSet", Name: "web-7d4b9c", APIVersion: "apps/v1", Kind: "ReplicaSet", Status: component.NodeStatusWarning,
+				Details: []string{`Replica Set "web-7d4b9c" <2 of 3 ready>`},
+			},
+			{ID: "service", Name: "web", APIVersion: "v1", Kind: "Service", Status: component.NodeStatusOK},
+		},
+		Edges: []GraphEdge{
+			{From: "deployment", To: "replicaSet", Type: component.EdgeTypeExplicit},
+			{From: "replicaSet", To: "pods", Type: component.EdgeTypeExplicit},
+			{From: "service", To: "pods", Type: component.EdgeTypeImplicit},
+		},
+	}
+
+	assert.Equal(t, expected, got)
+}
+
+func TestGraph_layers(t *testing.T) {
+	g := NewGraph(createExportResourceViewer(t))
+
+	rowIDs := func(rows [][]GraphNode) [][]string {
+		var ids [][]string
+		for _, row := range rows {
+			var rowIDs []string
+			for _, node := range row {
+				rowIDs = append(rowIDs, node.ID)
+			}
+			ids = append(ids, rowIDs)
+		}
+		return ids
+	}
+
+	expected := [][]string{{"deployment", "service"}, {"pods", "replicaSet"}}
+	assert.Equal(t, expected, rowIDs(g.layers()))
+
+	g.Edges = append(g.Edges, GraphEdge{From: "pods", To: "deployment"})
+	expected = [][]string{{"service"}, {"pods"}, {"deployment"}, {"replicaSet"}}
+	assert.Equal(t, expected, rowIDs(g.layers()))
+
+	g.Edges = append(g.Edges, GraphEdge{From: "pods", To: "service"})
+	expected = [][]string{{"deployment"}, {"replicaSet"}, {"pods"}, {"service"}}
+	assert.Equal(t, expected, rowIDs(g.layers()), "a cycle starts from its first node")
+}
+
+func TestExport(t *testing.T) {
+	rv := createExportResourceViewer(t)
+
+	t.Run("dot", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Export(&buf, rv, ExportFormatDOT))
+
+		got := buf.String()
+		assert.True(t, strings.HasPrefix(got, "digraph {\n"))
+		assert.Contains(t, got, `"deployment" [label="web\napps/v1 Deployment", fillcolor="#60b515", tooltip="Deployment is OK", penwidth=2];`)
+		assert.Contains(t, got, `"replicaSet" [label="web-7d4b9c\napps/v1 ReplicaSet", fillcolor="#f57600", tooltip="Replica Set \"web-7d4b9c\" <2 of 3 ready>"];`)
+		assert.Contains(t, got, `"service" -> "pods" [color="#003d79", style=dashed];`)
+	})
+
+	t.Run("svg", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Export(&buf, rv, ExportFormatSVG))
+
+		// The image is well formed XML.
+		decoder := xml.NewDecoder(bytes.NewReader(buf.Bytes()))
+		rects, lines := 0, 0
+		for {
+			token, err := decoder.Token()
+			if err == io.EOF {
+				break
+			}
+			require.NoError(t, err)
+			if start, ok := token.(xml.StartElement); ok {
+				switch start.Name.Local {
+				case "rect":
+					rects++
+				case "line":
+					lines++
+				}
+			}
+		}
+		assert.Equal(t, 4, rects)
+		assert.Equal(t, 3, lines)
+		assert.Contains(t, buf.String(), "Replica Set &#34;web-7d4b9c&#34; &lt;2 of 3 ready&gt;")
+	})
+
+	t.Run("json", func(t *testing.T) {
+		var buf bytes.Buffer
+		require.NoError(t, Export(&buf, rv, ExportFormatJSON))
+
+		got := buf.String()
+		assert.Contains(t, got, `"selected": "deployment"`)
+		assert.Contains(t, got, `"path": "/overview/namespace/default/workloads/deployments/web"`)
+		assert.Contains(t, got, `"type": "implicit"`)
+	})
+}
+
+func TestParseExportFormat(t *testing.T) {
+	got, err := ParseExportFormat("")
+	require.NoError(t, err)
+	assert.Equal(t, ExportFormatSVG, got)
+
+	got, err = ParseExportFormat("DOT")
+	require.NoError(t, err)
+	assert.Equal(t, ExportFormatDOT, got)
+
+	_, err = ParseExportFormat("png")
+	require.Error(t, err)
+}
+
+func TestExportURLPath(t *testing.T) {
+	got := ExportURLPath("apps/v1", "Deployment", "default", "web")
+	assert.Equal(t, "/resource-viewer/export?apiVersion=apps%2Fv1&kind=Deployment&name=web&namespace=default", got)
+}
+
+func TestExporter_unknownFormat(t *testing.T) {
+	object := testutil.ToUnstructured(t, testutil.CreateDeployment("web"))
+
+	// The format is checked before the graph is created, so no config is needed.
+	_, err := Exporter(nil)(context.Background(), object, "png")
+	assert.EqualError(t, err, `unknown export format "png": use dot, svg or json`)
+}
@@ -19,6 +19,7 @@ import (
 	"github.com/vmware-tanzu/octant/internal/log"
 	"github.com/vmware-tanzu/octant/internal/objectvisitor"
 	"github.com/vmware-tanzu/octant/internal/queryer"
+	"github.com/vmware-tanzu/octant/internal/scheduling"
 	"github.com/vmware-tanzu/octant/internal/util/kubernetes"
 	"github.com/vmware-tanzu/octant/pkg/view/component"
 )
@@ -51,6 +52,10 @@ type ResourceViewer struct {
 
 // Create creates a resource viewer given a list objects.
 func Create(ctx context.Context, dashConfig config.Dash, q queryer.Queryer, objects ...*unstructured.Unstructured) (*component.ResourceViewer, error) {
+	// The statuses of pending pods share the cluster state their scheduling is
+	// diagnosed with.
+	ctx = scheduling.WithStateCache(ctx)
+
 	rv, err := New(dashConfig, WithDefaultQueryer(dashConfig, q))
 	if err != nil {
 		return nil, fmt.Errorf("create resource viewer: %w", err)
@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package scheduling
+
+import (
+	"context"
+	"fmt"
+	"sync"
+
+	corev1 "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/octant/internal/util/kubernetes"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+type stateCacheKey struct{}
+
+// stateCache holds the nodes and pods listed for the first diagnosis in a context,
+// so diagnosing every pending pod in a list only lists them once.
+type stateCache struct {
+	once  sync.Once
+	nodes []corev1.Node
+	pods  []corev1.Pod
+	err   error
+}
+
+// WithStateCache returns a copy of ctx in which diagnoses share the nodes and pods
+// they list. Use it for work which diagnoses several pods using the same store,
+// such as rendering content.
+func WithStateCache(ctx context.Context) context.Context {
+	if _, ok := ctx.Value(stateCacheKey{}).(*stateCache); ok {
+		return ctx
+	}
+	return context.WithValue(ctx, stateCacheKey{}, &stateCache{})
+}
+
+// nodesAndPods lists the nodes and pods in the store, or returns the ones in the
+// context's cache.
+func nodesAndPods(ctx context.Context, o store.Store) ([]corev1.Node, []corev1.Pod, error) {
+	cache, ok := ctx.Value(stateCacheKey{}).(*stateCache)
+	if !ok {
+		return listNodesAndPods(ctx, o)
+	}
+
+	cache.once.Do(func() {
+		cache.nodes, cache.pods, cache.err = listNodesAndPods(ctx, o)
+	})
+
+	return cache.nodes, cache.pods, cache.err
+}
+
+func listNodesAndPods(ctx context.Context, o store.Store) ([]corev1.Node, []corev1.Pod, error) {
+	var nodes []corev1.Node
+	if err := list(ctx, o, store.Key{APIVersion: "v1", Kind: "Node"}, func(u *unstructured.Unstructured) error {
+		node := corev1.Node{}
+		if err := kubernetes.FromUnstructured(u, &node); err != nil {
+			return err
+		}
+		nodes = append(nodes, node)
+		return nil
+	}); err != nil {
+		return nil, nil, fmt.Errorf("list nodes: %w", err)
+	}
+
+	var pods []corev1.Pod
+	if err := list(ctx, o, store.Key{APIVersion: "v1", Kind: "Pod"}, func(u *unstructured.Unstructured) error {
+		p := corev1.Pod{}
+		if err := kubernetes.FromUnstructured(u, &p); err != nil {
+			return err
+		}
+		pods = append(pods, p)
+		return nil
+	}); err != nil {
+		return nil, nil, fmt.Errorf("list pods: %w", err)
+	}
+
+	return nodes, pods, nil
+}
@@ -0,0 +1,270 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package scheduling explains why a pending pod hasn't been scheduled. It checks
+// each node against the scheduler's main predicates: taints and tolerations, node
+// selector and affinity, allocatable resources, volume binding and topology spread.
+package scheduling
+
+import (
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/octant/internal/util/kubernetes"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+// Predicates checked for each node. They are named after the scheduler plugins
+// which check them.
+const (
+	PredicateNodeUnschedulable = "NodeUnschedulable"
+	PredicateTaintToleration   = "TaintToleration"
+	PredicateNodeAffinity      = "NodeAffinity"
+	PredicateNodeResources     = "NodeResourcesFit"
+	PredicateVolumeBinding     = "VolumeBinding"
+	PredicateTopologySpread    = "PodTopologySpread"
+)
+
+// Failure is a predicate a node fails.
+type Failure struct {
+	Predicate string
+	// Reason is shared by nodes failing the predicate for the same cause.
+	Reason string
+	// Message describes the failure for the node.
+	Message string
+}
+
+// NodeResult is the result of checking a node.
+type NodeResult struct {
+	Node     string
+	Failures []Failure
+}
+
+// Fits returns true if the pod can be scheduled on the node.
+func (r NodeResult) Fits() bool {
+	return len(r.Failures) == 0
+}
+
+// Diagnosis explains why a pod hasn't been scheduled.
+type Diagnosis struct {
+	// Issues are problems with the pod which prevent it from being scheduled on any node.
+	Issues []string
+	// Nodes are the results for each node, sorted by name.
+	Nodes []NodeResult
+}
+
+// FittingNodes returns the names of the nodes the pod can be scheduled on.
+func (d *Diagnosis) FittingNodes() []string {
+	var names []string
+	for _, result := range d.Nodes {
+		if result.Fits() {
+			names = append(names, result.Node)
+		}
+	}
+	return names
+}
+
+// Summary summarizes the diagnosis like the scheduler's FailedScheduling event, e.g.
+// "0/3 nodes are available: 1 Insufficient cpu, 2 node(s) didn't match Pod's node affinity/selector."
+func (d *Diagnosis) Summary() string {
+	counts := make(map[string]int)
+	for _, result := range d.Nodes {
+		seen := make(map[string]bool)
+		for _, failure := range result.Failures {
+			if !seen[failure.Reason] {
+				seen[failure.Reason] = true
+				counts[failure.Reason]++
+			}
+		}
+	}
+
+	var reasons []string
+	for reason := range counts {
+		reasons = append(reasons, reason)
+	}
+	sort.Strings(reasons)
+
+	var parts []string
+	for _, reason := range reasons {
+		parts = append(parts, fmt.Sprintf("%d %s", counts[reason], reason))
+	}
+
+	summary := fmt.Sprintf("%d/%d nodes are available", len(d.FittingNodes()), len(d.Nodes))
+	if len(parts) > 0 {
+		summary += ": " + strings.Join(parts, ", ")
+	}
+	summary += "."
+
+	for _, issue := range d.Issues {
+		summary += " " + issue + "."
+	}
+
+	return summary
+}
+
+// NeedsDiagnosis returns true if a pod is pending and hasn't been scheduled.
+func NeedsDiagnosis(pod *corev1.Pod) bool {
+	return pod != nil &&
+		pod.Status.Phase == corev1.PodPending &&
+		pod.Spec.NodeName == "" &&
+		pod.DeletionTimestamp == nil
+}
+
+// Diagnose checks a pending pod against each node using objects from the store. If
+// ctx has a state cache, the nodes and pods are only listed once for it.
+func Diagnose(ctx context.Context, o store.Store, pod *corev1.Pod) (*Diagnosis, error) {
+	if pod == nil {
+		return nil, fmt.Errorf("pod is nil")
+	}
+
+	state := &clusterState{
+		claims:         make(map[string]*corev1.PersistentVolumeClaim),
+		volumes:        make(map[string]*corev1.PersistentVolume),
+		storageClasses: make(map[string]*storagev1.StorageClass),
+	}
+
+	var err error
+	if state.nodes, state.pods, err = nodesAndPods(ctx, o); err != nil {
+		return nil, err
+	}
+
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+
+		if err := state.loadClaim(ctx, o, pod.Namespace, volume.PersistentVolumeClaim.ClaimName); err != nil {
+			return nil, err
+		}
+	}
+
+	return diagnose(pod, state), nil
+}
+
+// clusterState is the state the scheduler uses to place a pod.
+type clusterState struct {
+	nodes []corev1.Node
+	pods  []corev1.Pod
+	// claims, volumes and storage classes are keyed by name. Claims are in the pod's namespace.
+	claims         map[string]*corev1.PersistentVolumeClaim
+	volumes        map[string]*corev1.PersistentVolume
+	storageClasses map[string]*storagev1.StorageClass
+}
+
+func (s *clusterState) loadClaim(ctx context.Context, o store.Store, namespace, name string) error {
+	claim := &corev1.PersistentVolumeClaim{}
+	found, err := get(ctx, o, store.Key{Namespace: namespace, APIVersion: "v1", Kind: "PersistentVolumeClaim", Name: name}, claim)
+	if err != nil {
+		return fmt.Errorf("get persistent volume claim %s: %w", name, err)
+	}
+	if !found {
+		return nil
+	}
+	s.claims[name] = claim
+
+	if volumeName := claim.Spec.VolumeName; volumeName != "" {
+		volume := &corev1.PersistentVolume{}
+		found, err := get(ctx, o, store.Key{APIVersion: "v1", Kind: "PersistentVolume", Name: volumeName}, volume)
+		if err != nil {
+			return fmt.Errorf("get persistent volume %s: %w", volumeName, err)
+		}
+		if found {
+			s.volumes[volumeName] = volume
+		}
+	}
+
+	if className := claimStorageClass(claim); className != "" {
+		storageClass := &storagev1.StorageClass{}
+		found, err := get(ctx, o, store.Key{APIVersion: "storage.k8s.io/v1", Kind: "StorageClass", Name: className}, storageClass)
+		if err != nil {
+			return fmt.Errorf("get storage class %s: %w", className, err)
+		}
+		if found {
+			s.storageClasses[className] = storageClass
+		}
+	}
+
+	return nil
+}
+
+func list(ctx context.Context, o store.Store, key store.Key, fn func(*unstructured.Unstructured) error) error {
+	ul, _, err := o.List(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	for i := range ul.Items {
+		if err := fn(&ul.Items[i]); err != nil {
+			return err
+		}
+	}
+
+	return nil
+}
+
+func get(ctx context.Context, o store.Store, key store.Key, into interface{}) (bool, error) {
+	u, err := o.Get(ctx, key)
+	if err != nil {
+		if kerrors.IsNotFound(err) {
+			return false, nil
+		}
+		return false, err
+	}
+	if u == nil {
+		return false, nil
+	}
+
+	if err := kubernetes.FromUnstructured(u, into); err != nil {
+		return false, err
+	}
+
+	return true, nil
+}
+
+func claimStorageClass(claim *corev1.PersistentVolumeClaim) string {
+	if claim.Spec.StorageClassName != nil {
+		return *claim.Spec.StorageClassName
+	}
+	return claim.Annotations[corev1.BetaStorageClassAnnotation]
+}
+
+// diagnose checks a pod against each node.
+func diagnose(pod *corev1.Pod, state *clusterState) *Diagnosis {
+	d := &Diagnosis{
+		Issues: volumeIssues(pod, state),
+	}
+
+	spread := newTopologySpread(pod, state)
+
+	for i := range state.nodes {
+		node := &state.nodes[i]
+
+		var failures []Failure
+		failures = append(failures, checkUnschedulable(pod, node)...)
+		failures = append(failures, checkTaints(pod, node)...)
+		failures = append(failures, checkNodeAffinity(pod, node)...)
+		failures = append(failures, checkResources(pod, node, state.pods)...)
+		failures = append(failures, checkVolumeAffinity(pod, node, state)...)
+		failures = append(failures, spread.check(node)...)
+
+		d.Nodes = append(d.Nodes, NodeResult{
+			Node:     node.Name,
+			Failures: failures,
+		})
+	}
+
+	sort.Slice(d.Nodes, func(i, j int) bool {
+		return d.Nodes[i].Node < d.Nodes[j].Node
+	})
+
+	return d
+}
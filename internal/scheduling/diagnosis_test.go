@@ -0,0 +1,431 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package scheduling
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+
+	"github.com/vmware-tanzu/octant/pkg/store"
+	storeFake "github.com/vmware-tanzu/octant/pkg/store/fake"
+)
+
+func newNode(name string, labels map[string]string, cpu, memory string) corev1.Node {
+	return corev1.Node{
+		TypeMeta:   metav1.TypeMeta{APIVersion: "v1", Kind: "Node"},
+		ObjectMeta: metav1.ObjectMeta{Name: name, Labels: labels},
+		Status: corev1.NodeStatus{
+			Allocatable: corev1.ResourceList{
+				corev1.ResourceCPU:    resource.MustParse(cpu),
+				corev1.ResourceMemory: resource.MustParse(memory),
+				corev1.ResourcePods:   resource.MustParse("110"),
+			},
+		},
+	}
+}
+
+func newPod(name, nodeName string, labels map[string]string, cpu, memory string) corev1.Pod {
+	pod := corev1.Pod{
+		TypeMeta: metav1.TypeMeta{APIVersion: "v1", Kind: "Pod"},
+		ObjectMeta: metav1.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			UID:       types.UID(name),
+			Labels:    labels,
+		},
+		Spec: corev1.PodSpec{
+			NodeName: nodeName,
+			Containers: []corev1.Container{
+				{
+					Name: "app",
+					Resources: corev1.ResourceRequirements{
+						Requests: corev1.ResourceList{
+							corev1.ResourceCPU:    resource.MustParse(cpu),
+							corev1.ResourceMemory: resource.MustParse(memory),
+						},
+					},
+				},
+			},
+		},
+		Status: corev1.PodStatus{Phase: corev1.PodRunning},
+	}
+	if nodeName == "" {
+		pod.Status.Phase = corev1.PodPending
+	}
+	return pod
+}
+
+func newState(nodes []corev1.Node, pods ...corev1.Pod) *clusterState {
+	return &clusterState{
+		nodes:          nodes,
+		pods:           pods,
+		claims:         make(map[string]*corev1.PersistentVolumeClaim),
+		volumes:        make(map[string]*corev1.PersistentVolume),
+		storageClasses: make(map[string]*storagev1.StorageClass),
+	}
+}
+
+func reasons(result NodeResult) []string {
+	var got []string
+	for _, failure := range result.Failures {
+		got = append(got, failure.Reason)
+	}
+	return got
+}
+
+func Test_diagnose(t *testing.T) {
+	zone := func(z string) map[string]string {
+		return map[string]string{"topology.kubernetes.io/zone": z}
+	}
+
+	cases := []struct {
+		name     string
+		pod      func() corev1.Pod
+		state    func() *clusterState
+		expected map[string][]string
+		issues   []string
+	}{
+		{
+			name: "fits",
+			pod: func() corev1.Pod {
+				return newPod("web", "", nil, "100m", "64Mi")
+			},
+			state: func() *clusterState {
+				return newState([]corev1.Node{newNode("node-1", nil, "1", "1Gi")})
+			},
+			expected: map[string][]string{"node-1": nil},
+		},
+		{
+			name: "unschedulable and tainted",
+			pod: func() corev1.Pod {
+				pod := newPod("web", "", nil, "100m", "64Mi")
+				pod.Spec.Tolerations = []corev1.Toleration{
+					{Key: "dedicated", Operator: corev1.TolerationOpEqual, Value: "web", Effect: corev1.TaintEffectNoSchedule},
+				}
+				return pod
+			},
+			state: func() *clusterState {
+				cordoned := newNode("node-1", nil, "1", "1Gi")
+				cordoned.Spec.Unschedulable = true
+
+				tainted := newNode("node-2", nil, "1", "1Gi")
+				tainted.Spec.Taints = []corev1.Taint{
+					{Key: "dedicated", Value: "db", Effect: corev1.TaintEffectNoSchedule},
+				}
+
+				tolerated := newNode("node-3", nil, "1", "1Gi")
+				tolerated.Spec.Taints = []corev1.Taint{
+					{Key: "dedicated", Value: "web", Effect: corev1.TaintEffectNoSchedule},
+					{Key: "spot", Effect: corev1.TaintEffectPreferNoSchedule},
+				}
+
+				return newState([]corev1.Node{cordoned, tainted, tolerated})
+			},
+			expected: map[string][]string{
+				"node-1": {"node(s) were unschedulable"},
+				"node-2": {"node(s) had untolerated taint {dedicated: db}"},
+				"node-3": nil,
+			},
+		},
+		{
+			name: "node selector and affinity",
+			pod: func() corev1.Pod {
+				pod := newPod("web", "", nil, "100m", "64Mi")
+				pod.Spec.NodeSelector = map[string]string{"disk": "ssd"}
+				pod.Spec.Affinity = &corev1.Affinity{
+					NodeAffinity: &corev1.NodeAffinity{
+						RequiredDuringSchedulingIgnoredDuringExecution: &corev1.NodeSelector{
+							NodeSelectorTerms: []corev1.NodeSelectorTerm{
+								{
+									MatchExpressions: []corev1.NodeSelectorRequirement{
+										{Key: "cores", Operator: corev1.NodeSelectorOpGt, Values: []string{"4"}},
+									},
+								},
+								{
+									MatchFields: []corev1.NodeSelectorRequirement{
+										{Key: "metadata.name", Operator: corev1.NodeSelectorOpIn, Values: []string{"node-3"}},
+									},
+								},
+							},
+						},
+					},
+				}
+				return pod
+			},
+			state: func() *clusterState {
+				return newState([]corev1.Node{
+					newNode("node-1", map[string]string{"disk": "hdd", "cores": "8"}, "1", "1Gi"),
+					newNode("node-2", map[string]string{"disk": "ssd", "cores": "2"}, "1", "1Gi"),
+					newNode("node-3", map[string]string{"disk": "ssd", "cores": "2"}, "1", "1Gi"),
+				})
+			},
+			expected: map[string][]string{
+				"node-1": {nodeAffinityReason},
+				"node-2": {nodeAffinityReason},
+				"node-3": nil,
+			},
+		},
+		{
+			name: "insufficient resources",
+			pod: func() corev1.Pod {
+				pod := newPod("web", "", nil, "500m", "256Mi")
+				pod.Spec.InitContainers = []corev1.Container{
+					{
+						Name: "init",
+						Resources: corev1.ResourceRequirements{
+							Requests: corev1.ResourceList{corev1.ResourceMemory: resource.MustParse("768Mi")},
+						},
+					},
+				}
+				return pod
+			},
+			state: func() *clusterState {
+				completed := newPod("job", "node-2", nil, "1", "1Gi")
+				completed.Status.Phase = corev1.PodSucceeded
+
+				return newState(
+					[]corev1.Node{
+						newNode("node-1", nil, "1", "1Gi"),
+						newNode("node-2", nil, "1", "1Gi"),
+					},
+					newPod("db", "node-1", nil, "600m", "512Mi"),
+					completed,
+				)
+			},
+			expected: map[string][]string{
+				"node-1": {"Insufficient cpu", "Insufficient memory"},
+				"node-2": nil,
+			},
+		},
+		{
+			name: "volumes",
+			pod: func() corev1.Pod {
+				pod := newPod("web", "", nil, "100m", "64Mi")
+				for _, name := range []string{"data", "logs", "missing"} {
+					pod.Spec.Volumes = append(pod.Spec.Volumes, corev1.Volume{
+						Name: name,
+						VolumeSource: corev1.VolumeSource{
+							PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: name},
+						},
+					})
+				}
+				return pod
+			},
+			state: func() *clusterState {
+				state := newState([]corev1.Node{
+					newNode("node-1", zone("a"), "1", "1Gi"),
+					newNode("node-2", zone("b"), "1", "1Gi"),
+				})
+
+				state.claims["data"] = &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: "data"},
+					Spec:       corev1.PersistentVolumeClaimSpec{VolumeName: "pv-data"},
+					Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimBound},
+				}
+				state.volumes["pv-data"] = &corev1.PersistentVolume{
+					ObjectMeta: metav1.ObjectMeta{Name: "pv-data"},
+					Spec: corev1.PersistentVolumeSpec{
+						NodeAffinity: &corev1.VolumeNodeAffinity{
+							Required: &corev1.NodeSelector{
+								NodeSelectorTerms: []corev1.NodeSelectorTerm{
+									{
+										MatchExpressions: []corev1.NodeSelectorRequirement{
+											{Key: "topology.kubernetes.io/zone", Operator: corev1.NodeSelectorOpIn, Values: []string{"a"}},
+										},
+									},
+								},
+							},
+						},
+					},
+				}
+
+				className := "standard"
+				state.claims["logs"] = &corev1.PersistentVolumeClaim{
+					ObjectMeta: metav1.ObjectMeta{Name: "logs"},
+					Spec:       corev1.PersistentVolumeClaimSpec{StorageClassName: &className},
+					Status:     corev1.PersistentVolumeClaimStatus{Phase: corev1.ClaimPending},
+				}
+
+				return state
+			},
+			expected: map[string][]string{
+				"node-1": nil,
+				"node-2": {"node(s) had volume node affinity conflict"},
+			},
+			issues: []string{
+				`pod has unbound immediate PersistentVolumeClaim "logs"`,
+				`persistentvolumeclaim "missing" not found`,
+			},
+		},
+		{
+			name: "topology spread",
+			pod: func() corev1.Pod {
+				pod := newPod("web-3", "", map[string]string{"app": "web"}, "100m", "64Mi")
+				pod.Spec.TopologySpreadConstraints = []corev1.TopologySpreadConstraint{
+					{
+						MaxSkew:           1,
+						TopologyKey:       "topology.kubernetes.io/zone",
+						WhenUnsatisfiable: corev1.DoNotSchedule,
+						LabelSelector:     &metav1.LabelSelector{MatchLabels: map[string]string{"app": "web"}},
+					},
+				}
+				return pod
+			},
+			state: func() *clusterState {
+				return newState(
+					[]corev1.Node{
+						newNode("node-1", zone("a"), "1", "1Gi"),
+						newNode("node-2", zone("b"), "1", "1Gi"),
+						newNode("node-3", nil, "1", "1Gi"),
+					},
+					newPod("web-1", "node-1", map[string]string{"app": "web"}, "100m", "64Mi"),
+					newPod("web-2", "node-1", map[string]string{"app": "web"}, "100m", "64Mi"),
+					newPod("web-4", "node-2", map[string]string{"app": "web"}, "100m", "64Mi"),
+				)
+			},
+			expected: map[string][]string{
+				"node-1": {topologySpreadReason},
+				"node-2": nil,
+				"node-3": {topologySpreadReason + " (missing required label)"},
+			},
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			pod := tc.pod()
+			got := diagnose(&pod, tc.state())
+
+			actual := make(map[string][]string)
+			for _, result := range got.Nodes {
+				actual[result.Node] = reasons(result)
+			}
+
+			assert.Equal(t, tc.expected, actual)
+			assert.Equal(t, tc.issues, got.Issues)
+		})
+	}
+}
+
+func TestDiagnosis_Summary(t *testing.T) {
+	d := &Diagnosis{
+		Issues: []string{`pod has unbound immediate PersistentVolumeClaim "logs"`},
+		Nodes: []NodeResult{
+			{Node: "node-1", Failures: []Failure{
+				{Reason: "Insufficient cpu"},
+				{Reason: "Insufficient memory"},
+			}},
+			{Node: "node-2", Failures: []Failure{
+				{Reason: "Insufficient cpu"},
+				{Reason: "Insufficient cpu"},
+			}},
+			{Node: "node-3"},
+		},
+	}
+
+	assert.Equal(t, []string{"node-3"}, d.FittingNodes())
+	assert.Equal(t,
+		`1/3 nodes are available: 2 Insufficient cpu, 1 Insufficient memory. pod has unbound immediate PersistentVolumeClaim "logs".`,
+		d.Summary())
+}
+
+func TestNeedsDiagnosis(t *testing.T) {
+	pending := newPod("web", "", nil, "100m", "64Mi")
+	assert.True(t, NeedsDiagnosis(&pending))
+
+	scheduled := newPod("web", "node-1", nil, "100m", "64Mi")
+	scheduled.Status.Phase = corev1.PodPending
+	assert.False(t, NeedsDiagnosis(&scheduled))
+
+	deleting := newPod("web", "", nil, "100m", "64Mi")
+	deleting.DeletionTimestamp = &metav1.Time{}
+	assert.False(t, NeedsDiagnosis(&deleting))
+
+	assert.False(t, NeedsDiagnosis(nil))
+}
+
+func toUnstructured(t *testing.T, object runtime.Object) unstructured.Unstructured {
+	m, err := runtime.DefaultUnstructuredConverter.ToUnstructured(object)
+	require.NoError(t, err)
+	return unstructured.Unstructured{Object: m}
+}
+
+func TestDiagnose(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	pod := newPod("web", "", nil, "2", "64Mi")
+	pod.Spec.Volumes = []corev1.Volume{
+		{
+			Name: "data",
+			VolumeSource: corev1.VolumeSource{
+				PersistentVolumeClaim: &corev1.PersistentVolumeClaimVolumeSource{ClaimName: "data"},
+			},
+		},
+	}
+
+	node := newNode("node-1", nil, "1", "1Gi")
+
+	o := storeFake.NewMockStore(controller)
+	o.EXPECT().
+		List(gomock.Any(), store.Key{APIVersion: "v1", Kind: "Node"}).
+		Return(&unstructured.UnstructuredList{Items: []unstructured.Unstructured{toUnstructured(t, &node)}}, false, nil)
+	o.EXPECT().
+		List(gomock.Any(), store.Key{APIVersion: "v1", Kind: "Pod"}).
+		Return(&unstructured.UnstructuredList{Items: []unstructured.Unstructured{toUnstructured(t, &pod)}}, false, nil)
+	o.EXPECT().
+		Get(gomock.Any(), store.Key{Namespace: "default", APIVersion: "v1", Kind: "PersistentVolumeClaim", Name: "data"}).
+		Return(nil, kerrors.NewNotFound(schema.GroupResource{Resource: "persistentvolumeclaims"}, "data"))
+
+	got, err := Diagnose(context.Background(), o, &pod)
+	require.NoError(t, err)
+
+	assert.Equal(t,
+		`0/1 nodes are available: 1 Insufficient cpu. persistentvolumeclaim "data" not found.`,
+		got.Summary())
+}
+
+func TestDiagnose_stateCache(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	web := newPod("web", "", nil, "2", "64Mi")
+	api := newPod("api", "", nil, "100m", "64Mi")
+	node := newNode("node-1", nil, "1", "1Gi")
+
+	// Nodes and pods are listed once for every diagnosis in the context.
+	o := storeFake.NewMockStore(controller)
+	o.EXPECT().
+		List(gomock.Any(), store.Key{APIVersion: "v1", Kind: "Node"}).
+		Return(&unstructured.UnstructuredList{Items: []unstructured.Unstructured{toUnstructured(t, &node)}}, false, nil)
+	o.EXPECT().
+		List(gomock.Any(), store.Key{APIVersion: "v1", Kind: "Pod"}).
+		Return(&unstructured.UnstructuredList{Items: []unstructured.Unstructured{toUnstructured(t, &web), toUnstructured(t, &api)}}, false, nil)
+
+	ctx := WithStateCache(context.Background())
+	assert.Equal(t, ctx, WithStateCache(ctx))
+
+	got, err := Diagnose(ctx, o, &web)
+	require.NoError(t, err)
+	assert.Equal(t, "0/1 nodes are available: 1 Insufficient cpu.", got.Summary())
+
+	got, err = Diagnose(ctx, o, &api)
+	require.NoError(t, err)
+	assert.Equal(t, "1/1 nodes are available.", got.Summary())
+}
@@ -0,0 +1,456 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package scheduling
+
+import (
+	"fmt"
+	"sort"
+	"strconv"
+	"strings"
+
+	corev1 "k8s.io/api/core/v1"
+	storagev1 "k8s.io/api/storage/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+func checkUnschedulable(pod *corev1.Pod, node *corev1.Node) []Failure {
+	if !node.Spec.Unschedulable {
+		return nil
+	}
+
+	taint := &corev1.Taint{Key: corev1.TaintNodeUnschedulable, Effect: corev1.TaintEffectNoSchedule}
+	if tolerates(pod.Spec.Tolerations, taint) {
+		return nil
+	}
+
+	return []Failure{{
+		Predicate: PredicateNodeUnschedulable,
+		Reason:    "node(s) were unschedulable",
+		Message:   "node is cordoned",
+	}}
+}
+
+func checkTaints(pod *corev1.Pod, node *corev1.Node) []Failure {
+	for i := range node.Spec.Taints {
+		taint := &node.Spec.Taints[i]
+		if taint.Effect == corev1.TaintEffectPreferNoSchedule {
+			continue
+		}
+
+		if !tolerates(pod.Spec.Tolerations, taint) {
+			return []Failure{{
+				Predicate: PredicateTaintToleration,
+				Reason:    fmt.Sprintf("node(s) had untolerated taint {%s: %s}", taint.Key, taint.Value),
+				Message:   fmt.Sprintf("taint %s is not tolerated", taint.ToString()),
+			}}
+		}
+	}
+
+	return nil
+}
+
+func tolerates(tolerations []corev1.Toleration, taint *corev1.Taint) bool {
+	for i := range tolerations {
+		if tolerations[i].ToleratesTaint(taint) {
+			return true
+		}
+	}
+	return false
+}
+
+const nodeAffinityReason = "node(s) didn't match Pod's node affinity/selector"
+
+func checkNodeAffinity(pod *corev1.Pod, node *corev1.Node) []Failure {
+	var failures []Failure
+
+	var mismatched []string
+	for _, key := range sortedKeys(pod.Spec.NodeSelector) {
+		value := pod.Spec.NodeSelector[key]
+		if node.Labels[key] != value {
+			mismatched = append(mismatched, fmt.Sprintf("%s=%s", key, value))
+		}
+	}
+	if len(mismatched) > 0 {
+		failures = append(failures, Failure{
+			Predicate: PredicateNodeAffinity,
+			Reason:    nodeAffinityReason,
+			Message:   fmt.Sprintf("node selector %s doesn't match", strings.Join(mismatched, ",")),
+		})
+	}
+
+	if selector := requiredNodeSelector(pod); selector != nil && !matchNodeSelector(node, selector) {
+		failures = append(failures, Failure{
+			Predicate: PredicateNodeAffinity,
+			Reason:    nodeAffinityReason,
+			Message:   "required node affinity doesn't match",
+		})
+	}
+
+	return failures
+}
+
+func requiredNodeSelector(pod *corev1.Pod) *corev1.NodeSelector {
+	affinity := pod.Spec.Affinity
+	if affinity == nil || affinity.NodeAffinity == nil {
+		return nil
+	}
+	return affinity.NodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+}
+
+// matchNodeSelector returns true if a node matches any of a selector's terms.
+func matchNodeSelector(node *corev1.Node, selector *corev1.NodeSelector) bool {
+	for _, term := range selector.NodeSelectorTerms {
+		if matchNodeSelectorTerm(node, term) {
+			return true
+		}
+	}
+	return false
+}
+
+// matchNodeSelectorTerm returns true if a node matches all of a term's requirements.
+// An empty term matches no nodes.
+func matchNodeSelectorTerm(node *corev1.Node, term corev1.NodeSelectorTerm) bool {
+	if len(term.MatchExpressions) == 0 && len(term.MatchFields) == 0 {
+		return false
+	}
+
+	nodeLabels := labels.Set(node.Labels)
+	for _, requirement := range term.MatchExpressions {
+		if !matchRequirement(nodeLabels, requirement) {
+			return false
+		}
+	}
+
+	nodeFields := labels.Set{"metadata.name": node.Name}
+	for _, requirement := range term.MatchFields {
+		if !matchRequirement(nodeFields, requirement) {
+			return false
+		}
+	}
+
+	return true
+}
+
+func matchRequirement(set labels.Set, requirement corev1.NodeSelectorRequirement) bool {
+	value, ok := set[requirement.Key]
+
+	switch requirement.Operator {
+	case corev1.NodeSelectorOpIn:
+		return ok && contains(requirement.Values, value)
+	case corev1.NodeSelectorOpNotIn:
+		return !ok || !contains(requirement.Values, value)
+	case corev1.NodeSelectorOpExists:
+		return ok
+	case corev1.NodeSelectorOpDoesNotExist:
+		return !ok
+	case corev1.NodeSelectorOpGt, corev1.NodeSelectorOpLt:
+		if !ok || len(requirement.Values) != 1 {
+			return false
+		}
+		actual, err := strconv.ParseInt(value, 10, 64)
+		if err != nil {
+			return false
+		}
+		expected, err := strconv.ParseInt(requirement.Values[0], 10, 64)
+		if err != nil {
+			return false
+		}
+		if requirement.Operator == corev1.NodeSelectorOpGt {
+			return actual > expected
+		}
+		return actual < expected
+	default:
+		return false
+	}
+}
+
+func checkResources(pod *corev1.Pod, node *corev1.Node, pods []corev1.Pod) []Failure {
+	requested := podRequests(pod)
+
+	used := corev1.ResourceList{}
+	podCount := 0
+	for i := range pods {
+		p := &pods[i]
+		if p.Spec.NodeName != node.Name || p.UID == pod.UID || isTerminated(p) {
+			continue
+		}
+		podCount++
+		addResources(used, podRequests(p))
+	}
+
+	var failures []Failure
+
+	if allocatable, ok := node.Status.Allocatable[corev1.ResourcePods]; ok && int64(podCount+1) > allocatable.Value() {
+		failures = append(failures, Failure{
+			Predicate: PredicateNodeResources,
+			Reason:    "Too many pods",
+			Message:   fmt.Sprintf("%d of %d pods are running", podCount, allocatable.Value()),
+		})
+	}
+
+	for _, name := range sortedResourceNames(requested) {
+		request := requested[name]
+		if request.IsZero() {
+			continue
+		}
+
+		allocatable := node.Status.Allocatable[name]
+		free := allocatable.DeepCopy()
+		free.Sub(used[name])
+
+		if request.Cmp(free) > 0 {
+			free = nonNegative(free)
+			failures = append(failures, Failure{
+				Predicate: PredicateNodeResources,
+				Reason:    fmt.Sprintf("Insufficient %s", name),
+				Message: fmt.Sprintf("requested %s, %s of %s allocatable is free",
+					request.String(), free.String(), allocatable.String()),
+			})
+		}
+	}
+
+	return failures
+}
+
+// podRequests returns the resources a pod requests. Init containers run one at a time,
+// so the pod needs the larger of the sum of its containers and its largest init container.
+func podRequests(pod *corev1.Pod) corev1.ResourceList {
+	requests := corev1.ResourceList{}
+	for _, container := range pod.Spec.Containers {
+		addResources(requests, container.Resources.Requests)
+	}
+
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if current, ok := requests[name]; !ok || quantity.Cmp(current) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+
+	addResources(requests, pod.Spec.Overhead)
+
+	return requests
+}
+
+func addResources(list, add corev1.ResourceList) {
+	for name, quantity := range add {
+		if current, ok := list[name]; ok {
+			current.Add(quantity)
+			list[name] = current
+		} else {
+			list[name] = quantity.DeepCopy()
+		}
+	}
+}
+
+func nonNegative(q resource.Quantity) resource.Quantity {
+	if q.Sign() < 0 {
+		return resource.Quantity{Format: q.Format}
+	}
+	return q
+}
+
+func isTerminated(pod *corev1.Pod) bool {
+	return pod.Status.Phase == corev1.PodSucceeded || pod.Status.Phase == corev1.PodFailed
+}
+
+// volumeIssues returns the issues with the pod's persistent volume claims which
+// prevent it from being scheduled on any node.
+func volumeIssues(pod *corev1.Pod, state *clusterState) []string {
+	var issues []string
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+
+		name := volume.PersistentVolumeClaim.ClaimName
+		claim, ok := state.claims[name]
+		if !ok {
+			issues = append(issues, fmt.Sprintf("persistentvolumeclaim %q not found", name))
+			continue
+		}
+
+		if claim.Status.Phase == corev1.ClaimBound || waitsForFirstConsumer(claim, state) {
+			continue
+		}
+
+		issues = append(issues, fmt.Sprintf("pod has unbound immediate PersistentVolumeClaim %q", name))
+	}
+	return issues
+}
+
+// waitsForFirstConsumer returns true if a claim is bound once a pod using it is scheduled.
+func waitsForFirstConsumer(claim *corev1.PersistentVolumeClaim, state *clusterState) bool {
+	storageClass, ok := state.storageClasses[claimStorageClass(claim)]
+	return ok && storageClass.VolumeBindingMode != nil &&
+		*storageClass.VolumeBindingMode == storagev1.VolumeBindingWaitForFirstConsumer
+}
+
+// checkVolumeAffinity checks the node affinity of the pod's bound persistent volumes.
+func checkVolumeAffinity(pod *corev1.Pod, node *corev1.Node, state *clusterState) []Failure {
+	for _, volume := range pod.Spec.Volumes {
+		if volume.PersistentVolumeClaim == nil {
+			continue
+		}
+
+		claim, ok := state.claims[volume.PersistentVolumeClaim.ClaimName]
+		if !ok {
+			continue
+		}
+
+		pv, ok := state.volumes[claim.Spec.VolumeName]
+		if !ok || pv.Spec.NodeAffinity == nil || pv.Spec.NodeAffinity.Required == nil {
+			continue
+		}
+
+		if !matchNodeSelector(node, pv.Spec.NodeAffinity.Required) {
+			return []Failure{{
+				Predicate: PredicateVolumeBinding,
+				Reason:    "node(s) had volume node affinity conflict",
+				Message:   fmt.Sprintf("persistent volume %s for claim %s can't be used on this node", pv.Name, claim.Name),
+			}}
+		}
+	}
+
+	return nil
+}
+
+// topologySpread checks a pod's DoNotSchedule topology spread constraints.
+type topologySpread struct {
+	pod         *corev1.Pod
+	constraints []spreadConstraint
+}
+
+type spreadConstraint struct {
+	constraint corev1.TopologySpreadConstraint
+	// counts are the number of matching pods in each topology domain.
+	counts   map[string]int
+	min      int
+	selfSkew int
+}
+
+func newTopologySpread(pod *corev1.Pod, state *clusterState) *topologySpread {
+	ts := &topologySpread{pod: pod}
+
+	for _, constraint := range pod.Spec.TopologySpreadConstraints {
+		if constraint.WhenUnsatisfiable != corev1.DoNotSchedule {
+			continue
+		}
+
+		selector := labels.Nothing()
+		if constraint.LabelSelector != nil {
+			s, err := metav1.LabelSelectorAsSelector(constraint.LabelSelector)
+			if err != nil {
+				continue
+			}
+			selector = s
+		}
+
+		sc := spreadConstraint{
+			constraint: constraint,
+			counts:     make(map[string]int),
+		}
+		if selector.Matches(labels.Set(pod.Labels)) {
+			sc.selfSkew = 1
+		}
+
+		// Only nodes the pod could be placed on by affinity are domains.
+		domains := make(map[string]string)
+		for i := range state.nodes {
+			node := &state.nodes[i]
+			value, ok := node.Labels[constraint.TopologyKey]
+			if !ok || len(checkNodeAffinity(pod, node)) > 0 {
+				continue
+			}
+			domains[node.Name] = value
+			sc.counts[value] += 0
+		}
+
+		for i := range state.pods {
+			p := &state.pods[i]
+			domain, ok := domains[p.Spec.NodeName]
+			if !ok || p.Namespace != pod.Namespace || p.UID == pod.UID || isTerminated(p) {
+				continue
+			}
+			if selector.Matches(labels.Set(p.Labels)) {
+				sc.counts[domain]++
+			}
+		}
+
+		first := true
+		for _, count := range sc.counts {
+			if first || count < sc.min {
+				sc.min = count
+				first = false
+			}
+		}
+
+		ts.constraints = append(ts.constraints, sc)
+	}
+
+	return ts
+}
+
+const topologySpreadReason = "node(s) didn't match pod topology spread constraints"
+
+func (ts *topologySpread) check(node *corev1.Node) []Failure {
+	for _, sc := range ts.constraints {
+		key := sc.constraint.TopologyKey
+
+		value, ok := node.Labels[key]
+		if !ok {
+			return []Failure{{
+				Predicate: PredicateTopologySpread,
+				Reason:    topologySpreadReason + " (missing required label)",
+				Message:   fmt.Sprintf("node doesn't have label %s", key),
+			}}
+		}
+
+		skew := sc.counts[value] + sc.selfSkew - sc.min
+		if skew > int(sc.constraint.MaxSkew) {
+			return []Failure{{
+				Predicate: PredicateTopologySpread,
+				Reason:    topologySpreadReason,
+				Message: fmt.Sprintf("placing the pod in %s=%s makes the skew %d, max skew is %d",
+					key, value, skew, sc.constraint.MaxSkew),
+			}}
+		}
+	}
+
+	return nil
+}
+
+func contains(values []string, s string) bool {
+	for _, value := range values {
+		if value == s {
+			return true
+		}
+	}
+	return false
+}
+
+func sortedKeys(m map[string]string) []string {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+	return keys
+}
+
+func sortedResourceNames(list corev1.ResourceList) []corev1.ResourceName {
+	var names []corev1.ResourceName
+	for name := range list {
+		names = append(names, name)
+	}
+	sort.Slice(names, func(i, j int) bool {
+		return names[i] < names[j]
+	})
+	return names
+}
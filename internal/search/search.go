@@ -0,0 +1,439 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package search indexes the name, namespace, labels and annotations of cached
+// objects so they can be found without knowing their kind or namespace.
+//
+// A query is a list of whitespace separated terms and an object matches if it
+// matches every term. Terms can be:
+//
+//	web            fuzzy match against the name, namespace, kind, labels and annotations
+//	app=web        label or annotation with key app and a value starting with web
+//	kind:deploy    kind starting with deploy
+//	ns:kube        namespace starting with kube (namespace:kube also works)
+package search
+
+import (
+	"context"
+	"sort"
+	"strings"
+	"sync"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kcache "k8s.io/client-go/tools/cache"
+
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+// DefaultLimit is the number of results returned when no limit is given.
+const DefaultLimit = 50
+
+// Scores for matching a term against an object's fields.
+const (
+	scoreNameExact      = 100
+	scoreNamePrefix     = 80
+	scoreNameContains   = 60
+	scoreNameFuzzy      = 30
+	scoreFieldExact     = 50
+	scoreFieldPrefix    = 40
+	scoreMetadataPrefix = 20
+	scoreMetadataMatch  = 10
+)
+
+// Access checks if a verb is allowed for a key. objectstore.ResourceAccess
+// implements Access.
+type Access interface {
+	HasAccess(ctx context.Context, key store.Key, verb string) error
+}
+
+// Document is the indexed representation of an object.
+type Document struct {
+	APIVersion  string            `json:"apiVersion"`
+	Kind        string            `json:"kind"`
+	Namespace   string            `json:"namespace,omitempty"`
+	Name        string            `json:"name"`
+	Labels      map[string]string `json:"labels,omitempty"`
+	Annotations map[string]string `json:"annotations,omitempty"`
+}
+
+func (d Document) groupKind() schema.GroupKind {
+	return schema.FromAPIVersionAndKind(d.APIVersion, d.Kind).GroupKind()
+}
+
+// Result is a document matching a query.
+type Result struct {
+	Document
+	Score int `json:"score"`
+}
+
+// Index is a search index. It is safe for concurrent use.
+type Index struct {
+	mu        sync.RWMutex
+	documents map[documentKey]Document
+	access    Access
+}
+
+type documentKey struct {
+	groupKind schema.GroupKind
+	namespace string
+	name      string
+}
+
+// NewIndex creates an instance of Index.
+func NewIndex() *Index {
+	return &Index{
+		documents: make(map[documentKey]Document),
+	}
+}
+
+// SetAccess sets the access used to exclude kinds which can't be listed. If access
+// is nil, no results are excluded.
+func (i *Index) SetAccess(access Access) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.access = access
+}
+
+// Add adds or updates an object.
+func (i *Index) Add(object *unstructured.Unstructured) {
+	if object == nil {
+		return
+	}
+
+	document := Document{
+		APIVersion:  object.GetAPIVersion(),
+		Kind:        object.GetKind(),
+		Namespace:   object.GetNamespace(),
+		Name:        object.GetName(),
+		Labels:      object.GetLabels(),
+		Annotations: object.GetAnnotations(),
+	}
+
+	// The last applied configuration is large and repeats the object.
+	if _, ok := document.Annotations[lastAppliedAnnotation]; ok {
+		annotations := make(map[string]string, len(document.Annotations))
+		for k, v := range document.Annotations {
+			if k != lastAppliedAnnotation {
+				annotations[k] = v
+			}
+		}
+		document.Annotations = annotations
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.documents[keyFor(document)] = document
+}
+
+// Delete deletes an object.
+func (i *Index) Delete(object *unstructured.Unstructured) {
+	if object == nil {
+		return
+	}
+
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	delete(i.documents, keyFor(Document{
+		APIVersion: object.GetAPIVersion(),
+		Kind:       object.GetKind(),
+		Namespace:  object.GetNamespace(),
+		Name:       object.GetName(),
+	}))
+}
+
+// DeleteKind deletes all objects of a kind. It is used when the kind is no longer watched.
+func (i *Index) DeleteKind(groupVersionKind schema.GroupVersionKind) {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	for key := range i.documents {
+		if key.groupKind == groupVersionKind.GroupKind() {
+			delete(i.documents, key)
+		}
+	}
+}
+
+// Reset deletes all objects.
+func (i *Index) Reset() {
+	i.mu.Lock()
+	defer i.mu.Unlock()
+
+	i.documents = make(map[documentKey]Document)
+}
+
+// Len returns the number of indexed objects.
+func (i *Index) Len() int {
+	i.mu.RLock()
+	defer i.mu.RUnlock()
+
+	return len(i.documents)
+}
+
+// Handler returns an informer event handler which keeps the index current.
+func (i *Index) Handler() kcache.ResourceEventHandler {
+	return kcache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			if object, ok := obj.(*unstructured.Unstructured); ok {
+				i.Add(object)
+			}
+		},
+		UpdateFunc: func(_, obj interface{}) {
+			if object, ok := obj.(*unstructured.Unstructured); ok {
+				i.Add(object)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			if tombstone, ok := obj.(kcache.DeletedFinalStateUnknown); ok {
+				obj = tombstone.Obj
+			}
+			if object, ok := obj.(*unstructured.Unstructured); ok {
+				i.Delete(object)
+			}
+		},
+	}
+}
+
+// Search returns up to limit objects matching a query, best matches first. Objects
+// of kinds which can't be listed in their namespace are excluded.
+func (i *Index) Search(ctx context.Context, query string, limit int) []Result {
+	i.mu.RLock()
+	access := i.access
+	i.mu.RUnlock()
+
+	return i.SearchWithAccess(ctx, access, query, limit)
+}
+
+// SearchWithAccess is like Search, but excludes objects which can't be listed with
+// access instead of the index's access. If access is nil, no results are excluded.
+func (i *Index) SearchWithAccess(ctx context.Context, access Access, query string, limit int) []Result {
+	terms := parseQuery(query)
+	if len(terms) == 0 {
+		return nil
+	}
+
+	if limit <= 0 {
+		limit = DefaultLimit
+	}
+
+	i.mu.RLock()
+	var results []Result
+	for _, document := range i.documents {
+		if score, ok := match(document, terms); ok {
+			results = append(results, Result{Document: document, Score: score})
+		}
+	}
+	i.mu.RUnlock()
+
+	results = filterAllowed(ctx, access, results)
+
+	sort.Slice(results, func(a, b int) bool {
+		ra, rb := results[a], results[b]
+		if ra.Score != rb.Score {
+			return ra.Score > rb.Score
+		}
+		if ra.Kind != rb.Kind {
+			return ra.Kind < rb.Kind
+		}
+		if ra.Namespace != rb.Namespace {
+			return ra.Namespace < rb.Namespace
+		}
+		return ra.Name < rb.Name
+	})
+
+	if len(results) > limit {
+		results = results[:limit]
+	}
+
+	return results
+}
+
+// filterAllowed removes results which can't be listed. Access is checked once for
+// each kind and namespace.
+func filterAllowed(ctx context.Context, access Access, results []Result) []Result {
+	if access == nil {
+		return results
+	}
+
+	allowed := make(map[store.Key]bool)
+	var filtered []Result
+	for _, result := range results {
+		key := store.Key{
+			Namespace:  result.Namespace,
+			APIVersion: result.APIVersion,
+			Kind:       result.Kind,
+		}
+
+		ok, seen := allowed[key]
+		if !seen {
+			ok = access.HasAccess(ctx, key, "list") == nil
+			allowed[key] = ok
+		}
+
+		if ok {
+			filtered = append(filtered, result)
+		}
+	}
+
+	return filtered
+}
+
+func keyFor(document Document) documentKey {
+	return documentKey{
+		groupKind: document.groupKind(),
+		namespace: document.Namespace,
+		name:      document.Name,
+	}
+}
+
+const lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+
+type termType int
+
+const (
+	termAny termType = iota
+	termKind
+	termNamespace
+	termMetadata
+)
+
+type term struct {
+	termType termType
+	value    string
+	key      string
+}
+
+func parseQuery(query string) []term {
+	var terms []term
+	for _, field := range strings.Fields(strings.ToLower(query)) {
+		switch {
+		case strings.HasPrefix(field, "kind:"):
+			terms = append(terms, term{termType: termKind, value: strings.TrimPrefix(field, "kind:")})
+		case strings.HasPrefix(field, "ns:"):
+			terms = append(terms, term{termType: termNamespace, value: strings.TrimPrefix(field, "ns:")})
+		case strings.HasPrefix(field, "namespace:"):
+			terms = append(terms, term{termType: termNamespace, value: strings.TrimPrefix(field, "namespace:")})
+		case strings.Contains(field, "="):
+			parts := strings.SplitN(field, "=", 2)
+			terms = append(terms, term{termType: termMetadata, key: parts[0], value: parts[1]})
+		default:
+			terms = append(terms, term{termType: termAny, value: field})
+		}
+	}
+	return terms
+}
+
+// match returns the score of a document for terms. ok is false if any term doesn't match.
+func match(document Document, terms []term) (score int, ok bool) {
+	for _, t := range terms {
+		termScore := matchTerm(document, t)
+		if termScore == 0 {
+			return 0, false
+		}
+		score += termScore
+	}
+	return score, true
+}
+
+func matchTerm(document Document, t term) int {
+	switch t.termType {
+	case termKind:
+		return matchField(strings.ToLower(document.Kind), t.value)
+	case termNamespace:
+		return matchField(strings.ToLower(document.Namespace), t.value)
+	case termMetadata:
+		return matchMetadata(document, t.key, t.value)
+	}
+
+	best := matchName(strings.ToLower(document.Name), t.value)
+	for _, field := range []string{document.Namespace, document.Kind} {
+		if score := matchField(strings.ToLower(field), t.value); score > best {
+			best = score
+		}
+	}
+	for _, m := range []map[string]string{document.Labels, document.Annotations} {
+		for k, v := range m {
+			for _, s := range []string{strings.ToLower(k), strings.ToLower(v)} {
+				if score := matchMetadataValue(s, t.value); score > best {
+					best = score
+				}
+			}
+		}
+	}
+	return best
+}
+
+func matchName(name, value string) int {
+	switch {
+	case name == value:
+		return scoreNameExact
+	case strings.HasPrefix(name, value):
+		return scoreNamePrefix
+	case strings.Contains(name, value):
+		return scoreNameContains
+	case fuzzy(name, value):
+		return scoreNameFuzzy
+	default:
+		return 0
+	}
+}
+
+func matchField(field, value string) int {
+	switch {
+	case value == "":
+		return scoreFieldPrefix
+	case field == value:
+		return scoreFieldExact
+	case strings.HasPrefix(field, value):
+		return scoreFieldPrefix
+	default:
+		return 0
+	}
+}
+
+func matchMetadata(document Document, key, value string) int {
+	best := 0
+	for _, m := range []map[string]string{document.Labels, document.Annotations} {
+		for k, v := range m {
+			if strings.ToLower(k) != key {
+				continue
+			}
+			v = strings.ToLower(v)
+			switch {
+			case v == value:
+				return scoreFieldExact
+			case strings.HasPrefix(v, value):
+				best = scoreFieldPrefix
+			}
+		}
+	}
+	return best
+}
+
+func matchMetadataValue(s, value string) int {
+	switch {
+	case strings.HasPrefix(s, value):
+		return scoreMetadataPrefix
+	case strings.Contains(s, value):
+		return scoreMetadataMatch
+	default:
+		return 0
+	}
+}
+
+// fuzzy returns true if the characters of value appear in order in s.
+func fuzzy(s, value string) bool {
+	for _, r := range value {
+		i := strings.IndexRune(s, r)
+		if i < 0 {
+			return false
+		}
+		s = s[i+len(string(r)):]
+	}
+	return true
+}
@@ -0,0 +1,205 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package search
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kcache "k8s.io/client-go/tools/cache"
+
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+func newObject(apiVersion, kind, namespace, name string, labels map[string]string) *unstructured.Unstructured {
+	object := &unstructured.Unstructured{}
+	object.SetAPIVersion(apiVersion)
+	object.SetKind(kind)
+	object.SetNamespace(namespace)
+	object.SetName(name)
+	object.SetLabels(labels)
+	return object
+}
+
+func newTestIndex() *Index {
+	index := NewIndex()
+	index.Add(newObject("apps/v1", "Deployment", "default", "web", map[string]string{"app": "web"}))
+	index.Add(newObject("v1", "Service", "default", "web-frontend", map[string]string{"app": "web"}))
+	index.Add(newObject("v1", "Pod", "default", "worker-7d9f", map[string]string{"app": "worker"}))
+	index.Add(newObject("v1", "Pod", "kube-system", "coredns-1", map[string]string{"k8s-app": "kube-dns"}))
+	index.Add(newObject("v1", "Secret", "kube-system", "bootstrap", nil))
+	index.Add(newObject("rbac.authorization.k8s.io/v1", "ClusterRole", "", "web-reader", nil))
+	return index
+}
+
+func resultNames(results []Result) []string {
+	var names []string
+	for _, result := range results {
+		names = append(names, fmt.Sprintf("%s/%s", result.Kind, result.Name))
+	}
+	return names
+}
+
+func TestIndex_Search(t *testing.T) {
+	cases := []struct {
+		name     string
+		query    string
+		limit    int
+		expected []string
+	}{
+		{
+			name:     "exact name before prefix",
+			query:    "web",
+			expected: []string{"Deployment/web", "ClusterRole/web-reader", "Service/web-frontend"},
+		},
+		{
+			name:     "substring",
+			query:    "front",
+			expected: []string{"Service/web-frontend"},
+		},
+		{
+			name:     "fuzzy",
+			query:    "wrkr",
+			expected: []string{"Pod/worker-7d9f"},
+		},
+		{
+			name:     "case insensitive",
+			query:    "CoreDNS",
+			expected: []string{"Pod/coredns-1"},
+		},
+		{
+			name:     "label",
+			query:    "app=web",
+			expected: []string{"Deployment/web", "Service/web-frontend"},
+		},
+		{
+			name:     "label value prefix",
+			query:    "k8s-app=kube",
+			expected: []string{"Pod/coredns-1"},
+		},
+		{
+			name:     "kind",
+			query:    "kind:pod",
+			expected: []string{"Pod/worker-7d9f", "Pod/coredns-1"},
+		},
+		{
+			name:     "all terms match",
+			query:    "ns:kube kind:secret",
+			expected: []string{"Secret/bootstrap"},
+		},
+		{
+			name:     "limit",
+			query:    "web",
+			limit:    2,
+			expected: []string{"Deployment/web", "ClusterRole/web-reader"},
+		},
+		{
+			name:  "no match",
+			query: "missing",
+		},
+		{
+			name:  "empty query",
+			query: "  ",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			index := newTestIndex()
+			got := index.Search(context.Background(), tc.query, tc.limit)
+			assert.Equal(t, tc.expected, resultNames(got))
+		})
+	}
+}
+
+type stubAccess struct {
+	denied map[store.Key]bool
+	calls  int
+}
+
+func (s *stubAccess) HasAccess(_ context.Context, key store.Key, verb string) error {
+	s.calls++
+	if verb != "list" || s.denied[key] {
+		return fmt.Errorf("forbidden")
+	}
+	return nil
+}
+
+func TestIndex_Search_access(t *testing.T) {
+	index := newTestIndex()
+	index.Add(newObject("v1", "Secret", "default", "web-tls", nil))
+	index.Add(newObject("v1", "Secret", "default", "web-token", nil))
+
+	access := &stubAccess{
+		denied: map[store.Key]bool{
+			{Namespace: "default", APIVersion: "v1", Kind: "Secret"}: true,
+		},
+	}
+	index.SetAccess(access)
+
+	got := index.Search(context.Background(), "web", 0)
+	assert.NotContains(t, resultNames(got), "Secret/web-tls")
+	assert.NotContains(t, resultNames(got), "Secret/web-token")
+	assert.Contains(t, resultNames(got), "Deployment/web")
+	assert.Equal(t, 4, access.calls, "access is checked once for each kind and namespace")
+}
+
+func TestIndex_Handler(t *testing.T) {
+	index := NewIndex()
+	handler := index.Handler()
+
+	pod := newObject("v1", "Pod", "default", "web", nil)
+	handler.OnAdd(pod)
+	require.Equal(t, 1, index.Len())
+
+	updated := pod.DeepCopy()
+	updated.SetLabels(map[string]string{"tier": "frontend"})
+	handler.OnUpdate(pod, updated)
+	require.Equal(t, 1, index.Len())
+	assert.Len(t, index.Search(context.Background(), "tier=frontend", 0), 1)
+
+	handler.OnDelete(kcache.DeletedFinalStateUnknown{Key: "default/web", Obj: updated})
+	assert.Equal(t, 0, index.Len())
+}
+
+func TestIndex_Add_versions(t *testing.T) {
+	index := NewIndex()
+	index.Add(newObject("apps/v1", "Deployment", "default", "web", nil))
+	index.Add(newObject("apps/v1beta1", "Deployment", "default", "web", nil))
+
+	assert.Equal(t, 1, index.Len(), "versions of a kind are the same object")
+}
+
+func TestIndex_Add_lastAppliedConfiguration(t *testing.T) {
+	object := newObject("v1", "Service", "default", "web", nil)
+	object.SetAnnotations(map[string]string{
+		lastAppliedAnnotation: `{"kind":"Service","metadata":{"name":"kubernetes"}}`,
+		"owner":               "team-a",
+	})
+
+	index := NewIndex()
+	index.Add(object)
+
+	assert.Empty(t, index.Search(context.Background(), "kubernetes", 0))
+	assert.Len(t, index.Search(context.Background(), "team-a", 0), 1)
+	assert.Len(t, object.GetAnnotations(), 2, "the object is not modified")
+}
+
+func TestIndex_DeleteKind(t *testing.T) {
+	index := newTestIndex()
+	index.DeleteKind(schema.GroupVersionKind{Version: "v1", Kind: "Pod"})
+
+	assert.Empty(t, index.Search(context.Background(), "kind:pod", 0))
+	assert.Equal(t, 4, index.Len())
+
+	index.Reset()
+	assert.Equal(t, 0, index.Len())
+}
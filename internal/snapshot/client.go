@@ -0,0 +1,428 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+	"sort"
+
+	openapi_v2 "github.com/googleapis/gnostic/openapiv2"
+	corev1 "k8s.io/api/core/v1"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/version"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/kubernetes/scheme"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/restmapper"
+
+	"github.com/vmware-tanzu/octant/internal/cluster"
+	"github.com/vmware-tanzu/octant/internal/gvk"
+	clusterTypes "github.com/vmware-tanzu/octant/pkg/cluster"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+// offlineHost is the API server host used for clients which need a live cluster, e.g.
+// logs and port forwards. Requests to it fail because the .invalid domain never resolves.
+const offlineHost = "https://snapshot.invalid"
+
+// Client is a cluster.ClientInterface backed by a snapshot. The discovery and dynamic
+// clients serve the snapshot's data. Clients which need a live API server return errors
+// when they are used.
+type Client struct {
+	snapshot         *Snapshot
+	objectStore      *Store
+	discoveryClient  *discoveryClient
+	dynamicClient    *dynamicClient
+	kubernetesClient kubernetes.Interface
+	restConfig       *rest.Config
+	restMapper       meta.RESTMapper
+}
+
+var _ cluster.ClientInterface = (*Client)(nil)
+
+// NewClient creates an instance of Client.
+func NewClient(s *Snapshot) (*Client, error) {
+	if s == nil {
+		return nil, fmt.Errorf("snapshot is nil")
+	}
+
+	c := &Client{
+		snapshot:        s,
+		objectStore:     NewStore(s),
+		discoveryClient: &discoveryClient{discovery: &s.Discovery},
+		restConfig: &rest.Config{
+			Host:    offlineHost,
+			APIPath: "/api",
+			ContentConfig: rest.ContentConfig{
+				GroupVersion:         &corev1.SchemeGroupVersion,
+				NegotiatedSerializer: scheme.Codecs.WithoutConversion(),
+			},
+		},
+	}
+
+	groupResources, err := restmapper.GetAPIGroupResources(c.discoveryClient)
+	if err != nil {
+		return nil, fmt.Errorf("create rest mapper: %w", err)
+	}
+	c.restMapper = restmapper.NewDiscoveryRESTMapper(groupResources)
+
+	c.dynamicClient = &dynamicClient{objectStore: c.objectStore, restMapper: c.restMapper}
+
+	kubernetesClient, err := kubernetes.NewForConfig(c.restConfig)
+	if err != nil {
+		return nil, fmt.Errorf("create kubernetes client: %w", err)
+	}
+	c.kubernetesClient = kubernetesClient
+
+	return c, nil
+}
+
+// DefaultNamespace returns the initial namespace.
+func (c *Client) DefaultNamespace() string {
+	return c.namespaceClient().InitialNamespace()
+}
+
+// ResourceExists returns true if the resource is in the snapshot's discovery information.
+func (c *Client) ResourceExists(gvr schema.GroupVersionResource) bool {
+	_, err := c.restMapper.KindFor(gvr)
+	return err == nil
+}
+
+// Resource returns the resource for a group kind and whether it is namespaced.
+func (c *Client) Resource(gk schema.GroupKind) (schema.GroupVersionResource, bool, error) {
+	restMapping, err := c.restMapper.RESTMapping(gk)
+	if err != nil {
+		return schema.GroupVersionResource{}, false, err
+	}
+	return restMapping.Resource, restMapping.Scope.Name() == meta.RESTScopeNameNamespace, nil
+}
+
+// ResetMapper does nothing. The snapshot's resources never change.
+func (c *Client) ResetMapper() {
+}
+
+// KubernetesClient returns a Kubernetes client. There is no API server, so requests fail.
+func (c *Client) KubernetesClient() (kubernetes.Interface, error) {
+	return c.kubernetesClient, nil
+}
+
+// DynamicClient returns a read-only dynamic client for the snapshot's objects.
+func (c *Client) DynamicClient() (dynamic.Interface, error) {
+	return c.dynamicClient, nil
+}
+
+// DiscoveryClient returns a discovery client for the snapshot's discovery information.
+func (c *Client) DiscoveryClient() (discovery.DiscoveryInterface, error) {
+	return c.discoveryClient, nil
+}
+
+// NamespaceClient returns a namespace client for the snapshot's namespaces.
+func (c *Client) NamespaceClient() (clusterTypes.NamespaceInterface, error) {
+	return c.namespaceClient(), nil
+}
+
+func (c *Client) namespaceClient() *namespaceClient {
+	var names []string
+	for _, object := range c.snapshot.objects {
+		if object.GroupVersionKind().GroupKind() == gvk.Namespace.GroupKind() {
+			names = append(names, object.GetName())
+		}
+	}
+	sort.Strings(names)
+
+	return &namespaceClient{
+		names:              names,
+		providedNamespaces: c.snapshot.Metadata.Namespaces,
+	}
+}
+
+// InfoClient returns information about the snapshot.
+func (c *Client) InfoClient() (clusterTypes.InfoInterface, error) {
+	return &infoClient{snapshot: c.snapshot}, nil
+}
+
+// ForUser returns the client. Snapshots are not partitioned by user.
+func (c *Client) ForUser(user string, groups []string) (cluster.ClientInterface, error) {
+	return c, nil
+}
+
+// ReleaseUser does nothing. Snapshots are not partitioned by user.
+func (c *Client) ReleaseUser(user string, groups []string) {
+}
+
+// Close does nothing.
+func (c *Client) Close() {
+}
+
+// RESTClient returns a REST client. There is no API server, so requests fail.
+func (c *Client) RESTClient() (rest.Interface, error) {
+	return rest.RESTClientFor(c.restConfig)
+}
+
+// RESTConfig returns the REST configuration used by clients which need an API server.
+func (c *Client) RESTConfig() *rest.Config {
+	return c.restConfig
+}
+
+type namespaceClient struct {
+	names              []string
+	providedNamespaces []string
+}
+
+var _ clusterTypes.NamespaceInterface = (*namespaceClient)(nil)
+
+func (n *namespaceClient) Names() ([]string, error) {
+	return n.names, nil
+}
+
+func (n *namespaceClient) InitialNamespace() string {
+	for _, name := range n.names {
+		if name == metav1.NamespaceDefault {
+			return name
+		}
+	}
+	if len(n.names) > 0 {
+		return n.names[0]
+	}
+	return metav1.NamespaceDefault
+}
+
+func (n *namespaceClient) ProvidedNamespaces() []string {
+	if len(n.providedNamespaces) == 0 {
+		return []string{n.InitialNamespace()}
+	}
+	return n.providedNamespaces
+}
+
+func (n *namespaceClient) HasNamespace(namespace string) bool {
+	for _, name := range n.names {
+		if name == namespace {
+			return true
+		}
+	}
+	return false
+}
+
+type infoClient struct {
+	snapshot *Snapshot
+}
+
+var _ clusterTypes.InfoInterface = (*infoClient)(nil)
+
+func (i *infoClient) Context() string {
+	if i.snapshot.Metadata.Context != "" {
+		return i.snapshot.Metadata.Context
+	}
+	return "snapshot"
+}
+
+func (i *infoClient) Cluster() string {
+	return i.snapshot.Source
+}
+
+func (i *infoClient) Server() string {
+	return i.snapshot.Source
+}
+
+func (i *infoClient) User() string {
+	return ""
+}
+
+// discoveryClient serves a snapshot's discovery information.
+type discoveryClient struct {
+	discovery *Discovery
+}
+
+var _ discovery.DiscoveryInterface = (*discoveryClient)(nil)
+
+func (d *discoveryClient) RESTClient() rest.Interface {
+	return nil
+}
+
+func (d *discoveryClient) ServerGroups() (*metav1.APIGroupList, error) {
+	if d.discovery.Groups == nil {
+		return &metav1.APIGroupList{}, nil
+	}
+	return d.discovery.Groups.DeepCopy(), nil
+}
+
+func (d *discoveryClient) ServerResourcesForGroupVersion(groupVersion string) (*metav1.APIResourceList, error) {
+	for _, resourceList := range d.discovery.Resources {
+		if resourceList.GroupVersion == groupVersion {
+			return resourceList.DeepCopy(), nil
+		}
+	}
+
+	return nil, kerrors.NewNotFound(schema.GroupResource{}, groupVersion)
+}
+
+func (d *discoveryClient) ServerResources() ([]*metav1.APIResourceList, error) {
+	_, resources, err := d.ServerGroupsAndResources()
+	return resources, err
+}
+
+func (d *discoveryClient) ServerGroupsAndResources() ([]*metav1.APIGroup, []*metav1.APIResourceList, error) {
+	groupList, err := d.ServerGroups()
+	if err != nil {
+		return nil, nil, err
+	}
+
+	var groups []*metav1.APIGroup
+	for i := range groupList.Groups {
+		groups = append(groups, &groupList.Groups[i])
+	}
+
+	var resources []*metav1.APIResourceList
+	for _, resourceList := range d.discovery.Resources {
+		resources = append(resources, resourceList.DeepCopy())
+	}
+
+	return groups, resources, nil
+}
+
+// ServerPreferredResources returns the resources. Snapshots only contain the
+// preferred version of each resource.
+func (d *discoveryClient) ServerPreferredResources() ([]*metav1.APIResourceList, error) {
+	return d.ServerResources()
+}
+
+func (d *discoveryClient) ServerPreferredNamespacedResources() ([]*metav1.APIResourceList, error) {
+	resources, err := d.ServerPreferredResources()
+	if err != nil {
+		return nil, err
+	}
+
+	return discovery.FilteredBy(discovery.ResourcePredicateFunc(func(groupVersion string, r *metav1.APIResource) bool {
+		return r.Namespaced
+	}), resources), nil
+}
+
+func (d *discoveryClient) ServerVersion() (*version.Info, error) {
+	if d.discovery.ServerVersion == nil {
+		return &version.Info{}, nil
+	}
+	info := *d.discovery.ServerVersion
+	return &info, nil
+}
+
+func (d *discoveryClient) OpenAPISchema() (*openapi_v2.Document, error) {
+	return &openapi_v2.Document{}, nil
+}
+
+// dynamicClient is a read-only dynamic client for a snapshot's objects.
+type dynamicClient struct {
+	objectStore *Store
+	restMapper  meta.RESTMapper
+}
+
+var _ dynamic.Interface = (*dynamicClient)(nil)
+
+func (d *dynamicClient) Resource(resource schema.GroupVersionResource) dynamic.NamespaceableResourceInterface {
+	return &resourceClient{client: d, resource: resource}
+}
+
+type resourceClient struct {
+	client    *dynamicClient
+	resource  schema.GroupVersionResource
+	namespace string
+}
+
+var _ dynamic.NamespaceableResourceInterface = (*resourceClient)(nil)
+
+func (r *resourceClient) Namespace(namespace string) dynamic.ResourceInterface {
+	c := *r
+	c.namespace = namespace
+	return &c
+}
+
+func (r *resourceClient) key(name string) (store.Key, error) {
+	groupVersionKind, err := r.client.restMapper.KindFor(r.resource)
+	if err != nil {
+		return store.Key{}, kerrors.NewNotFound(r.resource.GroupResource(), name)
+	}
+
+	apiVersion, kind := groupVersionKind.ToAPIVersionAndKind()
+	return store.Key{
+		Namespace:  r.namespace,
+		APIVersion: apiVersion,
+		Kind:       kind,
+		Name:       name,
+	}, nil
+}
+
+func (r *resourceClient) Get(ctx context.Context, name string, options metav1.GetOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	key, err := r.key(name)
+	if err != nil {
+		return nil, err
+	}
+
+	object, err := r.client.objectStore.Get(ctx, key)
+	if err != nil {
+		return nil, kerrors.NewNotFound(r.resource.GroupResource(), name)
+	}
+
+	return object, nil
+}
+
+func (r *resourceClient) List(ctx context.Context, options metav1.ListOptions) (*unstructured.UnstructuredList, error) {
+	key, err := r.key("")
+	if err != nil {
+		return nil, err
+	}
+
+	if options.LabelSelector != "" {
+		selector, err := metav1.ParseToLabelSelector(options.LabelSelector)
+		if err != nil {
+			return nil, kerrors.NewBadRequest(err.Error())
+		}
+		key.LabelSelector = selector
+	}
+
+	list, _, err := r.client.objectStore.List(ctx, key)
+	return list, err
+}
+
+// Watch returns a watch which never sends events. Snapshots never change.
+func (r *resourceClient) Watch(ctx context.Context, options metav1.ListOptions) (watch.Interface, error) {
+	return watch.NewFake(), nil
+}
+
+func (r *resourceClient) Create(ctx context.Context, obj *unstructured.Unstructured, options metav1.CreateOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return nil, r.readOnly()
+}
+
+func (r *resourceClient) Update(ctx context.Context, obj *unstructured.Unstructured, options metav1.UpdateOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return nil, r.readOnly()
+}
+
+func (r *resourceClient) UpdateStatus(ctx context.Context, obj *unstructured.Unstructured, options metav1.UpdateOptions) (*unstructured.Unstructured, error) {
+	return nil, r.readOnly()
+}
+
+func (r *resourceClient) Delete(ctx context.Context, name string, options metav1.DeleteOptions, subresources ...string) error {
+	return r.readOnly()
+}
+
+func (r *resourceClient) DeleteCollection(ctx context.Context, options metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	return r.readOnly()
+}
+
+func (r *resourceClient) Patch(ctx context.Context, name string, pt types.PatchType, data []byte, options metav1.PatchOptions, subresources ...string) (*unstructured.Unstructured, error) {
+	return nil, r.readOnly()
+}
+
+func (r *resourceClient) readOnly() error {
+	return kerrors.NewMethodNotSupported(r.resource.GroupResource(), "write")
+}
@@ -0,0 +1,81 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+)
+
+func TestClient_Resource(t *testing.T) {
+	client, err := NewClient(loadManifests(t))
+	require.NoError(t, err)
+
+	gvr, namespaced, err := client.Resource(schema.GroupKind{Group: "apps", Kind: "Deployment"})
+	require.NoError(t, err)
+	assert.Equal(t, schema.GroupVersionResource{Group: "apps", Version: "v1", Resource: "deployments"}, gvr)
+	assert.True(t, namespaced)
+
+	gvr, namespaced, err = client.Resource(schema.GroupKind{Group: "example.com", Kind: "Widget"})
+	require.NoError(t, err)
+	assert.Equal(t, "widgets", gvr.Resource)
+	assert.False(t, namespaced)
+
+	assert.True(t, client.ResourceExists(schema.GroupVersionResource{Version: "v1", Resource: "services"}))
+	assert.False(t, client.ResourceExists(schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "gadgets"}))
+}
+
+func TestClient_NamespaceClient(t *testing.T) {
+	client, err := NewClient(loadManifests(t))
+	require.NoError(t, err)
+
+	assert.Equal(t, "default", client.DefaultNamespace())
+
+	namespaceClient, err := client.NamespaceClient()
+	require.NoError(t, err)
+
+	names, err := namespaceClient.Names()
+	require.NoError(t, err)
+	assert.Equal(t, []string{"default", "web"}, names)
+	assert.True(t, namespaceClient.HasNamespace("web"))
+	assert.False(t, namespaceClient.HasNamespace("kube-system"))
+}
+
+func TestClient_DynamicClient(t *testing.T) {
+	client, err := NewClient(loadManifests(t))
+	require.NoError(t, err)
+
+	dynamicClient, err := client.DynamicClient()
+	require.NoError(t, err)
+
+	ctx := context.Background()
+	services := dynamicClient.Resource(schema.GroupVersionResource{Version: "v1", Resource: "services"})
+
+	object, err := services.Namespace("default").Get(ctx, "web", metav1.GetOptions{})
+	require.NoError(t, err)
+	assert.Equal(t, "web", object.GetName())
+
+	list, err := services.List(ctx, metav1.ListOptions{LabelSelector: "app=web"})
+	require.NoError(t, err)
+	assert.Equal(t, []string{"web"}, names(list.Items))
+
+	_, err = services.Namespace("default").Get(ctx, "missing", metav1.GetOptions{})
+	assert.True(t, kerrors.IsNotFound(err))
+
+	_, err = services.Namespace("default").Create(ctx, &unstructured.Unstructured{}, metav1.CreateOptions{})
+	assert.True(t, kerrors.IsMethodNotSupported(err))
+
+	gadgets := dynamicClient.Resource(schema.GroupVersionResource{Group: "example.com", Version: "v1", Resource: "gadgets"})
+	_, err = gadgets.Get(ctx, "gadget", metav1.GetOptions{})
+	assert.True(t, kerrors.IsNotFound(err))
+}
@@ -0,0 +1,440 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"archive/tar"
+	"bufio"
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"fmt"
+	"io"
+	"io/ioutil"
+	"os"
+	"path"
+	"path/filepath"
+	"sort"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kyaml "k8s.io/apimachinery/pkg/util/yaml"
+	"k8s.io/apimachinery/pkg/version"
+
+	"github.com/vmware-tanzu/octant/internal/gvk"
+)
+
+var gzipMagic = []byte{0x1f, 0x8b}
+
+// builtinKinds are included in the discovery information of manifest directories so
+// Octant's describers can resolve them even if the manifests don't contain them.
+var builtinKinds = []schema.GroupVersionKind{
+	gvk.AppReplicaSet,
+	gvk.ClusterRole,
+	gvk.ClusterRoleBinding,
+	gvk.ConfigMap,
+	gvk.ControllerRevision,
+	gvk.CronJob,
+	gvk.CustomResourceDefinition,
+	gvk.DaemonSet,
+	gvk.Deployment,
+	gvk.Event,
+	gvk.HorizontalPodAutoscaler,
+	gvk.Ingress,
+	gvk.Job,
+	gvk.MutatingWebhookConfiguration,
+	gvk.Namespace,
+	gvk.NetworkPolicy,
+	gvk.Node,
+	gvk.PersistentVolume,
+	gvk.PersistentVolumeClaim,
+	gvk.Pod,
+	gvk.ReplicationController,
+	gvk.Role,
+	gvk.RoleBinding,
+	gvk.Secret,
+	gvk.Service,
+	gvk.ServiceAccount,
+	gvk.StatefulSet,
+	gvk.StorageClass,
+	gvk.ValidatingWebhookConfiguration,
+}
+
+// clusterScopedKinds are the built in kinds which are not namespaced.
+var clusterScopedKinds = map[schema.GroupKind]bool{
+	{Group: "", Kind: "Namespace"}:        true,
+	{Group: "", Kind: "Node"}:             true,
+	{Group: "", Kind: "PersistentVolume"}: true,
+	{Group: "admissionregistration.k8s.io", Kind: "MutatingWebhookConfiguration"}:   true,
+	{Group: "admissionregistration.k8s.io", Kind: "ValidatingWebhookConfiguration"}: true,
+	{Group: "apiextensions.k8s.io", Kind: "CustomResourceDefinition"}:               true,
+	{Group: "apiregistration.k8s.io", Kind: "APIService"}:                           true,
+	{Group: "networking.k8s.io", Kind: "IngressClass"}:                              true,
+	{Group: "node.k8s.io", Kind: "RuntimeClass"}:                                    true,
+	{Group: "policy", Kind: "PodSecurityPolicy"}:                                    true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRole"}:                       true,
+	{Group: "rbac.authorization.k8s.io", Kind: "ClusterRoleBinding"}:                true,
+	{Group: "scheduling.k8s.io", Kind: "PriorityClass"}:                             true,
+	{Group: "storage.k8s.io", Kind: "CSIDriver"}:                                    true,
+	{Group: "storage.k8s.io", Kind: "CSINode"}:                                      true,
+	{Group: "storage.k8s.io", Kind: "StorageClass"}:                                 true,
+	{Group: "storage.k8s.io", Kind: "VolumeAttachment"}:                             true,
+}
+
+// Snapshot is cluster state loaded from a snapshot archive or a directory of manifests.
+type Snapshot struct {
+	// Source is the path the snapshot was loaded from.
+	Source    string
+	Metadata  Metadata
+	Discovery Discovery
+
+	objects []*unstructured.Unstructured
+}
+
+// Objects returns the objects in the snapshot.
+func (s *Snapshot) Objects() []*unstructured.Unstructured {
+	return s.objects
+}
+
+// Load loads a snapshot. The path can be a snapshot archive written by Writer, a
+// directory containing an extracted snapshot or manifests, or a single manifest file.
+// Discovery information is generated from the objects when the source doesn't
+// include it, and namespaced objects without a namespace are put in "default".
+func Load(source string) (*Snapshot, error) {
+	info, err := os.Stat(source)
+	if err != nil {
+		return nil, fmt.Errorf("open snapshot: %w", err)
+	}
+
+	l := &loader{}
+
+	if info.IsDir() {
+		err = l.loadDir(source)
+	} else {
+		err = l.loadFile(source)
+	}
+	if err != nil {
+		return nil, err
+	}
+
+	s := &Snapshot{
+		Source:    source,
+		Metadata:  l.metadata,
+		Discovery: l.discovery,
+		objects:   l.objects,
+	}
+
+	if len(s.Discovery.Resources) == 0 {
+		s.Discovery = discoverObjects(s.objects)
+	}
+	if s.Discovery.ServerVersion == nil {
+		s.Discovery.ServerVersion = &version.Info{GitVersion: "unknown"}
+	}
+
+	s.defaultNamespaces()
+	s.addMissingNamespaces()
+
+	sort.SliceStable(s.objects, func(i, j int) bool {
+		if s.objects[i].GetNamespace() != s.objects[j].GetNamespace() {
+			return s.objects[i].GetNamespace() < s.objects[j].GetNamespace()
+		}
+		return s.objects[i].GetName() < s.objects[j].GetName()
+	})
+
+	return s, nil
+}
+
+// namespaced returns true if objects of a group kind are namespaced.
+func (s *Snapshot) namespaced(gk schema.GroupKind) bool {
+	for _, resourceList := range s.Discovery.Resources {
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil || gv.Group != gk.Group {
+			continue
+		}
+
+		for _, resource := range resourceList.APIResources {
+			if resource.Kind == gk.Kind && !isSubresource(resource) {
+				return resource.Namespaced
+			}
+		}
+	}
+
+	return !clusterScopedKinds[gk]
+}
+
+func (s *Snapshot) defaultNamespaces() {
+	for _, object := range s.objects {
+		if object.GetNamespace() == "" && s.namespaced(object.GroupVersionKind().GroupKind()) {
+			object.SetNamespace(metav1.NamespaceDefault)
+		}
+	}
+}
+
+// addMissingNamespaces adds Namespace objects for namespaces which contain objects,
+// but were not captured. Manifest directories rarely contain the namespaces.
+func (s *Snapshot) addMissingNamespaces() {
+	namespaces := map[string]bool{}
+	for _, object := range s.objects {
+		if object.GroupVersionKind().GroupKind() == gvk.Namespace.GroupKind() {
+			namespaces[object.GetName()] = true
+		}
+	}
+
+	var missing []string
+	for _, object := range s.objects {
+		namespace := object.GetNamespace()
+		if namespace == "" || namespaces[namespace] {
+			continue
+		}
+		namespaces[namespace] = true
+		missing = append(missing, namespace)
+	}
+
+	for _, name := range missing {
+		namespace := &unstructured.Unstructured{}
+		namespace.SetGroupVersionKind(gvk.Namespace)
+		namespace.SetName(name)
+		namespace.Object["status"] = map[string]interface{}{"phase": "Active"}
+		s.objects = append(s.objects, namespace)
+	}
+}
+
+type loader struct {
+	metadata  Metadata
+	discovery Discovery
+	objects   []*unstructured.Unstructured
+}
+
+func (l *loader) loadDir(dir string) error {
+	return filepath.Walk(dir, func(name string, info os.FileInfo, err error) error {
+		if err != nil {
+			return err
+		}
+		if info.IsDir() {
+			if name != dir && strings.HasPrefix(info.Name(), ".") {
+				return filepath.SkipDir
+			}
+			return nil
+		}
+
+		rel, err := filepath.Rel(dir, name)
+		if err != nil {
+			return err
+		}
+
+		data, err := ioutil.ReadFile(name)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", name, err)
+		}
+
+		return l.add(filepath.ToSlash(rel), data)
+	})
+}
+
+func (l *loader) loadFile(name string) error {
+	f, err := os.Open(name)
+	if err != nil {
+		return fmt.Errorf("open snapshot: %w", err)
+	}
+	defer f.Close()
+
+	r := bufio.NewReader(f)
+	magic, err := r.Peek(len(gzipMagic))
+	if err == nil && bytes.Equal(magic, gzipMagic) {
+		return l.loadArchive(r)
+	}
+
+	data, err := ioutil.ReadAll(r)
+	if err != nil {
+		return fmt.Errorf("read %s: %w", name, err)
+	}
+
+	return l.decodeObjects(name, data)
+}
+
+func (l *loader) loadArchive(r io.Reader) error {
+	gz, err := gzip.NewReader(r)
+	if err != nil {
+		return fmt.Errorf("open snapshot archive: %w", err)
+	}
+	defer gz.Close()
+
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			return nil
+		}
+		if err != nil {
+			return fmt.Errorf("read snapshot archive: %w", err)
+		}
+
+		if header.Typeflag != tar.TypeReg {
+			continue
+		}
+
+		data, err := ioutil.ReadAll(tr)
+		if err != nil {
+			return fmt.Errorf("read %s: %w", header.Name, err)
+		}
+
+		if err := l.add(path.Clean(header.Name), data); err != nil {
+			return err
+		}
+	}
+}
+
+// add adds a file from a snapshot. name is relative to the root of the snapshot.
+func (l *loader) add(name string, data []byte) error {
+	switch name {
+	case MetadataFile:
+		if err := json.Unmarshal(data, &l.metadata); err != nil {
+			return fmt.Errorf("decode %s: %w", name, err)
+		}
+		return nil
+	case DiscoveryFile:
+		if err := json.Unmarshal(data, &l.discovery); err != nil {
+			return fmt.Errorf("decode %s: %w", name, err)
+		}
+		return nil
+	}
+
+	switch strings.ToLower(path.Ext(name)) {
+	case ".yaml", ".yml", ".json":
+		return l.decodeObjects(name, data)
+	default:
+		return nil
+	}
+}
+
+// decodeObjects decodes a stream of YAML or JSON documents. Lists are expanded into
+// their items.
+func (l *loader) decodeObjects(name string, data []byte) error {
+	decoder := kyaml.NewYAMLOrJSONDecoder(bytes.NewReader(data), 4096)
+
+	for {
+		object := &unstructured.Unstructured{}
+		if err := decoder.Decode(&object.Object); err != nil {
+			if err == io.EOF {
+				return nil
+			}
+			return fmt.Errorf("decode %s: %w", name, err)
+		}
+
+		if len(object.Object) == 0 {
+			continue
+		}
+
+		// Manifest directories often contain other YAML, e.g. kustomization files.
+		if object.GetKind() == "" || object.GetAPIVersion() == "" ||
+			object.GroupVersionKind().Group == "kustomize.config.k8s.io" {
+			continue
+		}
+
+		if object.IsList() {
+			list, err := object.ToList()
+			if err != nil {
+				return fmt.Errorf("decode %s: %w", name, err)
+			}
+			for i := range list.Items {
+				l.objects = append(l.objects, &list.Items[i])
+			}
+			continue
+		}
+
+		l.objects = append(l.objects, object)
+	}
+}
+
+// discoverObjects creates discovery information for built in kinds and the kinds
+// in objects. CustomResourceDefinitions in objects determine the scope of custom
+// resources.
+func discoverObjects(objects []*unstructured.Unstructured) Discovery {
+	crdScopes := map[schema.GroupKind]bool{}
+	for _, object := range objects {
+		if object.GroupVersionKind().GroupKind() != gvk.CustomResourceDefinition.GroupKind() {
+			continue
+		}
+		group, _, _ := unstructured.NestedString(object.Object, "spec", "group")
+		kind, _, _ := unstructured.NestedString(object.Object, "spec", "names", "kind")
+		scope, _, _ := unstructured.NestedString(object.Object, "spec", "scope")
+		crdScopes[schema.GroupKind{Group: group, Kind: kind}] = scope == "Namespaced"
+	}
+
+	kinds := map[schema.GroupVersionKind]bool{}
+	for _, k := range builtinKinds {
+		kinds[k] = true
+	}
+	for _, object := range objects {
+		kinds[object.GroupVersionKind()] = true
+	}
+
+	resourceLists := map[string]*metav1.APIResourceList{}
+	for k := range kinds {
+		groupVersion := k.GroupVersion().String()
+		resourceList, ok := resourceLists[groupVersion]
+		if !ok {
+			resourceList = &metav1.APIResourceList{GroupVersion: groupVersion}
+			resourceLists[groupVersion] = resourceList
+		}
+
+		namespaced, ok := crdScopes[k.GroupKind()]
+		if !ok {
+			namespaced = !clusterScopedKinds[k.GroupKind()]
+		}
+
+		plural, singular := meta.UnsafeGuessKindToResource(k)
+		resourceList.APIResources = append(resourceList.APIResources, metav1.APIResource{
+			Name:         plural.Resource,
+			SingularName: singular.Resource,
+			Namespaced:   namespaced,
+			Kind:         k.Kind,
+			Verbs:        metav1.Verbs{"get", "list", "watch"},
+		})
+	}
+
+	d := Discovery{Groups: &metav1.APIGroupList{}}
+
+	groups := map[string]*metav1.APIGroup{}
+	for _, resourceList := range resourceLists {
+		sort.Slice(resourceList.APIResources, func(i, j int) bool {
+			return resourceList.APIResources[i].Name < resourceList.APIResources[j].Name
+		})
+		d.Resources = append(d.Resources, resourceList)
+
+		gv, _ := schema.ParseGroupVersion(resourceList.GroupVersion)
+		group, ok := groups[gv.Group]
+		if !ok {
+			group = &metav1.APIGroup{Name: gv.Group}
+			groups[gv.Group] = group
+		}
+		group.Versions = append(group.Versions, metav1.GroupVersionForDiscovery{
+			GroupVersion: gv.String(),
+			Version:      gv.Version,
+		})
+	}
+
+	sort.Slice(d.Resources, func(i, j int) bool {
+		return d.Resources[i].GroupVersion < d.Resources[j].GroupVersion
+	})
+
+	for _, group := range groups {
+		sort.Slice(group.Versions, func(i, j int) bool {
+			return group.Versions[i].Version < group.Versions[j].Version
+		})
+		group.PreferredVersion = group.Versions[0]
+		d.Groups.Groups = append(d.Groups.Groups, *group)
+	}
+
+	sort.Slice(d.Groups.Groups, func(i, j int) bool {
+		return d.Groups.Groups[i].Name < d.Groups.Groups[j].Name
+	})
+
+	return d
+}
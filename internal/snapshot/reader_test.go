@@ -0,0 +1,136 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+
+	"github.com/vmware-tanzu/octant/internal/testutil"
+)
+
+func TestLoad_manifests(t *testing.T) {
+	s, err := Load(filepath.Join("testdata", "manifests"))
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		"/Namespace/default",
+		"/Namespace/web",
+		"apiextensions.k8s.io/CustomResourceDefinition/widgets.example.com",
+		"apps/Deployment/default/web",
+		"/Service/default/web",
+		"/ConfigMap/web/web-config",
+		"rbac.authorization.k8s.io/ClusterRole/web-reader",
+		"example.com/Widget/sprocket",
+	}, objectKeys(s))
+
+	assert.Equal(t, "unknown", s.Discovery.ServerVersion.GitVersion)
+
+	resource, ok := findResource(s.Discovery, schema.GroupVersionKind{Group: "example.com", Version: "v1", Kind: "Widget"})
+	require.True(t, ok)
+	assert.Equal(t, "widgets", resource.Name)
+	assert.False(t, resource.Namespaced)
+
+	resource, ok = findResource(s.Discovery, schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"})
+	require.True(t, ok)
+	assert.True(t, resource.Namespaced)
+}
+
+func TestLoad_archive(t *testing.T) {
+	s, err := Load(writeTestArchive(t))
+	require.NoError(t, err)
+
+	assert.ElementsMatch(t, []string{
+		"/Namespace/namespace",
+		"/Pod/namespace/pod",
+	}, objectKeys(s))
+	assert.Equal(t, "kind", s.Metadata.Context)
+	assert.Equal(t, "v1.19.3", s.Discovery.ServerVersion.GitVersion)
+
+	_, ok := findResource(s.Discovery, schema.GroupVersionKind{Version: "v1", Kind: "Pod"})
+	assert.True(t, ok)
+	_, ok = findResource(s.Discovery, schema.GroupVersionKind{Group: "apps", Version: "v1", Kind: "Deployment"})
+	assert.False(t, ok, "discovery should come from the archive")
+}
+
+func TestLoad_missing(t *testing.T) {
+	_, err := Load(filepath.Join("testdata", "missing"))
+	require.Error(t, err)
+}
+
+func objectKeys(s *Snapshot) []string {
+	var out []string
+	for _, object := range s.Objects() {
+		key := object.GroupVersionKind().Group + "/" + object.GetKind()
+		if object.GetNamespace() != "" {
+			key += "/" + object.GetNamespace()
+		}
+		out = append(out, key+"/"+object.GetName())
+	}
+	return out
+}
+
+func findResource(discovery Discovery, groupVersionKind schema.GroupVersionKind) (metav1.APIResource, bool) {
+	for _, resourceList := range discovery.Resources {
+		if resourceList.GroupVersion != groupVersionKind.GroupVersion().String() {
+			continue
+		}
+		for _, resource := range resourceList.APIResources {
+			if resource.Kind == groupVersionKind.Kind {
+				return resource, true
+			}
+		}
+	}
+	return metav1.APIResource{}, false
+}
+
+// writeTestArchive writes a snapshot archive containing a namespace and a pod and
+// returns its path.
+func writeTestArchive(t *testing.T) string {
+	var buf bytes.Buffer
+	gz := gzip.NewWriter(&buf)
+	tw := tar.NewWriter(gz)
+	archive := &archiveWriter{tw: tw}
+
+	require.NoError(t, archive.addJSON(MetadataFile, Metadata{FormatVersion: FormatVersion, Context: "kind"}))
+	require.NoError(t, archive.addJSON(DiscoveryFile, Discovery{
+		ServerVersion: &version.Info{GitVersion: "v1.19.3"},
+		Resources: []*metav1.APIResourceList{
+			{
+				GroupVersion: "v1",
+				APIResources: []metav1.APIResource{
+					{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: []string{"get", "list"}},
+					{Name: "namespaces", Kind: "Namespace", Verbs: []string{"get", "list"}},
+				},
+			},
+		},
+	}))
+	pods, err := marshalObjects([]unstructured.Unstructured{*testutil.ToUnstructured(t, testutil.CreatePod("pod"))})
+	require.NoError(t, err)
+	require.NoError(t, archive.add("objects/core/v1/Pod.yaml", pods))
+	require.NoError(t, tw.Close())
+	require.NoError(t, gz.Close())
+
+	dir, err := ioutil.TempDir("", "snapshot")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	name := filepath.Join(dir, "snapshot.tar.gz")
+	require.NoError(t, ioutil.WriteFile(name, buf.Bytes(), 0600))
+	return name
+}
@@ -0,0 +1,95 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package snapshot captures the state of a cluster in an archive which can be
+// inspected without access to the cluster.
+//
+// A snapshot is a gzipped tar archive with the following layout:
+//
+//	metadata.json                          information about the capture
+//	discovery.json                         server version, API groups and resources
+//	events.yaml                            recent events
+//	objects/<group>/<version>/<Kind>.yaml  objects of a kind, one YAML document each
+//
+// Core objects are stored under the "core" group.
+package snapshot
+
+import (
+	"path"
+	"strings"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/version"
+)
+
+const (
+	// FormatVersion is the version of the snapshot layout.
+	FormatVersion = 1
+
+	// MetadataFile is the name of the snapshot metadata file.
+	MetadataFile = "metadata.json"
+	// DiscoveryFile is the name of the discovery metadata file.
+	DiscoveryFile = "discovery.json"
+	// EventsFile is the name of the events file.
+	EventsFile = "events.yaml"
+	// ObjectsDir is the directory containing objects.
+	ObjectsDir = "objects"
+
+	// RedactedAnnotation is set on Secrets whose data was removed.
+	RedactedAnnotation = "octant.dev/redacted"
+
+	coreGroup = "core"
+)
+
+// Metadata describes a snapshot.
+type Metadata struct {
+	FormatVersion   int       `json:"formatVersion"`
+	CreatedAt       time.Time `json:"createdAt"`
+	Context         string    `json:"context,omitempty"`
+	Namespaces      []string  `json:"namespaces,omitempty"`
+	SecretsRedacted bool      `json:"secretsRedacted"`
+	ObjectCount     int       `json:"objectCount"`
+	EventCount      int       `json:"eventCount"`
+	// Errors are resources which could not be captured.
+	Errors []ResourceError `json:"errors,omitempty"`
+}
+
+// ResourceError is an error encountered while capturing a resource.
+type ResourceError struct {
+	APIVersion string `json:"apiVersion"`
+	Kind       string `json:"kind"`
+	Namespace  string `json:"namespace,omitempty"`
+	Message    string `json:"message"`
+}
+
+// Discovery is the discovery information of the captured cluster.
+type Discovery struct {
+	ServerVersion *version.Info             `json:"serverVersion,omitempty"`
+	Groups        *metav1.APIGroupList      `json:"groups,omitempty"`
+	Resources     []*metav1.APIResourceList `json:"resources,omitempty"`
+}
+
+// ObjectPath returns the archive path for objects of a group version kind.
+func ObjectPath(gvk schema.GroupVersionKind) string {
+	group := gvk.Group
+	if group == "" {
+		group = coreGroup
+	}
+
+	return path.Join(ObjectsDir, group, gvk.Version, gvk.Kind+".yaml")
+}
+
+// isEvent returns true if the resource is one of the event resources. Events are
+// stored separately from other objects.
+func isEvent(gvk schema.GroupVersionKind) bool {
+	return gvk.Kind == "Event" && (gvk.Group == "" || gvk.Group == "events.k8s.io")
+}
+
+// isSubresource returns true if an API resource is a subresource, e.g. pods/log.
+func isSubresource(resource metav1.APIResource) bool {
+	return strings.Contains(resource.Name, "/")
+}
@@ -0,0 +1,165 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"context"
+	"fmt"
+
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/api/meta"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	kcache "k8s.io/client-go/tools/cache"
+
+	"github.com/vmware-tanzu/octant/internal/cluster"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+// Store is a read-only store.Store backed by a snapshot. Objects are matched by
+// group and kind, so a request for any version of a kind returns the captured version.
+type Store struct {
+	snapshot *Snapshot
+}
+
+var _ store.Store = (*Store)(nil)
+
+// NewStore creates an instance of Store.
+func NewStore(s *Snapshot) *Store {
+	return &Store{snapshot: s}
+}
+
+// List lists objects matching the key's kind, namespace and selectors.
+func (s *Store) List(ctx context.Context, key store.Key) (*unstructured.UnstructuredList, bool, error) {
+	selector, err := keySelector(key)
+	if err != nil {
+		return nil, false, err
+	}
+
+	list := &unstructured.UnstructuredList{}
+	list.SetAPIVersion(key.APIVersion)
+	list.SetKind(key.Kind + "List")
+
+	for _, object := range s.matching(key) {
+		if !selector.Matches(labels.Set(object.GetLabels())) {
+			continue
+		}
+		list.Items = append(list.Items, *object.DeepCopy())
+	}
+
+	return list, false, nil
+}
+
+// Get gets the object matching the key. It returns a not found error if the object
+// is not in the snapshot.
+func (s *Store) Get(ctx context.Context, key store.Key) (*unstructured.Unstructured, error) {
+	for _, object := range s.matching(key) {
+		if object.GetName() == key.Name {
+			return object.DeepCopy(), nil
+		}
+	}
+
+	resource, _ := meta.UnsafeGuessKindToResource(key.GroupVersionKind())
+	return nil, kerrors.NewNotFound(resource.GroupResource(), key.Name)
+}
+
+// Watch calls the handler's OnAdd for the objects matching the key. Snapshots never
+// change, so there are no further events.
+func (s *Store) Watch(ctx context.Context, key store.Key, handler kcache.ResourceEventHandler) error {
+	if handler == nil {
+		return nil
+	}
+
+	list, _, err := s.List(ctx, key)
+	if err != nil {
+		return err
+	}
+
+	for i := range list.Items {
+		handler.OnAdd(&list.Items[i])
+	}
+
+	return nil
+}
+
+// Unwatch does nothing.
+func (s *Store) Unwatch(ctx context.Context, groupVersionKinds ...schema.GroupVersionKind) error {
+	return nil
+}
+
+// UpdateClusterClient does nothing.
+func (s *Store) UpdateClusterClient(ctx context.Context, client cluster.ClientInterface) error {
+	return nil
+}
+
+// RegisterOnUpdate does nothing. The store is never updated.
+func (s *Store) RegisterOnUpdate(fn store.UpdateFn) {
+}
+
+// IsLoading returns false. Snapshots are loaded before the store is created.
+func (s *Store) IsLoading(ctx context.Context, key store.Key) bool {
+	return false
+}
+
+// Delete returns store.ErrReadOnly.
+func (s *Store) Delete(ctx context.Context, key store.Key) error {
+	return store.ErrReadOnly
+}
+
+// Update returns store.ErrReadOnly.
+func (s *Store) Update(ctx context.Context, key store.Key, updater func(*unstructured.Unstructured) error) error {
+	return store.ErrReadOnly
+}
+
+// Create returns store.ErrReadOnly.
+func (s *Store) Create(ctx context.Context, object *unstructured.Unstructured) error {
+	return store.ErrReadOnly
+}
+
+// CreateOrUpdateFromYAML returns store.ErrReadOnly.
+func (s *Store) CreateOrUpdateFromYAML(ctx context.Context, namespace, input string) ([]string, error) {
+	return nil, store.ErrReadOnly
+}
+
+// matching returns the objects with the key's group, kind and namespace.
+func (s *Store) matching(key store.Key) []*unstructured.Unstructured {
+	gk := key.GroupVersionKind().GroupKind()
+
+	var objects []*unstructured.Unstructured
+	for _, object := range s.snapshot.objects {
+		if object.GroupVersionKind().GroupKind() != gk {
+			continue
+		}
+		if key.Namespace != "" && object.GetNamespace() != key.Namespace {
+			continue
+		}
+		objects = append(objects, object)
+	}
+
+	return objects
+}
+
+func keySelector(key store.Key) (labels.Selector, error) {
+	if key.Selector != nil && key.LabelSelector != nil {
+		return nil, fmt.Errorf("must provide only one of Key.Selector and Key.LabelSelector")
+	}
+
+	if key.Selector != nil {
+		return labels.SelectorFromSet(*key.Selector), nil
+	}
+
+	if key.LabelSelector != nil {
+		selector, err := metav1.LabelSelectorAsSelector(key.LabelSelector)
+		if err != nil {
+			return nil, fmt.Errorf("invalid label selector: %w", err)
+		}
+		return selector, nil
+	}
+
+	return labels.Everything(), nil
+}
@@ -0,0 +1,145 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"context"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	kerrors "k8s.io/apimachinery/pkg/api/errors"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/labels"
+	kcache "k8s.io/client-go/tools/cache"
+
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+func TestStore_List(t *testing.T) {
+	s := NewStore(loadManifests(t))
+	ctx := context.Background()
+
+	tests := []struct {
+		name     string
+		key      store.Key
+		expected []string
+		isErr    bool
+	}{
+		{
+			name:     "kind in namespace",
+			key:      store.Key{Namespace: "default", APIVersion: "v1", Kind: "Service"},
+			expected: []string{"web"},
+		},
+		{
+			name:     "other version of kind",
+			key:      store.Key{Namespace: "default", APIVersion: "apps/v1beta2", Kind: "Deployment"},
+			expected: []string{"web"},
+		},
+		{
+			name:     "all namespaces",
+			key:      store.Key{APIVersion: "v1", Kind: "Namespace"},
+			expected: []string{"default", "web"},
+		},
+		{
+			name: "other namespace",
+			key:  store.Key{Namespace: "web", APIVersion: "v1", Kind: "Service"},
+		},
+		{
+			name:     "selector",
+			key:      store.Key{Namespace: "default", APIVersion: "v1", Kind: "Service", Selector: &labels.Set{"app": "web"}},
+			expected: []string{"web"},
+		},
+		{
+			name: "label selector",
+			key: store.Key{Namespace: "default", APIVersion: "v1", Kind: "Service", LabelSelector: &metav1.LabelSelector{
+				MatchLabels: map[string]string{"app": "other"},
+			}},
+		},
+		{
+			name: "selector and label selector",
+			key: store.Key{
+				APIVersion:    "v1",
+				Kind:          "Service",
+				Selector:      &labels.Set{"app": "web"},
+				LabelSelector: &metav1.LabelSelector{},
+			},
+			isErr: true,
+		},
+	}
+
+	for _, test := range tests {
+		t.Run(test.name, func(t *testing.T) {
+			list, loading, err := s.List(ctx, test.key)
+			if test.isErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			assert.False(t, loading)
+			assert.Equal(t, test.expected, names(list.Items))
+		})
+	}
+}
+
+func TestStore_Get(t *testing.T) {
+	s := NewStore(loadManifests(t))
+	ctx := context.Background()
+
+	object, err := s.Get(ctx, store.Key{Namespace: "default", APIVersion: "apps/v1", Kind: "Deployment", Name: "web"})
+	require.NoError(t, err)
+	assert.Equal(t, "web", object.GetName())
+
+	object.SetName("changed")
+	object, err = s.Get(ctx, store.Key{Namespace: "default", APIVersion: "apps/v1", Kind: "Deployment", Name: "web"})
+	require.NoError(t, err)
+	assert.Equal(t, "web", object.GetName(), "objects returned by the store should be copies")
+
+	_, err = s.Get(ctx, store.Key{Namespace: "default", APIVersion: "apps/v1", Kind: "Deployment", Name: "missing"})
+	assert.True(t, kerrors.IsNotFound(err))
+}
+
+func TestStore_Watch(t *testing.T) {
+	s := NewStore(loadManifests(t))
+
+	var added []string
+	handler := kcache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			added = append(added, obj.(*unstructured.Unstructured).GetName())
+		},
+	}
+
+	require.NoError(t, s.Watch(context.Background(), store.Key{APIVersion: "v1", Kind: "Namespace"}, handler))
+	assert.Equal(t, []string{"default", "web"}, added)
+}
+
+func TestStore_readOnly(t *testing.T) {
+	s := NewStore(loadManifests(t))
+	ctx := context.Background()
+	key := store.Key{Namespace: "default", APIVersion: "v1", Kind: "Service", Name: "web"}
+
+	assert.Equal(t, store.ErrReadOnly, s.Create(ctx, &unstructured.Unstructured{}))
+	assert.Equal(t, store.ErrReadOnly, s.Delete(ctx, key))
+	assert.Equal(t, store.ErrReadOnly, s.Update(ctx, key, func(*unstructured.Unstructured) error { return nil }))
+	_, err := s.CreateOrUpdateFromYAML(ctx, "default", "")
+	assert.Equal(t, store.ErrReadOnly, err)
+}
+
+func loadManifests(t *testing.T) *Snapshot {
+	s, err := Load(filepath.Join("testdata", "manifests"))
+	require.NoError(t, err)
+	return s
+}
+
+func names(objects []unstructured.Unstructured) []string {
+	var out []string
+	for i := range objects {
+		out = append(out, objects[i].GetName())
+	}
+	return out
+}
@@ -0,0 +1,378 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"fmt"
+	"io"
+	"sort"
+	"time"
+
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/client-go/discovery"
+	"k8s.io/client-go/dynamic"
+	sigyaml "sigs.k8s.io/yaml"
+
+	"github.com/vmware-tanzu/octant/internal/cluster"
+	"github.com/vmware-tanzu/octant/internal/log"
+)
+
+const (
+	lastAppliedAnnotation = "kubectl.kubernetes.io/last-applied-configuration"
+)
+
+// Options are options for writing a snapshot.
+type Options struct {
+	// Context is the name of the kube config context being captured.
+	Context string
+	// Namespaces limits namespaced objects to these namespaces. All namespaces
+	// are captured if it is empty.
+	Namespaces []string
+	// RedactSecrets removes the data from Secrets.
+	RedactSecrets bool
+	// EventWindow limits events to ones which occurred within the window. All
+	// events are captured if it is zero.
+	EventWindow time.Duration
+}
+
+// Writer writes cluster snapshots. Objects are listed once with the dynamic client,
+// so no informers are started.
+type Writer struct {
+	client cluster.ClientInterface
+	now    func() time.Time
+}
+
+// NewWriter creates an instance of Writer.
+func NewWriter(client cluster.ClientInterface) *Writer {
+	return &Writer{
+		client: client,
+		now:    time.Now,
+	}
+}
+
+// Write walks every listable API resource and writes the objects, recent events
+// and discovery information to out as a gzipped tar archive. Resources which can't
+// be listed are recorded in the returned metadata rather than failing the snapshot.
+func (w *Writer) Write(ctx context.Context, out io.Writer, options Options) (*Metadata, error) {
+	logger := log.From(ctx)
+	now := w.now().UTC()
+
+	discoveryClient, err := w.client.DiscoveryClient()
+	if err != nil {
+		return nil, fmt.Errorf("get discovery client: %w", err)
+	}
+
+	d, err := captureDiscovery(discoveryClient)
+	if err != nil {
+		return nil, err
+	}
+
+	dynamicClient, err := w.client.DynamicClient()
+	if err != nil {
+		return nil, fmt.Errorf("get dynamic client: %w", err)
+	}
+
+	metadata := &Metadata{
+		FormatVersion:   FormatVersion,
+		CreatedAt:       now,
+		Context:         options.Context,
+		Namespaces:      options.Namespaces,
+		SecretsRedacted: options.RedactSecrets,
+	}
+
+	gz := gzip.NewWriter(out)
+	tw := tar.NewWriter(gz)
+	archive := &archiveWriter{tw: tw, modTime: now}
+
+	var events []unstructured.Unstructured
+
+	for _, gvk := range listableKinds(d) {
+		objects, errs := list(ctx, dynamicClient, gvk, options.Namespaces)
+		metadata.Errors = append(metadata.Errors, errs...)
+
+		if isEvent(gvk.gvk) {
+			// events.k8s.io events are the same objects as core events.
+			if gvk.gvk.Group == "" {
+				events = append(events, objects...)
+			}
+			continue
+		}
+
+		if len(objects) == 0 {
+			continue
+		}
+
+		if options.RedactSecrets && gvk.gvk.Group == "" && gvk.gvk.Kind == "Secret" {
+			for i := range objects {
+				redactSecret(&objects[i])
+			}
+		}
+
+		sortObjects(objects)
+
+		data, err := marshalObjects(objects)
+		if err != nil {
+			return nil, err
+		}
+
+		if err := archive.add(ObjectPath(gvk.gvk), data); err != nil {
+			return nil, err
+		}
+		metadata.ObjectCount += len(objects)
+
+		logger.With("apiVersion", gvk.gvk.GroupVersion().String(), "kind", gvk.gvk.Kind, "count", len(objects)).
+			Debugf("captured objects")
+	}
+
+	events = recentEvents(events, now, options.EventWindow)
+	metadata.EventCount = len(events)
+	data, err := marshalObjects(events)
+	if err != nil {
+		return nil, err
+	}
+	if err := archive.add(EventsFile, data); err != nil {
+		return nil, err
+	}
+
+	if err := archive.addJSON(DiscoveryFile, d); err != nil {
+		return nil, err
+	}
+
+	if err := archive.addJSON(MetadataFile, metadata); err != nil {
+		return nil, err
+	}
+
+	if err := tw.Close(); err != nil {
+		return nil, fmt.Errorf("close archive: %w", err)
+	}
+
+	if err := gz.Close(); err != nil {
+		return nil, fmt.Errorf("close archive: %w", err)
+	}
+
+	return metadata, nil
+}
+
+func list(ctx context.Context, dynamicClient dynamic.Interface, lk listableKind, namespaces []string) ([]unstructured.Unstructured, []ResourceError) {
+	apiVersion, kind := lk.gvk.ToAPIVersionAndKind()
+	resourceClient := dynamicClient.Resource(lk.gvk.GroupVersion().WithResource(lk.resource))
+
+	if !lk.namespaced || len(namespaces) == 0 {
+		namespaces = []string{""}
+	}
+
+	var objects []unstructured.Unstructured
+	var errs []ResourceError
+
+	for _, namespace := range namespaces {
+		list, err := resourceClient.Namespace(namespace).List(ctx, metav1.ListOptions{})
+		if err != nil {
+			errs = append(errs, ResourceError{
+				APIVersion: apiVersion,
+				Kind:       kind,
+				Namespace:  namespace,
+				Message:    err.Error(),
+			})
+			continue
+		}
+
+		objects = append(objects, list.Items...)
+	}
+
+	return objects, errs
+}
+
+func captureDiscovery(discoveryClient discovery.DiscoveryInterface) (*Discovery, error) {
+	serverVersion, err := discoveryClient.ServerVersion()
+	if err != nil {
+		return nil, fmt.Errorf("get server version: %w", err)
+	}
+
+	groups, err := discoveryClient.ServerGroups()
+	if err != nil {
+		return nil, fmt.Errorf("get server groups: %w", err)
+	}
+
+	// Partial results are returned when some groups can't be discovered, e.g. an
+	// unavailable metrics server.
+	resources, err := discovery.ServerPreferredResources(discoveryClient)
+	if err != nil && !discovery.IsGroupDiscoveryFailedError(err) {
+		return nil, fmt.Errorf("get server resources: %w", err)
+	}
+
+	return &Discovery{
+		ServerVersion: serverVersion,
+		Groups:        groups,
+		Resources:     resources,
+	}, nil
+}
+
+type listableKind struct {
+	gvk        schema.GroupVersionKind
+	resource   string
+	namespaced bool
+}
+
+// listableKinds returns the kinds which can be listed sorted by group, version and kind.
+func listableKinds(d *Discovery) []listableKind {
+	var kinds []listableKind
+
+	for _, resourceList := range d.Resources {
+		if resourceList == nil {
+			continue
+		}
+
+		gv, err := schema.ParseGroupVersion(resourceList.GroupVersion)
+		if err != nil {
+			continue
+		}
+
+		for _, resource := range resourceList.APIResources {
+			if isSubresource(resource) || !hasVerb(resource.Verbs, "list") {
+				continue
+			}
+
+			kinds = append(kinds, listableKind{
+				gvk:        gv.WithKind(resource.Kind),
+				resource:   resource.Name,
+				namespaced: resource.Namespaced,
+			})
+		}
+	}
+
+	sort.Slice(kinds, func(i, j int) bool {
+		return ObjectPath(kinds[i].gvk) < ObjectPath(kinds[j].gvk)
+	})
+
+	return kinds
+}
+
+func hasVerb(verbs []string, verb string) bool {
+	for _, v := range verbs {
+		if v == verb {
+			return true
+		}
+	}
+	return false
+}
+
+// redactSecret removes the values from a Secret. The keys are kept so the shape of
+// the Secret is still visible.
+func redactSecret(object *unstructured.Unstructured) {
+	data, _, _ := unstructured.NestedMap(object.Object, "data")
+	for key := range data {
+		data[key] = ""
+	}
+	if data != nil {
+		_ = unstructured.SetNestedMap(object.Object, data, "data")
+	}
+	unstructured.RemoveNestedField(object.Object, "stringData")
+
+	annotations := object.GetAnnotations()
+	if annotations == nil {
+		annotations = map[string]string{}
+	}
+	delete(annotations, lastAppliedAnnotation)
+	annotations[RedactedAnnotation] = "true"
+	object.SetAnnotations(annotations)
+}
+
+// recentEvents returns the events which occurred within window of now, oldest first.
+func recentEvents(events []unstructured.Unstructured, now time.Time, window time.Duration) []unstructured.Unstructured {
+	var out []unstructured.Unstructured
+	for i := range events {
+		if window > 0 && eventTime(&events[i]).Before(now.Add(-window)) {
+			continue
+		}
+		out = append(out, events[i])
+	}
+
+	sort.SliceStable(out, func(i, j int) bool {
+		return eventTime(&out[i]).Before(eventTime(&out[j]))
+	})
+
+	return out
+}
+
+// eventTime returns the last time an event occurred.
+func eventTime(event *unstructured.Unstructured) time.Time {
+	for _, field := range []string{"lastTimestamp", "eventTime", "firstTimestamp"} {
+		s, _, _ := unstructured.NestedString(event.Object, field)
+		if s == "" {
+			continue
+		}
+		if t, err := time.Parse(time.RFC3339, s); err == nil {
+			return t
+		}
+	}
+
+	return event.GetCreationTimestamp().Time
+}
+
+func sortObjects(objects []unstructured.Unstructured) {
+	sort.Slice(objects, func(i, j int) bool {
+		if objects[i].GetNamespace() != objects[j].GetNamespace() {
+			return objects[i].GetNamespace() < objects[j].GetNamespace()
+		}
+		return objects[i].GetName() < objects[j].GetName()
+	})
+}
+
+// marshalObjects converts objects to a multi document YAML stream.
+func marshalObjects(objects []unstructured.Unstructured) ([]byte, error) {
+	var buf bytes.Buffer
+	for i := range objects {
+		data, err := sigyaml.Marshal(objects[i].Object)
+		if err != nil {
+			return nil, fmt.Errorf("marshal %s %s: %w", objects[i].GetKind(), objects[i].GetName(), err)
+		}
+
+		buf.WriteString("---\n")
+		buf.Write(data)
+	}
+
+	return buf.Bytes(), nil
+}
+
+type archiveWriter struct {
+	tw      *tar.Writer
+	modTime time.Time
+}
+
+func (a *archiveWriter) add(name string, data []byte) error {
+	header := &tar.Header{
+		Name:    name,
+		Mode:    0644,
+		Size:    int64(len(data)),
+		ModTime: a.modTime,
+	}
+
+	if err := a.tw.WriteHeader(header); err != nil {
+		return fmt.Errorf("write %s header: %w", name, err)
+	}
+
+	if _, err := a.tw.Write(data); err != nil {
+		return fmt.Errorf("write %s: %w", name, err)
+	}
+
+	return nil
+}
+
+func (a *archiveWriter) addJSON(name string, v interface{}) error {
+	data, err := json.MarshalIndent(v, "", "  ")
+	if err != nil {
+		return fmt.Errorf("marshal %s: %w", name, err)
+	}
+
+	return a.add(name, data)
+}
@@ -0,0 +1,222 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package snapshot
+
+import (
+	"archive/tar"
+	"bytes"
+	"compress/gzip"
+	"context"
+	"encoding/json"
+	"errors"
+	"io"
+	"io/ioutil"
+	"testing"
+	"time"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	corev1 "k8s.io/api/core/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/version"
+	fakediscovery "k8s.io/client-go/discovery/fake"
+	dynamicfake "k8s.io/client-go/dynamic/fake"
+	"k8s.io/client-go/kubernetes/scheme"
+	clienttesting "k8s.io/client-go/testing"
+	sigyaml "sigs.k8s.io/yaml"
+
+	clusterFake "github.com/vmware-tanzu/octant/internal/cluster/fake"
+	"github.com/vmware-tanzu/octant/internal/testutil"
+)
+
+func TestWriter_Write(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	now := time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC)
+
+	discoveryClient := &fakediscovery.FakeDiscovery{
+		Fake: &clienttesting.Fake{
+			Resources: []*metav1.APIResourceList{
+				{
+					GroupVersion: "v1",
+					APIResources: []metav1.APIResource{
+						{Name: "pods", Kind: "Pod", Namespaced: true, Verbs: []string{"get", "list"}},
+						{Name: "pods/log", Kind: "Pod", Namespaced: true, Verbs: []string{"get"}},
+						{Name: "secrets", Kind: "Secret", Namespaced: true, Verbs: []string{"get", "list"}},
+						{Name: "events", Kind: "Event", Namespaced: true, Verbs: []string{"get", "list"}},
+						{Name: "namespaces", Kind: "Namespace", Verbs: []string{"get", "list"}},
+						{Name: "bindings", Kind: "Binding", Namespaced: true, Verbs: []string{"create"}},
+					},
+				},
+				{
+					GroupVersion: "apps/v1",
+					APIResources: []metav1.APIResource{
+						{Name: "deployments", Kind: "Deployment", Namespaced: true, Verbs: []string{"get", "list"}},
+					},
+				},
+			},
+		},
+		FakedServerVersion: &version.Info{GitVersion: "v1.19.3"},
+	}
+
+	client := clusterFake.NewMockClientInterface(controller)
+	client.EXPECT().DiscoveryClient().Return(discoveryClient, nil)
+
+	secret := testutil.CreateSecret("secret")
+	secret.Data = map[string][]byte{"password": []byte("hunter2")}
+
+	recentEvent := testutil.CreateEvent("recent")
+	recentEvent.LastTimestamp = metav1.NewTime(now.Add(-10 * time.Minute))
+	oldEvent := testutil.CreateEvent("old")
+	oldEvent.LastTimestamp = metav1.NewTime(now.Add(-2 * time.Hour))
+
+	// Pods outside of the captured namespaces are skipped.
+	otherPod := testutil.CreatePod("other")
+	otherPod.Namespace = "other"
+
+	objects := []runtime.Object{
+		testutil.CreatePod("pod-b"),
+		testutil.CreatePod("pod-a"),
+		otherPod,
+		secret,
+		recentEvent,
+		oldEvent,
+		testutil.CreateNamespace("namespace"),
+	}
+
+	for i := range objects {
+		objects[i] = testutil.ToUnstructured(t, objects[i])
+	}
+
+	dynamicClient := dynamicfake.NewSimpleDynamicClient(scheme.Scheme, objects...)
+	dynamicClient.PrependReactor("list", "deployments", func(clienttesting.Action) (bool, runtime.Object, error) {
+		return true, nil, errors.New("forbidden")
+	})
+	client.EXPECT().DynamicClient().Return(dynamicClient, nil)
+
+	w := NewWriter(client)
+	w.now = func() time.Time { return now }
+
+	var buf bytes.Buffer
+	metadata, err := w.Write(context.Background(), &buf, Options{
+		Context:       "kind",
+		Namespaces:    []string{"namespace"},
+		RedactSecrets: true,
+		EventWindow:   time.Hour,
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, 4, metadata.ObjectCount)
+	assert.Equal(t, 1, metadata.EventCount)
+	assert.Equal(t, []ResourceError{
+		{APIVersion: "apps/v1", Kind: "Deployment", Namespace: "namespace", Message: "forbidden"},
+	}, metadata.Errors)
+
+	files := readArchive(t, &buf)
+
+	var names []string
+	for name := range files {
+		names = append(names, name)
+	}
+	assert.ElementsMatch(t, []string{
+		"metadata.json",
+		"discovery.json",
+		"events.yaml",
+		"objects/core/v1/Namespace.yaml",
+		"objects/core/v1/Pod.yaml",
+		"objects/core/v1/Secret.yaml",
+	}, names)
+
+	pods := decodeObjects(t, files["objects/core/v1/Pod.yaml"])
+	require.Len(t, pods, 2)
+	assert.Equal(t, "pod-a", pods[0].GetName())
+	assert.Equal(t, "pod-b", pods[1].GetName())
+
+	secrets := decodeObjects(t, files["objects/core/v1/Secret.yaml"])
+	require.Len(t, secrets, 1)
+	data, _, err := unstructured.NestedStringMap(secrets[0].Object, "data")
+	require.NoError(t, err)
+	assert.Equal(t, map[string]string{"password": ""}, data)
+	assert.Equal(t, "true", secrets[0].GetAnnotations()[RedactedAnnotation])
+
+	events := decodeObjects(t, files["events.yaml"])
+	require.Len(t, events, 1)
+	assert.Equal(t, "recent", events[0].GetName())
+
+	var gotMetadata Metadata
+	require.NoError(t, json.Unmarshal(files["metadata.json"], &gotMetadata))
+	assert.Equal(t, "kind", gotMetadata.Context)
+	assert.True(t, gotMetadata.SecretsRedacted)
+	assert.True(t, now.Equal(gotMetadata.CreatedAt))
+
+	var gotDiscovery Discovery
+	require.NoError(t, json.Unmarshal(files["discovery.json"], &gotDiscovery))
+	assert.Equal(t, "v1.19.3", gotDiscovery.ServerVersion.GitVersion)
+	assert.Len(t, gotDiscovery.Resources, 2)
+}
+
+func Test_redactSecret(t *testing.T) {
+	secret := testutil.CreateSecret("secret")
+	secret.Data = map[string][]byte{"token": []byte("secret")}
+	secret.StringData = map[string]string{"password": "secret"}
+	secret.Annotations = map[string]string{lastAppliedAnnotation: `{"data":{"token":"c2VjcmV0"}}`}
+
+	object := testutil.ToUnstructured(t, secret)
+	redactSecret(object)
+
+	got := &corev1.Secret{}
+	require.NoError(t, runtime.DefaultUnstructuredConverter.FromUnstructured(object.Object, got))
+	assert.Equal(t, []string{"token"}, keys(got.Data))
+	assert.Empty(t, got.Data["token"])
+	assert.Empty(t, got.StringData)
+	assert.Equal(t, map[string]string{RedactedAnnotation: "true"}, got.Annotations)
+}
+
+func readArchive(t *testing.T, r io.Reader) map[string][]byte {
+	gz, err := gzip.NewReader(r)
+	require.NoError(t, err)
+
+	files := map[string][]byte{}
+	tr := tar.NewReader(gz)
+	for {
+		header, err := tr.Next()
+		if err == io.EOF {
+			break
+		}
+		require.NoError(t, err)
+
+		data, err := ioutil.ReadAll(tr)
+		require.NoError(t, err)
+		files[header.Name] = data
+	}
+
+	return files
+}
+
+func decodeObjects(t *testing.T, data []byte) []*unstructured.Unstructured {
+	var objects []*unstructured.Unstructured
+	for _, doc := range bytes.Split(data, []byte("---\n")) {
+		if len(bytes.TrimSpace(doc)) == 0 {
+			continue
+		}
+		object := &unstructured.Unstructured{}
+		require.NoError(t, sigyaml.Unmarshal(doc, &object.Object))
+		objects = append(objects, object)
+	}
+	return objects
+}
+
+func keys(data map[string][]byte) []string {
+	var out []string
+	for key := range data {
+		out = append(out, key)
+	}
+	return out
+}
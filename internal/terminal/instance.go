@@ -67,8 +67,9 @@ type pty struct {
 	resize       chan remotecommand.TerminalSize
 	activityFunc func()
 
-	out  io.ReadWriter
-	size *remotecommand.TerminalSize
+	out      io.ReadWriter
+	size     *remotecommand.TerminalSize
+	recorder *Recorder
 
 	mu sync.RWMutex
 }
@@ -82,6 +83,8 @@ func (p *pty) Write(b []byte) (int, error) {
 	defer p.mu.Unlock()
 	defer p.activityFunc()
 
+	p.record(func(r *Recorder) error { return r.Output(b) })
+
 	return p.out.Write(b)
 }
 
@@ -115,9 +118,23 @@ func (p *pty) Read(b []byte) (int, error) {
 
 	defer p.activityFunc()
 
+	p.record(func(r *Recorder) error { return r.Input(key) })
+
 	return copy(b, key), nil
 }
 
+// record calls fn with the recorder if the session is being recorded. Recording errors
+// are logged rather than interrupting the session.
+func (p *pty) record(fn func(r *Recorder) error) {
+	if p.recorder == nil {
+		return
+	}
+
+	if err := fn(p.recorder); err != nil {
+		p.logger.WithErr(err).Errorf("record terminal session")
+	}
+}
+
 // Next creates a new TerminalSize based on resize events.
 func (p *pty) Next() *remotecommand.TerminalSize {
 	select {
@@ -169,8 +186,27 @@ type instance struct {
 
 var _ Instance = (*instance)(nil)
 
+// InstanceOption is an option for configuring a terminal instance.
+type InstanceOption func(o *instanceOptions)
+
+type instanceOptions struct {
+	recordingDir string
+}
+
+// WithRecording records the terminal session in asciicast v2 format to dir.
+func WithRecording(dir string) InstanceOption {
+	return func(o *instanceOptions) {
+		o.recordingDir = dir
+	}
+}
+
 // NewTerminalInstance creates a concrete Terminal
-func NewTerminalInstance(ctx context.Context, client cluster.ClientInterface, logger log.Logger, key store.Key, container, command string, activityChan chan Instance) (Instance, error) {
+func NewTerminalInstance(ctx context.Context, client cluster.ClientInterface, logger log.Logger, key store.Key, container, command string, activityChan chan Instance, options ...InstanceOption) (Instance, error) {
+	opts := instanceOptions{}
+	for _, option := range options {
+		option(&opts)
+	}
+
 	ctx, cancelFn := context.WithCancel(ctx)
 
 	restClient, err := client.RESTClient()
@@ -212,7 +248,23 @@ func NewTerminalInstance(ctx context.Context, client cluster.ClientInterface, lo
 		activityChan <- t
 	}
 
-	return t, t.terminalStream()
+	if opts.recordingDir != "" {
+		recorder, err := NewRecorder(opts.recordingDir, key, container, command)
+		if err != nil {
+			cancelFn()
+			return nil, errors.Wrap(err, "start terminal recording")
+		}
+		termPty.recorder = recorder
+	}
+
+	if err := t.terminalStream(); err != nil {
+		if termPty.recorder != nil {
+			_ = termPty.recorder.Discard()
+		}
+		return t, err
+	}
+
+	return t, nil
 }
 
 func (t *instance) terminalStream() error {
@@ -264,6 +316,7 @@ func (t *instance) terminalStream() error {
 }
 
 func (t *instance) Resize(cols, rows uint16) {
+	t.pty.record(func(r *Recorder) error { return r.Resize(cols, rows) })
 	t.pty.resize <- remotecommand.TerminalSize{
 		Width:  cols,
 		Height: rows,
@@ -346,7 +399,15 @@ func (t *instance) Active() bool {
 // Stop stops the terminal from attempting to read/write to stdout/in streams.
 // Calling stop will also cause the PTY to return an io.ErrClosedPipe from the PTY
 // Read command.
-func (t *instance) Stop() { t.pty.cancelFn() }
+func (t *instance) Stop() {
+	t.pty.cancelFn()
+
+	if t.pty.recorder != nil {
+		if err := t.pty.recorder.Close(); err != nil {
+			t.logger.WithErr(err).Errorf("close terminal recording")
+		}
+	}
+}
 
 // Key returns the store.Key for the Pod that this terminal is associated with.
 func (t *instance) Key() store.Key { return t.key }
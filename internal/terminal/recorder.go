@@ -0,0 +1,166 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package terminal
+
+import (
+	"fmt"
+	"os"
+	"path/filepath"
+	"sync"
+	"time"
+
+	"github.com/vmware-tanzu/octant/internal/util/json"
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+const (
+	// RecordingExtension is the file extension of terminal recordings.
+	RecordingExtension = ".cast"
+
+	defaultRecordingWidth  = 80
+	defaultRecordingHeight = 24
+
+	eventOutput = "o"
+	eventInput  = "i"
+	eventResize = "r"
+)
+
+// RecordingHeader is the header line of an asciicast v2 recording.
+type RecordingHeader struct {
+	Version   int    `json:"version"`
+	Width     uint16 `json:"width"`
+	Height    uint16 `json:"height"`
+	Timestamp int64  `json:"timestamp"`
+	Command   string `json:"command,omitempty"`
+	Title     string `json:"title,omitempty"`
+}
+
+// Recorder records a terminal session in asciicast v2 format. Output, input and resize
+// events are written as they happen, so a recording is usable even if octant exits
+// before the session ends.
+type Recorder struct {
+	path  string
+	start time.Time
+	now   func() time.Time
+
+	mu     sync.Mutex
+	file   *os.File
+	closed bool
+}
+
+// NewRecorder creates a recording for a container's terminal session in
+// dir/<namespace>/<pod>.
+func NewRecorder(dir string, key store.Key, container, command string) (*Recorder, error) {
+	return newRecorder(dir, key, container, command, time.Now)
+}
+
+func newRecorder(dir string, key store.Key, container, command string, now func() time.Time) (*Recorder, error) {
+	start := now()
+
+	podDir := filepath.Join(dir, key.Namespace, key.Name)
+	if err := os.MkdirAll(podDir, 0700); err != nil {
+		return nil, fmt.Errorf("create recording directory: %w", err)
+	}
+
+	name := fmt.Sprintf("%s-%d%s", container, start.UnixNano(), RecordingExtension)
+	path := filepath.Join(podDir, name)
+
+	file, err := os.OpenFile(path, os.O_CREATE|os.O_EXCL|os.O_WRONLY, 0600)
+	if err != nil {
+		return nil, fmt.Errorf("create recording: %w", err)
+	}
+
+	r := &Recorder{
+		path:  path,
+		start: start,
+		now:   now,
+		file:  file,
+	}
+
+	header := RecordingHeader{
+		Version:   2,
+		Width:     defaultRecordingWidth,
+		Height:    defaultRecordingHeight,
+		Timestamp: start.Unix(),
+		Command:   command,
+		Title:     fmt.Sprintf("%s/%s/%s", key.Namespace, key.Name, container),
+	}
+
+	if err := r.writeLine(header); err != nil {
+		_ = file.Close()
+		_ = os.Remove(path)
+		return nil, err
+	}
+
+	return r, nil
+}
+
+// Path returns the path of the recording.
+func (r *Recorder) Path() string {
+	return r.path
+}
+
+// Output records data written to the terminal.
+func (r *Recorder) Output(b []byte) error {
+	return r.event(eventOutput, string(b))
+}
+
+// Input records data typed into the terminal.
+func (r *Recorder) Input(b []byte) error {
+	return r.event(eventInput, string(b))
+}
+
+// Resize records a change of terminal size.
+func (r *Recorder) Resize(cols, rows uint16) error {
+	return r.event(eventResize, fmt.Sprintf("%dx%d", cols, rows))
+}
+
+// Close closes the recording. It is safe to call Close more than once.
+func (r *Recorder) Close() error {
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+	r.closed = true
+
+	return r.file.Close()
+}
+
+// Discard closes and removes the recording.
+func (r *Recorder) Discard() error {
+	if err := r.Close(); err != nil {
+		return err
+	}
+
+	return os.Remove(r.path)
+}
+
+func (r *Recorder) event(eventType, data string) error {
+	elapsed := r.now().Sub(r.start).Seconds()
+	return r.writeLine([]interface{}{elapsed, eventType, data})
+}
+
+func (r *Recorder) writeLine(v interface{}) error {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return fmt.Errorf("encode recording event: %w", err)
+	}
+
+	r.mu.Lock()
+	defer r.mu.Unlock()
+
+	if r.closed {
+		return nil
+	}
+
+	if _, err := r.file.Write(append(b, '\n')); err != nil {
+		return fmt.Errorf("write recording event: %w", err)
+	}
+
+	return nil
+}
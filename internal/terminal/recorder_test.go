@@ -0,0 +1,128 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package terminal
+
+import (
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/octant/pkg/store"
+)
+
+func TestRecorder(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recorder")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	start := time.Unix(1600000000, 0)
+	now := start
+	clock := func() time.Time { return now }
+
+	key := store.Key{Namespace: "default", Name: "pod"}
+	recorder, err := newRecorder(dir, key, "app", "bash", clock)
+	require.NoError(t, err)
+
+	now = start.Add(500 * time.Millisecond)
+	require.NoError(t, recorder.Resize(120, 40))
+	now = start.Add(time.Second)
+	require.NoError(t, recorder.Input([]byte("ls\r")))
+	now = start.Add(1500 * time.Millisecond)
+	require.NoError(t, recorder.Output([]byte("file\r\n")))
+
+	require.NoError(t, recorder.Close())
+	require.NoError(t, recorder.Close())
+	require.NoError(t, recorder.Output([]byte("ignored")))
+
+	expectedPath := filepath.Join(dir, "default", "pod", "app-1600000000000000000.cast")
+	assert.Equal(t, expectedPath, recorder.Path())
+
+	got, err := ioutil.ReadFile(expectedPath)
+	require.NoError(t, err)
+
+	expected := `{"version":2,"width":80,"height":24,"timestamp":1600000000,"command":"bash","title":"default/pod/app"}
+[0.5,"r","120x40"]
+[1,"i","ls\r"]
+[1.5,"o","file\r\n"]
+`
+	assert.Equal(t, expected, string(got))
+}
+
+func TestRecorder_Discard(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recorder")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	recorder, err := NewRecorder(dir, store.Key{Namespace: "default", Name: "pod"}, "app", "sh")
+	require.NoError(t, err)
+
+	require.NoError(t, recorder.Discard())
+
+	_, err = os.Stat(recorder.Path())
+	assert.True(t, os.IsNotExist(err))
+}
+
+func TestListRecordings(t *testing.T) {
+	dir, err := ioutil.TempDir("", "recordings")
+	require.NoError(t, err)
+	defer os.RemoveAll(dir)
+
+	key := store.Key{Namespace: "default", Name: "pod"}
+	for i, container := range []string{"app", "side-car"} {
+		start := time.Unix(1600000000+int64(i)*60, 0)
+		recorder, err := newRecorder(dir, key, container, "bash", func() time.Time { return start })
+		require.NoError(t, err)
+		require.NoError(t, recorder.Close())
+		require.NoError(t, os.Chtimes(recorder.Path(), start.Add(time.Minute), start.Add(time.Minute)))
+	}
+
+	// Files which aren't recordings are ignored.
+	podDir := filepath.Join(dir, "default", "pod")
+	require.NoError(t, ioutil.WriteFile(filepath.Join(podDir, "notes.txt"), []byte("notes"), 0600))
+	require.NoError(t, ioutil.WriteFile(filepath.Join(podDir, "broken-1.cast"), []byte("{"), 0600))
+
+	got, err := ListRecordings(dir, "default", "pod")
+	require.NoError(t, err)
+	require.Len(t, got, 2)
+
+	assert.Equal(t, "side-car", got[0].Container)
+	assert.Equal(t, "app", got[1].Container)
+	assert.Equal(t, "bash", got[1].Command)
+	assert.Equal(t, time.Unix(1600000000, 0), got[1].StartedAt)
+	assert.Equal(t, time.Minute, got[1].Duration)
+
+	got, err = ListRecordings(dir, "default", "other")
+	require.NoError(t, err)
+	assert.Empty(t, got)
+}
+
+func TestRecordingPath(t *testing.T) {
+	path, err := RecordingPath("/recordings", "default", "pod", "app-1.cast")
+	require.NoError(t, err)
+	assert.Equal(t, filepath.Join("/recordings", "default", "pod", "app-1.cast"), path)
+
+	invalid := []struct {
+		namespace, pod, name string
+	}{
+		{"default", "pod", "../../secret.cast"},
+		{"default", "pod", "app-1.txt"},
+		{"..", "pod", "app-1.cast"},
+		{"default", "", "app-1.cast"},
+	}
+
+	for _, tc := range invalid {
+		_, err := RecordingPath("/recordings", tc.namespace, tc.pod, tc.name)
+		assert.Error(t, err, "%s/%s/%s", tc.namespace, tc.pod, tc.name)
+	}
+
+	_, err = RecordingPath("", "default", "pod", "app-1.cast")
+	assert.Error(t, err)
+}
@@ -0,0 +1,170 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package terminal
+
+import (
+	"bufio"
+	"fmt"
+	"io/ioutil"
+	"net/url"
+	"os"
+	"path/filepath"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/spf13/viper"
+
+	"github.com/vmware-tanzu/octant/internal/util/json"
+)
+
+const (
+	// RecordingDirKey is the configuration key for the directory terminal sessions are
+	// recorded to. Sessions are not recorded if it is empty.
+	RecordingDirKey = "terminal-recording-dir"
+
+	// RecordingRoute is the API route recordings are served from.
+	RecordingRoute = "/terminal/recordings/namespace/{namespace}/pod/{pod}/{name}"
+)
+
+// RecordingURLPath returns the path of a recording relative to the API prefix.
+func RecordingURLPath(namespace, pod, name string) string {
+	return fmt.Sprintf("/terminal/recordings/namespace/%s/pod/%s/%s",
+		url.PathEscape(namespace), url.PathEscape(pod), url.PathEscape(name))
+}
+
+// RecordingDir returns the directory terminal sessions are recorded to.
+func RecordingDir() string {
+	return viper.GetString(RecordingDirKey)
+}
+
+// Recording describes a recorded terminal session.
+type Recording struct {
+	// Name is the file name of the recording.
+	Name string
+	// Container is the container the session was run in.
+	Container string
+	// Command is the command the session ran.
+	Command string
+	// StartedAt is when the session started.
+	StartedAt time.Time
+	// Duration is how long the session was recorded for.
+	Duration time.Duration
+	// Size is the size of the recording in bytes.
+	Size int64
+}
+
+// ListRecordings lists the recordings for a pod, newest first.
+func ListRecordings(dir, namespace, pod string) ([]Recording, error) {
+	podDir, err := recordingPodDir(dir, namespace, pod)
+	if err != nil {
+		return nil, err
+	}
+
+	entries, err := ioutil.ReadDir(podDir)
+	if err != nil {
+		if os.IsNotExist(err) {
+			return nil, nil
+		}
+		return nil, fmt.Errorf("read recording directory: %w", err)
+	}
+
+	var recordings []Recording
+	for _, entry := range entries {
+		if entry.IsDir() || filepath.Ext(entry.Name()) != RecordingExtension {
+			continue
+		}
+
+		header, err := readRecordingHeader(filepath.Join(podDir, entry.Name()))
+		if err != nil {
+			continue
+		}
+
+		startedAt := time.Unix(header.Timestamp, 0)
+		recording := Recording{
+			Name:      entry.Name(),
+			Container: recordingContainer(entry.Name()),
+			Command:   header.Command,
+			StartedAt: startedAt,
+			Size:      entry.Size(),
+		}
+		if duration := entry.ModTime().Sub(startedAt); duration > 0 {
+			recording.Duration = duration.Round(time.Second)
+		}
+
+		recordings = append(recordings, recording)
+	}
+
+	sort.Slice(recordings, func(i, j int) bool {
+		return recordings[i].StartedAt.After(recordings[j].StartedAt)
+	})
+
+	return recordings, nil
+}
+
+// RecordingPath returns the path of a pod's recording. It returns an error if name
+// isn't the name of a recording file.
+func RecordingPath(dir, namespace, pod, name string) (string, error) {
+	if name != filepath.Base(name) || filepath.Ext(name) != RecordingExtension || strings.HasPrefix(name, ".") {
+		return "", fmt.Errorf("invalid recording name %q", name)
+	}
+
+	podDir, err := recordingPodDir(dir, namespace, pod)
+	if err != nil {
+		return "", err
+	}
+
+	return filepath.Join(podDir, name), nil
+}
+
+func recordingPodDir(dir, namespace, pod string) (string, error) {
+	if dir == "" {
+		return "", fmt.Errorf("terminal recording is not enabled")
+	}
+
+	for _, part := range []string{namespace, pod} {
+		if part == "" || part != filepath.Base(part) || strings.HasPrefix(part, ".") {
+			return "", fmt.Errorf("invalid recording path %q", part)
+		}
+	}
+
+	return filepath.Join(dir, namespace, pod), nil
+}
+
+// recordingContainer returns the container from a recording name, which is in the
+// form <container>-<start>.cast.
+func recordingContainer(name string) string {
+	name = strings.TrimSuffix(name, RecordingExtension)
+	if i := strings.LastIndex(name, "-"); i > 0 {
+		return name[:i]
+	}
+
+	return name
+}
+
+func readRecordingHeader(path string) (RecordingHeader, error) {
+	f, err := os.Open(path)
+	if err != nil {
+		return RecordingHeader{}, err
+	}
+	defer f.Close()
+
+	line, err := bufio.NewReader(f).ReadBytes('\n')
+	if err != nil {
+		return RecordingHeader{}, err
+	}
+
+	var header RecordingHeader
+	if err := json.Unmarshal(line, &header); err != nil {
+		return RecordingHeader{}, err
+	}
+
+	if header.Version != 2 {
+		return RecordingHeader{}, fmt.Errorf("unsupported recording version %d", header.Version)
+	}
+
+	return header, nil
+}
@@ -14,3 +14,12 @@ type NotFoundError struct {
 func (e *NotFoundError) Error() string {
 	return fmt.Sprintf("action path %q not found", e.Path)
 }
+
+// ReadOnlyError is returned when a mutating action is dispatched in read-only mode.
+type ReadOnlyError struct {
+	Path string
+}
+
+func (e *ReadOnlyError) Error() string {
+	return fmt.Sprintf("action path %q is disabled in read-only mode", e.Path)
+}
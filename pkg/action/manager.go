@@ -112,6 +112,22 @@ type Manager struct {
 
 	// key: string, value: []dispatcherEntry
 	dispatches sync.Map
+
+	// readOnlyPaths are the action paths which are denied in read-only mode.
+	readOnlyPaths map[string]bool
+}
+
+// ManagerOption is an option for configuring Manager.
+type ManagerOption func(m *Manager)
+
+// WithReadOnly denies dispatching to the mutating action paths.
+func WithReadOnly(mutatingPaths ...string) ManagerOption {
+	return func(m *Manager) {
+		m.readOnlyPaths = make(map[string]bool)
+		for _, actionPath := range mutatingPaths {
+			m.readOnlyPaths[actionPath] = true
+		}
+	}
 }
 
 type dispatcherEntry struct {
@@ -120,11 +136,17 @@ type dispatcherEntry struct {
 }
 
 // NewManager creates an instance of Manager.
-func NewManager(logger log.Logger) *Manager {
-	return &Manager{
+func NewManager(logger log.Logger, options ...ManagerOption) *Manager {
+	m := &Manager{
 		logger:     logger.With("component", "action-manager"),
 		dispatches: sync.Map{},
 	}
+
+	for _, option := range options {
+		option(m)
+	}
+
+	return m
 }
 
 // Register registers a dispatcher function to an action path.
@@ -182,6 +204,12 @@ func (m *Manager) Unregister(actionPath string, pluginName string) {
 
 // Dispatch dispatches a payload to a path.
 func (m *Manager) Dispatch(ctx context.Context, alerter Alerter, actionPath string, payload Payload) error {
+	if m.readOnlyPaths[actionPath] {
+		err := &ReadOnlyError{Path: actionPath}
+		alerter.SendAlert(CreateAlert(AlertTypeWarning, err.Error(), DefaultAlertExpiration))
+		return err
+	}
+
 	val, ok := m.dispatches.Load(actionPath)
 	if !ok {
 		return &NotFoundError{Path: actionPath}
@@ -48,3 +48,35 @@ func TestManager(t *testing.T) {
 
 	assert.True(t, payloadRan)
 }
+
+func TestManager_ReadOnly(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	alerter := fake.NewMockAlerter(controller)
+	alerter.EXPECT().SendAlert(gomock.Any()).Do(func(alert action.Alert) {
+		assert.Equal(t, action.AlertTypeWarning, alert.Type)
+	})
+
+	m := action.NewManager(log.NopLogger(), action.WithReadOnly("mutate"))
+
+	var ran []string
+	for _, actionPath := range []string{"mutate", "read"} {
+		actionPath := actionPath
+		fn := func(context.Context, action.Alerter, action.Payload) error {
+			ran = append(ran, actionPath)
+			return nil
+		}
+		require.NoError(t, m.Register(actionPath, "internal", fn))
+	}
+
+	ctx := context.Background()
+
+	err := m.Dispatch(ctx, alerter, "mutate", action.Payload{})
+	var readOnlyErr *action.ReadOnlyError
+	require.ErrorAs(t, err, &readOnlyErr)
+
+	require.NoError(t, m.Dispatch(ctx, alerter, "read", action.Payload{}))
+
+	assert.Equal(t, []string{"read"}, ran)
+}
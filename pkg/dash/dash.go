@@ -27,6 +27,7 @@ import (
 	"go.opencensus.io/trace"
 
 	"github.com/vmware-tanzu/octant/internal/api"
+	"github.com/vmware-tanzu/octant/internal/auth"
 	"github.com/vmware-tanzu/octant/internal/cluster"
 	"github.com/vmware-tanzu/octant/internal/config"
 	ocontext "github.com/vmware-tanzu/octant/internal/context"
@@ -34,15 +35,21 @@ import (
 	oerrors "github.com/vmware-tanzu/octant/internal/errors"
 	"github.com/vmware-tanzu/octant/internal/kubeconfig"
 	internalLog "github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/metrics"
 	"github.com/vmware-tanzu/octant/internal/module"
 	"github.com/vmware-tanzu/octant/internal/modules/applications"
 	"github.com/vmware-tanzu/octant/internal/modules/clusteroverview"
 	"github.com/vmware-tanzu/octant/internal/modules/configuration"
+	"github.com/vmware-tanzu/octant/internal/modules/events"
 	"github.com/vmware-tanzu/octant/internal/modules/localcontent"
 	"github.com/vmware-tanzu/octant/internal/modules/overview"
 	"github.com/vmware-tanzu/octant/internal/modules/workloads"
+	"github.com/vmware-tanzu/octant/internal/objectstatus"
 	"github.com/vmware-tanzu/octant/internal/objectstore"
+	internalOctant "github.com/vmware-tanzu/octant/internal/octant"
 	"github.com/vmware-tanzu/octant/internal/portforward"
+	"github.com/vmware-tanzu/octant/internal/search"
+	"github.com/vmware-tanzu/octant/internal/snapshot"
 	"github.com/vmware-tanzu/octant/pkg/action"
 	"github.com/vmware-tanzu/octant/pkg/log"
 	"github.com/vmware-tanzu/octant/pkg/octant"
@@ -56,6 +63,8 @@ type Options struct {
 	EnableOpenCensus       bool
 	EnableMemStats         bool
 	DisableClusterOverview bool
+	ReadOnly               bool
+	ImpersonateUsers       bool
 	KubeConfig             string
 	Namespace              string
 	Namespaces             []string
@@ -67,7 +76,14 @@ type Options struct {
 	UserAgent              string
 	BuildInfo              config.BuildInfo
 	Listener               net.Listener
+	AuthProvider           auth.Provider
+	Snapshot               string
+	PortForwardsPath       string
+	StatusOverridesPath    string
 	clusterClient          cluster.ClientInterface
+	objectStore            store.Store
+	searchIndex            *search.Index
+	metricsSamplerFactory  metricsSamplerFactory
 }
 
 type RunnerOption struct {
@@ -102,6 +118,37 @@ func WithoutClusterOverview() RunnerOption {
 	}
 }
 
+// WithReadOnly disables actions and store methods which change the cluster.
+func WithReadOnly() RunnerOption {
+	return RunnerOption{
+		kubeConfigOption: kubeconfig.Noop(),
+		nonClusterOption: func(o *Options) {
+			o.ReadOnly = true
+		},
+	}
+}
+
+// WithUserImpersonation makes Kubernetes requests as the authenticated user, using
+// impersonation headers. Each user gets their own object store.
+func WithUserImpersonation() RunnerOption {
+	return RunnerOption{
+		kubeConfigOption: kubeconfig.FromClusterOption(cluster.WithUserImpersonation()),
+		nonClusterOption: func(o *Options) {
+			o.ImpersonateUsers = true
+		},
+	}
+}
+
+// WithAuthProvider authenticates requests to the dashboard with provider.
+func WithAuthProvider(provider auth.Provider) RunnerOption {
+	return RunnerOption{
+		kubeConfigOption: kubeconfig.Noop(),
+		nonClusterOption: func(o *Options) {
+			o.AuthProvider = provider
+		},
+	}
+}
+
 func WithKubeConfig(kubeConfig string) RunnerOption {
 	return RunnerOption{
 		kubeConfigOption: kubeconfig.WithKubeConfigList(kubeConfig),
@@ -195,6 +242,68 @@ func WithListener(listener net.Listener) RunnerOption {
 	}
 }
 
+// WithSnapshot serves the dashboard from a snapshot archive or a directory of manifests
+// instead of a live cluster. The dashboard is read-only.
+func WithSnapshot(source string) RunnerOption {
+	return RunnerOption{
+		kubeConfigOption: kubeconfig.Noop(),
+		nonClusterOption: func(o *Options) {
+			o.Snapshot = source
+			o.ReadOnly = true
+		},
+	}
+}
+
+// WithPortForwardsPath saves port forwards to a file at path, so they are restored when
+// the dashboard starts and when the context changes.
+func WithPortForwardsPath(path string) RunnerOption {
+	return RunnerOption{
+		kubeConfigOption: kubeconfig.Noop(),
+		nonClusterOption: func(o *Options) {
+			o.PortForwardsPath = path
+		},
+	}
+}
+
+// WithStatusOverridesPath loads overrides for how the status of kinds without a
+// status function is read from their conditions and phase from a file at path.
+func WithStatusOverridesPath(path string) RunnerOption {
+	return RunnerOption{
+		kubeConfigOption: kubeconfig.Noop(),
+		nonClusterOption: func(o *Options) {
+			o.StatusOverridesPath = path
+		},
+	}
+}
+
+func withObjectStore(objectStore store.Store) RunnerOption {
+	return RunnerOption{
+		kubeConfigOption: kubeconfig.Noop(),
+		nonClusterOption: func(o *Options) {
+			o.objectStore = objectStore
+		},
+	}
+}
+
+func withSearchIndex(index *search.Index) RunnerOption {
+	return RunnerOption{
+		kubeConfigOption: kubeconfig.Noop(),
+		nonClusterOption: func(o *Options) {
+			o.searchIndex = index
+		},
+	}
+}
+
+// withMetricsSamplerFactory replaces how the metrics sampler is created.
+func withMetricsSamplerFactory(factory metricsSamplerFactory) RunnerOption {
+	return RunnerOption{
+		kubeConfigOption: kubeconfig.Noop(),
+		nonClusterOption: func(o *Options) {
+			o.metricsSamplerFactory = factory
+		},
+	}
+}
+
 func WithClusterClient(client cluster.ClientInterface) RunnerOption {
 	return RunnerOption{
 		nonClusterOption: func(o *Options) {
@@ -212,6 +321,7 @@ type Runner struct {
 	websocketClientManager *api.WebsocketClientManager
 	apiCreated             bool
 	fs                     afero.Fs
+	metricsSampler         metricsSampler
 }
 
 func NewRunner(ctx context.Context, logger log.Logger, opts ...RunnerOption) (*Runner, error) {
@@ -229,7 +339,43 @@ func NewRunner(ctx context.Context, logger log.Logger, opts ...RunnerOption) (*R
 		logger.With("initial-context", options.Context).Infof("Setting initial context from user flags")
 	}
 
-	actionManger := action.NewManager(logger)
+	if options.StatusOverridesPath != "" {
+		overrides, err := objectstatus.LoadConditionOverrides(options.StatusOverridesPath)
+		if err != nil {
+			return nil, fmt.Errorf("load status overrides: %w", err)
+		}
+		objectstatus.SetConditionOverrides(overrides)
+	}
+
+	if options.Snapshot != "" {
+		s, err := snapshot.Load(options.Snapshot)
+		if err != nil {
+			return nil, fmt.Errorf("load snapshot: %w", err)
+		}
+
+		client, err := snapshot.NewClient(s)
+		if err != nil {
+			return nil, fmt.Errorf("create snapshot client: %w", err)
+		}
+
+		// Snapshot objects don't change, so they are indexed once.
+		index := search.NewIndex()
+		for _, object := range s.Objects() {
+			index.Add(object)
+		}
+
+		logger.With("source", s.Source, "objects", len(s.Objects())).Infof("Serving from snapshot")
+		opts = append(opts, WithClusterClient(client), withObjectStore(snapshot.NewStore(s)), withSearchIndex(index))
+		options.clusterClient = client
+	}
+
+	var actionOptions []action.ManagerOption
+	if options.ReadOnly {
+		logger.Infof("Running in read-only mode")
+		actionOptions = append(actionOptions, action.WithReadOnly(internalOctant.MutatingActions...))
+	}
+
+	actionManger := action.NewManager(logger, actionOptions...)
 	r.actionManager = actionManger
 
 	websocketClientManager := api.NewWebsocketClientManager(ctx, r.actionManager)
@@ -253,11 +399,20 @@ func NewRunner(ctx context.Context, logger log.Logger, opts ...RunnerOption) (*R
 		return nil, fmt.Errorf("failed to start service api: %w", apiErr)
 	}
 
-	d, err := newDash(options.Listener, options.Namespace, options.FrontendURL, options.BrowserPath, apiService, pluginService, logger)
+	d, err := newDash(options.Listener, options.Namespace, options.FrontendURL, options.BrowserPath, apiService, pluginService, options.AuthProvider, logger)
 	if err != nil {
 		return nil, fmt.Errorf("failed to create dash instance: %w", err)
 	}
 
+	if options.ImpersonateUsers && options.AuthProvider == nil {
+		logger.Warnf("User impersonation is enabled without authentication, so requests use Octant's credentials")
+	}
+
+	if options.AuthProvider == nil && options.Listener != nil && !isLoopback(options.Listener.Addr()) {
+		logger.With("addr", options.Listener.Addr().String()).
+			Warnf("Dashboard is listening on a non-loopback address without authentication")
+	}
+
 	if viper.GetBool("disable-open-browser") {
 		d.willOpenBrowser = false
 	}
@@ -292,7 +447,9 @@ func (r *Runner) Start(startupCh, shutdownCh chan bool, opts ...RunnerOption) er
 		}
 	}()
 
-	if !r.apiCreated {
+	if r.apiCreated {
+		r.startMetricsSampler()
+	} else {
 		go func() {
 			logger.Infof("waiting for kube config ...")
 			options.KubeConfig = <-ocontext.KubeConfigChFrom(r.ctx)
@@ -306,12 +463,12 @@ func (r *Runner) Start(startupCh, shutdownCh chan bool, opts ...RunnerOption) er
 			apiService, pluginService, err := r.initAPI(r.ctx, logger, opts...)
 			if err != nil {
 				logger.Errorf("cannot create api: %v", err)
+			} else {
+				r.startMetricsSampler()
 			}
 			r.dash.apiHandler = apiService
 			r.dash.pluginService = pluginService
-			hf := octant.NewHandlerFactory(
-				octant.BackendHandler(r.dash.apiHandler.Handler),
-				octant.FrontendURL(viper.GetString("proxy-frontend")))
+			hf := newHandlerFactory(r.dash.apiHandler, r.dash.authProvider)
 
 			r.dash.server.Handler, err = hf.Handler(r.ctx)
 			if err != nil {
@@ -363,7 +520,6 @@ func (r *Runner) initAPI(ctx context.Context, logger log.Logger, opts ...RunnerO
 		}
 	}
 
-	fmt.Println(options.EnableMemStats)
 	if options.EnableMemStats {
 		if err := memStats(); err != nil {
 			logger.Infof("Enable MemStat")
@@ -383,9 +539,26 @@ func (r *Runner) initAPI(ctx context.Context, logger log.Logger, opts ...RunnerO
 
 	logger.Debugf("initial namespace for dashboard is %s", options.Namespace)
 
-	appObjectStore, err := initObjectStore(ctx, clusterClient)
-	if err != nil {
-		return nil, nil, fmt.Errorf("initializing store: %w", err)
+	searchIndex := options.searchIndex
+	if searchIndex == nil {
+		searchIndex = search.NewIndex()
+	}
+
+	appObjectStore := options.objectStore
+	if appObjectStore == nil {
+		appObjectStore, err = initObjectStore(ctx, clusterClient, searchIndex)
+		if err != nil {
+			return nil, nil, fmt.Errorf("initializing store: %w", err)
+		}
+	}
+
+	if options.ImpersonateUsers {
+		logger.Infof("Impersonating authenticated users")
+		appObjectStore = objectstore.NewPartitionedStore(ctx, clusterClient, appObjectStore)
+	}
+
+	if options.ReadOnly {
+		appObjectStore = store.NewReadOnly(appObjectStore)
 	}
 
 	errorStore, err := oerrors.NewErrorStore()
@@ -405,11 +578,21 @@ func (r *Runner) initAPI(ctx context.Context, logger log.Logger, opts ...RunnerO
 		}
 	}
 
-	portForwarder, err := initPortForwarder(ctx, clusterClient, appObjectStore)
+	// Snapshots don't have pods to forward to, so saved port forwards are only restored
+	// for live clusters.
+	var portForwardDefinitions portforward.DefinitionStore
+	if options.PortForwardsPath != "" && options.Snapshot == "" {
+		portForwardDefinitions = portforward.NewFileDefinitionStore(options.PortForwardsPath)
+	}
+
+	portForwarder, err := initPortForwarder(ctx, clusterClient, appObjectStore, kubeContextDecorator.CurrentContext(), portForwardDefinitions)
 	if err != nil {
 		return nil, nil, fmt.Errorf("initializing port forwarder: %w", err)
 	}
 
+	metricsHistory, metricsSampler := initMetricsHistory(kubeContextDecorator, options.metricsSamplerFactory)
+	r.metricsSampler = metricsSampler
+
 	mo := &moduleOptions{
 		clusterClient: clusterClient,
 		namespace:     options.Namespace,
@@ -457,15 +640,21 @@ func (r *Runner) initAPI(ctx context.Context, logger log.Logger, opts ...RunnerO
 		errorStore,
 		pluginManager,
 		portForwarder,
+		metricsHistory,
+		searchIndex,
 		restConfigOptions,
 		buildInfo,
 		false,
+		options.ReadOnly,
 	)
 
 	pluginManager.SetOctantClient(dashConfig)
 
-	if err := watchConfigs(ctx, dashConfig, options.KubeConfig); err != nil {
-		return nil, nil, fmt.Errorf("set up config watcher: %w", err)
+	// Snapshots don't use the kube config, so changes to it are ignored.
+	if options.Snapshot == "" {
+		if err := watchConfigs(ctx, dashConfig, options.KubeConfig); err != nil {
+			return nil, nil, fmt.Errorf("set up config watcher: %w", err)
+		}
 	}
 
 	moduleList, err := initModules(ctx, dashConfig, options.Namespace, options)
@@ -496,13 +685,15 @@ func (r *Runner) initAPI(ctx context.Context, logger log.Logger, opts ...RunnerO
 }
 
 // initObjectStore initializes the cluster object store interface
-func initObjectStore(ctx context.Context, client cluster.ClientInterface) (store.Store, error) {
+func initObjectStore(ctx context.Context, client cluster.ClientInterface, searchIndex *search.Index) (store.Store, error) {
 	if client == nil {
 		return nil, fmt.Errorf("nil cluster client")
 	}
 
 	resourceAccess := objectstore.NewResourceAccess(client)
-	appObjectStore, err := objectstore.NewDynamicCache(ctx, client, objectstore.Access(resourceAccess))
+	appObjectStore, err := objectstore.NewDynamicCache(ctx, client,
+		objectstore.Access(resourceAccess),
+		objectstore.SearchIndex(searchIndex))
 
 	if err != nil {
 		return nil, fmt.Errorf("creating object store for app: %w", err)
@@ -511,8 +702,40 @@ func initObjectStore(ctx context.Context, client cluster.ClientInterface) (store
 	return appObjectStore, nil
 }
 
-func initPortForwarder(ctx context.Context, client cluster.ClientInterface, appObjectStore store.Store) (portforward.PortForwarder, error) {
-	return portforward.Default(ctx, client, appObjectStore)
+func initPortForwarder(ctx context.Context, client cluster.ClientInterface, appObjectStore store.Store, contextName string, definitions portforward.DefinitionStore) (portforward.PortForwarder, error) {
+	return portforward.Default(ctx, client, appObjectStore, contextName, definitions)
+}
+
+// metricsSampler records pod and node metrics until its context is canceled.
+type metricsSampler interface {
+	Run(ctx context.Context)
+}
+
+// metricsSamplerFactory creates the metrics sampler recording into a history.
+type metricsSamplerFactory func(clientProvider metrics.ClusterClientProvider, history *metrics.MemoryHistory) metricsSampler
+
+func defaultMetricsSampler(clientProvider metrics.ClusterClientProvider, history *metrics.MemoryHistory) metricsSampler {
+	return metrics.NewSampler(clientProvider, history,
+		metrics.WithSampleInterval(viper.GetDuration("metrics-sample-interval")))
+}
+
+// initMetricsHistory creates the metrics history and the sampler recording into it.
+// The sampler is started with the runner.
+func initMetricsHistory(clientProvider metrics.ClusterClientProvider, factory metricsSamplerFactory) (metrics.History, metricsSampler) {
+	if factory == nil {
+		factory = defaultMetricsSampler
+	}
+
+	history := metrics.NewMemoryHistory(viper.GetInt("metrics-history-size"))
+	return history, factory(clientProvider, history)
+}
+
+// startMetricsSampler starts sampling pod and node metrics in the background.
+func (r *Runner) startMetricsSampler() {
+	if r.metricsSampler == nil {
+		return
+	}
+	go r.metricsSampler.Run(r.ctx)
 }
 
 type moduleOptions struct {
@@ -570,6 +793,12 @@ func initModules(ctx context.Context, dashConfig config.Dash, namespace string,
 		list = append(list, clusterOverviewModule)
 	}
 
+	eventsModule := events.New(ctx, events.Options{
+		DashConfig: dashConfig,
+	})
+
+	list = append(list, eventsModule)
+
 	configurationOptions := configuration.Options{
 		DashConfig: dashConfig,
 	}
@@ -609,12 +838,11 @@ type dash struct {
 	handlerFactory  *octant.HandlerFactory
 	server          http.Server
 	pluginService   pluginAPI.Service
+	authProvider    auth.Provider
 }
 
-func newDash(listener net.Listener, namespace, uiURL string, browserPath string, apiHandler api.Service, pluginHandler pluginAPI.Service, logger log.Logger) (*dash, error) {
-	hf := octant.NewHandlerFactory(
-		octant.BackendHandler(apiHandler.Handler),
-		octant.FrontendURL(viper.GetString("proxy-frontend")))
+func newDash(listener net.Listener, namespace, uiURL string, browserPath string, apiHandler api.Service, pluginHandler pluginAPI.Service, authProvider auth.Provider, logger log.Logger) (*dash, error) {
+	hf := newHandlerFactory(apiHandler, authProvider)
 
 	return &dash{
 		mux:             cmux.New(listener),
@@ -627,15 +855,28 @@ func newDash(listener net.Listener, namespace, uiURL string, browserPath string,
 		willOpenBrowser: true,
 		apiHandler:      apiHandler,
 		pluginService:   pluginHandler,
+		authProvider:    authProvider,
 		logger:          logger,
 	}, nil
 }
 
+// newHandlerFactory creates a handler factory which serves apiHandler and the frontend.
+func newHandlerFactory(apiHandler api.Service, authProvider auth.Provider) *octant.HandlerFactory {
+	return octant.NewHandlerFactory(
+		octant.BackendHandler(apiHandler.Handler),
+		octant.FrontendURL(viper.GetString("proxy-frontend")),
+		octant.Authentication(authProvider))
+}
+
+// isLoopback returns true if addr is a loopback address.
+func isLoopback(addr net.Addr) bool {
+	tcpAddr, ok := addr.(*net.TCPAddr)
+	return ok && tcpAddr.IP.IsLoopback()
+}
+
 func (d *dash) SetAPIService(ctx context.Context, apiService api.Service) error {
 	d.apiHandler = apiService
-	hf := octant.NewHandlerFactory(
-		octant.BackendHandler(d.apiHandler.Handler),
-		octant.FrontendURL(viper.GetString("proxy-frontend")))
+	hf := newHandlerFactory(d.apiHandler, d.authProvider)
 	var err error
 	d.server.Handler, err = hf.Handler(ctx)
 	return err
@@ -33,6 +33,7 @@ import (
 
 	"github.com/vmware-tanzu/octant/internal/cluster"
 	internalLog "github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/metrics"
 	"github.com/vmware-tanzu/octant/pkg/event"
 
 	clusterFake "github.com/vmware-tanzu/octant/internal/cluster/fake"
@@ -181,18 +182,55 @@ func TestNewRunnerUsesClusterClient(t *testing.T) {
 	clusterClient := mockClusterClientReturningNamespace(controller, namespace)
 	listener := NewInMemoryListener()
 
+	sampler := newFakeMetricsSampler()
+
 	logger := internalLog.NopLogger()
-	cancel, err := makeRunner(
-		logger,
+	opts := []RunnerOption{
 		WithClusterClient(clusterClient),
 		WithListener(listener),
-	)
-	require.NoError(t, err)
+		withMetricsSamplerFactory(sampler.factory),
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
 	defer cancel()
+	runner, err := NewRunner(ctx, logger, opts...)
+	require.NoError(t, err)
+
+	select {
+	case <-sampler.started:
+		t.Fatal("NewRunner started the metrics sampler")
+	default:
+	}
+
+	go runner.Start(make(chan bool), make(chan bool), opts...)
 	namespacesEvent, err := waitForEventOfType(listener, event.EventTypeNamespaces)
 	require.NoError(t, err)
 
 	require.Equal(t, []interface{}{namespace}, namespacesEvent.Data["namespaces"].([]interface{}))
+
+	select {
+	case <-sampler.started:
+	case <-time.After(5 * time.Second):
+		t.Fatal("Start didn't start the metrics sampler")
+	}
+}
+
+// fakeMetricsSampler records when it is started instead of sampling the cluster.
+type fakeMetricsSampler struct {
+	started chan struct{}
+}
+
+func newFakeMetricsSampler() *fakeMetricsSampler {
+	return &fakeMetricsSampler{started: make(chan struct{})}
+}
+
+func (s *fakeMetricsSampler) factory(metrics.ClusterClientProvider, *metrics.MemoryHistory) metricsSampler {
+	return s
+}
+
+func (s *fakeMetricsSampler) Run(ctx context.Context) {
+	close(s.started)
+	<-ctx.Done()
 }
 
 func mockClusterClientReturningNamespace(controller *gomock.Controller, namespace string) cluster.ClientInterface {
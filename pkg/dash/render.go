@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dash
+
+import (
+	"context"
+	"fmt"
+	"strings"
+
+	"k8s.io/apimachinery/pkg/labels"
+
+	internalLog "github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/module"
+	"github.com/vmware-tanzu/octant/pkg/log"
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+type notFound interface {
+	NotFound() bool
+	Path() string
+}
+
+// Render generates the content for a content path without serving the dashboard.
+// Modules and plugins are started for the cluster selected by the options and are
+// stopped once the content has been generated.
+func Render(ctx context.Context, logger log.Logger, contentPath string, labelSet *labels.Set, opts ...RunnerOption) (component.ContentResponse, error) {
+	ctx, cancel := context.WithCancel(ctx)
+	defer cancel()
+
+	r, err := NewRunner(ctx, logger, opts...)
+	if err != nil {
+		return component.ContentResponse{}, err
+	}
+
+	if !r.apiCreated {
+		return component.ContentResponse{}, fmt.Errorf("no valid kube config found")
+	}
+
+	defer func() {
+		shutdownCtx := internalLog.WithLoggerContext(context.Background(), logger)
+		r.moduleManager.Unload()
+		r.pluginManager.Stop(shutdownCtx)
+	}()
+
+	return r.content(r.ctx, contentPath, labelSet)
+}
+
+// content generates the content for a content path using the module which owns it.
+func (r *Runner) content(ctx context.Context, contentPath string, labelSet *labels.Set) (component.ContentResponse, error) {
+	contentPath = strings.TrimPrefix(contentPath, "/")
+
+	m, ok := r.moduleManager.ModuleForContentPath(contentPath)
+	if !ok {
+		return component.ContentResponse{}, fmt.Errorf("unable to find module for content path %q", contentPath)
+	}
+
+	modulePath := strings.TrimPrefix(contentPath, m.Name())
+	contentResponse, err := m.Content(ctx, modulePath, module.ContentOptions{LabelSet: labelSet})
+	if err != nil {
+		if nfe, ok := err.(notFound); ok && nfe.NotFound() {
+			return component.ContentResponse{}, fmt.Errorf("content path %q not found", contentPath)
+		}
+		return component.ContentResponse{}, fmt.Errorf("generate content for %q: %w", contentPath, err)
+	}
+
+	return contentResponse, nil
+}
@@ -0,0 +1,100 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package dash
+
+import (
+	"context"
+	"io/ioutil"
+	"os"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	internalLog "github.com/vmware-tanzu/octant/internal/log"
+	"github.com/vmware-tanzu/octant/internal/util/json"
+)
+
+const renderManifest = `apiVersion: apps/v1
+kind: Deployment
+metadata:
+  name: web
+  namespace: default
+  labels:
+    app: web
+spec:
+  replicas: 1
+  selector:
+    matchLabels:
+      app: web
+  template:
+    metadata:
+      labels:
+        app: web
+    spec:
+      containers:
+        - name: nginx
+          image: nginx:1.20
+`
+
+func TestRender(t *testing.T) {
+	dir := renderSnapshot(t)
+
+	cases := []struct {
+		name        string
+		contentPath string
+		wantError   string
+	}{
+		{
+			name:        "deployments",
+			contentPath: "/overview/namespace/default/workloads/deployments",
+		},
+		{
+			name:        "deployment",
+			contentPath: "overview/namespace/default/workloads/deployments/web",
+		},
+		{
+			name:        "missing object",
+			contentPath: "overview/namespace/default/workloads/deployments/missing",
+			wantError:   "not found",
+		},
+		{
+			name:        "unknown module",
+			contentPath: "unknown/path",
+			wantError:   "unable to find module",
+		},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			ctx, cancel := context.WithCancel(context.Background())
+			defer cancel()
+
+			response, err := Render(ctx, internalLog.NopLogger(), tc.contentPath, nil,
+				WithSnapshot(dir), WithoutClusterOverview())
+			if tc.wantError != "" {
+				require.Error(t, err)
+				require.Contains(t, err.Error(), tc.wantError)
+				return
+			}
+			require.NoError(t, err)
+
+			require.NotEmpty(t, response.Components)
+			data, err := json.Marshal(response)
+			require.NoError(t, err)
+			require.Contains(t, string(data), `"web"`)
+		})
+	}
+}
+
+func renderSnapshot(t *testing.T) string {
+	dir, err := ioutil.TempDir("", "render")
+	require.NoError(t, err)
+	t.Cleanup(func() { os.RemoveAll(dir) })
+
+	require.NoError(t, ioutil.WriteFile(filepath.Join(dir, "app.yaml"), []byte(renderManifest), 0600))
+	return dir
+}
@@ -9,6 +9,7 @@ package event
 
 import (
 	"fmt"
+	"strings"
 )
 
 const (
@@ -60,6 +61,12 @@ const (
 	// EventTypeAppLogs is an app logs event.
 	EventTypeAppLogs EventType = "event.octant.dev/app-logs"
 
+	// EventTypeSearch is a search results event.
+	EventTypeSearch EventType = "event.octant.dev/search"
+
+	// EventTypeResourceViewerExport is an exported resource viewer graph event.
+	EventTypeResourceViewerExport EventType = "event.octant.dev/resourceViewerExport"
+
 	// EventTypeTerminalFormat is a string with format specifiers to assist in generating
 	// a terminal event type.
 	EventTypeTerminalFormat string = "event.octant.dev/terminals/namespace/%s/pod/%s/container/%s"
@@ -67,6 +74,14 @@ const (
 	// EventTypeLoggingFormat is a string with format specifiers to assist in generating
 	// a logging event type.
 	EventTypeLoggingFormat string = "event.octant.dev/logging/namespace/%s/pod/%s"
+
+	// EventTypeWorkloadLoggingFormat is a string with format specifiers to assist in generating
+	// a workload logging event type.
+	EventTypeWorkloadLoggingFormat string = "event.octant.dev/logging/namespace/%s/%s/%s"
+
+	// EventTypeSelectorLoggingFormat is a string with format specifiers to assist in generating
+	// a label selector logging event type.
+	EventTypeSelectorLoggingFormat string = "event.octant.dev/logging/namespace/%s/selector/%s"
 )
 
 // NewTerminalEventType returns an event type for a specific terminal instance.
@@ -81,6 +96,16 @@ func NewLoggingEventType(namespace, pod string) EventType {
 	return EventType(fmt.Sprintf(EventTypeLoggingFormat, namespace, pod))
 }
 
+// NewWorkloadLoggingEventType returns an event type for the logs of all pods in a workload.
+func NewWorkloadLoggingEventType(namespace, kind, name string) EventType {
+	return EventType(fmt.Sprintf(EventTypeWorkloadLoggingFormat, namespace, strings.ToLower(kind), name))
+}
+
+// NewSelectorLoggingEventType returns an event type for the logs of all pods matching a label selector.
+func NewSelectorLoggingEventType(namespace, selector string) EventType {
+	return EventType(fmt.Sprintf(EventTypeSelectorLoggingFormat, namespace, selector))
+}
+
 type EventType string
 
 // Event is an event for the dash frontend.
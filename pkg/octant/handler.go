@@ -17,6 +17,7 @@ import (
 	"github.com/gorilla/mux"
 
 	"github.com/vmware-tanzu/octant/internal/api"
+	"github.com/vmware-tanzu/octant/internal/auth"
 	"github.com/vmware-tanzu/octant/internal/log"
 )
 
@@ -28,6 +29,7 @@ type HandlerFactoryFunc func(ctx context.Context) (http.Handler, error)
 type HandlerFactory struct {
 	frontendHandler HandlerFactoryFunc
 	backendHandler  HandlerFactoryFunc
+	authProvider    auth.Provider
 
 	mu sync.RWMutex
 }
@@ -39,6 +41,7 @@ func NewHandlerFactory(optionList ...Option) *HandlerFactory {
 	hf := HandlerFactory{
 		frontendHandler: opts.frontendHandler,
 		backendHandler:  opts.backendHandler,
+		authProvider:    opts.authProvider,
 	}
 
 	return &hf
@@ -65,6 +68,13 @@ func (hf *HandlerFactory) Handler(ctx context.Context) (http.Handler, error) {
 		return nil, err
 	}
 
+	if hf.authProvider != nil {
+		authRouter := router.PathPrefix(auth.PathPrefix).Subrouter()
+		authRouter.Use(api.SameOriginHandler(ctx))
+		hf.authProvider.RegisterRoutes(authRouter)
+		router.Use(auth.Middleware(hf.authProvider))
+	}
+
 	router.PathPrefix(api.PathPrefix).Handler(backendHandler)
 
 	router.PathPrefix("/").HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
@@ -78,7 +88,7 @@ func (hf *HandlerFactory) Handler(ctx context.Context) (http.Handler, error) {
 	router.Use(noCacheRootMiddleware)
 
 	allowedOrigins := handlers.AllowedOrigins([]string{"*"})
-	allowedHeaders := handlers.AllowedHeaders([]string{"Accept", "Accept-Language", "Content-Language", "Origin", "Content-Type"})
+	allowedHeaders := handlers.AllowedHeaders([]string{"Accept", "Accept-Language", "Content-Language", "Origin", "Content-Type", "Authorization"})
 	allowedMethods := handlers.AllowedMethods([]string{"GET", "HEAD", "POST", "PUT", "DELETE", "OPTIONS"})
 
 	return handlers.CORS(allowedOrigins, allowedHeaders, allowedMethods)(router), nil
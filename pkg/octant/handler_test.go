@@ -18,6 +18,8 @@ import (
 	"testing"
 
 	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/octant/internal/auth"
 )
 
 func TestHandlerFactory_Handler(t *testing.T) {
@@ -187,3 +189,51 @@ func genTestURL(t *testing.T, base string, parts ...string) string {
 	u.Path = path.Join(append([]string{u.Path}, parts...)...)
 	return u.String()
 }
+
+func TestHandlerFactory_Handler_authentication(t *testing.T) {
+	provider, err := auth.NewTokenProvider("secret", nil)
+	require.NoError(t, err)
+
+	hf := NewHandlerFactory(
+		BackendHandler(func(ctx context.Context) (http.Handler, error) {
+			return http.HandlerFunc(func(w http.ResponseWriter, r *http.Request) {
+				fmt.Fprint(w, "backend")
+			}), nil
+		}),
+		Authentication(provider))
+
+	h, err := hf.Handler(context.Background())
+	require.NoError(t, err)
+
+	ts := httptest.NewServer(h)
+	defer ts.Close()
+
+	backendPath := genTestURL(t, ts.URL, "api", "v1", "foo")
+
+	res, err := http.Get(backendPath)
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusUnauthorized, res.StatusCode)
+
+	res, err = http.Get(genTestURL(t, ts.URL, auth.LoginPath))
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusOK, res.StatusCode)
+
+	req, err := http.NewRequest(http.MethodGet, backendPath, nil)
+	require.NoError(t, err)
+	req.Header.Set("Authorization", "Bearer secret")
+
+	res, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	require.Equal(t, "backend", string(readFromCloser(t, res.Body)))
+
+	req, err = http.NewRequest(http.MethodPost, genTestURL(t, ts.URL, auth.LogoutPath), nil)
+	require.NoError(t, err)
+	req.Header.Set("Origin", "http://example.com")
+
+	res, err = http.DefaultClient.Do(req)
+	require.NoError(t, err)
+	res.Body.Close()
+	require.Equal(t, http.StatusForbidden, res.StatusCode, "logout from another origin")
+}
@@ -10,6 +10,8 @@ import (
 	"context"
 	"fmt"
 	"net/http"
+
+	"github.com/vmware-tanzu/octant/internal/auth"
 )
 
 // options is an internal set of options that can be used to configure Octant. These are
@@ -21,6 +23,8 @@ type options struct {
 	frontendHandler func(ctx context.Context) (http.Handler, error)
 	// backendHandler is a function that creates a backend handler.
 	backendHandler func(ctx context.Context) (http.Handler, error)
+	// authProvider authenticates requests. Requests are not authenticated if it is nil.
+	authProvider auth.Provider
 }
 
 // buildOptions builds an options struct from a list of functional options.
@@ -85,6 +89,14 @@ func BackendHandler(fn func(ctx context.Context) (http.Handler, error)) Option {
 	}
 }
 
+// Authentication configures Octant to authenticate requests to both the frontend
+// and backend with provider.
+func Authentication(provider auth.Provider) Option {
+	return func(o *options) {
+		o.authProvider = provider
+	}
+}
+
 // defaultFrontendHandler is the default factory for creating a frontend handler.
 // TODO: this namespace should not know about the web namespace.
 func defaultFrontendHandler() (http.Handler, error) {
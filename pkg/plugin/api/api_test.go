@@ -15,11 +15,15 @@ import (
 	"github.com/spf13/viper"
 	"github.com/stretchr/testify/assert"
 	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 
+	"github.com/vmware-tanzu/octant/internal/auth"
 	"github.com/vmware-tanzu/octant/internal/gvk"
 	"github.com/vmware-tanzu/octant/internal/portforward"
 	portForwardFake "github.com/vmware-tanzu/octant/internal/portforward/fake"
 	"github.com/vmware-tanzu/octant/internal/testutil"
+	"github.com/vmware-tanzu/octant/pkg/action"
 	"github.com/vmware-tanzu/octant/pkg/plugin/api"
 	"github.com/vmware-tanzu/octant/pkg/store"
 	storeFake "github.com/vmware-tanzu/octant/pkg/store/fake"
@@ -142,6 +146,40 @@ func TestAPI(t *testing.T) {
 				assert.Equal(t, expected, got)
 			},
 		},
+		{
+			name: "port forward as user",
+			initFunc: func(t *testing.T, mocks *apiMocks) {
+				resp := portforward.CreateResponse{
+					ID: "12345",
+					Ports: []portforward.PortForwardPortSpec{
+						{Local: uint16(54321)},
+					},
+				}
+
+				mocks.pf.EXPECT().
+					Create(
+						gomock.Any(), gomock.Any(), gvk.Pod, "pod", "default", uint16(8080)).
+					DoAndReturn(func(ctx context.Context, alerter action.Alerter, _ schema.GroupVersionKind, _, _ string, _ uint16) (portforward.CreateResponse, error) {
+						user, ok := auth.UserFrom(ctx)
+						require.True(t, ok, "the port forward is created for the user")
+						assert.Equal(t, &auth.User{Name: "alice", Groups: []string{"dev", "ops"}}, user)
+						return resp, nil
+					})
+			},
+			doFunc: func(t *testing.T, client *api.Client) {
+				clientCtx, cancel := context.WithTimeout(context.Background(), 1*time.Second)
+				defer cancel()
+
+				// The plugin is handling a request Octant made for alice.
+				clientCtx = metadata.NewIncomingContext(clientCtx, metadata.Pairs(
+					"octant-user", "alice", "octant-groups", "dev", "octant-groups", "ops"))
+
+				got, err := client.PortForward(clientCtx, pfRequest)
+				require.NoError(t, err)
+
+				assert.Equal(t, pfResponse, got)
+			},
+		},
 		{
 			name: "port forward cancel",
 			initFunc: func(t *testing.T, mocks *apiMocks) {
@@ -173,7 +173,7 @@ func (c *Client) PortForward(ctx context.Context, req PortForwardRequest) (PortF
 		PodName:    req.PodName,
 		PortNumber: uint32(req.Port),
 	}
-	resp, err := client.PortForward(ctx, pfRequest)
+	resp, err := client.PortForward(OutgoingUserContext(ctx), pfRequest)
 	if err != nil {
 		return PortForwardResponse{}, err
 	}
@@ -282,7 +282,8 @@ func (c *grpcServer) PortForward(ctx context.Context, in *proto.PortForwardReque
 		return nil, err
 	}
 
-	pfResp, err := c.service.PortForward(ctx, *req)
+	// Plugins forward as the user whose request they are handling.
+	pfResp, err := c.service.PortForward(incomingUserContext(ctx), *req)
 	if err != nil {
 		return nil, err
 	}
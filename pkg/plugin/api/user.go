@@ -0,0 +1,70 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"context"
+
+	"google.golang.org/grpc/metadata"
+
+	"github.com/vmware-tanzu/octant/internal/auth"
+)
+
+// Metadata keys which carry the authenticated user in requests between Octant and
+// plugins.
+const (
+	userMetadataKey   = "octant-user"
+	groupsMetadataKey = "octant-groups"
+)
+
+// OutgoingUserContext returns a copy of ctx whose outgoing gRPC requests carry the
+// authenticated user. The user is taken from ctx, or from the metadata of the request
+// a plugin is handling, so requests a plugin makes while handling a user's request
+// are made for that user.
+func OutgoingUserContext(ctx context.Context) context.Context {
+	user, ok := auth.UserFrom(ctx)
+	if !ok {
+		user, ok = userFromMetadata(ctx)
+	}
+	if !ok {
+		return ctx
+	}
+
+	md := metadata.MD{userMetadataKey: []string{user.Name}}
+	if len(user.Groups) > 0 {
+		md[groupsMetadataKey] = user.Groups
+	}
+	if outgoing, ok := metadata.FromOutgoingContext(ctx); ok {
+		md = metadata.Join(outgoing, md)
+	}
+
+	return metadata.NewOutgoingContext(ctx, md)
+}
+
+// incomingUserContext returns a copy of ctx which contains the user carried by the
+// metadata of the incoming gRPC request.
+func incomingUserContext(ctx context.Context) context.Context {
+	user, ok := userFromMetadata(ctx)
+	if !ok {
+		return ctx
+	}
+
+	return auth.WithUser(ctx, user)
+}
+
+func userFromMetadata(ctx context.Context) (*auth.User, bool) {
+	md, ok := metadata.FromIncomingContext(ctx)
+	if !ok {
+		return nil, false
+	}
+
+	names := md.Get(userMetadataKey)
+	if len(names) == 0 || names[0] == "" {
+		return nil, false
+	}
+
+	return &auth.User{Name: names[0], Groups: md.Get(groupsMetadataKey)}, true
+}
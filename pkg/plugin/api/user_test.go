@@ -0,0 +1,39 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package api
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"google.golang.org/grpc/metadata"
+
+	"github.com/vmware-tanzu/octant/internal/auth"
+)
+
+func TestOutgoingUserContext(t *testing.T) {
+	user := &auth.User{Name: "alice", Groups: []string{"dev"}}
+
+	ctx := OutgoingUserContext(auth.WithUser(context.Background(), user))
+	md, ok := metadata.FromOutgoingContext(ctx)
+	require.True(t, ok)
+
+	// The receiving side sees the user in the incoming metadata.
+	got, ok := auth.UserFrom(incomingUserContext(metadata.NewIncomingContext(context.Background(), md)))
+	require.True(t, ok)
+	assert.Equal(t, user, got)
+}
+
+func TestOutgoingUserContext_withoutUser(t *testing.T) {
+	ctx := OutgoingUserContext(context.Background())
+	_, ok := metadata.FromOutgoingContext(ctx)
+	assert.False(t, ok)
+
+	_, ok = auth.UserFrom(incomingUserContext(context.Background()))
+	assert.False(t, ok)
+}
@@ -21,6 +21,7 @@ import (
 
 	"github.com/vmware-tanzu/octant/pkg/action"
 	"github.com/vmware-tanzu/octant/pkg/navigation"
+	"github.com/vmware-tanzu/octant/pkg/plugin/api"
 	"github.com/vmware-tanzu/octant/pkg/plugin/dashboard"
 	"github.com/vmware-tanzu/octant/pkg/view/component"
 )
@@ -59,7 +60,7 @@ func (c *GRPCClient) Content(ctx context.Context, contentPath string) (component
 			Path: contentPath,
 		}
 
-		resp, err := c.client.Content(ctx, req, grpc.WaitForReady(true))
+		resp, err := c.client.Content(api.OutgoingUserContext(ctx), req, grpc.WaitForReady(true))
 		if err != nil {
 			return errors.Wrap(err, "grpc client content")
 		}
@@ -93,7 +94,7 @@ func (c *GRPCClient) HandleAction(ctx context.Context, actionName string, payloa
 			ClientID:   clientID,
 		}
 
-		_, err = c.client.HandleAction(ctx, req, grpc.WaitForReady(true))
+		_, err = c.client.HandleAction(api.OutgoingUserContext(ctx), req, grpc.WaitForReady(true))
 		if err != nil {
 			if s, isStatus := status.FromError(err); isStatus {
 				return errors.Errorf("grpc error: %s", s.Message())
@@ -0,0 +1,67 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package store
+
+import (
+	"context"
+	"errors"
+
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+)
+
+// ErrReadOnly is returned when a read-only store is asked to change an object.
+var ErrReadOnly = errors.New("octant is running in read-only mode")
+
+// readOnly is a Store which rejects mutations.
+type readOnly struct {
+	Store
+}
+
+var _ Store = (*readOnly)(nil)
+
+// NewReadOnly wraps a store so Create, Update, Delete and CreateOrUpdateFromYAML
+// return ErrReadOnly. Reads and watches are passed to the wrapped store.
+func NewReadOnly(s Store) Store {
+	if IsReadOnly(s) {
+		return s
+	}
+
+	return &readOnly{Store: s}
+}
+
+// IsReadOnly returns true if the store rejects mutations.
+func IsReadOnly(s Store) bool {
+	_, ok := s.(*readOnly)
+	return ok
+}
+
+// Delete returns ErrReadOnly.
+func (r *readOnly) Delete(ctx context.Context, key Key) error {
+	return ErrReadOnly
+}
+
+// Update returns ErrReadOnly.
+func (r *readOnly) Update(ctx context.Context, key Key, updater func(*unstructured.Unstructured) error) error {
+	return ErrReadOnly
+}
+
+// Create returns ErrReadOnly.
+func (r *readOnly) Create(ctx context.Context, object *unstructured.Unstructured) error {
+	return ErrReadOnly
+}
+
+// CreateOrUpdateFromYAML returns ErrReadOnly.
+func (r *readOnly) CreateOrUpdateFromYAML(ctx context.Context, namespace, input string) ([]string, error) {
+	return nil, ErrReadOnly
+}
+
+// RegisterOnUpdate registers fn with the wrapped store. The store passed to fn is
+// wrapped so it stays read-only after the cluster client changes.
+func (r *readOnly) RegisterOnUpdate(fn UpdateFn) {
+	r.Store.RegisterOnUpdate(func(s Store) {
+		fn(NewReadOnly(s))
+	})
+}
@@ -0,0 +1,75 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package store_test
+
+import (
+	"context"
+	"testing"
+
+	"github.com/golang/mock/gomock"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+
+	"github.com/vmware-tanzu/octant/internal/testutil"
+	"github.com/vmware-tanzu/octant/pkg/store"
+	"github.com/vmware-tanzu/octant/pkg/store/fake"
+)
+
+func TestReadOnly(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	ctx := context.Background()
+	pod := testutil.ToUnstructured(t, testutil.CreatePod("pod"))
+	key, err := store.KeyFromObject(pod)
+	require.NoError(t, err)
+
+	objectStore := fake.NewMockStore(controller)
+	objectStore.EXPECT().Get(ctx, key).Return(pod, nil)
+
+	s := store.NewReadOnly(objectStore)
+	require.True(t, store.IsReadOnly(s))
+	require.False(t, store.IsReadOnly(objectStore))
+	require.Equal(t, s, store.NewReadOnly(s))
+
+	got, err := s.Get(ctx, key)
+	require.NoError(t, err)
+	assert.Equal(t, pod, got)
+
+	assert.Equal(t, store.ErrReadOnly, s.Create(ctx, pod))
+	assert.Equal(t, store.ErrReadOnly, s.Delete(ctx, key))
+	assert.Equal(t, store.ErrReadOnly, s.Update(ctx, key, func(*unstructured.Unstructured) error {
+		return nil
+	}))
+
+	created, err := s.CreateOrUpdateFromYAML(ctx, "default", "kind: Pod")
+	assert.Equal(t, store.ErrReadOnly, err)
+	assert.Empty(t, created)
+}
+
+func TestReadOnly_RegisterOnUpdate(t *testing.T) {
+	controller := gomock.NewController(t)
+	defer controller.Finish()
+
+	objectStore := fake.NewMockStore(controller)
+
+	var registered store.UpdateFn
+	objectStore.EXPECT().RegisterOnUpdate(gomock.Any()).
+		Do(func(fn store.UpdateFn) {
+			registered = fn
+		})
+
+	var updated store.Store
+	store.NewReadOnly(objectStore).RegisterOnUpdate(func(s store.Store) {
+		updated = s
+	})
+
+	require.NotNil(t, registered)
+	registered(objectStore)
+
+	assert.True(t, store.IsReadOnly(updated))
+}
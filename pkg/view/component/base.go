@@ -18,6 +18,8 @@ const (
 	TypeCode = "codeBlock"
 	// TypeContainers is a container component.
 	TypeContainers = "containers"
+	// TypeDiff is a side-by-side diff component.
+	TypeDiff = "diff"
 	// TypeDonutChart is a donut chart component.
 	TypeDonutChart = "donutChart"
 	// TypeDropdown is a dropdown component.
@@ -42,6 +44,8 @@ const (
 	TypeLabels = "labels"
 	// TypeLabelSelector is a label selector component.
 	TypeLabelSelector = "labelSelector"
+	// TypeLineChart is a line chart component.
+	TypeLineChart = "lineChart"
 	// TypeLink is a link component.
 	TypeLink = "link"
 	// TypeList is a list component.
@@ -74,6 +78,8 @@ const (
 	TypeTable = "table"
 	// TypeTerminal is a terminal component.
 	TypeTerminal = "terminal"
+	// TypeTerminalReplay is a terminal recording replay component.
+	TypeTerminalReplay = "terminalReplay"
 	// TypeText is a text component.
 	TypeText = "text"
 	// TypeTimeline is a timeline component.
@@ -0,0 +1,74 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"github.com/vmware-tanzu/octant/internal/util/json"
+)
+
+// DiffConfig is the contents of Diff.
+type DiffConfig struct {
+	// Original is the text shown on the left side of the diff.
+	Original string `json:"original"`
+	// Modified is the text shown on the right side of the diff.
+	Modified string `json:"modified"`
+	// Language is the language used to highlight the text.
+	Language string `json:"language,omitempty"`
+	// Versions are texts the user can choose to compare. Original and Modified
+	// are the texts compared initially.
+	Versions []DiffVersion `json:"versions,omitempty"`
+}
+
+// DiffVersion is a named text which can be compared in a Diff.
+type DiffVersion struct {
+	Name  string `json:"name"`
+	Value string `json:"value"`
+}
+
+// Diff shows the differences between two texts side by side.
+//
+// +octant:component
+type Diff struct {
+	Base
+	Config DiffConfig `json:"config"`
+}
+
+var _ Component = (*Diff)(nil)
+
+// NewDiff creates a diff component for original and modified.
+func NewDiff(title []TitleComponent, original, modified string) *Diff {
+	return &Diff{
+		Base: newBase(TypeDiff, title),
+		Config: DiffConfig{
+			Original: original,
+			Modified: modified,
+			Language: "yaml",
+		},
+	}
+}
+
+// SetVersions sets the versions the user can compare. The last two versions
+// are compared initially.
+func (d *Diff) SetVersions(versions []DiffVersion) {
+	d.Config.Versions = versions
+
+	switch n := len(versions); {
+	case n == 1:
+		d.Config.Original = ""
+		d.Config.Modified = versions[0].Value
+	case n > 1:
+		d.Config.Original = versions[n-2].Value
+		d.Config.Modified = versions[n-1].Value
+	}
+}
+
+type diffMarshal Diff
+
+func (d *Diff) MarshalJSON() ([]byte, error) {
+	m := diffMarshal(*d)
+	m.Metadata.Type = TypeDiff
+	return json.Marshal(&m)
+}
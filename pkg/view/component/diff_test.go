@@ -0,0 +1,45 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_Diff_Marshal(t *testing.T) {
+	diff := NewDiff(TitleFromString("Deployment default/nginx"),
+		"spec:\n  replicas: 1\n", "spec:\n  replicas: 2\n")
+
+	got, err := diff.MarshalJSON()
+	require.NoError(t, err)
+
+	expected, err := ioutil.ReadFile(filepath.Join("testdata", "diff.json"))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(expected), string(got))
+}
+
+func TestDiff_SetVersions(t *testing.T) {
+	diff := NewDiff(nil, "", "")
+	diff.SetVersions([]DiffVersion{
+		{Name: "1", Value: "a"},
+		{Name: "2", Value: "b"},
+		{Name: "3", Value: "c"},
+	})
+
+	assert.Equal(t, "b", diff.Config.Original)
+	assert.Equal(t, "c", diff.Config.Modified)
+	assert.Len(t, diff.Config.Versions, 3)
+
+	diff.SetVersions([]DiffVersion{{Name: "1", Value: "a"}})
+	assert.Equal(t, "", diff.Config.Original)
+	assert.Equal(t, "a", diff.Config.Modified)
+}
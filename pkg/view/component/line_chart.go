@@ -0,0 +1,82 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"time"
+
+	"github.com/vmware-tanzu/octant/internal/util/json"
+)
+
+// LineChartPoint is a single point in a line chart series.
+type LineChartPoint struct {
+	// Timestamp is the time of the point in unix seconds.
+	Timestamp int64 `json:"timestamp"`
+	// Value is the value of the point.
+	Value float64 `json:"value"`
+}
+
+// LineChartSeries is a named series of points.
+type LineChartSeries struct {
+	Name   string           `json:"name"`
+	Color  string           `json:"color,omitempty"`
+	Points []LineChartPoint `json:"points"`
+}
+
+// LineChartConfig is the contents of LineChart.
+type LineChartConfig struct {
+	Title  string            `json:"title"`
+	Unit   string            `json:"unit,omitempty"`
+	Area   bool              `json:"area,omitempty"`
+	Series []LineChartSeries `json:"series"`
+}
+
+// LineChart is a time series chart. Series can be rendered as lines or areas.
+//
+// +octant:component
+type LineChart struct {
+	Base
+	Config LineChartConfig `json:"config"`
+}
+
+var _ Component = (*LineChart)(nil)
+
+// NewLineChart creates a line chart.
+func NewLineChart(title, unit string) *LineChart {
+	return &LineChart{
+		Base: newBase(TypeLineChart, nil),
+		Config: LineChartConfig{
+			Title: title,
+			Unit:  unit,
+		},
+	}
+}
+
+// SetArea renders the chart's series as filled areas.
+func (lc *LineChart) SetArea(area bool) {
+	lc.Config.Area = area
+}
+
+// AddSeries adds a series to the chart.
+func (lc *LineChart) AddSeries(series ...LineChartSeries) {
+	lc.Config.Series = append(lc.Config.Series, series...)
+}
+
+// NewLineChartPoint creates a line chart point.
+func NewLineChartPoint(ts time.Time, value float64) LineChartPoint {
+	return LineChartPoint{
+		Timestamp: ts.Unix(),
+		Value:     value,
+	}
+}
+
+type lineChartMarshal LineChart
+
+func (lc *LineChart) MarshalJSON() ([]byte, error) {
+	m := lineChartMarshal(*lc)
+	m.Metadata.Type = TypeLineChart
+	return json.Marshal(&m)
+}
@@ -0,0 +1,36 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_LineChart_Marshal(t *testing.T) {
+	lineChart := NewLineChart("CPU", "m")
+	lineChart.SetArea(true)
+	lineChart.AddSeries(LineChartSeries{
+		Name: "usage",
+		Points: []LineChartPoint{
+			NewLineChartPoint(time.Unix(1600000000, 0), 12),
+			NewLineChartPoint(time.Unix(1600000030, 0), 15.5),
+		},
+	})
+
+	got, err := lineChart.MarshalJSON()
+	require.NoError(t, err)
+
+	expected, err := ioutil.ReadFile(filepath.Join("testdata", "line_chart.json"))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(expected), string(got))
+}
@@ -25,6 +25,7 @@ type Since struct {
 type LogsConfig struct {
 	Namespace  string   `json:"namespace,omitempty"`
 	Name       string   `json:"name,omitempty"`
+	Kind       string   `json:"kind,omitempty"`
 	Containers []string `json:"containers,omitempty"`
 	Durations  []Since  `json:"durations,omitempty"`
 }
@@ -49,6 +50,14 @@ func NewLogs(namespace, name string, containers ...string) *Logs {
 	}
 }
 
+// NewWorkloadLogs creates a logs component which aggregates the logs of all
+// the pods in a workload.
+func NewWorkloadLogs(namespace, kind, name string, containers ...string) *Logs {
+	l := NewLogs(namespace, name, containers...)
+	l.Config.Kind = kind
+	return l
+}
+
 // GetMetadata accesses the components metadata. Implements Component.
 func (l *Logs) GetMetadata() Metadata {
 	return l.Metadata
@@ -119,6 +119,8 @@ type ResourceViewerConfig struct {
 	Edges    AdjList `json:"edges,omitempty"`
 	Nodes    Nodes   `json:"nodes,omitempty"`
 	Selected string  `json:"selected,omitempty"`
+	// ExportPath is the API path the graph can be exported from.
+	ExportPath string `json:"exportPath,omitempty"`
 }
 
 // ResourceView is a resource viewer component.
@@ -168,6 +170,11 @@ func (rv *ResourceViewer) Select(id string) {
 	rv.Config.Selected = id
 }
 
+// SetExportPath sets the API path the graph can be exported from.
+func (rv *ResourceViewer) SetExportPath(path string) {
+	rv.Config.ExportPath = path
+}
+
 func (rv *ResourceViewer) GetMetadata() Metadata {
 	return rv.Metadata
 }
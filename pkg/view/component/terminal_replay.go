@@ -0,0 +1,55 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"github.com/vmware-tanzu/octant/internal/util/json"
+)
+
+// TerminalReplayConfig is the contents of TerminalReplay.
+type TerminalReplayConfig struct {
+	// Name is the name of the recording.
+	Name string `json:"name"`
+	// URL is the path of the asciicast recording relative to the API prefix.
+	URL       string `json:"url"`
+	Container string `json:"container,omitempty"`
+	Command   string `json:"command,omitempty"`
+}
+
+// TerminalReplay plays back a recorded terminal session.
+//
+// +octant:component
+type TerminalReplay struct {
+	Base
+	Config TerminalReplayConfig `json:"config"`
+}
+
+var _ Component = (*TerminalReplay)(nil)
+
+// NewTerminalReplay creates a terminal replay component for the recording at url.
+func NewTerminalReplay(name, url string) *TerminalReplay {
+	return &TerminalReplay{
+		Base: newBase(TypeTerminalReplay, nil),
+		Config: TerminalReplayConfig{
+			Name: name,
+			URL:  url,
+		},
+	}
+}
+
+// SetSession sets the container and command of the recorded session.
+func (tr *TerminalReplay) SetSession(container, command string) {
+	tr.Config.Container = container
+	tr.Config.Command = command
+}
+
+type terminalReplayMarshal TerminalReplay
+
+func (tr *TerminalReplay) MarshalJSON() ([]byte, error) {
+	m := terminalReplayMarshal(*tr)
+	m.Metadata.Type = TypeTerminalReplay
+	return json.Marshal(&m)
+}
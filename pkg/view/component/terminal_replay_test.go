@@ -0,0 +1,29 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package component
+
+import (
+	"io/ioutil"
+	"path/filepath"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func Test_TerminalReplay_Marshal(t *testing.T) {
+	replay := NewTerminalReplay("app-1600000000000000000.cast",
+		"/terminal/recordings/namespace/default/pod/pod/app-1600000000000000000.cast")
+	replay.SetSession("app", "bash")
+
+	got, err := replay.MarshalJSON()
+	require.NoError(t, err)
+
+	expected, err := ioutil.ReadFile(filepath.Join("testdata", "terminal_replay.json"))
+	require.NoError(t, err)
+
+	assert.JSONEq(t, string(expected), string(got))
+}
@@ -216,6 +216,55 @@ func Test_unmarshal(t *testing.T) {
 				Base: newBase(TypeLabels, nil),
 			},
 		},
+		{
+			name:       "terminalReplay",
+			configFile: "config_terminal_replay.json",
+			objectType: "terminalReplay",
+			expected: &TerminalReplay{
+				Config: TerminalReplayConfig{
+					Name:      "app-1600000000000000000.cast",
+					URL:       "/terminal/recordings/namespace/default/pod/pod/app-1600000000000000000.cast",
+					Container: "app",
+					Command:   "bash",
+				},
+				Base: newBase(TypeTerminalReplay, nil),
+			},
+		},
+		{
+			name:       "diff",
+			configFile: "config_diff.json",
+			objectType: "diff",
+			expected: &Diff{
+				Config: DiffConfig{
+					Original: "spec:\n  replicas: 1\n",
+					Modified: "spec:\n  replicas: 2\n",
+					Language: "yaml",
+				},
+				Base: newBase(TypeDiff, nil),
+			},
+		},
+		{
+			name:       "lineChart",
+			configFile: "config_line_chart.json",
+			objectType: "lineChart",
+			expected: &LineChart{
+				Config: LineChartConfig{
+					Title: "CPU",
+					Unit:  "m",
+					Area:  true,
+					Series: []LineChartSeries{
+						{
+							Name: "usage",
+							Points: []LineChartPoint{
+								{Timestamp: 1600000000, Value: 12},
+								{Timestamp: 1600000030, Value: 15.5},
+							},
+						},
+					},
+				},
+				Base: newBase(TypeLineChart, nil),
+			},
+		},
 		{
 			name:       "link",
 			configFile: "config_link.json",
@@ -0,0 +1,328 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+// Package html renders content responses as static HTML documents. Documents are
+// self-contained: styles are inlined and there are no scripts, so they can be
+// stored as CI artifacts and viewed without Octant.
+package html
+
+import (
+	"bytes"
+	"fmt"
+	"html"
+	"io"
+	"net/url"
+	"sort"
+	"strings"
+	"time"
+
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+const style = `
+body { font-family: -apple-system, BlinkMacSystemFont, "Segoe UI", Helvetica, Arial, sans-serif; font-size: 14px; color: #313131; margin: 24px; }
+h1 { font-size: 22px; font-weight: 400; }
+h2 { font-size: 16px; font-weight: 500; margin: 16px 0 8px; }
+a { color: #0072a3; text-decoration: none; }
+table { border-collapse: collapse; width: 100%; margin-bottom: 16px; }
+th, td { border: 1px solid #ddd; padding: 6px 8px; text-align: left; vertical-align: top; }
+th { background: #fafafa; font-weight: 500; }
+pre { background: #fafafa; border: 1px solid #ddd; padding: 8px; overflow-x: auto; }
+.card { border: 1px solid #ddd; border-radius: 3px; padding: 8px 12px; margin-bottom: 16px; }
+.flex { display: flex; flex-wrap: wrap; margin: 0 -8px; }
+.flex > div { box-sizing: border-box; padding: 0 8px; }
+.label { display: inline-block; background: #e8e8e8; border-radius: 3px; padding: 1px 6px; margin: 1px 2px; }
+.empty { color: #8c8c8c; }
+.ok { color: #2f8400; }
+.warning { color: #c27b00; }
+.error { color: #c21d00; }
+.alert { border: 1px solid #ddd; padding: 8px; margin-bottom: 8px; }
+`
+
+// flexWidth is the number of width units in a flex layout row.
+const flexWidth = 24
+
+// Render writes a content response as an HTML document.
+func Render(w io.Writer, response component.ContentResponse) error {
+	r := &renderer{}
+
+	title := titleText(response.Title)
+	if title == "" && len(response.Components) > 0 {
+		title = titleText(response.Components[0].GetMetadata().Title)
+	}
+
+	r.printf("<!DOCTYPE html>\n<html>\n<head>\n<meta charset=\"utf-8\">\n")
+	r.printf("<title>%s</title>\n<style>%s</style>\n</head>\n<body>\n", html.EscapeString(title), style)
+	if len(response.Title) > 0 {
+		r.printf("<h1>%s</h1>\n", html.EscapeString(title))
+	}
+
+	for _, c := range response.Components {
+		r.render(c)
+	}
+
+	r.printf("</body>\n</html>\n")
+
+	_, err := w.Write(r.buf.Bytes())
+	return err
+}
+
+type renderer struct {
+	buf bytes.Buffer
+
+	// heading and headingEnd track the last heading so a component titled the same
+	// as its container doesn't repeat the heading.
+	heading    string
+	headingEnd int
+}
+
+func (r *renderer) printf(format string, a ...interface{}) {
+	fmt.Fprintf(&r.buf, format, a...)
+}
+
+func (r *renderer) text(s string) {
+	r.buf.WriteString(html.EscapeString(s))
+}
+
+// link renders a link. Links to other Octant views are relative to the dashboard
+// and can't be followed from a static document, so only their text is rendered.
+func (r *renderer) link(ref, text string) {
+	u, err := url.Parse(ref)
+	if err != nil || !u.IsAbs() {
+		r.text(text)
+		return
+	}
+
+	r.printf("<a href=\"%s\">", html.EscapeString(ref))
+	r.text(text)
+	r.printf("</a>")
+}
+
+// render renders a component. Components without an HTML representation are
+// rendered as their string value.
+func (r *renderer) render(c component.Component) {
+	if c == nil {
+		return
+	}
+
+	switch c := c.(type) {
+	case *component.Text:
+		r.status(c.Config.Status, func() {
+			if c.Config.IsMarkdown {
+				r.printf("<pre>")
+				r.text(c.Config.Text)
+				r.printf("</pre>")
+				return
+			}
+			r.text(c.Config.Text)
+		})
+	case *component.Link:
+		r.status(c.Config.Status, func() {
+			r.link(c.Config.Ref, c.Config.Text)
+		})
+	case *component.Timestamp:
+		r.text(time.Unix(c.Config.Timestamp, 0).UTC().Format(time.RFC3339))
+	case *component.Code:
+		r.pre(c.Config.Code)
+	case *component.YAML:
+		r.titled(c, func() { r.pre(c.Config.Data) })
+	case *component.Graphviz:
+		r.pre(c.Config.DOT)
+	case *component.Error:
+		r.printf("<div class=\"error\">")
+		r.text(c.Config.Data)
+		r.printf("</div>\n")
+	case *component.Labels:
+		r.labels(c.Config.Labels)
+	case *component.Annotations:
+		r.labels(c.Config.Annotations)
+	case *component.Containers:
+		for _, container := range c.Config.Containers {
+			r.printf("<div>")
+			r.text(container.Name + ": " + container.Image)
+			r.printf("</div>")
+		}
+	case *component.Ports:
+		for _, port := range c.Config.Ports {
+			r.printf("<div>")
+			r.text(fmt.Sprintf("%d/%s", port.Config.Port, port.Config.Protocol))
+			r.printf("</div>")
+		}
+	case *component.Selectors:
+		for _, selector := range c.Config.Selectors {
+			r.printf("<span class=\"label\">")
+			r.text(selectorText(selector))
+			r.printf("</span>")
+		}
+	case *component.List:
+		r.titled(c, func() {
+			for _, item := range c.Config.Items {
+				r.render(item)
+			}
+		})
+	case *component.Card:
+		r.printf("<div class=\"card\">\n")
+		r.titled(c, func() {
+			r.render(c.Config.Body)
+		})
+		r.printf("</div>\n")
+	case *component.FlexLayout:
+		for _, section := range c.Config.Sections {
+			r.printf("<div class=\"flex\">\n")
+			for _, item := range section {
+				width := item.Width
+				if width <= 0 || width > flexWidth {
+					width = flexWidth
+				}
+				r.printf("<div style=\"width: %.2f%%\">\n", float64(width)*100/flexWidth)
+				r.render(item.View)
+				r.printf("</div>\n")
+			}
+			r.printf("</div>\n")
+		}
+	case *component.Summary:
+		r.titled(c, func() {
+			if c.Config.Alert != nil {
+				r.alert(*c.Config.Alert)
+			}
+			r.printf("<table>\n")
+			for _, section := range c.Config.Sections {
+				r.printf("<tr><th>")
+				r.text(section.Header)
+				r.printf("</th><td>")
+				r.render(section.Content)
+				r.printf("</td></tr>\n")
+			}
+			r.printf("</table>\n")
+		})
+	case *component.Table:
+		r.titled(c, func() { r.table(c) })
+	case *component.Quadrant:
+		r.printf("<table>\n<tr>")
+		for _, value := range []component.QuadrantValue{c.Config.NW, c.Config.NE, c.Config.SW, c.Config.SE} {
+			r.printf("<td>")
+			r.text(value.Label + ": " + value.Value)
+			r.printf("</td>")
+		}
+		r.printf("</tr>\n</table>\n")
+	case *component.SingleStat:
+		r.printf("<div class=\"card\"><h2>")
+		r.text(c.Config.Title)
+		r.printf("</h2>")
+		r.text(c.Config.Value.Text)
+		r.printf("</div>\n")
+	default:
+		r.titled(c, func() { r.text(c.String()) })
+	}
+}
+
+// titled renders a component's title as a heading before the component.
+func (r *renderer) titled(c component.Component, fn func()) {
+	title := titleText(c.GetMetadata().Title)
+	if title != "" && (title != r.heading || r.buf.Len() != r.headingEnd) {
+		r.printf("<h2>")
+		r.text(title)
+		r.printf("</h2>\n")
+		r.heading, r.headingEnd = title, r.buf.Len()
+	}
+	fn()
+}
+
+func (r *renderer) table(t *component.Table) {
+	rows := t.Rows()
+	if len(rows) == 0 {
+		r.printf("<p class=\"empty\">")
+		r.text(t.Config.EmptyContent)
+		r.printf("</p>\n")
+		return
+	}
+
+	columns := t.Columns()
+
+	r.printf("<table>\n<tr>")
+	for _, column := range columns {
+		r.printf("<th>")
+		r.text(column.Name)
+		r.printf("</th>")
+	}
+	r.printf("</tr>\n")
+
+	for _, row := range rows {
+		r.printf("<tr>")
+		for _, column := range columns {
+			r.printf("<td>")
+			r.render(row[column.Accessor])
+			r.printf("</td>")
+		}
+		r.printf("</tr>\n")
+	}
+	r.printf("</table>\n")
+}
+
+func (r *renderer) status(status component.TextStatus, fn func()) {
+	class := ""
+	switch status {
+	case component.TextStatusOK:
+		class = "ok"
+	case component.TextStatusWarning:
+		class = "warning"
+	case component.TextStatusError:
+		class = "error"
+	}
+
+	if class == "" {
+		fn()
+		return
+	}
+
+	r.printf("<span class=\"%s\">", class)
+	fn()
+	r.printf("</span>")
+}
+
+func (r *renderer) pre(s string) {
+	r.printf("<pre>")
+	r.text(s)
+	r.printf("</pre>\n")
+}
+
+func (r *renderer) alert(alert component.Alert) {
+	r.printf("<div class=\"alert %s\">", html.EscapeString(string(alert.Type)))
+	r.text(alert.Message)
+	r.printf("</div>\n")
+}
+
+func (r *renderer) labels(m map[string]string) {
+	var keys []string
+	for key := range m {
+		keys = append(keys, key)
+	}
+	sort.Strings(keys)
+
+	for _, key := range keys {
+		r.printf("<span class=\"label\">")
+		r.text(key + ":" + m[key])
+		r.printf("</span>")
+	}
+}
+
+func selectorText(selector component.Selector) string {
+	switch s := selector.(type) {
+	case *component.LabelSelector:
+		return s.Config.Key + ":" + s.Config.Value
+	case *component.ExpressionSelector:
+		return fmt.Sprintf("%s %s [%s]", s.Config.Key, s.Config.Operator, strings.Join(s.Config.Values, ", "))
+	default:
+		return selector.Name()
+	}
+}
+
+func titleText(title []component.TitleComponent) string {
+	var parts []string
+	for _, tc := range title {
+		parts = append(parts, tc.String())
+	}
+	return strings.Join(parts, " / ")
+}
@@ -0,0 +1,77 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+package html
+
+import (
+	"bytes"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+
+	"github.com/vmware-tanzu/octant/pkg/view/component"
+)
+
+func TestRender(t *testing.T) {
+	table := component.NewTableWithRows("Deployments", "We couldn't find any deployments!",
+		component.NewTableCols("Name", "Labels", "Age"),
+		[]component.TableRow{
+			{
+				"Name":   component.NewLink("", "web", "/overview/namespace/default/workloads/deployments/web"),
+				"Labels": component.NewLabels(map[string]string{"tier": "frontend", "app": "web"}),
+				"Age":    component.NewTimestamp(time.Date(2021, 4, 1, 12, 0, 0, 0, time.UTC)),
+			},
+		})
+
+	list := component.NewList(component.TitleFromString("Deployments"), []component.Component{table})
+
+	summary := component.NewSummary("Configuration", component.SummarySection{
+		Header:  "Script",
+		Content: component.NewText("<script>alert(1)</script>"),
+	})
+
+	response := component.ContentResponse{
+		Title:      component.TitleFromString("Workloads"),
+		Components: []component.Component{list, summary},
+	}
+
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, response))
+	got := buf.String()
+
+	assert.Contains(t, got, "<title>Workloads</title>")
+	assert.Contains(t, got, "<h1>Workloads</h1>")
+	assert.Equal(t, 1, bytes.Count(buf.Bytes(), []byte("<h2>Deployments</h2>")), "nested component with the same title repeats the heading")
+	assert.Contains(t, got, `<th>Name</th><th>Labels</th><th>Age</th>`)
+	assert.Contains(t, got, `<td>web</td>`)
+	assert.NotContains(t, got, `href="/overview`, "links to dashboard views can't be followed from a static document")
+	assert.Contains(t, got, `<span class="label">app:web</span><span class="label">tier:frontend</span>`)
+	assert.Contains(t, got, "2021-04-01T12:00:00Z")
+	assert.Contains(t, got, "<h2>Configuration</h2>")
+	assert.Contains(t, got, "<tr><th>Script</th><td>&lt;script&gt;alert(1)&lt;/script&gt;</td></tr>")
+	assert.NotContains(t, got, "<script>")
+}
+
+func TestRender_emptyTable(t *testing.T) {
+	table := component.NewTable("Pods", "We couldn't find any pods!", component.NewTableCols("Name"))
+
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, component.ContentResponse{Components: []component.Component{table}}))
+
+	assert.Contains(t, buf.String(), "<title>Pods</title>")
+	assert.NotContains(t, buf.String(), "<h1>")
+	assert.Contains(t, buf.String(), `<p class="empty">We couldn&#39;t find any pods!</p>`)
+}
+
+func TestRender_externalLink(t *testing.T) {
+	link := component.NewLink("", "docs", "https://octant.dev/docs")
+
+	var buf bytes.Buffer
+	require.NoError(t, Render(&buf, component.ContentResponse{Components: []component.Component{link}}))
+
+	assert.Contains(t, buf.String(), `<a href="https://octant.dev/docs">docs</a>`)
+}
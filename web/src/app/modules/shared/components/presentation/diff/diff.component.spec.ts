@@ -0,0 +1,73 @@
+/* Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { ComponentFixture, TestBed, waitForAsync } from '@angular/core/testing';
+import { FormsModule } from '@angular/forms';
+import { MonacoEditorModule } from '@materia-ui/ngx-monaco-editor';
+import { DiffComponent } from './diff.component';
+import { DiffView } from '../../../models/content';
+
+describe('DiffComponent', () => {
+  let component: DiffComponent;
+  let fixture: ComponentFixture<DiffComponent>;
+
+  beforeEach(
+    waitForAsync(() => {
+      TestBed.configureTestingModule({
+        imports: [MonacoEditorModule, FormsModule],
+        declarations: [DiffComponent],
+      }).compileComponents();
+    })
+  );
+
+  beforeEach(() => {
+    fixture = TestBed.createComponent(DiffComponent);
+    component = fixture.componentInstance;
+    const view: DiffView = {
+      metadata: {
+        type: 'diff',
+      },
+      config: {
+        original: 'replicas: 1\n',
+        modified: 'replicas: 2\n',
+        language: 'yaml',
+      },
+    };
+    component.view = view;
+    fixture.detectChanges();
+  });
+
+  it('should create', () => {
+    expect(component).toBeTruthy();
+  });
+
+  it('shows the original and modified text', () => {
+    expect(component.original).toEqual('replicas: 1\n');
+    expect(component.modified).toEqual('replicas: 2\n');
+  });
+
+  it('compares the selected versions', () => {
+    component.view = {
+      metadata: { type: 'diff' },
+      config: {
+        original: 'b',
+        modified: 'c',
+        versions: [
+          { name: '1', value: 'a' },
+          { name: '2', value: 'b' },
+          { name: '3', value: 'c' },
+        ],
+      },
+    } as DiffView;
+    fixture.detectChanges();
+
+    expect(component.originalVersion).toEqual(1);
+    expect(component.modifiedVersion).toEqual(2);
+
+    component.originalVersion = 0;
+    component.selectVersions();
+    expect(component.original).toEqual('a');
+    expect(component.modified).toEqual('c');
+  });
+});
@@ -0,0 +1,92 @@
+/*
+Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+SPDX-License-Identifier: Apache-2.0
+*/
+
+import { Component, OnDestroy, OnInit } from '@angular/core';
+import { Subscription } from 'rxjs';
+import {
+  DiffVersion,
+  DiffView,
+} from 'src/app/modules/shared/models/content';
+import { AbstractViewComponent } from '../../abstract-view/abstract-view.component';
+import { ThemeService } from '../../../services/theme/theme.service';
+
+interface Options {
+  readOnly: boolean;
+  language: string;
+  theme: string;
+  renderSideBySide: boolean;
+}
+
+@Component({
+  selector: 'app-view-diff',
+  templateUrl: './diff.component.html',
+  styleUrls: ['./diff.component.scss'],
+})
+export class DiffComponent
+  extends AbstractViewComponent<DiffView>
+  implements OnInit, OnDestroy {
+  original = '';
+  modified = '';
+
+  versions: DiffVersion[] = [];
+  originalVersion: number;
+  modifiedVersion: number;
+
+  options: Options = {
+    readOnly: true,
+    language: 'yaml',
+    theme: 'vs-dark',
+    renderSideBySide: true,
+  };
+
+  private themeSubscription: Subscription;
+
+  constructor(private themeService: ThemeService) {
+    super();
+  }
+
+  ngOnInit() {
+    this.themeSubscription = this.themeService.themeType.subscribe(() => {
+      const theme = this.themeService.isLightThemeEnabled() ? 'vs' : 'vs-dark';
+      this.options = { ...this.options, theme };
+    });
+  }
+
+  update() {
+    this.original = this.v.config.original;
+    this.modified = this.v.config.modified;
+
+    const versions = this.v.config.versions || [];
+    if (versions.length !== this.versions.length) {
+      this.versions = versions;
+      this.originalVersion = Math.max(versions.length - 2, 0);
+      this.modifiedVersion = Math.max(versions.length - 1, 0);
+    } else {
+      this.versions = versions;
+      this.selectVersions();
+    }
+    if (this.v.config.language) {
+      this.options = { ...this.options, language: this.v.config.language };
+    }
+  }
+
+  selectVersions() {
+    if (this.versions.length === 0) {
+      return;
+    }
+    this.original = this.versions[this.originalVersion]?.value || '';
+    this.modified = this.versions[this.modifiedVersion]?.value || '';
+  }
+
+  trackByIndex(index: number): number {
+    return index;
+  }
+
+  ngOnDestroy() {
+    if (this.themeSubscription) {
+      this.themeSubscription.unsubscribe();
+    }
+  }
+}
@@ -0,0 +1,59 @@
+/*
+ * Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { ComponentFixture, TestBed, waitForAsync } from '@angular/core/testing';
+
+import { LineChartComponent } from './line-chart.component';
+import { LineChartView } from '../../../models/content';
+
+describe('LineChartComponent', () => {
+  let component: LineChartComponent;
+  let fixture: ComponentFixture<LineChartComponent>;
+
+  beforeEach(
+    waitForAsync(() => {
+      TestBed.configureTestingModule({
+        declarations: [LineChartComponent],
+      }).compileComponents();
+    })
+  );
+
+  beforeEach(() => {
+    fixture = TestBed.createComponent(LineChartComponent);
+    component = fixture.componentInstance;
+    const view: LineChartView = {
+      metadata: {
+        type: 'lineChart',
+      },
+      config: {
+        title: 'CPU Usage',
+        unit: 'm',
+        area: true,
+        series: [
+          {
+            name: 'CPU',
+            points: [
+              { timestamp: 1600000000, value: 100 },
+              { timestamp: 1600000030, value: 250 },
+            ],
+          },
+        ],
+      },
+    };
+    component.view = view;
+    fixture.detectChanges();
+  });
+
+  it('should create', () => {
+    expect(component).toBeTruthy();
+  });
+
+  it('should describe series paths', () => {
+    expect(component.series.length).toEqual(1);
+    expect(component.series[0].line).toContain('M ');
+    expect(component.series[0].area).toContain('Z');
+    expect(component.formatValue(component.maxValue)).toEqual('250m');
+  });
+});
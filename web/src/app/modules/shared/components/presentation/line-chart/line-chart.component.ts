@@ -0,0 +1,110 @@
+/*
+ * Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+ * SPDX-License-Identifier: Apache-2.0
+ */
+
+import { Component } from '@angular/core';
+import { LineChartSeries, LineChartView } from '../../../models/content';
+import { AbstractViewComponent } from '../../abstract-view/abstract-view.component';
+
+export interface SeriesDescriptor {
+  name: string;
+  color: string;
+  line: string;
+  area: string;
+}
+
+const defaultColors = ['#0072a3', '#60b515', '#c92100', '#8939ad', '#f57600'];
+
+@Component({
+  selector: 'app-view-line-chart',
+  templateUrl: './line-chart.component.html',
+  styleUrls: ['./line-chart.component.scss'],
+})
+export class LineChartComponent extends AbstractViewComponent<LineChartView> {
+  width = 400;
+  height = 160;
+  padding = 4;
+
+  series: SeriesDescriptor[] = [];
+  minValue = 0;
+  maxValue = 0;
+  startTime: Date;
+  endTime: Date;
+
+  constructor() {
+    super();
+  }
+
+  update() {
+    const allPoints = (this.v?.config?.series || []).flatMap(s => s.points);
+    if (allPoints.length === 0) {
+      this.series = [];
+      return;
+    }
+
+    const timestamps = allPoints.map(p => p.timestamp);
+    const values = allPoints.map(p => p.value);
+    const minTime = Math.min(...timestamps);
+    const maxTime = Math.max(...timestamps);
+    this.minValue = 0;
+    this.maxValue = Math.max(...values);
+    this.startTime = new Date(minTime * 1000);
+    this.endTime = new Date(maxTime * 1000);
+
+    this.series = this.v.config.series.map((series, index) =>
+      this.describeSeries(series, index, minTime, maxTime)
+    );
+  }
+
+  viewBox(): string {
+    return `0 0 ${this.width} ${this.height}`;
+  }
+
+  trackByName(index: number, item: SeriesDescriptor) {
+    return item?.name;
+  }
+
+  formatValue(value: number): string {
+    const unit = this.v?.config?.unit ? this.v.config.unit : '';
+    return `${Math.round(value * 100) / 100}${unit}`;
+  }
+
+  private describeSeries(
+    series: LineChartSeries,
+    index: number,
+    minTime: number,
+    maxTime: number
+  ): SeriesDescriptor {
+    const timeRange = maxTime - minTime || 1;
+    const valueRange = this.maxValue - this.minValue || 1;
+    const drawWidth = this.width - 2 * this.padding;
+    const drawHeight = this.height - 2 * this.padding;
+
+    const coordinates = (series.points || []).map(point => {
+      const x =
+        this.padding + ((point.timestamp - minTime) / timeRange) * drawWidth;
+      const y =
+        this.padding +
+        drawHeight -
+        ((point.value - this.minValue) / valueRange) * drawHeight;
+      return `${x.toFixed(2)},${y.toFixed(2)}`;
+    });
+
+    const line = coordinates.length > 0 ? `M ${coordinates.join(' L ')}` : '';
+    let area = '';
+    if (this.v.config.area && coordinates.length > 0) {
+      const bottom = (this.height - this.padding).toFixed(2);
+      const first = coordinates[0].split(',')[0];
+      const last = coordinates[coordinates.length - 1].split(',')[0];
+      area = `${line} L ${last},${bottom} L ${first},${bottom} Z`;
+    }
+
+    return {
+      name: series.name,
+      color: series.color || defaultColors[index % defaultColors.length],
+      line,
+      area,
+    };
+  }
+}
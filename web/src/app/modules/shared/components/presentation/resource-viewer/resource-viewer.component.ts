@@ -15,6 +15,9 @@ import {
 } from 'src/app/modules/shared/models/content';
 import { ElementsDefinition, Stylesheet } from 'cytoscape';
 import { AbstractViewComponent } from '../../abstract-view/abstract-view.component';
+import getAPIBase from '../../../services/common/getAPIBase';
+
+const API_BASE = getAPIBase();
 
 const statusColorCodes = {
   ok: '#60b515',
@@ -97,6 +100,8 @@ export class ResourceViewerComponent
 
   graphData: ElementsDefinition;
 
+  exportFormats = ['svg', 'dot', 'json'];
+
   private afterFirstChange: boolean;
 
   constructor() {
@@ -116,6 +121,10 @@ export class ResourceViewerComponent
     this.afterFirstChange = true;
   }
 
+  exportUrl(format: string): string {
+    return `${API_BASE}/api/v1${this.v.config.exportPath}&format=${format}`;
+  }
+
   nodeChange(event) {
     this.select(event.id);
   }
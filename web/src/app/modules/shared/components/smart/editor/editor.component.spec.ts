@@ -6,6 +6,15 @@ import { ComponentFixture, TestBed, waitForAsync } from '@angular/core/testing';
 import { EditorComponent } from './editor.component';
 import { MonacoEditorModule } from '@materia-ui/ngx-monaco-editor';
 import { windowProvider, WindowToken } from '../../../../../window';
+import { FormsModule } from '@angular/forms';
+import { HttpClientTestingModule } from '@angular/common/http/testing';
+import { HttpErrorResponse } from '@angular/common/http';
+import { of, throwError } from 'rxjs';
+import {
+  ApplyResponse,
+  ApplyService,
+} from '../../../services/apply/apply.service';
+import { DiffComponent } from '../../presentation/diff/diff.component';
 
 describe('EditorComponent', () => {
   let component: EditorComponent;
@@ -15,8 +24,8 @@ describe('EditorComponent', () => {
     waitForAsync(() => {
       TestBed.configureTestingModule({
         providers: [{ provide: WindowToken, useFactory: windowProvider }],
-        imports: [MonacoEditorModule],
-        declarations: [EditorComponent],
+        imports: [MonacoEditorModule, FormsModule, HttpClientTestingModule],
+        declarations: [EditorComponent, DiffComponent],
       }).compileComponents();
     })
   );
@@ -38,4 +47,70 @@ describe('EditorComponent', () => {
     expect(editorElement).not.toBeNull();
     expect(editorElement.classList.contains('editor')).toBeTruthy();
   });
+
+  describe('server-side apply', () => {
+    const conflict: ApplyResponse = {
+      dryRun: false,
+      conflicts: true,
+      applied: false,
+      objects: [
+        {
+          apiVersion: 'v1',
+          kind: 'ConfigMap',
+          namespace: 'default',
+          name: 'cm',
+          operation: 'conflict',
+          conflicts: [{ field: '.data.key', message: 'conflict' }],
+        },
+      ],
+    };
+
+    let applyService: ApplyService;
+
+    beforeEach(() => {
+      applyService = TestBed.inject(ApplyService);
+      component.submitAction = 'action.octant.dev/apply';
+      component.value = 'kind: ConfigMap';
+    });
+
+    it('previews before applying', () => {
+      const preview: ApplyResponse = {
+        dryRun: true,
+        conflicts: false,
+        applied: false,
+        objects: [],
+      };
+      spyOn(applyService, 'preview').and.returnValue(of(preview));
+      spyOn(applyService, 'apply').and.returnValue(
+        of({ dryRun: false, conflicts: false, applied: true, objects: [] })
+      );
+
+      component.submit();
+      expect(component.preview).toEqual(preview);
+      expect(applyService.apply).not.toHaveBeenCalled();
+
+      component.confirm();
+      expect(applyService.apply).toHaveBeenCalledWith(
+        jasmine.any(String),
+        'kind: ConfigMap',
+        false
+      );
+      expect(component.preview).toBeNull();
+    });
+
+    it('asks to force apply after a conflict', () => {
+      spyOn(applyService, 'preview').and.returnValue(
+        of({ dryRun: true, conflicts: false, applied: false, objects: [] })
+      );
+      spyOn(applyService, 'apply').and.returnValue(
+        throwError(new HttpErrorResponse({ status: 409, error: conflict }))
+      );
+
+      component.submit();
+      component.confirm();
+
+      expect(component.preview).not.toBeNull();
+      expect(component.conflicts.length).toEqual(1);
+    });
+  });
 });
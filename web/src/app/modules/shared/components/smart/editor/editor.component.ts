@@ -10,6 +10,24 @@ import { ActionService } from '../../../services/action/action.service';
 import { AbstractViewComponent } from '../../abstract-view/abstract-view.component';
 import { ThemeService } from '../../../services/theme/theme.service';
 import { Subscription } from 'rxjs';
+import { HttpErrorResponse } from '@angular/common/http';
+import {
+  ApplyObject,
+  ApplyResponse,
+  ApplyService,
+} from '../../../services/apply/apply.service';
+import {
+  NotifierService,
+  NotifierSession,
+  NotifierSignalType,
+} from '../../../notifier/notifier.service';
+
+// Actions which are previewed with a server-side apply dry run before they are
+// applied.
+const serverSideApplyActions = [
+  'action.octant.dev/update',
+  'action.octant.dev/apply',
+];
 
 interface Options {
   readOnly: boolean;
@@ -37,6 +55,7 @@ export class EditorComponent
   }
 
   private subscriptionTheme: Subscription;
+  private notifierSession: NotifierSession;
   private syncMonacoTheme: () => void;
   private editorValue: string;
   private pristineValue: string;
@@ -45,6 +64,10 @@ export class EditorComponent
 
   isModified = false;
 
+  preview: ApplyResponse;
+  conflicts: ApplyObject[] = [];
+  applying = false;
+
   options: Options = { theme: 'vs-dark', language: 'yaml', readOnly: false };
 
   submitAction = 'action.octant.dev/update';
@@ -53,10 +76,14 @@ export class EditorComponent
   constructor(
     private namespaceService: NamespaceService,
     private themeService: ThemeService,
-    private actionService: ActionService
+    private actionService: ActionService,
+    private applyService: ApplyService,
+    private notifierService: NotifierService
   ) {
     super();
 
+    this.notifierSession = this.notifierService.createSession();
+
     this.uri =
       'file:text-' + Math.random().toString(36).substring(2, 15) + '.yaml';
 
@@ -90,14 +117,88 @@ export class EditorComponent
   }
 
   submit() {
-    const payload = {
-      action: this.submitAction,
-      update: this.value,
-      ...(this.metadata || {
-        namespace: this.namespaceService.activeNamespace.value,
-      }),
-    };
-    this.actionService.perform(payload);
+    if (!serverSideApplyActions.includes(this.submitAction)) {
+      const payload = {
+        action: this.submitAction,
+        update: this.value,
+        ...(this.metadata || {
+          namespace: this.namespaceService.activeNamespace.value,
+        }),
+      };
+      this.actionService.perform(payload);
+      return;
+    }
+
+    this.applying = true;
+    this.applyService.preview(this.namespace(), this.value).subscribe(
+      res => {
+        this.applying = false;
+        this.preview = res;
+        this.conflicts = res.objects.filter(o => o.operation === 'conflict');
+      },
+      err => this.applyFailed(err)
+    );
+  }
+
+  confirm(force = false) {
+    this.applying = true;
+    this.applyService.apply(this.namespace(), this.value, force).subscribe(
+      res => {
+        this.applying = false;
+        this.closePreview();
+        this.pristineValue = this.value;
+        this.isModified = false;
+
+        const applied = res.objects.filter(o => o.operation !== 'unchanged');
+        this.notifierSession.pushSignal(
+          NotifierSignalType.SUCCESS,
+          `Applied ${applied.length} of ${res.objects.length} object(s)`
+        );
+      },
+      (err: HttpErrorResponse) => {
+        if (err.status === 409 && err.error?.objects) {
+          // Fields changed owners after the preview. Ask before forcing the apply.
+          this.applying = false;
+          const res = err.error as ApplyResponse;
+          this.conflicts = res.objects.filter(o => o.operation === 'conflict');
+          return;
+        }
+        this.applyFailed(err);
+      }
+    );
+  }
+
+  closePreview() {
+    this.preview = null;
+    this.conflicts = [];
+  }
+
+  private namespace(): string {
+    return (
+      this.metadata?.namespace || this.namespaceService.activeNamespace.value
+    );
+  }
+
+  private applyFailed(err: HttpErrorResponse) {
+    this.applying = false;
+    if (typeof err.error?.error === 'string' && err.error.applied) {
+      // Some objects were applied before the error.
+      const res = err.error as ApplyResponse;
+      this.notifierSession.pushSignal(
+        NotifierSignalType.ERROR,
+        `Applied ${res.objects?.length || 0} object(s) before failing: ${
+          res.error
+        }`
+      );
+      return;
+    }
+
+    const message =
+      err.error?.error?.message || err.error?.error || err.message;
+    this.notifierSession.pushSignal(
+      NotifierSignalType.ERROR,
+      `Unable to apply: ${message}`
+    );
   }
 
   isUpdateEnabled() {
@@ -110,5 +211,6 @@ export class EditorComponent
 
   ngOnDestroy() {
     this.subscriptionTheme.unsubscribe();
+    this.notifierSession.removeAllSignals();
   }
 }
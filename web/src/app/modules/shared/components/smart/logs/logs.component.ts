@@ -18,6 +18,7 @@ import {
 } from '@angular/core';
 import { LogEntry, LogsView } from 'src/app/modules/shared/models/content';
 import {
+  LogStreamOptions,
   PodLogsService,
   PodLogsStreamer,
 } from 'src/app/modules/shared/pod-logs/pod-logs.service';
@@ -46,6 +47,9 @@ export class LogsComponent
   shouldDisplayTimestamp = false;
   shouldDisplayName = true;
   showOnlyFiltered = false;
+  showPrevious = false;
+  selectedSeverity = '';
+  severities = ['', 'debug', 'info', 'warning', 'error', 'fatal'];
   filterText = '';
   oldFilterText = '';
   currentSelection = 0;
@@ -109,6 +113,35 @@ export class LogsComponent
     this.scrollToHighlight(0, 0);
   }
 
+  togglePrevious(): void {
+    this.showPrevious = !this.showPrevious;
+    this.stopStreamIfStarted();
+    this.startStream();
+  }
+
+  onSeverityChange(severity: string): void {
+    this.selectedSeverity = severity;
+    this.stopStreamIfStarted();
+    this.startStream();
+  }
+
+  streamOptions(): LogStreamOptions {
+    return {
+      previous: this.showPrevious,
+      severity: this.selectedSeverity,
+    };
+  }
+
+  downloadUrl(): string {
+    return this.podLogsService.downloadUrl(
+      this.v.config.namespace,
+      this.v.config.name,
+      this.selectedContainer,
+      this.selectedSince,
+      this.streamOptions()
+    );
+  }
+
   toggleShowOnlyFiltered(): void {
     this.showOnlyFiltered = !this.showOnlyFiltered;
     this.scrollToHighlight(0, 0);
@@ -124,7 +157,9 @@ export class LogsComponent
         namespace,
         pod,
         container,
-        since
+        since,
+        this.v.config.kind,
+        this.streamOptions()
       );
       this.logSubscription = this.logStream.logEntry.subscribe(
         (entry: LogEntry) => {
@@ -0,0 +1,58 @@
+// Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+import { ComponentFixture, TestBed, waitForAsync } from '@angular/core/testing';
+
+import {
+  parseAsciicast,
+  TerminalReplayComponent,
+} from './terminal-replay.component';
+import { TerminalReplayView } from '../../../models/content';
+
+describe('TerminalReplayComponent', () => {
+  let component: TerminalReplayComponent;
+  let fixture: ComponentFixture<TerminalReplayComponent>;
+
+  beforeEach(
+    waitForAsync(() => {
+      TestBed.configureTestingModule({
+        declarations: [TerminalReplayComponent],
+      }).compileComponents();
+    })
+  );
+
+  beforeEach(() => {
+    fixture = TestBed.createComponent(TerminalReplayComponent);
+    component = fixture.componentInstance;
+    const view: TerminalReplayView = {
+      metadata: {
+        type: 'terminalReplay',
+      },
+      config: {
+        name: 'app-1.cast',
+        url: '/terminal/recordings/namespace/default/pod/pod/app-1.cast',
+      },
+    };
+    component.view = view;
+    fixture.detectChanges();
+  });
+
+  it('should create', () => {
+    expect(component).toBeTruthy();
+  });
+
+  it('should parse asciicast v2 recordings', () => {
+    const cast = parseAsciicast(
+      '{"version":2,"width":80,"height":24}\n[0.5,"o","$ "]\n[1,"r","100x30"]\n'
+    );
+    expect(cast.header.width).toEqual(80);
+    expect(cast.events.length).toEqual(2);
+    expect(cast.events[1]).toEqual([1, 'r', '100x30']);
+  });
+
+  it('should reject other versions', () => {
+    expect(() => parseAsciicast('{"version":1}')).toThrowError(
+      'unsupported recording version 1'
+    );
+  });
+});
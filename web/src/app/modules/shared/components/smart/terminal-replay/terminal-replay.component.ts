@@ -0,0 +1,145 @@
+// Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+import {
+  ChangeDetectionStrategy,
+  ChangeDetectorRef,
+  Component,
+  ElementRef,
+  OnDestroy,
+  ViewChild,
+  ViewEncapsulation,
+} from '@angular/core';
+import { Terminal } from 'xterm';
+import { TerminalReplayView } from 'src/app/modules/shared/models/content';
+import getAPIBase from '../../../services/common/getAPIBase';
+import { AbstractViewComponent } from '../../abstract-view/abstract-view.component';
+
+const API_BASE = getAPIBase();
+
+export interface AsciicastHeader {
+  version: number;
+  width: number;
+  height: number;
+  timestamp?: number;
+  command?: string;
+  title?: string;
+}
+
+// AsciicastEvent is an asciicast v2 event: [elapsed seconds, type, data].
+export type AsciicastEvent = [number, string, string];
+
+export interface Asciicast {
+  header: AsciicastHeader;
+  events: AsciicastEvent[];
+}
+
+export function parseAsciicast(data: string): Asciicast {
+  const lines = data.split('\n').filter(line => line.trim() !== '');
+  if (lines.length === 0) {
+    throw new Error('recording is empty');
+  }
+
+  const header = JSON.parse(lines[0]) as AsciicastHeader;
+  if (header.version !== 2) {
+    throw new Error(`unsupported recording version ${header.version}`);
+  }
+
+  const events = lines
+    .slice(1)
+    .map(line => JSON.parse(line) as AsciicastEvent);
+  return { header, events };
+}
+
+@Component({
+  encapsulation: ViewEncapsulation.None,
+  selector: 'app-view-terminal-replay',
+  styleUrls: ['./terminal-replay.component.scss'],
+  templateUrl: './terminal-replay.component.html',
+  changeDetection: ChangeDetectionStrategy.OnPush,
+})
+export class TerminalReplayComponent
+  extends AbstractViewComponent<TerminalReplayView>
+  implements OnDestroy {
+  @ViewChild('player', { static: true }) playerDiv: ElementRef;
+
+  playing = false;
+  error: string;
+
+  private term: Terminal;
+  private timers: ReturnType<typeof setTimeout>[] = [];
+
+  constructor(private cdr: ChangeDetectorRef) {
+    super();
+  }
+
+  update() {}
+
+  ngOnDestroy(): void {
+    this.stop();
+    if (this.term) {
+      this.term.dispose();
+      this.term = null;
+    }
+  }
+
+  play(): void {
+    this.stop();
+    this.error = null;
+
+    fetch(`${API_BASE}/api/v1${this.v.config.url}`)
+      .then(res => {
+        if (!res.ok) {
+          throw new Error(`unable to load recording (${res.status})`);
+        }
+        return res.text();
+      })
+      .then(data => this.replay(parseAsciicast(data)))
+      .catch(err => {
+        this.error = err.message;
+        this.playing = false;
+        this.cdr.markForCheck();
+      });
+  }
+
+  stop(): void {
+    this.timers.forEach(timer => clearTimeout(timer));
+    this.timers = [];
+    this.playing = false;
+  }
+
+  private replay(cast: Asciicast): void {
+    if (!this.term) {
+      this.term = new Terminal({ disableStdin: true });
+      this.term.open(this.playerDiv.nativeElement);
+    }
+    this.term.reset();
+    this.term.resize(cast.header.width, cast.header.height);
+
+    this.playing = true;
+    this.cdr.markForCheck();
+
+    cast.events.forEach(([elapsed, type, data]) => {
+      const timer = setTimeout(() => {
+        if (type === 'o') {
+          this.term.write(data);
+        } else if (type === 'r') {
+          const [cols, rows] = data.split('x').map(n => +n);
+          if (cols > 0 && rows > 0) {
+            this.term.resize(cols, rows);
+          }
+        }
+      }, elapsed * 1000);
+      this.timers.push(timer);
+    });
+
+    const last =
+      cast.events.length > 0 ? cast.events[cast.events.length - 1][0] : 0;
+    this.timers.push(
+      setTimeout(() => {
+        this.playing = false;
+        this.cdr.markForCheck();
+      }, last * 1000)
+    );
+  }
+}
@@ -23,7 +23,9 @@ import { TextComponent } from './components/presentation/text/text.component';
 import { QuadrantComponent } from './components/presentation/quadrant/quadrant.component';
 import { SelectorsComponent } from './components/presentation/selectors/selectors.component';
 import { TerminalComponent } from './components/smart/terminal/terminal.component';
+import { TerminalReplayComponent } from './components/smart/terminal-replay/terminal-replay.component';
 import { DatagridComponent } from './components/presentation/datagrid/datagrid.component';
+import { DiffComponent } from './components/presentation/diff/diff.component';
 import { DonutChartComponent } from './components/presentation/donut-chart/donut-chart.component';
 import { GraphvizComponent } from './components/presentation/graphviz/graphviz.component';
 import { ErrorComponent } from './components/presentation/error/error.component';
@@ -31,6 +33,7 @@ import { LabelSelectorComponent } from './components/presentation/label-selector
 import { PortsComponent } from './components/presentation/ports/ports.component';
 import { CardListComponent } from './components/presentation/card-list/card-list.component';
 import { LabelsComponent } from './components/presentation/labels/labels.component';
+import { LineChartComponent } from './components/presentation/line-chart/line-chart.component';
 import { LinkComponent } from './components/presentation/link/link.component';
 import { SingleStatComponent } from './components/presentation/single-stat/single-stat.component';
 import { SummaryComponent } from './components/presentation/summary/summary.component';
@@ -52,6 +55,7 @@ const DynamicComponentMapping: ComponentMapping = {
   cardList: CardListComponent,
   codeBlock: CodeComponent,
   containers: ContainersComponent,
+  diff: DiffComponent,
   donutChart: DonutChartComponent,
   dropdown: DropdownComponent,
   editor: EditorComponent,
@@ -63,6 +67,7 @@ const DynamicComponentMapping: ComponentMapping = {
   loading: LoadingComponent,
   error: ErrorComponent,
   iframe: IFrameComponent,
+  lineChart: LineChartComponent,
   link: LinkComponent,
   list: ListComponent,
   logs: LogsComponent,
@@ -78,6 +83,7 @@ const DynamicComponentMapping: ComponentMapping = {
   summary: SummaryComponent,
   table: DatagridComponent,
   terminal: TerminalComponent,
+  terminalReplay: TerminalReplayComponent,
   text: TextComponent,
   timeline: TimelineComponent,
   timestamp: TimestampComponent,
@@ -160,6 +160,26 @@ export interface LabelsView extends View {
   };
 }
 
+export interface LineChartPoint {
+  timestamp: number;
+  value: number;
+}
+
+export interface LineChartSeries {
+  name: string;
+  color?: string;
+  points: LineChartPoint[];
+}
+
+export interface LineChartView extends View {
+  config: {
+    title: string;
+    unit?: string;
+    area?: boolean;
+    series: LineChartSeries[];
+  };
+}
+
 export interface LinkView extends View {
   config: {
     ref: string;
@@ -278,6 +298,7 @@ export interface ResourceViewerView extends View {
     edges: { [key: string]: Edge[] };
     nodes: Node[];
     selected: string;
+    exportPath?: string;
   };
 }
 
@@ -447,6 +468,7 @@ export interface LogsView extends View {
   config: {
     namespace: string;
     name: string;
+    kind?: string;
     containers: string[];
     durations: Since[];
   };
@@ -456,6 +478,7 @@ export interface LogEntry {
   timestamp: string;
   message: string;
   container: string;
+  pod?: string;
 }
 
 export interface LogResponse {
@@ -484,6 +507,15 @@ export interface TerminalView extends View {
   };
 }
 
+export interface TerminalReplayView extends View {
+  config: {
+    name: string;
+    url: string;
+    container?: string;
+    command?: string;
+  };
+}
+
 export interface EditorView extends View {
   config: {
     value: string;
@@ -551,6 +583,20 @@ export interface CodeView extends View {
   };
 }
 
+export interface DiffView extends View {
+  config: {
+    original: string;
+    modified: string;
+    language?: string;
+    versions?: DiffVersion[];
+  };
+}
+
+export interface DiffVersion {
+  name: string;
+  value: string;
+}
+
 export interface StepItem {
   name: string;
   form: ActionForm;
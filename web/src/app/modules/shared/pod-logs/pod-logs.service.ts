@@ -10,6 +10,15 @@ import { WebsocketService } from '../../../data/services/websocket/websocket.ser
 
 const API_BASE = getAPIBase();
 
+export interface LogStreamOptions {
+  previous?: boolean;
+  include?: string;
+  exclude?: string;
+  severity?: string;
+  startTime?: string;
+  endTime?: string;
+}
+
 export class PodLogsStreamer {
   public logEntry: BehaviorSubject<LogEntry>;
 
@@ -18,7 +27,9 @@ export class PodLogsStreamer {
     private pod: string,
     private container: string,
     private since: number,
-    private wss: WebsocketService
+    private wss: WebsocketService,
+    private kind?: string,
+    private options: LogStreamOptions = {}
   ) {}
 
   public start(): void {
@@ -32,8 +43,8 @@ export class PodLogsStreamer {
     this.logEntry = new BehaviorSubject(emptyEntry);
 
     this.wss.sendMessage('action.octant.dev/podLogs/subscribe', {
-      namespace: this.namespace,
-      podName: this.pod,
+      ...this.target(),
+      ...this.options,
       containerName: this.container,
       sinceSeconds: this.since,
     });
@@ -46,19 +57,26 @@ export class PodLogsStreamer {
   }
 
   public close(): void {
-    this.wss.sendMessage('action.octant.dev/podLogs/unsubscribe', {
-      namespace: this.namespace,
-      podName: this.pod,
-    });
+    this.wss.sendMessage('action.octant.dev/podLogs/unsubscribe', this.target());
     this.logEntry.unsubscribe();
   }
 
+  private target(): { [key: string]: string } {
+    if (this.kind) {
+      return { namespace: this.namespace, kind: this.kind, name: this.pod };
+    }
+    return { namespace: this.namespace, podName: this.pod };
+  }
+
   private streamUrl(): string {
+    const source = this.kind
+      ? `${this.kind.toLowerCase()}/${this.pod}`
+      : `pod/${this.pod}`;
     return [
       'event.octant.dev',
       'logging',
       `namespace/${this.namespace}`,
-      `pod/${this.pod}`,
+      source,
     ].join('/');
   }
 }
@@ -73,10 +91,43 @@ export class PodLogsService {
     namespace,
     pod,
     container: string,
-    since?: number
+    since?: number,
+    kind?: string,
+    options?: LogStreamOptions
   ): PodLogsStreamer {
-    const pls = new PodLogsStreamer(namespace, pod, container, since, this.wss);
+    const pls = new PodLogsStreamer(
+      namespace,
+      pod,
+      container,
+      since,
+      this.wss,
+      kind,
+      options
+    );
     pls.start();
     return pls;
   }
+
+  public downloadUrl(
+    namespace: string,
+    pod: string,
+    container: string,
+    since?: number,
+    options: LogStreamOptions = {}
+  ): string {
+    const params = new URLSearchParams();
+    if (container) {
+      params.set('container', container);
+    }
+    if (since) {
+      params.set('sinceSeconds', `${since}`);
+    }
+    Object.keys(options).forEach(key => {
+      if (options[key]) {
+        params.set(key, `${options[key]}`);
+      }
+    });
+
+    return `${API_BASE}/api/v1/logs/namespace/${namespace}/pod/${pod}?${params.toString()}`;
+  }
 }
@@ -0,0 +1,53 @@
+// Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+
+import { TestBed } from '@angular/core/testing';
+import {
+  HttpClientTestingModule,
+  HttpTestingController,
+} from '@angular/common/http/testing';
+import { ApplyService } from './apply.service';
+
+describe('ApplyService', () => {
+  let service: ApplyService;
+  let httpTestingController: HttpTestingController;
+
+  beforeEach(() => {
+    TestBed.configureTestingModule({
+      imports: [HttpClientTestingModule],
+    });
+    service = TestBed.inject(ApplyService);
+    httpTestingController = TestBed.inject(HttpTestingController);
+  });
+
+  afterEach(() => {
+    httpTestingController.verify();
+  });
+
+  it('previews an update', () => {
+    service.preview('default', 'kind: ConfigMap').subscribe(res => {
+      expect(res.dryRun).toBeTrue();
+    });
+
+    const req = httpTestingController.expectOne(r =>
+      r.url.endsWith('/api/v1/apply/preview')
+    );
+    expect(req.request.method).toEqual('POST');
+    expect(req.request.body).toEqual({
+      namespace: 'default',
+      update: 'kind: ConfigMap',
+    });
+    req.flush({ dryRun: true, conflicts: false, objects: [] });
+  });
+
+  it('force applies an update', () => {
+    service.apply('default', 'kind: ConfigMap', true).subscribe();
+
+    const req = httpTestingController.expectOne(r =>
+      r.url.endsWith('/api/v1/apply')
+    );
+    expect(req.request.body.force).toBeTrue();
+    req.flush({ dryRun: false, conflicts: false, objects: [] });
+  });
+});
@@ -0,0 +1,69 @@
+// Copyright (c) 2021 the Octant contributors. All Rights Reserved.
+// SPDX-License-Identifier: Apache-2.0
+//
+
+import { Injectable } from '@angular/core';
+import { HttpClient } from '@angular/common/http';
+import { Observable } from 'rxjs';
+import { DiffView } from '../../models/content';
+import getAPIBase from '../common/getAPIBase';
+
+const API_BASE = getAPIBase();
+
+export interface ApplyConflict {
+  field?: string;
+  message: string;
+}
+
+export interface ApplyObject {
+  apiVersion: string;
+  kind: string;
+  namespace?: string;
+  name: string;
+  operation: 'create' | 'update' | 'unchanged' | 'conflict';
+  changes?: string[];
+  conflicts?: ApplyConflict[];
+  diff?: DiffView;
+}
+
+export interface ApplyResponse {
+  dryRun: boolean;
+  conflicts: boolean;
+  // applied is true if any of the objects were persisted.
+  applied: boolean;
+  objects: ApplyObject[];
+  // error is the reason the remaining objects were not applied.
+  error?: string;
+}
+
+@Injectable({
+  providedIn: 'root',
+})
+export class ApplyService {
+  constructor(private http: HttpClient) {}
+
+  // preview applies update with dryRun=All and returns a diff of each object.
+  preview(namespace: string, update: string): Observable<ApplyResponse> {
+    return this.http.post<ApplyResponse>(`${API_BASE}/api/v1/apply/preview`, {
+      namespace,
+      update,
+    });
+  }
+
+  // apply server-side applies update. Objects are only applied if all of them
+  // pass a dry run. The request fails with a 409 status and an ApplyResponse body
+  // if fields are owned by other field managers and force is not set, or with a
+  // 400 status and an ApplyResponse body listing the objects applied before an
+  // error.
+  apply(
+    namespace: string,
+    update: string,
+    force = false
+  ): Observable<ApplyResponse> {
+    return this.http.post<ApplyResponse>(`${API_BASE}/api/v1/apply`, {
+      namespace,
+      update,
+      force,
+    });
+  }
+}
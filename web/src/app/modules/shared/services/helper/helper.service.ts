@@ -11,6 +11,7 @@ export interface BuildInfoMessage {
   version: string;
   commit: string;
   time: string;
+  readOnly: boolean;
 }
 
 @Injectable({
@@ -20,6 +21,7 @@ export class HelperService {
   private version = new BehaviorSubject<string>('');
   private commit = new BehaviorSubject<string>('');
   private time = new BehaviorSubject<string>('');
+  private readOnly = new BehaviorSubject<boolean>(false);
 
   constructor(
     private router: Router,
@@ -30,6 +32,7 @@ export class HelperService {
       this.version.next(update.version);
       this.commit.next(update.commit);
       this.time.next(update.time);
+      this.readOnly.next(update.readOnly);
     });
   }
 
@@ -44,4 +47,8 @@ export class HelperService {
   buildTime() {
     return this.time;
   }
+
+  isReadOnly() {
+    return this.readOnly;
+  }
 }
@@ -11,6 +11,7 @@ import { CardListComponent } from './components/presentation/card-list/card-list
 import { CodeComponent } from './components/presentation/code/code.component';
 import { DropdownComponent } from './components/presentation/dropdown/dropdown.component';
 import { LabelsComponent } from './components/presentation/labels/labels.component';
+import { LineChartComponent } from './components/presentation/line-chart/line-chart.component';
 import { LinkComponent } from './components/presentation/link/link.component';
 import { ListComponent } from './components/presentation/list/list.component';
 import { TabsComponent } from './components/presentation/tabs/tabs.component';
@@ -49,6 +50,8 @@ import { ObjectStatusComponent } from './components/presentation/object-status/o
 import { PodStatusComponent } from './components/presentation/pod-status/pod-status.component';
 import { FormsModule, ReactiveFormsModule } from '@angular/forms';
 import { TerminalComponent } from './components/smart/terminal/terminal.component';
+import { DiffComponent } from './components/presentation/diff/diff.component';
+import { TerminalReplayComponent } from './components/smart/terminal-replay/terminal-replay.component';
 import { LogsComponent } from './components/smart/logs/logs.component';
 import { PortsComponent } from './components/presentation/ports/ports.component';
 import { FiltersComponent } from './components/smart/filters/filters.component';
@@ -117,6 +120,7 @@ import { StringEscapePipe } from './pipes/stringEscape/string.escape.pipe';
     IndicatorComponent,
     LabelsComponent,
     LabelSelectorComponent,
+    LineChartComponent,
     LinkComponent,
     ListComponent,
     LoadingComponent,
@@ -141,6 +145,8 @@ import { StringEscapePipe } from './pipes/stringEscape/string.escape.pipe';
     TableComponent,
     TabsComponent,
     TerminalComponent,
+    TerminalReplayComponent,
+    DiffComponent,
     TextComponent,
     TimelineComponent,
     TimestampComponent,
@@ -189,6 +195,7 @@ import { StringEscapePipe } from './pipes/stringEscape/string.escape.pipe';
     IndicatorComponent,
     LabelsComponent,
     LabelSelectorComponent,
+    LineChartComponent,
     LinkComponent,
     ListComponent,
     LoadingComponent,
@@ -207,6 +214,8 @@ import { StringEscapePipe } from './pipes/stringEscape/string.escape.pipe';
     TableComponent,
     TabsComponent,
     TerminalComponent,
+    TerminalReplayComponent,
+    DiffComponent,
     TextComponent,
     TimelineComponent,
     TimestampComponent,
@@ -266,6 +275,7 @@ import { StringEscapePipe } from './pipes/stringEscape/string.escape.pipe';
     IFrameComponent,
     LabelsComponent,
     LabelSelectorComponent,
+    LineChartComponent,
     LinkComponent,
     ListComponent,
     LoadingComponent,
@@ -284,6 +294,8 @@ import { StringEscapePipe } from './pipes/stringEscape/string.escape.pipe';
     TableComponent,
     TabsComponent,
     TerminalComponent,
+    TerminalReplayComponent,
+    DiffComponent,
     TextComponent,
     TimestampComponent,
     TitleComponent,
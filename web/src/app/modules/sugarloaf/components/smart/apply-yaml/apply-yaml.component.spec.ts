@@ -3,6 +3,7 @@
 //
 import { ComponentFixture, TestBed, waitForAsync } from '@angular/core/testing';
 import { ApplyYAMLComponent } from './apply-yaml.component';
+import { HelperService } from '../../../../shared/services/helper/helper.service';
 
 describe('ApplyYAMLComponent', () => {
   let component: ApplyYAMLComponent;
@@ -12,6 +13,7 @@ describe('ApplyYAMLComponent', () => {
     waitForAsync(() => {
       TestBed.configureTestingModule({
         declarations: [ApplyYAMLComponent],
+        providers: [{ provide: HelperService }],
       }).compileComponents();
     })
   );
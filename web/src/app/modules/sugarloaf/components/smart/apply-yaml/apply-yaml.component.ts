@@ -3,16 +3,19 @@ Copyright (c) 2020 the Octant contributors. All Rights Reserved.
 SPDX-License-Identifier: Apache-2.0
 */
 
-import { Component, HostListener, OnInit } from '@angular/core';
+import { Component, HostListener, OnDestroy, OnInit } from '@angular/core';
+import { Subscription } from 'rxjs';
 import { EditorView } from 'src/app/modules/shared/models/content';
+import { HelperService } from 'src/app/modules/shared/services/helper/helper.service';
 
 @Component({
   selector: 'app-apply-yaml',
   templateUrl: './apply-yaml.component.html',
   styleUrls: ['./apply-yaml.component.scss'],
 })
-export class ApplyYAMLComponent implements OnInit {
+export class ApplyYAMLComponent implements OnInit, OnDestroy {
   isOpen: boolean;
+  readOnly = false;
   editorView: EditorView = {
     config: {
       value: '',
@@ -28,14 +31,29 @@ export class ApplyYAMLComponent implements OnInit {
     },
   };
 
-  constructor() {}
+  private readOnlySubscription: Subscription;
+
+  constructor(private helperService: HelperService) {}
 
   ngOnInit() {
     this.isOpen = false;
+    this.readOnlySubscription = this.helperService
+      .isReadOnly()
+      .subscribe(readOnly => (this.readOnly = readOnly));
+  }
+
+  ngOnDestroy() {
+    if (this.readOnlySubscription) {
+      this.readOnlySubscription.unsubscribe();
+    }
   }
 
   @HostListener('window:keydown', ['$event'])
   keyEvent(event: KeyboardEvent) {
+    if (this.readOnly) {
+      return;
+    }
+
     if (event.ctrlKey && event.key === 'y') {
       event.preventDefault();
       event.cancelBubble = true;